@@ -0,0 +1,111 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package cbor implements a CBOR encoding of Cadence values.
+//
+// The encoding has the same structure as the JSON-Cadence format:
+// https://github.com/onflow/flow/blob/master/docs/json-cadence-spec.md
+//
+// Each JSON object is encoded as a CBOR map with text string keys,
+// each JSON array as a CBOR array, each JSON string as a CBOR text string,
+// and each JSON boolean as a CBOR boolean.
+// Like in JSON-Cadence, number values are encoded as text strings,
+// and the sizes of constant sized array types are encoded as unsigned integers.
+//
+// The encoding is deterministic: it uses the core deterministic encoding
+// of RFC 8949, section 4.2.1, i.e. map keys are sorted and integers use the shortest form.
+//
+package cbor
+
+import (
+	"fmt"
+	"reflect"
+	goRuntime "runtime"
+
+	"github.com/fxamacker/cbor/v2"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/encoding/json"
+)
+
+var encMode = func() cbor.EncMode {
+	mode, err := cbor.CoreDetEncOptions().EncMode()
+	if err != nil {
+		panic(err)
+	}
+	return mode
+}()
+
+var decMode = func() cbor.DecMode {
+	mode, err := cbor.DecOptions{
+		DefaultMapType: reflect.TypeOf(map[string]interface{}(nil)),
+	}.DecMode()
+	if err != nil {
+		panic(err)
+	}
+	return mode
+}()
+
+// Encode returns the CBOR-encoded representation of the given value.
+//
+// This function returns an error if the Cadence value cannot be represented as CBOR.
+func Encode(value cadence.Value) (b []byte, err error) {
+	// capture panics that occur during struct preparation
+	defer func() {
+		if r := recover(); r != nil {
+			// don't recover Go errors
+			goErr, ok := r.(goRuntime.Error)
+			if ok {
+				panic(goErr)
+			}
+
+			panicErr, isError := r.(error)
+			if !isError {
+				panic(r)
+			}
+
+			err = fmt.Errorf("failed to encode value: %w", panicErr)
+		}
+	}()
+
+	return encMode.Marshal(json.Prepare(value))
+}
+
+// MustEncode returns the CBOR-encoded representation of the given value, or panics
+// if the value cannot be represented as CBOR.
+func MustEncode(value cadence.Value) []byte {
+	b, err := Encode(value)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// Decode returns a Cadence value decoded from its CBOR-encoded representation.
+//
+// This function returns an error if the bytes represent CBOR that is malformed
+// or does not conform to the structure of the JSON Cadence specification.
+func Decode(b []byte) (cadence.Value, error) {
+	var v interface{}
+	err := decMode.Unmarshal(b, &v)
+	if err != nil {
+		return nil, fmt.Errorf("cbor-cdc: failed to decode valid CBOR structure: %w", err)
+	}
+
+	return json.DecodePrepared(v)
+}
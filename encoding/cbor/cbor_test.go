@@ -0,0 +1,273 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cbor_test
+
+import (
+	"math/big"
+	"testing"
+	"testing/quick"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/encoding/cbor"
+	"github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func testRoundTrip(t *testing.T, value cadence.Value) bool {
+	encoded, err := cbor.Encode(value)
+	require.NoError(t, err)
+
+	decoded, err := cbor.Decode(encoded)
+	require.NoError(t, err)
+
+	return assert.Equal(t, value, decoded)
+}
+
+func TestRoundTripSimpleValues(t *testing.T) {
+
+	t.Parallel()
+
+	newBig := func(value int64) *big.Int {
+		return big.NewInt(value)
+	}
+
+	properties := map[string]interface{}{
+		"Bool":      func(v bool) bool { return testRoundTrip(t, cadence.NewBool(v)) },
+		"String":    func(v string) bool { return testRoundTrip(t, cadence.String(v)) },
+		"Address":   func(v [8]byte) bool { return testRoundTrip(t, cadence.NewAddress(v)) },
+		"Int":       func(v int64) bool { return testRoundTrip(t, cadence.NewIntFromBig(newBig(v))) },
+		"Int8":      func(v int8) bool { return testRoundTrip(t, cadence.NewInt8(v)) },
+		"Int16":     func(v int16) bool { return testRoundTrip(t, cadence.NewInt16(v)) },
+		"Int32":     func(v int32) bool { return testRoundTrip(t, cadence.NewInt32(v)) },
+		"Int64":     func(v int64) bool { return testRoundTrip(t, cadence.NewInt64(v)) },
+		"Int128":    func(v int64) bool { return testRoundTrip(t, cadence.Int128{Value: newBig(v)}) },
+		"Int256":    func(v int64) bool { return testRoundTrip(t, cadence.Int256{Value: newBig(v)}) },
+		"UInt":      func(v uint32) bool { return testRoundTrip(t, cadence.NewUInt(uint(v))) },
+		"UInt8":     func(v uint8) bool { return testRoundTrip(t, cadence.NewUInt8(v)) },
+		"UInt16":    func(v uint16) bool { return testRoundTrip(t, cadence.NewUInt16(v)) },
+		"UInt32":    func(v uint32) bool { return testRoundTrip(t, cadence.NewUInt32(v)) },
+		"UInt64":    func(v uint64) bool { return testRoundTrip(t, cadence.NewUInt64(v)) },
+		"UInt128":   func(v uint32) bool { return testRoundTrip(t, cadence.NewUInt128(uint(v))) },
+		"UInt256":   func(v uint32) bool { return testRoundTrip(t, cadence.NewUInt256(uint(v))) },
+		"Word8":     func(v uint8) bool { return testRoundTrip(t, cadence.NewWord8(v)) },
+		"Word16":    func(v uint16) bool { return testRoundTrip(t, cadence.NewWord16(v)) },
+		"Word32":    func(v uint32) bool { return testRoundTrip(t, cadence.NewWord32(v)) },
+		"Word64":    func(v uint64) bool { return testRoundTrip(t, cadence.NewWord64(v)) },
+		"Fix64":     func(v int64) bool { return testRoundTrip(t, cadence.Fix64(v)) },
+		"UFix64":    func(v uint64) bool { return testRoundTrip(t, cadence.UFix64(v)) },
+		"Optional":  func(v int8) bool { return testRoundTrip(t, cadence.NewOptional(cadence.NewInt8(v))) },
+		"Array":     func(v []int16) bool { return testRoundTrip(t, int16Array(v)) },
+		"Path":      func(v string) bool { return testRoundTrip(t, cadence.Path{Domain: "storage", Identifier: v}) },
+		"Character": func(v rune) bool { return testRoundTrip(t, cadence.Character(string(v))) },
+	}
+
+	for name, property := range properties {
+		name := name
+		property := property
+
+		t.Run(name, func(t *testing.T) {
+
+			t.Parallel()
+
+			err := quick.Check(property, nil)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func int16Array(values []int16) cadence.Array {
+	elements := make([]cadence.Value, len(values))
+	for i, value := range values {
+		elements[i] = cadence.NewInt16(value)
+	}
+	return cadence.NewArray(elements)
+}
+
+func TestRoundTripComplexValues(t *testing.T) {
+
+	t.Parallel()
+
+	fooResourceType := &cadence.ResourceType{
+		Location:            utils.TestLocation,
+		QualifiedIdentifier: "Foo",
+		Fields: []cadence.Field{
+			{
+				Identifier: "bar",
+				Type:       cadence.IntType{},
+			},
+		},
+	}
+
+	fields := []cadence.Field{
+		{
+			Identifier: "a",
+			Type:       cadence.IntType{},
+		},
+		{
+			Identifier: "b",
+			Type:       cadence.StringType{},
+		},
+	}
+
+	fieldValues := []cadence.Value{
+		cadence.NewInt(1),
+		cadence.String("foo"),
+	}
+
+	values := map[string]cadence.Value{
+		"Void": cadence.NewVoid(),
+		"nil":  cadence.NewOptional(nil),
+		"Dictionary": cadence.NewDictionary([]cadence.KeyValuePair{
+			{Key: cadence.String("a"), Value: cadence.NewInt(1)},
+			{Key: cadence.String("b"), Value: cadence.NewInt(2)},
+		}),
+		"Struct": cadence.NewStruct(fieldValues).WithType(&cadence.StructType{
+			Location:            utils.TestLocation,
+			QualifiedIdentifier: "FooStruct",
+			Fields:              fields,
+		}),
+		"Resource": cadence.NewResource([]cadence.Value{
+			cadence.NewInt(42),
+		}).WithType(fooResourceType),
+		"Event": cadence.NewEvent(fieldValues).WithType(&cadence.EventType{
+			Location:            utils.TestLocation,
+			QualifiedIdentifier: "FooEvent",
+			Fields:              fields,
+		}),
+		"Contract": cadence.NewContract(fieldValues).WithType(&cadence.ContractType{
+			Location:            utils.TestLocation,
+			QualifiedIdentifier: "FooContract",
+			Fields:              fields,
+		}),
+		"Link": cadence.NewLink(
+			cadence.Path{Domain: "storage", Identifier: "foo"},
+			"Bar",
+		),
+		"Capability": cadence.Capability{
+			Path:       cadence.Path{Domain: "storage", Identifier: "foo"},
+			Address:    cadence.BytesToAddress([]byte{1, 2, 3, 4, 5}),
+			BorrowType: cadence.IntType{},
+		},
+		"Type": cadence.TypeValue{
+			StaticType: cadence.ConstantSizedArrayType{
+				ElementType: cadence.IntType{},
+				Size:        3,
+			},
+		},
+		"Type of composite": cadence.TypeValue{
+			StaticType: &cadence.ResourceType{
+				Location:            utils.TestLocation,
+				QualifiedIdentifier: "Foo",
+				Fields:              fields,
+				Initializers:        [][]cadence.Parameter{},
+			},
+		},
+	}
+
+	for name, value := range values {
+		name := name
+		value := value
+
+		t.Run(name, func(t *testing.T) {
+
+			t.Parallel()
+
+			testRoundTrip(t, value)
+		})
+	}
+
+	t.Run("Enum", func(t *testing.T) {
+
+		t.Parallel()
+
+		value := cadence.NewEnum([]cadence.Value{
+			cadence.NewUInt8(1),
+		}).WithType(&cadence.EnumType{
+			Location:            utils.TestLocation,
+			QualifiedIdentifier: "FooEnum",
+			Fields: []cadence.Field{
+				{
+					Identifier: "rawValue",
+					Type:       cadence.UInt8Type{},
+				},
+			},
+		})
+
+		// The JSON-Cadence format does not encode the raw type of enums,
+		// so compare with the result of a round-trip through JSON
+
+		jsonEncoded, err := json.Encode(value)
+		require.NoError(t, err)
+
+		expected, err := json.Decode(jsonEncoded)
+		require.NoError(t, err)
+
+		encoded, err := cbor.Encode(value)
+		require.NoError(t, err)
+
+		decoded, err := cbor.Decode(encoded)
+		require.NoError(t, err)
+
+		assert.Equal(t, expected, decoded)
+	})
+}
+
+func TestEncodeDeterministic(t *testing.T) {
+
+	t.Parallel()
+
+	value := cadence.NewDictionary([]cadence.KeyValuePair{
+		{Key: cadence.String("a"), Value: cadence.NewInt(1)},
+		{Key: cadence.String("b"), Value: cadence.NewInt(2)},
+	})
+
+	expected, err := cbor.Encode(value)
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		encoded, err := cbor.Encode(value)
+		require.NoError(t, err)
+		assert.Equal(t, expected, encoded)
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("malformed CBOR", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := cbor.Decode([]byte{0xff})
+		require.Error(t, err)
+	})
+
+	t.Run("invalid structure", func(t *testing.T) {
+
+		t.Parallel()
+
+		// The CBOR encoding of the text string "foo"
+		_, err := cbor.Decode([]byte{0x63, 'f', 'o', 'o'})
+		require.Error(t, err)
+	})
+}
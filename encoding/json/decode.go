@@ -73,6 +73,18 @@ func (d *Decoder) Decode() (value cadence.Value, err error) {
 		return nil, fmt.Errorf("json-cdc: failed to decode valid JSON structure: %w", err)
 	}
 
+	return DecodePrepared(jsonMap)
+}
+
+// DecodePrepared returns a Cadence value decoded from its prepared representation,
+// i.e. the result of Prepare, converted to generic values:
+// objects are maps of type map[string]interface{}, arrays are slices of type []interface{},
+// and numbers are float64 or uint64 values.
+//
+// This allows decoding values which were encoded in other formats using Prepare, e.g. CBOR.
+//
+// This function returns an error if the value does not conform to the JSON Cadence specification.
+func DecodePrepared(v interface{}) (value cadence.Value, err error) {
 	// capture panics that occur during decoding
 	defer func() {
 		if r := recover(); r != nil {
@@ -85,7 +97,7 @@ func (d *Decoder) Decode() (value cadence.Value, err error) {
 		}
 	}()
 
-	value = decodeJSON(jsonMap)
+	value = decodeJSON(v)
 	return value, nil
 }
 
@@ -1030,13 +1042,15 @@ func toBool(valueJSON interface{}) bool {
 }
 
 func toUInt(valueJSON interface{}) uint {
-	v, isNum := valueJSON.(float64)
-	if !isNum {
+	switch v := valueJSON.(type) {
+	case float64:
+		return uint(v)
+	case uint64:
+		return uint(v)
+	default:
 		// TODO: improve error message
 		panic(ErrInvalidJSONCadence)
 	}
-
-	return uint(v)
 }
 
 func toString(valueJSON interface{}) string {
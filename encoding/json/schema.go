@@ -0,0 +1,392 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// jsonSchema is a JSON Schema, or a part of it.
+// Maps are marshalled with sorted keys, so the encoding is deterministic
+type jsonSchema map[string]interface{}
+
+const jsonSchemaDraft07 = "http://json-schema.org/draft-07/schema#"
+
+const (
+	signedIntegerPattern    = `^-?[0-9]+$`
+	unsignedIntegerPattern  = `^[0-9]+$`
+	signedFixedPointPattern = `^-?[0-9]+\.[0-9]{8}$`
+	ufixedPointPattern      = `^[0-9]+\.[0-9]{8}$`
+	addressPattern          = `^0x[0-9a-f]{16}$`
+)
+
+// EncodeTypeSchema returns a JSON Schema (draft-07), which describes
+// the JSON-Cadence representation of values of the given type.
+//
+// For example, SDKs can use the schema to validate JSON-Cadence encoded arguments.
+// As in JSON-Cadence, numbers are described as strings, with a pattern.
+//
+// Composite types are described in the definitions of the schema, keyed by their type ID,
+// and are referenced from the other parts of the schema, so recursive types are supported.
+//
+// This function returns an error if values of the given type cannot be represented as JSON-Cadence,
+// e.g. functions and references.
+func EncodeTypeSchema(t cadence.Type) ([]byte, error) {
+	builder := &typeSchemaBuilder{
+		definitions: map[string]jsonSchema{},
+	}
+
+	schema, err := builder.typeSchema(t)
+	if err != nil {
+		return nil, err
+	}
+
+	// Properties next to a reference are ignored,
+	// so wrap a reference to the definition of a composite type
+
+	if _, ok := schema["$ref"]; ok {
+		schema = jsonSchema{
+			"allOf": []jsonSchema{schema},
+		}
+	}
+
+	schema["$schema"] = jsonSchemaDraft07
+	if len(builder.definitions) > 0 {
+		schema["definitions"] = builder.definitions
+	}
+
+	return json.Marshal(schema)
+}
+
+type typeSchemaBuilder struct {
+	definitions map[string]jsonSchema
+}
+
+func (b *typeSchemaBuilder) typeSchema(t cadence.Type) (jsonSchema, error) {
+	switch t := t.(type) {
+	case cadence.AnyType, cadence.AnyStructType, cadence.AnyResourceType:
+		return jsonSchema{
+			"type":     "object",
+			"required": []string{"type"},
+		}, nil
+
+	case cadence.VoidType:
+		return jsonSchema{
+			"type": "object",
+			"properties": jsonSchema{
+				"type": jsonSchema{"const": voidTypeStr},
+			},
+			"required":             []string{"type"},
+			"additionalProperties": false,
+		}, nil
+
+	case cadence.BoolType:
+		return valueSchema(boolTypeStr, jsonSchema{"type": "boolean"}), nil
+
+	case cadence.StringType:
+		return valueSchema(stringTypeStr, jsonSchema{"type": "string"}), nil
+
+	case cadence.CharacterType:
+		return valueSchema(
+			characterTypeStr,
+			jsonSchema{
+				"type":      "string",
+				"minLength": 1,
+			},
+		), nil
+
+	case cadence.AddressType:
+		return valueSchema(addressTypeStr, patternSchema(addressPattern)), nil
+
+	case cadence.IntType,
+		cadence.Int8Type,
+		cadence.Int16Type,
+		cadence.Int32Type,
+		cadence.Int64Type,
+		cadence.Int128Type,
+		cadence.Int256Type:
+
+		return valueSchema(t.ID(), patternSchema(signedIntegerPattern)), nil
+
+	case cadence.UIntType,
+		cadence.UInt8Type,
+		cadence.UInt16Type,
+		cadence.UInt32Type,
+		cadence.UInt64Type,
+		cadence.UInt128Type,
+		cadence.UInt256Type,
+		cadence.Word8Type,
+		cadence.Word16Type,
+		cadence.Word32Type,
+		cadence.Word64Type:
+
+		return valueSchema(t.ID(), patternSchema(unsignedIntegerPattern)), nil
+
+	case cadence.Fix64Type:
+		return valueSchema(fix64TypeStr, patternSchema(signedFixedPointPattern)), nil
+
+	case cadence.UFix64Type:
+		return valueSchema(ufix64TypeStr, patternSchema(ufixedPointPattern)), nil
+
+	case cadence.OptionalType:
+		innerSchema, err := b.typeSchema(t.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		return valueSchema(
+			optionalTypeStr,
+			jsonSchema{
+				"anyOf": []jsonSchema{
+					{"type": "null"},
+					innerSchema,
+				},
+			},
+		), nil
+
+	case cadence.VariableSizedArrayType:
+		elementSchema, err := b.typeSchema(t.ElementType)
+		if err != nil {
+			return nil, err
+		}
+
+		return valueSchema(
+			arrayTypeStr,
+			jsonSchema{
+				"type":  "array",
+				"items": elementSchema,
+			},
+		), nil
+
+	case cadence.ConstantSizedArrayType:
+		elementSchema, err := b.typeSchema(t.ElementType)
+		if err != nil {
+			return nil, err
+		}
+
+		return valueSchema(
+			arrayTypeStr,
+			jsonSchema{
+				"type":     "array",
+				"items":    elementSchema,
+				"minItems": t.Size,
+				"maxItems": t.Size,
+			},
+		), nil
+
+	case cadence.DictionaryType:
+		keySchema, err := b.typeSchema(t.KeyType)
+		if err != nil {
+			return nil, err
+		}
+
+		elementSchema, err := b.typeSchema(t.ElementType)
+		if err != nil {
+			return nil, err
+		}
+
+		return valueSchema(
+			dictionaryTypeStr,
+			jsonSchema{
+				"type": "array",
+				"items": jsonSchema{
+					"type": "object",
+					"properties": jsonSchema{
+						"key":   keySchema,
+						"value": elementSchema,
+					},
+					"required":             []string{"key", "value"},
+					"additionalProperties": false,
+				},
+			},
+		), nil
+
+	case *cadence.StructType:
+		return b.compositeSchema(structTypeStr, t)
+
+	case *cadence.ResourceType:
+		return b.compositeSchema(resourceTypeStr, t)
+
+	case *cadence.EventType:
+		return b.compositeSchema(eventTypeStr, t)
+
+	case *cadence.ContractType:
+		return b.compositeSchema(contractTypeStr, t)
+
+	case *cadence.EnumType:
+		return b.compositeSchema(enumTypeStr, t)
+
+	case cadence.PathType:
+		return pathSchema(common.PathDomainStorage, common.PathDomainPublic, common.PathDomainPrivate), nil
+
+	case cadence.StoragePathType:
+		return pathSchema(common.PathDomainStorage), nil
+
+	case cadence.CapabilityPathType:
+		return pathSchema(common.PathDomainPublic, common.PathDomainPrivate), nil
+
+	case cadence.PublicPathType:
+		return pathSchema(common.PathDomainPublic), nil
+
+	case cadence.PrivatePathType:
+		return pathSchema(common.PathDomainPrivate), nil
+
+	case cadence.CapabilityType:
+		return valueSchema(
+			capabilityTypeStr,
+			objectSchema(jsonSchema{
+				"path":       pathSchema(common.PathDomainStorage, common.PathDomainPublic, common.PathDomainPrivate),
+				"address":    patternSchema(addressPattern),
+				"borrowType": jsonSchema{},
+			}),
+		), nil
+
+	case cadence.MetaType:
+		return valueSchema(
+			typeTypeStr,
+			objectSchema(jsonSchema{
+				"staticType": jsonSchema{},
+			}),
+		), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported type: %s", t.ID())
+	}
+}
+
+// compositeSchema returns a reference to the definition of the given composite type,
+// and adds the definition, if it does not exist yet
+//
+func (b *typeSchemaBuilder) compositeSchema(kind string, t cadence.CompositeType) (jsonSchema, error) {
+	id := t.ID()
+
+	reference := jsonSchema{
+		"$ref": "#/definitions/" + escapeJSONPointer(id),
+	}
+
+	if _, ok := b.definitions[id]; ok {
+		return reference, nil
+	}
+
+	// Add a placeholder definition before the fields are described,
+	// so recursive references to the type terminate
+
+	b.definitions[id] = jsonSchema{}
+
+	var fieldSchemas []jsonSchema
+
+	for _, field := range t.CompositeFields() {
+		// Functions are not encoded
+		if _, ok := field.Type.(cadence.FunctionType); ok {
+			continue
+		}
+
+		fieldSchema, err := b.typeSchema(field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("field %s of %s: %w", field.Identifier, id, err)
+		}
+
+		fieldSchemas = append(fieldSchemas, objectSchema(jsonSchema{
+			"name":  jsonSchema{"const": field.Identifier},
+			"value": fieldSchema,
+		}))
+	}
+
+	fieldsSchema := jsonSchema{
+		"type":     "array",
+		"minItems": len(fieldSchemas),
+		"maxItems": len(fieldSchemas),
+	}
+	if len(fieldSchemas) > 0 {
+		fieldsSchema["items"] = fieldSchemas
+	}
+
+	b.definitions[id] = valueSchema(
+		kind,
+		objectSchema(jsonSchema{
+			"id":     jsonSchema{"const": id},
+			"fields": fieldsSchema,
+		}),
+	)
+
+	return reference, nil
+}
+
+// valueSchema returns the schema for a JSON-Cadence value
+// with the given type name and the given schema for the value
+//
+func valueSchema(typeName string, value jsonSchema) jsonSchema {
+	return objectSchema(jsonSchema{
+		"type":  jsonSchema{"const": typeName},
+		"value": value,
+	})
+}
+
+// objectSchema returns the schema for an object
+// which has exactly the given properties
+//
+func objectSchema(properties jsonSchema) jsonSchema {
+	required := make([]string, 0, len(properties))
+	for name := range properties {
+		required = append(required, name)
+	}
+	sort.Strings(required)
+
+	return jsonSchema{
+		"type":                 "object",
+		"properties":           properties,
+		"required":             required,
+		"additionalProperties": false,
+	}
+}
+
+func patternSchema(pattern string) jsonSchema {
+	return jsonSchema{
+		"type":    "string",
+		"pattern": pattern,
+	}
+}
+
+func pathSchema(domains ...common.PathDomain) jsonSchema {
+	domainNames := make([]string, len(domains))
+	for i, domain := range domains {
+		domainNames[i] = domain.Identifier()
+	}
+
+	return valueSchema(
+		pathTypeStr,
+		objectSchema(jsonSchema{
+			"domain":     jsonSchema{"enum": domainNames},
+			"identifier": jsonSchema{"type": "string"},
+		}),
+	)
+}
+
+// escapeJSONPointer escapes the given reference token of a JSON pointer (RFC 6901)
+//
+func escapeJSONPointer(token string) string {
+	token = strings.ReplaceAll(token, "~", "~0")
+	return strings.ReplaceAll(token, "/", "~1")
+}
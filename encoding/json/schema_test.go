@@ -0,0 +1,328 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func TestEncodeTypeSchema(t *testing.T) {
+
+	t.Parallel()
+
+	test := func(t *testing.T, ty cadence.Type, expected string) {
+		actual, err := json.EncodeTypeSchema(ty)
+		require.NoError(t, err)
+
+		assert.JSONEq(t, expected, string(actual))
+	}
+
+	t.Run("Int", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t,
+			cadence.IntType{},
+			`{
+              "$schema": "http://json-schema.org/draft-07/schema#",
+              "type": "object",
+              "properties": {
+                "type": {"const": "Int"},
+                "value": {"type": "string", "pattern": "^-?[0-9]+$"}
+              },
+              "required": ["type", "value"],
+              "additionalProperties": false
+            }`,
+		)
+	})
+
+	t.Run("UFix64", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t,
+			cadence.UFix64Type{},
+			`{
+              "$schema": "http://json-schema.org/draft-07/schema#",
+              "type": "object",
+              "properties": {
+                "type": {"const": "UFix64"},
+                "value": {"type": "string", "pattern": "^[0-9]+\\.[0-9]{8}$"}
+              },
+              "required": ["type", "value"],
+              "additionalProperties": false
+            }`,
+		)
+	})
+
+	t.Run("String", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t,
+			cadence.StringType{},
+			`{
+              "$schema": "http://json-schema.org/draft-07/schema#",
+              "type": "object",
+              "properties": {
+                "type": {"const": "String"},
+                "value": {"type": "string"}
+              },
+              "required": ["type", "value"],
+              "additionalProperties": false
+            }`,
+		)
+	})
+
+	t.Run("Optional", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t,
+			cadence.OptionalType{Type: cadence.BoolType{}},
+			`{
+              "$schema": "http://json-schema.org/draft-07/schema#",
+              "type": "object",
+              "properties": {
+                "type": {"const": "Optional"},
+                "value": {
+                  "anyOf": [
+                    {"type": "null"},
+                    {
+                      "type": "object",
+                      "properties": {
+                        "type": {"const": "Bool"},
+                        "value": {"type": "boolean"}
+                      },
+                      "required": ["type", "value"],
+                      "additionalProperties": false
+                    }
+                  ]
+                }
+              },
+              "required": ["type", "value"],
+              "additionalProperties": false
+            }`,
+		)
+	})
+
+	t.Run("variable-sized array", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t,
+			cadence.VariableSizedArrayType{ElementType: cadence.StringType{}},
+			`{
+              "$schema": "http://json-schema.org/draft-07/schema#",
+              "type": "object",
+              "properties": {
+                "type": {"const": "Array"},
+                "value": {
+                  "type": "array",
+                  "items": {
+                    "type": "object",
+                    "properties": {
+                      "type": {"const": "String"},
+                      "value": {"type": "string"}
+                    },
+                    "required": ["type", "value"],
+                    "additionalProperties": false
+                  }
+                }
+              },
+              "required": ["type", "value"],
+              "additionalProperties": false
+            }`,
+		)
+	})
+
+	t.Run("constant-sized array", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t,
+			cadence.ConstantSizedArrayType{
+				ElementType: cadence.UInt8Type{},
+				Size:        2,
+			},
+			`{
+              "$schema": "http://json-schema.org/draft-07/schema#",
+              "type": "object",
+              "properties": {
+                "type": {"const": "Array"},
+                "value": {
+                  "type": "array",
+                  "items": {
+                    "type": "object",
+                    "properties": {
+                      "type": {"const": "UInt8"},
+                      "value": {"type": "string", "pattern": "^[0-9]+$"}
+                    },
+                    "required": ["type", "value"],
+                    "additionalProperties": false
+                  },
+                  "minItems": 2,
+                  "maxItems": 2
+                }
+              },
+              "required": ["type", "value"],
+              "additionalProperties": false
+            }`,
+		)
+	})
+
+	t.Run("dictionary", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t,
+			cadence.DictionaryType{
+				KeyType:     cadence.StringType{},
+				ElementType: cadence.BoolType{},
+			},
+			`{
+              "$schema": "http://json-schema.org/draft-07/schema#",
+              "type": "object",
+              "properties": {
+                "type": {"const": "Dictionary"},
+                "value": {
+                  "type": "array",
+                  "items": {
+                    "type": "object",
+                    "properties": {
+                      "key": {
+                        "type": "object",
+                        "properties": {
+                          "type": {"const": "String"},
+                          "value": {"type": "string"}
+                        },
+                        "required": ["type", "value"],
+                        "additionalProperties": false
+                      },
+                      "value": {
+                        "type": "object",
+                        "properties": {
+                          "type": {"const": "Bool"},
+                          "value": {"type": "boolean"}
+                        },
+                        "required": ["type", "value"],
+                        "additionalProperties": false
+                      }
+                    },
+                    "required": ["key", "value"],
+                    "additionalProperties": false
+                  }
+                }
+              },
+              "required": ["type", "value"],
+              "additionalProperties": false
+            }`,
+		)
+	})
+
+	t.Run("recursive struct", func(t *testing.T) {
+
+		t.Parallel()
+
+		structType := &cadence.StructType{
+			Location:            utils.TestLocation,
+			QualifiedIdentifier: "Node",
+		}
+		structType.Fields = []cadence.Field{
+			{
+				Identifier: "next",
+				Type:       cadence.OptionalType{Type: structType},
+			},
+			{
+				Identifier: "f",
+				Type:       cadence.FunctionType{},
+			},
+		}
+
+		test(t,
+			structType,
+			`{
+              "$schema": "http://json-schema.org/draft-07/schema#",
+              "allOf": [{"$ref": "#/definitions/S.test.Node"}],
+              "definitions": {
+                "S.test.Node": {
+                  "type": "object",
+                  "properties": {
+                    "type": {"const": "Struct"},
+                    "value": {
+                      "type": "object",
+                      "properties": {
+                        "id": {"const": "S.test.Node"},
+                        "fields": {
+                          "type": "array",
+                          "items": [
+                            {
+                              "type": "object",
+                              "properties": {
+                                "name": {"const": "next"},
+                                "value": {
+                                  "type": "object",
+                                  "properties": {
+                                    "type": {"const": "Optional"},
+                                    "value": {
+                                      "anyOf": [
+                                        {"type": "null"},
+                                        {"$ref": "#/definitions/S.test.Node"}
+                                      ]
+                                    }
+                                  },
+                                  "required": ["type", "value"],
+                                  "additionalProperties": false
+                                }
+                              },
+                              "required": ["name", "value"],
+                              "additionalProperties": false
+                            }
+                          ],
+                          "minItems": 1,
+                          "maxItems": 1
+                        }
+                      },
+                      "required": ["fields", "id"],
+                      "additionalProperties": false
+                    }
+                  },
+                  "required": ["type", "value"],
+                  "additionalProperties": false
+                }
+              }
+            }`,
+		)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := json.EncodeTypeSchema(cadence.ReferenceType{Type: cadence.IntType{}})
+		require.Error(t, err)
+	})
+}
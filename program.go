@@ -0,0 +1,54 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cadence
+
+import (
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// Program is the application binary interface (ABI) of a program,
+// i.e. its public declarations, without their implementations.
+type Program struct {
+	// Fields are the public global variables and constants
+	Fields []Field
+	// Functions are the public global functions
+	Functions []Function
+	// Types are the public composite and interface declarations, including nested declarations
+	Types []TypeDeclaration
+	// Events are the public event declarations, including nested declarations
+	Events []*EventType
+}
+
+// Function is a function declaration of a program ABI.
+type Function struct {
+	Identifier string
+	Type       FunctionType
+}
+
+// TypeDeclaration is a composite or interface declaration of a program ABI.
+// Only the public fields and functions of the declaration are included.
+type TypeDeclaration struct {
+	TypeID string
+	Kind   common.DeclarationKind
+	// InitializerParameters are the parameters of the initializer of a composite declaration,
+	// and nil for an interface declaration
+	InitializerParameters []Parameter
+	Fields                []Field
+	Functions             []Function
+}
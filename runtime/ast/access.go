@@ -20,6 +20,7 @@ package ast
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"github.com/onflow/cadence/runtime/errors"
 )
@@ -104,3 +105,20 @@ func (a Access) Description() string {
 func (a Access) MarshalJSON() ([]byte, error) {
 	return json.Marshal(a.String())
 }
+
+func (a *Access) UnmarshalJSON(data []byte) error {
+	var name string
+	err := json.Unmarshal(data, &name)
+	if err != nil {
+		return err
+	}
+
+	for access := Access(0); access < Access(AccessCount()); access++ {
+		if access.String() == name {
+			*a = access
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid access: %s", name)
+}
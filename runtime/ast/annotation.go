@@ -0,0 +1,54 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ast
+
+import (
+	"strings"
+)
+
+// AnnotationDeclaration is an annotation of a declaration,
+// e.g. `@deprecated` or `@inline(never)`.
+//
+// Annotations are not declarations themselves,
+// they are attached to the declaration that follows them.
+//
+type AnnotationDeclaration struct {
+	Identifier Identifier
+	Arguments  Arguments `json:",omitempty"`
+	Range
+}
+
+func (a *AnnotationDeclaration) String() string {
+	var builder strings.Builder
+	builder.WriteRune('@')
+	builder.WriteString(a.Identifier.Identifier)
+	if a.Arguments != nil {
+		builder.WriteString(a.Arguments.String())
+	}
+	return builder.String()
+}
+
+func (a *AnnotationDeclaration) Clone() *AnnotationDeclaration {
+	if a == nil {
+		return nil
+	}
+	clone := *a
+	clone.Arguments = cloneArguments(a.Arguments)
+	return &clone
+}
@@ -42,6 +42,14 @@ func (a *Argument) EndPosition() Position {
 	return a.Expression.EndPosition()
 }
 
+func (a *Argument) Clone() *Argument {
+	clone := *a
+	clone.LabelStartPos = clonePosition(a.LabelStartPos)
+	clone.LabelEndPos = clonePosition(a.LabelEndPos)
+	clone.Expression = cloneExpression(a.Expression)
+	return &clone
+}
+
 func (a *Argument) String() string {
 	var builder strings.Builder
 	if a.Label != "" {
@@ -62,3 +70,7 @@ func (a *Argument) MarshalJSON() ([]byte, error) {
 		Alias: (*Alias)(a),
 	})
 }
+
+func (a *Argument) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, a)
+}
@@ -0,0 +1,121 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ast
+
+import (
+	"encoding/json"
+
+	"github.com/turbolent/prettier"
+
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// AttachmentDeclaration
+
+type AttachmentDeclaration struct {
+	Access      Access
+	Identifier  Identifier
+	BaseType    *NominalType
+	Members     *Members
+	DocString   string
+	Annotations []*AnnotationDeclaration `json:",omitempty"`
+	Range
+}
+
+func (d *AttachmentDeclaration) Accept(visitor Visitor) Repr {
+	return visitor.VisitAttachmentDeclaration(d)
+}
+
+func (d *AttachmentDeclaration) Walk(walkChild func(Element)) {
+	walkDeclarations(walkChild, d.Members.declarations)
+}
+
+func (d *AttachmentDeclaration) Clone() Element {
+	clone := *d
+	clone.Annotations = cloneAnnotations(d.Annotations)
+	clone.BaseType = cloneNominalType(d.BaseType)
+	clone.Members = d.Members.Clone()
+	return &clone
+}
+
+func (d *AttachmentDeclaration) Equal(other Element) bool {
+	return equal(d, other)
+}
+
+func (*AttachmentDeclaration) isDeclaration() {}
+
+func (d *AttachmentDeclaration) DeclarationIdentifier() *Identifier {
+	return &d.Identifier
+}
+
+func (d *AttachmentDeclaration) DeclarationKind() common.DeclarationKind {
+	return common.DeclarationKindAttachment
+}
+
+func (d *AttachmentDeclaration) DeclarationAccess() Access {
+	return d.Access
+}
+
+func (d *AttachmentDeclaration) DeclarationMembers() *Members {
+	return d.Members
+}
+
+func (d *AttachmentDeclaration) DeclarationDocString() string {
+	return d.DocString
+}
+
+const attachmentKeywordDoc = prettier.Text("attachment")
+const attachmentForKeywordDoc = prettier.Text("for")
+
+func (d *AttachmentDeclaration) Doc() prettier.Doc {
+	return declarationDoc(
+		d.DocString,
+		d.Access,
+		prettier.Concat{
+			attachmentKeywordDoc,
+			prettier.Space,
+			prettier.Text(d.Identifier.Identifier),
+			prettier.Space,
+			attachmentForKeywordDoc,
+			prettier.Space,
+			d.BaseType.Doc(),
+			prettier.Space,
+			d.Members.Doc(),
+		},
+	)
+}
+
+func (d *AttachmentDeclaration) String() string {
+	return declarationString(d)
+}
+
+func (d *AttachmentDeclaration) MarshalJSON() ([]byte, error) {
+	type Alias AttachmentDeclaration
+	return json.Marshal(&struct {
+		Type string
+		*Alias
+	}{
+		Type:  "AttachmentDeclaration",
+		Alias: (*Alias)(d),
+	})
+}
+
+func (d *AttachmentDeclaration) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, d)
+}
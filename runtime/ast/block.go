@@ -41,6 +41,16 @@ func (b *Block) Walk(walkChild func(Element)) {
 	walkStatements(walkChild, b.Statements)
 }
 
+func (b *Block) Clone() Element {
+	clone := *b
+	clone.Statements = cloneStatements(b.Statements)
+	return &clone
+}
+
+func (b *Block) Equal(other Element) bool {
+	return equal(b, other)
+}
+
 var blockStartDoc prettier.Doc = prettier.Text("{")
 var blockEndDoc prettier.Doc = prettier.Text("}")
 var blockEmptyDoc prettier.Doc = prettier.Text("{}")
@@ -64,16 +74,10 @@ func StatementsDoc(statements []Statement) prettier.Doc {
 	var statementsDoc prettier.Concat
 
 	for _, statement := range statements {
-		// TODO: replace once Statement implements Doc
-		hasDoc, ok := statement.(interface{ Doc() prettier.Doc })
-		if !ok {
-			continue
-		}
-
 		statementsDoc = append(
 			statementsDoc,
 			prettier.HardLine{},
-			hasDoc.Doc(),
+			statement.Doc(),
 		)
 	}
 
@@ -91,6 +95,10 @@ func (b *Block) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (b *Block) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, b)
+}
+
 // FunctionBlock
 
 type FunctionBlock struct {
@@ -111,9 +119,70 @@ func (b *FunctionBlock) Accept(visitor Visitor) Repr {
 }
 
 func (b *FunctionBlock) Walk(walkChild func(Element)) {
-	// TODO: pre-conditions
+	walkConditions(walkChild, b.PreConditions)
 	walkChild(b.Block)
-	// TODO: post-conditions
+	walkConditions(walkChild, b.PostConditions)
+}
+
+func (b *FunctionBlock) Clone() Element {
+	clone := *b
+	clone.Block = cloneBlock(b.Block)
+	clone.PreConditions = b.PreConditions.Clone()
+	clone.PostConditions = b.PostConditions.Clone()
+	return &clone
+}
+
+func (b *FunctionBlock) Equal(other Element) bool {
+	return equal(b, other)
+}
+
+var preConditionsKeywordSpaceDoc prettier.Doc = prettier.Text("pre ")
+var postConditionsKeywordSpaceDoc prettier.Doc = prettier.Text("post ")
+
+func (b *FunctionBlock) Doc() prettier.Doc {
+	if b.IsEmpty() {
+		return blockEmptyDoc
+	}
+
+	if b.PreConditions.IsEmpty() && b.PostConditions.IsEmpty() {
+		return b.Block.Doc()
+	}
+
+	var bodyDoc prettier.Concat
+
+	if !b.PreConditions.IsEmpty() {
+		bodyDoc = append(
+			bodyDoc,
+			prettier.HardLine{},
+			preConditionsKeywordSpaceDoc,
+			b.PreConditions.Doc(),
+		)
+	}
+
+	if !b.PostConditions.IsEmpty() {
+		bodyDoc = append(
+			bodyDoc,
+			prettier.HardLine{},
+			postConditionsKeywordSpaceDoc,
+			b.PostConditions.Doc(),
+		)
+	}
+
+	if b.Block != nil && !b.Block.IsEmpty() {
+		bodyDoc = append(
+			bodyDoc,
+			StatementsDoc(b.Block.Statements),
+		)
+	}
+
+	return prettier.Concat{
+		blockStartDoc,
+		prettier.Indent{
+			Doc: bodyDoc,
+		},
+		prettier.HardLine{},
+		blockEndDoc,
+	}
 }
 
 func (b *FunctionBlock) MarshalJSON() ([]byte, error) {
@@ -129,6 +198,10 @@ func (b *FunctionBlock) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (b *FunctionBlock) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, b)
+}
+
 func (b *FunctionBlock) StartPosition() Position {
 	return b.Block.StartPos
 }
@@ -145,6 +218,40 @@ type Condition struct {
 	Message Expression
 }
 
+var conditionMessageSeparatorDoc prettier.Doc = prettier.Text(":")
+
+func (c *Condition) Doc() prettier.Doc {
+	doc := c.Test.Doc()
+
+	if c.Message == nil {
+		return doc
+	}
+
+	return prettier.Group{
+		Doc: prettier.Concat{
+			doc,
+			conditionMessageSeparatorDoc,
+			prettier.Indent{
+				Doc: prettier.Concat{
+					prettier.Line{},
+					c.Message.Doc(),
+				},
+			},
+		},
+	}
+}
+
+func (c *Condition) Clone() *Condition {
+	clone := *c
+	clone.Test = cloneExpression(c.Test)
+	clone.Message = cloneExpression(c.Message)
+	return &clone
+}
+
+func (c *Condition) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, c)
+}
+
 // Conditions
 
 type Conditions []*Condition
@@ -152,3 +259,43 @@ type Conditions []*Condition
 func (c *Conditions) IsEmpty() bool {
 	return c == nil || len(*c) == 0
 }
+
+func (c *Conditions) Clone() *Conditions {
+	if c == nil {
+		return nil
+	}
+
+	var clone Conditions
+	if *c != nil {
+		clone = make(Conditions, len(*c))
+		for i, condition := range *c {
+			clone[i] = condition.Clone()
+		}
+	}
+	return &clone
+}
+
+func (c *Conditions) Doc() prettier.Doc {
+	if c.IsEmpty() {
+		return blockEmptyDoc
+	}
+
+	var conditionsDoc prettier.Concat
+
+	for _, condition := range *c {
+		conditionsDoc = append(
+			conditionsDoc,
+			prettier.HardLine{},
+			condition.Doc(),
+		)
+	}
+
+	return prettier.Concat{
+		blockStartDoc,
+		prettier.Indent{
+			Doc: conditionsDoc,
+		},
+		prettier.HardLine{},
+		blockEndDoc,
+	}
+}
@@ -0,0 +1,231 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ast
+
+import (
+	"math/big"
+)
+
+func cloneExpression(expression Expression) Expression {
+	if expression == nil {
+		return nil
+	}
+	return expression.Clone().(Expression)
+}
+
+func cloneExpressions(expressions []Expression) []Expression {
+	if expressions == nil {
+		return nil
+	}
+	clones := make([]Expression, len(expressions))
+	for i, expression := range expressions {
+		clones[i] = cloneExpression(expression)
+	}
+	return clones
+}
+
+func cloneStatements(statements []Statement) []Statement {
+	if statements == nil {
+		return nil
+	}
+	clones := make([]Statement, len(statements))
+	for i, statement := range statements {
+		clones[i] = statement.Clone().(Statement)
+	}
+	return clones
+}
+
+func cloneDeclarations(declarations []Declaration) []Declaration {
+	if declarations == nil {
+		return nil
+	}
+	clones := make([]Declaration, len(declarations))
+	for i, declaration := range declarations {
+		clones[i] = declaration.Clone().(Declaration)
+	}
+	return clones
+}
+
+func cloneFieldDeclarations(fields []*FieldDeclaration) []*FieldDeclaration {
+	if fields == nil {
+		return nil
+	}
+	clones := make([]*FieldDeclaration, len(fields))
+	for i, field := range fields {
+		clones[i] = field.Clone().(*FieldDeclaration)
+	}
+	return clones
+}
+
+func cloneFunctionDeclaration(declaration *FunctionDeclaration) *FunctionDeclaration {
+	if declaration == nil {
+		return nil
+	}
+	return declaration.Clone().(*FunctionDeclaration)
+}
+
+func cloneSpecialFunctionDeclaration(declaration *SpecialFunctionDeclaration) *SpecialFunctionDeclaration {
+	if declaration == nil {
+		return nil
+	}
+	return declaration.Clone().(*SpecialFunctionDeclaration)
+}
+
+func cloneBlock(block *Block) *Block {
+	if block == nil {
+		return nil
+	}
+	return block.Clone().(*Block)
+}
+
+func cloneFunctionBlock(functionBlock *FunctionBlock) *FunctionBlock {
+	if functionBlock == nil {
+		return nil
+	}
+	return functionBlock.Clone().(*FunctionBlock)
+}
+
+func cloneInvocationExpression(expression *InvocationExpression) *InvocationExpression {
+	if expression == nil {
+		return nil
+	}
+	return expression.Clone().(*InvocationExpression)
+}
+
+func cloneIntegerExpression(expression *IntegerExpression) *IntegerExpression {
+	if expression == nil {
+		return nil
+	}
+	return expression.Clone().(*IntegerExpression)
+}
+
+func cloneDictionaryEntries(entries []DictionaryEntry) []DictionaryEntry {
+	if entries == nil {
+		return nil
+	}
+	clones := make([]DictionaryEntry, len(entries))
+	for i, entry := range entries {
+		clones[i] = DictionaryEntry{
+			Key:   cloneExpression(entry.Key),
+			Value: cloneExpression(entry.Value),
+		}
+	}
+	return clones
+}
+
+func cloneArguments(arguments Arguments) Arguments {
+	if arguments == nil {
+		return nil
+	}
+	clones := make(Arguments, len(arguments))
+	for i, argument := range arguments {
+		clones[i] = argument.Clone()
+	}
+	return clones
+}
+
+func cloneSwitchCases(cases []*SwitchCase) []*SwitchCase {
+	if cases == nil {
+		return nil
+	}
+	clones := make([]*SwitchCase, len(cases))
+	for i, switchCase := range cases {
+		clones[i] = switchCase.Clone()
+	}
+	return clones
+}
+
+func cloneType(ty Type) Type {
+	if ty == nil {
+		return nil
+	}
+	return ty.Clone()
+}
+
+func cloneTypeAnnotations(typeAnnotations []*TypeAnnotation) []*TypeAnnotation {
+	if typeAnnotations == nil {
+		return nil
+	}
+	clones := make([]*TypeAnnotation, len(typeAnnotations))
+	for i, typeAnnotation := range typeAnnotations {
+		clones[i] = typeAnnotation.Clone()
+	}
+	return clones
+}
+
+func cloneNominalType(ty *NominalType) *NominalType {
+	if ty == nil {
+		return nil
+	}
+	return ty.Clone().(*NominalType)
+}
+
+func cloneNominalTypes(types []*NominalType) []*NominalType {
+	if types == nil {
+		return nil
+	}
+	clones := make([]*NominalType, len(types))
+	for i, ty := range types {
+		clones[i] = ty.Clone().(*NominalType)
+	}
+	return clones
+}
+
+func cloneIdentifier(identifier *Identifier) *Identifier {
+	if identifier == nil {
+		return nil
+	}
+	clone := *identifier
+	return &clone
+}
+
+func cloneIdentifiers(identifiers []Identifier) []Identifier {
+	if identifiers == nil {
+		return nil
+	}
+	clones := make([]Identifier, len(identifiers))
+	copy(clones, identifiers)
+	return clones
+}
+
+func clonePosition(position *Position) *Position {
+	if position == nil {
+		return nil
+	}
+	clone := *position
+	return &clone
+}
+
+func cloneBigInt(value *big.Int) *big.Int {
+	if value == nil {
+		return nil
+	}
+	return new(big.Int).Set(value)
+}
+
+func cloneAnnotations(annotations []*AnnotationDeclaration) []*AnnotationDeclaration {
+	if annotations == nil {
+		return nil
+	}
+	clones := make([]*AnnotationDeclaration, len(annotations))
+	for i, annotation := range annotations {
+		clones[i] = annotation.Clone()
+	}
+	return clones
+}
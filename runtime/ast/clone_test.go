@@ -0,0 +1,279 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ast_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/parser2"
+)
+
+const cloneTestCode = `
+  #allowAccountLinking
+
+  import Foo, Bar from 0x1
+
+  /// A test contract
+  pub contract C: I {
+
+      pub let x: Int
+
+      pub var y: {String: [Int; 2]}?
+
+      pub enum E: UInt8 {
+          pub case a
+          pub case b
+      }
+
+      pub resource R {
+          let z: &AnyResource{I}
+
+          init(z: &AnyResource{I}) {
+              self.z = z
+          }
+
+          destroy() {}
+      }
+
+      pub event Created(id: UInt64)
+
+      init() {
+          self.x = 1
+          self.y = {"a": [1, 2]}
+      }
+
+      pub fun f(_ a: Int, b: Fix64): ((Int): Bool) {
+          pre {
+              a > 0: "a must be positive"
+          }
+          post {
+              result != nil
+          }
+
+          let r <- create R(z: &self as &AnyResource{I})
+          destroy r
+
+          if let y = self.y {
+              for i, v in y["a"] ?? [] {
+                  emit Created(id: UInt64(v))
+              }
+          } else {
+              while a < 10 {
+                  break
+              }
+          }
+
+          switch a {
+              case 1:
+                  return fun (x: Int): Bool { return !(x == -1) }
+              default:
+                  continue
+          }
+
+          var c = b as! Fix64
+          c <-> c
+          let p = /storage/foo
+          let n = f<Int>(a: -1.5)?.g[0]!
+          return fun (x: Int): Bool { return x > 0 ? true : false }
+      }
+  }
+
+  transaction(amount: UFix64) {
+      let a: Int
+
+      prepare(signer: AuthAccount) {
+          self.a = 1
+      }
+
+      pre {
+          amount > 0.0
+      }
+
+      execute {
+          log(amount)
+      }
+
+      post {
+          self.a == 1
+      }
+  }
+`
+
+func cloneTestProgram(t *testing.T) *ast.Program {
+	program, err := parser2.ParseProgram(cloneTestCode)
+	require.NoError(t, err)
+	return program
+}
+
+type elementCollector []ast.Element
+
+func (c *elementCollector) Walk(element ast.Element) ast.Walker {
+	if element != nil {
+		*c = append(*c, element)
+	}
+	return c
+}
+
+func collectElements(element ast.Element) []ast.Element {
+	var collector elementCollector
+	ast.Walk(&collector, element)
+	return collector
+}
+
+func TestClone(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("equal", func(t *testing.T) {
+
+		t.Parallel()
+
+		program := cloneTestProgram(t)
+
+		clone := program.Clone()
+
+		assert.Equal(t, program, clone)
+	})
+
+	t.Run("fresh allocations", func(t *testing.T) {
+
+		t.Parallel()
+
+		program := cloneTestProgram(t)
+
+		clone := program.Clone()
+
+		elements := collectElements(program)
+		clonedElements := collectElements(clone)
+
+		require.Len(t, clonedElements, len(elements))
+
+		for i, element := range elements {
+			assert.NotSame(t, element, clonedElements[i])
+		}
+	})
+
+	t.Run("mutation", func(t *testing.T) {
+
+		t.Parallel()
+
+		program := cloneTestProgram(t)
+
+		expected, err := json.Marshal(program)
+		require.NoError(t, err)
+
+		clone := program.Clone().(*ast.Program)
+
+		// Mutate every element of the clone
+
+		for _, element := range collectElements(clone) {
+			switch element := element.(type) {
+			case *ast.Block:
+				element.Statements = nil
+			case *ast.CompositeDeclaration:
+				element.Identifier.Identifier = "X"
+				if len(element.Conformances) > 0 {
+					element.Conformances[0].Identifier.Identifier = "X"
+				}
+			case *ast.FieldDeclaration:
+				element.TypeAnnotation.Type = &ast.NominalType{}
+			case *ast.FunctionDeclaration:
+				element.Identifier.Identifier = "x"
+				if len(element.ParameterList.Parameters) > 0 {
+					element.ParameterList.Parameters[0].Identifier.Identifier = "x"
+				}
+				if element.FunctionBlock.PreConditions != nil {
+					(*element.FunctionBlock.PreConditions)[0].Test = &ast.BoolExpression{}
+				}
+			case *ast.ImportDeclaration:
+				element.Identifiers[0].Identifier = "X"
+			case *ast.IntegerExpression:
+				element.Value.SetInt64(42)
+			case *ast.FixedPointExpression:
+				element.UnsignedInteger.SetInt64(42)
+			case *ast.InvocationExpression:
+				if len(element.Arguments) > 0 {
+					element.Arguments[0].Label = "x"
+				}
+			case *ast.DictionaryExpression:
+				if len(element.Entries) > 0 {
+					element.Entries[0].Key = &ast.NilExpression{}
+				}
+			case *ast.StringExpression:
+				element.Value = "x"
+			case *ast.SwitchStatement:
+				element.Cases[0].Statements = nil
+			case *ast.VariableDeclaration:
+				element.Identifier.Identifier = "x"
+				if element.TypeAnnotation != nil {
+					element.TypeAnnotation.IsResource = true
+				}
+			}
+		}
+
+		actual, err := json.Marshal(program)
+		require.NoError(t, err)
+
+		assert.JSONEq(t, string(expected), string(actual))
+	})
+
+	t.Run("back-pointers", func(t *testing.T) {
+
+		t.Parallel()
+
+		program, err := parser2.ParseProgram(`
+          fun test() {
+              if let x = 1 as? Int {}
+          }
+        `)
+		require.NoError(t, err)
+
+		clone := program.Clone().(*ast.Program)
+
+		ifStatement := clone.FunctionDeclarations()[0].FunctionBlock.Block.Statements[0].(*ast.IfStatement)
+		variableDeclaration := ifStatement.Test.(*ast.VariableDeclaration)
+		castingExpression := variableDeclaration.Value.(*ast.CastingExpression)
+
+		assert.Same(t, ifStatement, variableDeclaration.ParentIfStatement)
+		assert.Same(t, variableDeclaration, castingExpression.ParentVariableDeclaration)
+	})
+
+	t.Run("big integers", func(t *testing.T) {
+
+		t.Parallel()
+
+		expression := &ast.IntegerExpression{
+			PositiveLiteral: "1",
+			Value:           big.NewInt(1),
+			Base:            10,
+		}
+
+		clone := expression.Clone().(*ast.IntegerExpression)
+
+		clone.Value.SetInt64(2)
+
+		assert.Equal(t, big.NewInt(1), expression.Value)
+	})
+}
@@ -21,6 +21,8 @@ package ast
 import (
 	"encoding/json"
 
+	"github.com/turbolent/prettier"
+
 	"github.com/onflow/cadence/runtime/common"
 )
 
@@ -35,6 +37,7 @@ type CompositeDeclaration struct {
 	Conformances  []*NominalType
 	Members       *Members
 	DocString     string
+	Annotations   []*AnnotationDeclaration `json:",omitempty"`
 	Range
 }
 
@@ -46,6 +49,18 @@ func (d *CompositeDeclaration) Walk(walkChild func(Element)) {
 	walkDeclarations(walkChild, d.Members.declarations)
 }
 
+func (d *CompositeDeclaration) Clone() Element {
+	clone := *d
+	clone.Annotations = cloneAnnotations(d.Annotations)
+	clone.Conformances = cloneNominalTypes(d.Conformances)
+	clone.Members = d.Members.Clone()
+	return &clone
+}
+
+func (d *CompositeDeclaration) Equal(other Element) bool {
+	return equal(d, other)
+}
+
 func (*CompositeDeclaration) isDeclaration() {}
 
 // NOTE: statement, so it can be represented in the AST,
@@ -73,6 +88,78 @@ func (d *CompositeDeclaration) DeclarationDocString() string {
 	return d.DocString
 }
 
+var conformancesSeparatorDoc prettier.Doc = prettier.Text(":")
+var conformanceSeparatorDoc prettier.Doc = prettier.Concat{
+	prettier.Text(","),
+	prettier.Line{},
+}
+
+func (d *CompositeDeclaration) Doc() prettier.Doc {
+
+	doc := prettier.Concat{
+		prettier.Text(d.CompositeKind.Keyword()),
+		prettier.Space,
+		prettier.Text(d.Identifier.Identifier),
+	}
+
+	// Events are declared with a parameter list,
+	// which is the parameter list of their only member, the initializer
+
+	if d.CompositeKind == common.CompositeKindEvent {
+		var parameterList *ParameterList
+		specialFunctions := d.Members.SpecialFunctions()
+		if len(specialFunctions) > 0 {
+			parameterList = specialFunctions[0].FunctionDeclaration.ParameterList
+		}
+
+		return declarationDoc(
+			d.DocString,
+			d.Access,
+			append(
+				doc,
+				parameterList.Doc(),
+			),
+		)
+	}
+
+	if len(d.Conformances) > 0 {
+		conformanceDocs := make([]prettier.Doc, 0, len(d.Conformances))
+		for _, conformance := range d.Conformances {
+			conformanceDocs = append(conformanceDocs, conformance.Doc())
+		}
+
+		doc = append(
+			doc,
+			conformancesSeparatorDoc,
+			prettier.Group{
+				Doc: prettier.Indent{
+					Doc: prettier.Concat{
+						prettier.Line{},
+						prettier.Join(
+							conformanceSeparatorDoc,
+							conformanceDocs...,
+						),
+					},
+				},
+			},
+		)
+	}
+
+	return declarationDoc(
+		d.DocString,
+		d.Access,
+		append(
+			doc,
+			prettier.Space,
+			d.Members.Doc(),
+		),
+	)
+}
+
+func (d *CompositeDeclaration) String() string {
+	return declarationString(d)
+}
+
 func (d *CompositeDeclaration) MarshalJSON() ([]byte, error) {
 	type Alias CompositeDeclaration
 	return json.Marshal(&struct {
@@ -84,6 +171,10 @@ func (d *CompositeDeclaration) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (d *CompositeDeclaration) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, d)
+}
+
 // FieldDeclaration
 
 type FieldDeclaration struct {
@@ -92,6 +183,7 @@ type FieldDeclaration struct {
 	Identifier     Identifier
 	TypeAnnotation *TypeAnnotation
 	DocString      string
+	Annotations    []*AnnotationDeclaration `json:",omitempty"`
 	Range
 }
 
@@ -104,6 +196,17 @@ func (d *FieldDeclaration) Walk(_ func(Element)) {
 	// TODO: walk type
 }
 
+func (d *FieldDeclaration) Clone() Element {
+	clone := *d
+	clone.Annotations = cloneAnnotations(d.Annotations)
+	clone.TypeAnnotation = d.TypeAnnotation.Clone()
+	return &clone
+}
+
+func (d *FieldDeclaration) Equal(other Element) bool {
+	return equal(d, other)
+}
+
 func (*FieldDeclaration) isDeclaration() {}
 
 func (d *FieldDeclaration) DeclarationIdentifier() *Identifier {
@@ -126,6 +229,32 @@ func (d *FieldDeclaration) DeclarationDocString() string {
 	return d.DocString
 }
 
+func (d *FieldDeclaration) Doc() prettier.Doc {
+	var doc prettier.Concat
+
+	keyword := d.VariableKind.Keyword()
+	if keyword != "" {
+		doc = append(
+			doc,
+			prettier.Text(keyword),
+			prettier.Space,
+		)
+	}
+
+	doc = append(
+		doc,
+		prettier.Text(d.Identifier.Identifier),
+		typeSeparatorDoc,
+		d.TypeAnnotation.Doc(),
+	)
+
+	return declarationDoc(d.DocString, d.Access, doc)
+}
+
+func (d *FieldDeclaration) String() string {
+	return declarationString(d)
+}
+
 func (d *FieldDeclaration) MarshalJSON() ([]byte, error) {
 	type Alias FieldDeclaration
 	return json.Marshal(&struct {
@@ -137,13 +266,18 @@ func (d *FieldDeclaration) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (d *FieldDeclaration) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, d)
+}
+
 // EnumCaseDeclaration
 
 type EnumCaseDeclaration struct {
-	Access     Access
-	Identifier Identifier
-	DocString  string
-	StartPos   Position `json:"-"`
+	Access      Access
+	Identifier  Identifier
+	DocString   string
+	Annotations []*AnnotationDeclaration `json:",omitempty"`
+	StartPos    Position                 `json:"-"`
 }
 
 func (d *EnumCaseDeclaration) Accept(visitor Visitor) Repr {
@@ -154,6 +288,16 @@ func (*EnumCaseDeclaration) Walk(_ func(Element)) {
 	// NO-OP
 }
 
+func (d *EnumCaseDeclaration) Clone() Element {
+	clone := *d
+	clone.Annotations = cloneAnnotations(d.Annotations)
+	return &clone
+}
+
+func (d *EnumCaseDeclaration) Equal(other Element) bool {
+	return equal(d, other)
+}
+
 func (*EnumCaseDeclaration) isDeclaration() {}
 
 func (d *EnumCaseDeclaration) DeclarationIdentifier() *Identifier {
@@ -184,6 +328,23 @@ func (d *EnumCaseDeclaration) DeclarationDocString() string {
 	return d.DocString
 }
 
+var enumCaseKeywordSpaceDoc prettier.Doc = prettier.Text("case ")
+
+func (d *EnumCaseDeclaration) Doc() prettier.Doc {
+	return declarationDoc(
+		d.DocString,
+		d.Access,
+		prettier.Concat{
+			enumCaseKeywordSpaceDoc,
+			prettier.Text(d.Identifier.Identifier),
+		},
+	)
+}
+
+func (d *EnumCaseDeclaration) String() string {
+	return declarationString(d)
+}
+
 func (d *EnumCaseDeclaration) MarshalJSON() ([]byte, error) {
 	type Alias EnumCaseDeclaration
 	return json.Marshal(&struct {
@@ -196,3 +357,7 @@ func (d *EnumCaseDeclaration) MarshalJSON() ([]byte, error) {
 		Alias: (*Alias)(d),
 	})
 }
+
+func (d *EnumCaseDeclaration) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, d)
+}
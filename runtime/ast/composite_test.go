@@ -158,3 +158,46 @@ func TestCompositeDeclaration_MarshalJSON(t *testing.T) {
 		string(actual),
 	)
 }
+
+func TestCompositeDeclaration_String(t *testing.T) {
+
+	t.Parallel()
+
+	decl := &CompositeDeclaration{
+		Access:        AccessPublic,
+		CompositeKind: common.CompositeKindResource,
+		Identifier: Identifier{
+			Identifier: "AB",
+		},
+		Conformances: []*NominalType{
+			{
+				Identifier: Identifier{
+					Identifier: "CD",
+				},
+			},
+		},
+		Members: NewMembers(
+			[]Declaration{
+				&FieldDeclaration{
+					Access:       AccessPublic,
+					VariableKind: VariableKindConstant,
+					Identifier: Identifier{
+						Identifier: "x",
+					},
+					TypeAnnotation: &TypeAnnotation{
+						Type: &NominalType{
+							Identifier: Identifier{
+								Identifier: "Int",
+							},
+						},
+					},
+				},
+			},
+		),
+	}
+
+	assert.Equal(t,
+		"pub resource AB: CD {\n    pub let x: Int\n}",
+		decl.String(),
+	)
+}
@@ -20,6 +20,7 @@ package ast
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"github.com/onflow/cadence/runtime/errors"
 )
@@ -52,3 +53,20 @@ func (k ConditionKind) Name() string {
 func (k ConditionKind) MarshalJSON() ([]byte, error) {
 	return json.Marshal(k.String())
 }
+
+func (k *ConditionKind) UnmarshalJSON(data []byte) error {
+	var name string
+	err := json.Unmarshal(data, &name)
+	if err != nil {
+		return err
+	}
+
+	for kind := ConditionKind(0); kind < ConditionKind(ConditionKindCount()); kind++ {
+		if kind.String() == name {
+			*k = kind
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid condition kind: %s", name)
+}
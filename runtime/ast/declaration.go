@@ -18,14 +18,76 @@
 
 package ast
 
-import "github.com/onflow/cadence/runtime/common"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/turbolent/prettier"
+
+	"github.com/onflow/cadence/runtime/common"
+)
 
 type Declaration interface {
 	Element
+	fmt.Stringer
 	isDeclaration()
 	DeclarationIdentifier() *Identifier
 	DeclarationKind() common.DeclarationKind
 	DeclarationAccess() Access
 	DeclarationMembers() *Members
 	DeclarationDocString() string
+	Doc() prettier.Doc
+}
+
+const docStringPrefix = "///"
+
+const declarationStringMaxLineWidth = 80
+
+const declarationStringIndentation = "    "
+
+// declarationString returns the source code of the given declaration.
+//
+// The result is intended for debugging purposes.
+// Use the formatter package to format code canonically.
+//
+func declarationString(declaration Declaration) string {
+	var builder strings.Builder
+	prettier.Prettier(
+		&builder,
+		declaration.Doc(),
+		declarationStringMaxLineWidth,
+		declarationStringIndentation,
+	)
+	return builder.String()
+}
+
+// declarationDoc returns the document for a declaration,
+// prefixed with the doc string and the access modifier, if any
+//
+func declarationDoc(docString string, access Access, doc prettier.Doc) prettier.Doc {
+	if access != AccessNotSpecified {
+		doc = prettier.Concat{
+			prettier.Text(access.Keyword()),
+			prettier.Space,
+			doc,
+		}
+	}
+
+	if docString == "" {
+		return doc
+	}
+
+	lines := strings.Split(docString, "\n")
+
+	docStringDoc := make(prettier.Concat, 0, len(lines)*2+1)
+
+	for _, line := range lines {
+		docStringDoc = append(
+			docStringDoc,
+			prettier.Text(docStringPrefix+line),
+			prettier.HardLine{},
+		)
+	}
+
+	return append(docStringDoc, doc)
 }
@@ -0,0 +1,240 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ast
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+)
+
+// DiffEntry is a difference between two elements.
+//
+// Path is the path to the differing value, starting with the type of the element,
+// e.g. `FunctionDeclaration.FunctionBlock.Block.Statements[2].Expression`.
+// Got and Want are the differing values.
+//
+type DiffEntry struct {
+	Path string
+	Got  interface{}
+	Want interface{}
+}
+
+func (e DiffEntry) String() string {
+	return fmt.Sprintf("%s: got %v, want %v", e.Path, e.Got, e.Want)
+}
+
+// DiffOption is a function that configures the diff.
+type DiffOption func(*differ)
+
+// WithDiffPositions returns a diff option which sets
+// if source positions are compared.
+// Positions are not compared by default.
+//
+func WithDiffPositions(enabled bool) DiffOption {
+	return func(d *differ) {
+		d.positions = enabled
+	}
+}
+
+type differ struct {
+	positions bool
+	entries   []DiffEntry
+}
+
+var positionType = reflect.TypeOf(Position{})
+var bigIntType = reflect.TypeOf(&big.Int{})
+var membersType = reflect.TypeOf(&Members{})
+var programType = reflect.TypeOf(&Program{})
+
+// parentFields are the names of fields which refer back to a parent element.
+// They are not part of the structure of an element, and would lead to cycles.
+//
+var parentFields = map[string]bool{
+	"ParentIfStatement":         true,
+	"ParentVariableDeclaration": true,
+}
+
+// Diff returns the structural differences between the given elements,
+// in the order of their fields.
+// It returns nil if the elements are equal.
+//
+// Unexported fields, e.g. caches, and references to parents are ignored,
+// and the members of composites and the declarations of programs
+// are compared through their declarations.
+//
+func Diff(got, want Element, options ...DiffOption) []DiffEntry {
+	d := &differ{}
+	for _, option := range options {
+		option(d)
+	}
+
+	gotValue := reflect.ValueOf(got)
+	wantValue := reflect.ValueOf(want)
+
+	var path string
+	switch {
+	case got != nil:
+		path = reflect.Indirect(gotValue).Type().Name()
+	case want != nil:
+		path = reflect.Indirect(wantValue).Type().Name()
+	}
+
+	d.diff(path, gotValue, wantValue)
+
+	return d.entries
+}
+
+// equal returns true if the given values are structurally equal, ignoring positions.
+// It is used to implement the Equal functions of elements and types.
+//
+func equal(got, want interface{}) bool {
+	d := &differ{}
+	d.diff("", reflect.ValueOf(got), reflect.ValueOf(want))
+	return len(d.entries) == 0
+}
+
+func (d *differ) report(path string, got, want reflect.Value) {
+	d.entries = append(
+		d.entries,
+		DiffEntry{
+			Path: path,
+			Got:  valueInterface(got),
+			Want: valueInterface(want),
+		},
+	)
+}
+
+func valueInterface(value reflect.Value) interface{} {
+	if !value.IsValid() {
+		return nil
+	}
+	return value.Interface()
+}
+
+func (d *differ) diff(path string, got, want reflect.Value) {
+
+	if !got.IsValid() || !want.IsValid() {
+		if got.IsValid() != want.IsValid() {
+			d.report(path, got, want)
+		}
+		return
+	}
+
+	if got.Type() != want.Type() {
+		d.report(path, got, want)
+		return
+	}
+
+	switch got.Type() {
+	case positionType:
+		if d.positions && got.Interface() != want.Interface() {
+			d.report(path, got, want)
+		}
+		return
+
+	case bigIntType:
+		gotInt := got.Interface().(*big.Int)
+		wantInt := want.Interface().(*big.Int)
+		if (gotInt == nil) != (wantInt == nil) ||
+			gotInt != nil && gotInt.Cmp(wantInt) != 0 {
+
+			d.report(path, got, want)
+		}
+		return
+
+	case membersType:
+		if got.IsNil() || want.IsNil() {
+			break
+		}
+		d.diff(
+			path+".Declarations",
+			reflect.ValueOf(got.Interface().(*Members).Declarations()),
+			reflect.ValueOf(want.Interface().(*Members).Declarations()),
+		)
+		return
+
+	case programType:
+		if got.IsNil() || want.IsNil() {
+			break
+		}
+		d.diff(
+			path+".Declarations",
+			reflect.ValueOf(got.Interface().(*Program).Declarations()),
+			reflect.ValueOf(want.Interface().(*Program).Declarations()),
+		)
+		return
+	}
+
+	switch got.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if got.IsNil() || want.IsNil() {
+			if got.IsNil() != want.IsNil() {
+				d.report(path, got, want)
+			}
+			return
+		}
+		d.diff(path, got.Elem(), want.Elem())
+
+	case reflect.Struct:
+		ty := got.Type()
+		for i := 0; i < ty.NumField(); i++ {
+			field := ty.Field(i)
+			if field.PkgPath != "" || parentFields[field.Name] {
+				continue
+			}
+
+			d.diff(
+				path+"."+field.Name,
+				got.Field(i),
+				want.Field(i),
+			)
+		}
+
+	case reflect.Slice, reflect.Array:
+		gotLen := got.Len()
+		wantLen := want.Len()
+
+		length := gotLen
+		if wantLen > length {
+			length = wantLen
+		}
+
+		for i := 0; i < length; i++ {
+			var gotElement, wantElement reflect.Value
+			if i < gotLen {
+				gotElement = got.Index(i)
+			}
+			if i < wantLen {
+				wantElement = want.Index(i)
+			}
+
+			d.diff(
+				fmt.Sprintf("%s[%d]", path, i),
+				gotElement,
+				wantElement,
+			)
+		}
+
+	default:
+		if got.Interface() != want.Interface() {
+			d.report(path, got, want)
+		}
+	}
+}
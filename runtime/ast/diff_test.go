@@ -0,0 +1,187 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ast_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/parser2"
+)
+
+func TestDiff(t *testing.T) {
+
+	t.Parallel()
+
+	parseFunction := func(t *testing.T, code string) *ast.FunctionDeclaration {
+		program, err := parser2.ParseProgram(code)
+		require.NoError(t, err)
+
+		functions := program.FunctionDeclarations()
+		require.Len(t, functions, 1)
+
+		return functions[0]
+	}
+
+	t.Run("equal", func(t *testing.T) {
+
+		t.Parallel()
+
+		const code = `
+          fun test(): Int {
+              let x = 1
+              return x + 2
+          }
+        `
+
+		assert.Empty(t, ast.Diff(parseFunction(t, code), parseFunction(t, code)))
+	})
+
+	t.Run("positions ignored by default", func(t *testing.T) {
+
+		t.Parallel()
+
+		got := parseFunction(t, `fun test() { let x = 1 }`)
+		want := parseFunction(t, `
+          fun test() {
+              let x = 1
+          }
+        `)
+
+		assert.Empty(t, ast.Diff(got, want))
+
+		assert.Equal(t,
+			ast.DiffEntry{
+				Path: "FunctionDeclaration.Identifier.Pos",
+				Got:  ast.Position{Offset: 4, Line: 1, Column: 4},
+				Want: ast.Position{Offset: 15, Line: 2, Column: 14},
+			},
+			ast.Diff(got, want, ast.WithDiffPositions(true))[0],
+		)
+	})
+
+	t.Run("statements", func(t *testing.T) {
+
+		t.Parallel()
+
+		got := parseFunction(t, `
+          fun test() {
+              let x = 1
+              let y = 2
+              f(x, y)
+          }
+        `)
+		want := parseFunction(t, `
+          fun test() {
+              let x = 1
+              let y = 3
+              f(y, x)
+          }
+        `)
+
+		assert.Equal(t,
+			[]ast.DiffEntry{
+				{
+					Path: "FunctionDeclaration.FunctionBlock.Block.Statements[1].Value.PositiveLiteral",
+					Got:  "2",
+					Want: "3",
+				},
+				{
+					Path: "FunctionDeclaration.FunctionBlock.Block.Statements[1].Value.Value",
+					Got:  big.NewInt(2),
+					Want: big.NewInt(3),
+				},
+				{
+					Path: "FunctionDeclaration.FunctionBlock.Block.Statements[2].Expression.Arguments[0].Expression.Identifier.Identifier",
+					Got:  "x",
+					Want: "y",
+				},
+				{
+					Path: "FunctionDeclaration.FunctionBlock.Block.Statements[2].Expression.Arguments[1].Expression.Identifier.Identifier",
+					Got:  "y",
+					Want: "x",
+				},
+			},
+			ast.Diff(got, want),
+		)
+	})
+
+	t.Run("different types and lengths", func(t *testing.T) {
+
+		t.Parallel()
+
+		got := parseFunction(t, `
+          fun test() {
+              return 1
+          }
+        `)
+		want := parseFunction(t, `
+          fun test() {
+              return true
+              x
+          }
+        `)
+
+		entries := ast.Diff(got, want)
+		require.Len(t, entries, 2)
+
+		assert.Equal(t,
+			"FunctionDeclaration.FunctionBlock.Block.Statements[0].Expression",
+			entries[0].Path,
+		)
+		assert.IsType(t, &ast.IntegerExpression{}, entries[0].Got)
+		assert.IsType(t, &ast.BoolExpression{}, entries[0].Want)
+
+		assert.Equal(t,
+			"FunctionDeclaration.FunctionBlock.Block.Statements[1]",
+			entries[1].Path,
+		)
+		assert.Nil(t, entries[1].Got)
+		assert.IsType(t, &ast.ExpressionStatement{}, entries[1].Want)
+	})
+
+	t.Run("members", func(t *testing.T) {
+
+		t.Parallel()
+
+		parseComposite := func(code string) *ast.CompositeDeclaration {
+			program, err := parser2.ParseProgram(code)
+			require.NoError(t, err)
+			return program.CompositeDeclarations()[0]
+		}
+
+		got := parseComposite(`struct S { let x: Int }`)
+		want := parseComposite(`struct S { let y: Int }`)
+
+		assert.Equal(t,
+			[]ast.DiffEntry{
+				{
+					Path: "CompositeDeclaration.Members.Declarations[0].Identifier.Identifier",
+					Got:  "x",
+					Want: "y",
+				},
+			},
+			ast.Diff(got, want),
+		)
+	})
+}
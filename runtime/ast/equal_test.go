@@ -0,0 +1,275 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ast_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/parser2"
+)
+
+type equalTest struct {
+	name string
+	// code is the code of the element
+	code string
+	// equal is code which results in a structurally equal element,
+	// but at different positions
+	equal string
+	// unequal is code which results in a structurally different element
+	unequal string
+}
+
+func testEqual(t *testing.T, tests []equalTest, parse func(t *testing.T, code string) ast.Element) {
+
+	for _, test := range tests {
+
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			element := parse(t, test.code)
+			equal := parse(t, test.equal)
+			unequal := parse(t, test.unequal)
+
+			assert.True(t, element.Equal(element))
+			assert.True(t, element.Equal(element.Clone()))
+
+			assert.True(t, element.Equal(equal))
+			assert.True(t, equal.Equal(element))
+
+			assert.False(t, element.Equal(unequal))
+			assert.False(t, unequal.Equal(element))
+
+			assert.False(t, element.Equal(nil))
+			assert.False(t, element.Equal(ast.NotAnElement{}))
+		})
+	}
+}
+
+func TestExpressionEqual(t *testing.T) {
+
+	t.Parallel()
+
+	tests := []equalTest{
+		{name: "bool", code: "true", equal: " true", unequal: "false"},
+		{name: "nil", code: "nil", equal: " nil", unequal: "true"},
+		{name: "string", code: `"a"`, equal: ` "a"`, unequal: `"b"`},
+		{name: "integer", code: "1", equal: " 1", unequal: "2"},
+		{name: "fixed-point", code: "1.5", equal: " 1.5", unequal: "1.25"},
+		{name: "array", code: "[1, 2]", equal: "[ 1 , 2 ]", unequal: "[1, 3]"},
+		{name: "dictionary", code: "{1: 2}", equal: "{ 1 : 2 }", unequal: "{1: 3}"},
+		{name: "identifier", code: "a", equal: " a", unequal: "b"},
+		{name: "invocation", code: "f<Int>(x: 1)", equal: "f< Int >( x: 1 )", unequal: "f<Int>(y: 1)"},
+		{name: "member", code: "a.b", equal: "a .b", unequal: "a?.b"},
+		{name: "index", code: "a[1]", equal: "a[ 1 ]", unequal: "a[2]"},
+		{name: "conditional", code: "a ? 1 : 2", equal: "a  ?  1  :  2", unequal: "a ? 2 : 1"},
+		{name: "unary", code: "-a", equal: " -a", unequal: "!a"},
+		{name: "binary", code: "a + b", equal: "a  +  b", unequal: "a - b"},
+		{name: "function", code: "fun (a: Int): Int { return a }", equal: "fun ( a: Int ): Int {  return a  }", unequal: "fun (b: Int): Int { return b }"},
+		{name: "casting", code: "a as Int", equal: "a  as  Int", unequal: "a as? Int"},
+		{name: "create", code: "create R()", equal: "create  R()", unequal: "create S()"},
+		{name: "destroy", code: "destroy r", equal: "destroy  r", unequal: "destroy s"},
+		{name: "reference", code: "&a as &Int", equal: "& a  as  &Int", unequal: "&a as auth &Int"},
+		{name: "force", code: "a!", equal: " a!", unequal: "b!"},
+		{name: "path", code: "/storage/a", equal: " /storage/a", unequal: "/public/a"},
+	}
+
+	testEqual(t, tests, func(t *testing.T, code string) ast.Element {
+		expression, errs := parser2.ParseExpression(code)
+		require.Empty(t, errs)
+		return expression
+	})
+}
+
+func TestStatementEqual(t *testing.T) {
+
+	t.Parallel()
+
+	tests := []equalTest{
+		{name: "return", code: "return 1", equal: "return  1", unequal: "return"},
+		{name: "break", code: "break", equal: " break", unequal: "continue"},
+		{name: "continue", code: "continue", equal: " continue", unequal: "break"},
+		{name: "if", code: "if a { b } else { c }", equal: "if  a  { b }  else  { c }", unequal: "if a { b }"},
+		{name: "if-let", code: "if let x = a { x }", equal: "if  let x = a { x }", unequal: "if var x = a { x }"},
+		{name: "while", code: "while a { b }", equal: "while  a  { b }", unequal: "while a { c }"},
+		{name: "for", code: "for x in xs { x }", equal: "for  x  in  xs  { x }", unequal: "for y in xs { y }"},
+		{name: "emit", code: "emit E()", equal: "emit  E()", unequal: "emit F()"},
+		{name: "assignment", code: "a = b", equal: "a  =  b", unequal: "a <- b"},
+		{name: "swap", code: "a <-> b", equal: "a  <->  b", unequal: "b <-> a"},
+		{name: "expression", code: "f()", equal: " f()", unequal: "g()"},
+		{name: "switch", code: "switch a { case 1: b default: c }", equal: "switch a {  case 1:  b  default:  c }", unequal: "switch a { case 2: b default: c }"},
+		{name: "variable", code: "let a: Int = 1", equal: "let  a:  Int  =  1", unequal: "var a: Int = 1"},
+	}
+
+	testEqual(t, tests, func(t *testing.T, code string) ast.Element {
+		statements, errs := parser2.ParseStatements(code)
+		require.Empty(t, errs)
+		require.Len(t, statements, 1)
+		return statements[0]
+	})
+}
+
+func TestDeclarationEqual(t *testing.T) {
+
+	t.Parallel()
+
+	tests := []equalTest{
+		{name: "composite", code: "pub struct S {}", equal: "pub  struct  S  {}", unequal: "pub resource S {}"},
+		{name: "interface", code: "pub struct interface I {}", equal: "pub  struct  interface  I {}", unequal: "pub resource interface I {}"},
+		{name: "function", code: "fun f(a: Int) {}", equal: "fun  f( a: Int ) {}", unequal: "fun f(b: Int) {}"},
+		{name: "import", code: "import A from 0x1", equal: "import  A  from  0x1", unequal: "import A from 0x2"},
+		{name: "pragma", code: "#a", equal: " #a", unequal: "#b"},
+		{name: "transaction", code: "transaction { execute {} }", equal: "transaction {  execute  {} }", unequal: "transaction { prepare() {} }"},
+		{name: "variable", code: "let a = 1", equal: "let  a  =  1", unequal: "let a <- 1"},
+	}
+
+	testEqual(t, tests, func(t *testing.T, code string) ast.Element {
+		declarations, errs := parser2.ParseDeclarations(code)
+		require.Empty(t, errs)
+		require.Len(t, declarations, 1)
+		return declarations[0]
+	})
+}
+
+func TestMemberEqual(t *testing.T) {
+
+	t.Parallel()
+
+	tests := []equalTest{
+		{name: "field", code: "let a: Int", equal: "let  a:  Int", unequal: "var a: Int"},
+		{name: "enum case", code: "case a", equal: "case  a", unequal: "case b"},
+		{name: "special function", code: "init(a: Int) {}", equal: "init( a: Int )  {}", unequal: "init(b: Int) {}"},
+	}
+
+	testEqual(t, tests, func(t *testing.T, code string) ast.Element {
+		declarations, errs := parser2.ParseDeclarations("enum E { " + code + " }")
+		require.Empty(t, errs)
+		require.Len(t, declarations, 1)
+
+		members := declarations[0].(*ast.CompositeDeclaration).Members.Declarations()
+		require.Len(t, members, 1)
+		return members[0]
+	})
+}
+
+func TestBlockEqual(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("block", func(t *testing.T) {
+
+		t.Parallel()
+
+		tests := []equalTest{
+			{name: "block", code: "while true { a }", equal: "while true {  a  }", unequal: "while true { b }"},
+		}
+
+		testEqual(t, tests, func(t *testing.T, code string) ast.Element {
+			statements, errs := parser2.ParseStatements(code)
+			require.Empty(t, errs)
+			require.Len(t, statements, 1)
+			return statements[0].(*ast.WhileStatement).Block
+		})
+	})
+
+	t.Run("function block", func(t *testing.T) {
+
+		t.Parallel()
+
+		tests := []equalTest{
+			{name: "function block", code: "fun f() { pre { a } }", equal: "fun f() {  pre  { a } }", unequal: "fun f() { post { a } }"},
+		}
+
+		testEqual(t, tests, func(t *testing.T, code string) ast.Element {
+			declarations, errs := parser2.ParseDeclarations(code)
+			require.Empty(t, errs)
+			require.Len(t, declarations, 1)
+			return declarations[0].(*ast.FunctionDeclaration).FunctionBlock
+		})
+	})
+}
+
+func TestProgramEqual(t *testing.T) {
+
+	t.Parallel()
+
+	tests := []equalTest{
+		{name: "program", code: "let a = 1\nfun f() {}", equal: "\n\nlet a = 1\n\nfun f() {}", unequal: "fun f() {}\nlet a = 1"},
+	}
+
+	testEqual(t, tests, func(t *testing.T, code string) ast.Element {
+		program, err := parser2.ParseProgram(code)
+		require.NoError(t, err)
+		return program
+	})
+}
+
+func TestTypeEqual(t *testing.T) {
+
+	t.Parallel()
+
+	tests := []equalTest{
+		{name: "nominal", code: "A.B", equal: " A.B", unequal: "A.C"},
+		{name: "optional", code: "Int?", equal: " Int?", unequal: "Int??"},
+		{name: "variable-sized", code: "[Int]", equal: "[ Int ]", unequal: "[String]"},
+		{name: "constant-sized", code: "[Int; 2]", equal: "[ Int ;  2 ]", unequal: "[Int; 3]"},
+		{name: "dictionary", code: "{Int: String}", equal: "{ Int : String }", unequal: "{String: Int}"},
+		{name: "function", code: "((Int): String)", equal: "( ( Int ):  String )", unequal: "((String): Int)"},
+		{name: "reference", code: "&Int", equal: " &Int", unequal: "auth &Int"},
+		{name: "restricted", code: "R{I}", equal: " R{I }", unequal: "R{J}"},
+		{name: "instantiation", code: "Capability<&Int>", equal: "Capability< &Int >", unequal: "Capability<&String>"},
+	}
+
+	for _, test := range tests {
+
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			parse := func(code string) ast.Type {
+				ty, errs := parser2.ParseType(code)
+				require.Empty(t, errs)
+				return ty
+			}
+
+			ty := parse(test.code)
+			equal := parse(test.equal)
+			unequal := parse(test.unequal)
+
+			assert.True(t, ty.Equal(ty))
+			assert.True(t, ty.Equal(ty.Clone()))
+
+			assert.True(t, ty.Equal(equal))
+			assert.True(t, equal.Equal(ty))
+
+			assert.False(t, ty.Equal(unequal))
+			assert.False(t, unequal.Equal(ty))
+
+			assert.False(t, ty.Equal(nil))
+		})
+	}
+}
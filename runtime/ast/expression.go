@@ -59,6 +59,15 @@ func (*BoolExpression) Walk(_ func(Element)) {
 	// NO-OP
 }
 
+func (e *BoolExpression) Clone() Element {
+	clone := *e
+	return &clone
+}
+
+func (e *BoolExpression) Equal(other Element) bool {
+	return equal(e, other)
+}
+
 func (e *BoolExpression) AcceptExp(visitor ExpressionVisitor) Repr {
 	return visitor.VisitBoolExpression(e)
 }
@@ -92,6 +101,10 @@ func (e *BoolExpression) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (e *BoolExpression) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, e)
+}
+
 // NilExpression
 
 type NilExpression struct {
@@ -112,6 +125,15 @@ func (*NilExpression) Walk(_ func(Element)) {
 	// NO-OP
 }
 
+func (e *NilExpression) Clone() Element {
+	clone := *e
+	return &clone
+}
+
+func (e *NilExpression) Equal(other Element) bool {
+	return equal(e, other)
+}
+
 func (e *NilExpression) AcceptExp(visitor ExpressionVisitor) Repr {
 	return visitor.VisitNilExpression(e)
 }
@@ -147,6 +169,10 @@ func (e *NilExpression) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (e *NilExpression) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, e)
+}
+
 // StringExpression
 
 type StringExpression struct {
@@ -168,6 +194,15 @@ func (*StringExpression) Walk(_ func(Element)) {
 	// NO-OP
 }
 
+func (e *StringExpression) Clone() Element {
+	clone := *e
+	return &clone
+}
+
+func (e *StringExpression) Equal(other Element) bool {
+	return equal(e, other)
+}
+
 func (e *StringExpression) AcceptExp(visitor ExpressionVisitor) Repr {
 	return visitor.VisitStringExpression(e)
 }
@@ -191,6 +226,103 @@ func (e *StringExpression) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (e *StringExpression) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, e)
+}
+
+// StringInterpolationExpression
+
+// StringInterpolationExpression is a string literal with interpolations, e.g. `"a \(b) c"`.
+//
+// The segments alternate between string segments, which are StringExpressions,
+// and interpolated expressions. The first and the last segment are always string segments,
+// which may be empty.
+//
+type StringInterpolationExpression struct {
+	Segments []Expression
+	Range
+}
+
+var _ Expression = &StringInterpolationExpression{}
+
+func (*StringInterpolationExpression) isExpression() {}
+
+func (*StringInterpolationExpression) isIfStatementTest() {}
+
+func (e *StringInterpolationExpression) Accept(visitor Visitor) Repr {
+	return e.AcceptExp(visitor)
+}
+
+func (e *StringInterpolationExpression) Walk(walkChild func(Element)) {
+	walkExpressions(walkChild, e.Segments)
+}
+
+func (e *StringInterpolationExpression) Clone() Element {
+	clone := *e
+	clone.Segments = cloneExpressions(e.Segments)
+	return &clone
+}
+
+func (e *StringInterpolationExpression) Equal(other Element) bool {
+	return equal(e, other)
+}
+
+func (e *StringInterpolationExpression) AcceptExp(visitor ExpressionVisitor) Repr {
+	return visitor.VisitStringInterpolationExpression(e)
+}
+
+func (e *StringInterpolationExpression) String() string {
+	var builder strings.Builder
+	builder.WriteByte('"')
+	for _, segment := range e.Segments {
+		if stringSegment, ok := segment.(*StringExpression); ok {
+			quoted := QuoteString(stringSegment.Value)
+			builder.WriteString(quoted[1 : len(quoted)-1])
+		} else {
+			builder.WriteString(`\(`)
+			builder.WriteString(segment.String())
+			builder.WriteByte(')')
+		}
+	}
+	builder.WriteByte('"')
+	return builder.String()
+}
+
+func (e *StringInterpolationExpression) Doc() prettier.Doc {
+	doc := prettier.Concat{
+		prettier.Text(`"`),
+	}
+	for _, segment := range e.Segments {
+		if stringSegment, ok := segment.(*StringExpression); ok {
+			quoted := QuoteString(stringSegment.Value)
+			doc = append(doc, prettier.Text(quoted[1:len(quoted)-1]))
+		} else {
+			doc = append(
+				doc,
+				prettier.Text(`\(`),
+				segment.Doc(),
+				prettier.Text(")"),
+			)
+		}
+	}
+	return append(doc, prettier.Text(`"`))
+}
+
+func (e *StringInterpolationExpression) MarshalJSON() ([]byte, error) {
+	type Alias StringInterpolationExpression
+	return json.Marshal(&struct {
+		Type string
+		*Alias
+	}{
+		Type:  "StringInterpolationExpression",
+		Alias: (*Alias)(e),
+	})
+}
+
+func (e *StringInterpolationExpression) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, e)
+}
+
 // IntegerExpression
 
 type IntegerExpression struct {
@@ -214,6 +346,16 @@ func (*IntegerExpression) Walk(_ func(Element)) {
 	// NO-OP
 }
 
+func (e *IntegerExpression) Clone() Element {
+	clone := *e
+	clone.Value = cloneBigInt(e.Value)
+	return &clone
+}
+
+func (e *IntegerExpression) Equal(other Element) bool {
+	return equal(e, other)
+}
+
 func (e *IntegerExpression) AcceptExp(visitor ExpressionVisitor) Repr {
 	return visitor.VisitIntegerExpression(e)
 }
@@ -247,6 +389,10 @@ func (e *IntegerExpression) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (e *IntegerExpression) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, e)
+}
+
 // FixedPointExpression
 
 type FixedPointExpression struct {
@@ -272,6 +418,17 @@ func (*FixedPointExpression) Walk(_ func(Element)) {
 	// NO-OP
 }
 
+func (e *FixedPointExpression) Clone() Element {
+	clone := *e
+	clone.UnsignedInteger = cloneBigInt(e.UnsignedInteger)
+	clone.Fractional = cloneBigInt(e.Fractional)
+	return &clone
+}
+
+func (e *FixedPointExpression) Equal(other Element) bool {
+	return equal(e, other)
+}
+
 func (e *FixedPointExpression) AcceptExp(visitor ExpressionVisitor) Repr {
 	return visitor.VisitFixedPointExpression(e)
 }
@@ -322,6 +479,10 @@ func (e *FixedPointExpression) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (e *FixedPointExpression) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, e)
+}
+
 // ArrayExpression
 
 type ArrayExpression struct {
@@ -343,6 +504,16 @@ func (e *ArrayExpression) Walk(walkChild func(Element)) {
 	walkExpressions(walkChild, e.Values)
 }
 
+func (e *ArrayExpression) Clone() Element {
+	clone := *e
+	clone.Values = cloneExpressions(e.Values)
+	return &clone
+}
+
+func (e *ArrayExpression) Equal(other Element) bool {
+	return equal(e, other)
+}
+
 func (e *ArrayExpression) AcceptExp(visitor ExpressionVisitor) Repr {
 	return visitor.VisitArrayExpression(e)
 }
@@ -391,6 +562,10 @@ func (e *ArrayExpression) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (e *ArrayExpression) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, e)
+}
+
 // DictionaryExpression
 
 type DictionaryExpression struct {
@@ -415,6 +590,16 @@ func (e *DictionaryExpression) Walk(walkChild func(Element)) {
 	}
 }
 
+func (e *DictionaryExpression) Clone() Element {
+	clone := *e
+	clone.Entries = cloneDictionaryEntries(e.Entries)
+	return &clone
+}
+
+func (e *DictionaryExpression) Equal(other Element) bool {
+	return equal(e, other)
+}
+
 func (e *DictionaryExpression) AcceptExp(visitor ExpressionVisitor) Repr {
 	return visitor.VisitDictionaryExpression(e)
 }
@@ -466,6 +651,10 @@ func (e *DictionaryExpression) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (e *DictionaryExpression) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, e)
+}
+
 type DictionaryEntry struct {
 	Key   Expression
 	Value Expression
@@ -482,6 +671,10 @@ func (e DictionaryEntry) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (e *DictionaryEntry) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, e)
+}
+
 var dictionaryKeyValueSeparatorDoc prettier.Doc = prettier.Concat{
 	prettier.Text(":"),
 	prettier.Line{},
@@ -520,6 +713,15 @@ func (*IdentifierExpression) Walk(_ func(Element)) {
 	// NO-OP
 }
 
+func (e *IdentifierExpression) Clone() Element {
+	clone := *e
+	return &clone
+}
+
+func (e *IdentifierExpression) Equal(other Element) bool {
+	return equal(e, other)
+}
+
 func (e *IdentifierExpression) AcceptExp(visitor ExpressionVisitor) Repr {
 	return visitor.VisitIdentifierExpression(e)
 }
@@ -545,6 +747,10 @@ func (e *IdentifierExpression) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (e *IdentifierExpression) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, e)
+}
+
 func (e *IdentifierExpression) StartPosition() Position {
 	return e.Identifier.StartPosition()
 }
@@ -597,6 +803,18 @@ func (e *InvocationExpression) Walk(walkChild func(Element)) {
 	}
 }
 
+func (e *InvocationExpression) Clone() Element {
+	clone := *e
+	clone.InvokedExpression = cloneExpression(e.InvokedExpression)
+	clone.TypeArguments = cloneTypeAnnotations(e.TypeArguments)
+	clone.Arguments = cloneArguments(e.Arguments)
+	return &clone
+}
+
+func (e *InvocationExpression) Equal(other Element) bool {
+	return equal(e, other)
+}
+
 func (e *InvocationExpression) AcceptExp(visitor ExpressionVisitor) Repr {
 	return visitor.VisitInvocationExpression(e)
 }
@@ -621,8 +839,7 @@ func (e *InvocationExpression) String() string {
 func (e *InvocationExpression) Doc() prettier.Doc {
 
 	result := prettier.Concat{
-		// TODO: potentially parenthesize
-		e.InvokedExpression.Doc(),
+		parenthesizedExpressionDoc(e.InvokedExpression, precedenceAccess),
 	}
 
 	if len(e.TypeArguments) > 0 {
@@ -688,6 +905,10 @@ func (e *InvocationExpression) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (e *InvocationExpression) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, e)
+}
+
 // AccessExpression
 
 type AccessExpression interface {
@@ -727,6 +948,16 @@ func (e *MemberExpression) Walk(walkChild func(Element)) {
 	walkChild(e.Expression)
 }
 
+func (e *MemberExpression) Clone() Element {
+	clone := *e
+	clone.Expression = cloneExpression(e.Expression)
+	return &clone
+}
+
+func (e *MemberExpression) Equal(other Element) bool {
+	return equal(e, other)
+}
+
 func (e *MemberExpression) AcceptExp(visitor ExpressionVisitor) Repr {
 	return visitor.VisitMemberExpression(e)
 }
@@ -753,8 +984,7 @@ func (e *MemberExpression) Doc() prettier.Doc {
 		separatorDoc = memberExpressionSeparatorDoc
 	}
 	return prettier.Concat{
-		// TODO: potentially parenthesize
-		e.Expression.Doc(),
+		parenthesizedExpressionDoc(e.Expression, precedenceAccess),
 		prettier.Group{
 			Doc: prettier.Indent{
 				Doc: prettier.Concat{
@@ -792,6 +1022,10 @@ func (e *MemberExpression) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (e *MemberExpression) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, e)
+}
+
 // IndexExpression
 
 type IndexExpression struct {
@@ -821,6 +1055,17 @@ func (e *IndexExpression) Walk(walkChild func(Element)) {
 	walkChild(e.IndexingExpression)
 }
 
+func (e *IndexExpression) Clone() Element {
+	clone := *e
+	clone.TargetExpression = cloneExpression(e.TargetExpression)
+	clone.IndexingExpression = cloneExpression(e.IndexingExpression)
+	return &clone
+}
+
+func (e *IndexExpression) Equal(other Element) bool {
+	return equal(e, other)
+}
+
 func (e *IndexExpression) AcceptExp(visitor ExpressionVisitor) Repr {
 	return visitor.VisitIndexExpression(e)
 }
@@ -834,8 +1079,7 @@ func (e *IndexExpression) String() string {
 
 func (e *IndexExpression) Doc() prettier.Doc {
 	return prettier.Concat{
-		// TODO: potentially parenthesize
-		e.TargetExpression.Doc(),
+		parenthesizedExpressionDoc(e.TargetExpression, precedenceAccess),
 		prettier.WrapBrackets(
 			e.IndexingExpression.Doc(),
 			prettier.SoftLine{},
@@ -854,6 +1098,10 @@ func (e *IndexExpression) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (e *IndexExpression) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, e)
+}
+
 // ConditionalExpression
 
 type ConditionalExpression struct {
@@ -880,6 +1128,18 @@ func (e *ConditionalExpression) Walk(walkChild func(Element)) {
 	}
 }
 
+func (e *ConditionalExpression) Clone() Element {
+	clone := *e
+	clone.Test = cloneExpression(e.Test)
+	clone.Then = cloneExpression(e.Then)
+	clone.Else = cloneExpression(e.Else)
+	return &clone
+}
+
+func (e *ConditionalExpression) Equal(other Element) bool {
+	return equal(e, other)
+}
+
 func (e *ConditionalExpression) AcceptExp(visitor ExpressionVisitor) Repr {
 	return visitor.VisitConditionalExpression(e)
 }
@@ -900,14 +1160,10 @@ var conditionalExpressionBranchSeparatorDoc prettier.Doc = prettier.Concat{
 }
 
 func (e *ConditionalExpression) Doc() prettier.Doc {
-	// TODO: potentially parenthesize
-	testDoc := e.Test.Doc()
-
-	// TODO: potentially parenthesize
-	thenDoc := e.Then.Doc()
-
-	// TODO: potentially parenthesize
-	elseDoc := e.Else.Doc()
+	// The conditional expression is right associative
+	testDoc := parenthesizedExpressionDoc(e.Test, precedenceTernary+1)
+	thenDoc := parenthesizedExpressionDoc(e.Then, precedenceTernary)
+	elseDoc := parenthesizedExpressionDoc(e.Else, precedenceTernary)
 
 	return prettier.Group{
 		Doc: prettier.Concat{
@@ -949,6 +1205,10 @@ func (e *ConditionalExpression) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (e *ConditionalExpression) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, e)
+}
+
 // UnaryExpression
 
 type UnaryExpression struct {
@@ -971,6 +1231,16 @@ func (e *UnaryExpression) Walk(walkChild func(Element)) {
 	walkChild(e.Expression)
 }
 
+func (e *UnaryExpression) Clone() Element {
+	clone := *e
+	clone.Expression = cloneExpression(e.Expression)
+	return &clone
+}
+
+func (e *UnaryExpression) Equal(other Element) bool {
+	return equal(e, other)
+}
+
 func (e *UnaryExpression) AcceptExp(visitor ExpressionVisitor) Repr {
 	return visitor.VisitUnaryExpression(e)
 }
@@ -986,8 +1256,7 @@ func (e *UnaryExpression) String() string {
 func (e *UnaryExpression) Doc() prettier.Doc {
 	return prettier.Concat{
 		prettier.Text(e.Operation.Symbol()),
-		// TODO: potentially parenthesize
-		e.Expression.Doc(),
+		parenthesizedExpressionDoc(e.Expression, precedenceUnaryPrefix),
 	}
 }
 
@@ -1012,6 +1281,10 @@ func (e *UnaryExpression) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (e *UnaryExpression) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, e)
+}
+
 // BinaryExpression
 
 type BinaryExpression struct {
@@ -1035,6 +1308,17 @@ func (e *BinaryExpression) Walk(walkChild func(Element)) {
 	walkChild(e.Right)
 }
 
+func (e *BinaryExpression) Clone() Element {
+	clone := *e
+	clone.Left = cloneExpression(e.Left)
+	clone.Right = cloneExpression(e.Right)
+	return &clone
+}
+
+func (e *BinaryExpression) Equal(other Element) bool {
+	return equal(e, other)
+}
+
 func (e *BinaryExpression) AcceptExp(visitor ExpressionVisitor) Repr {
 	return visitor.VisitBinaryExpression(e)
 }
@@ -1047,11 +1331,19 @@ func (e *BinaryExpression) String() string {
 }
 
 func (e *BinaryExpression) Doc() prettier.Doc {
-	// TODO: potentially parenthesize
-	leftDoc := e.Left.Doc()
+	operationPrecedence := e.Operation.precedence()
 
-	// TODO: potentially parenthesize
-	rightDoc := e.Right.Doc()
+	// The operand on the side opposite to the associativity of the operation
+	// must be parenthesized if it has the same precedence
+
+	leftPrecedence := operationPrecedence
+	rightPrecedence := operationPrecedence + 1
+	if e.Operation.isRightAssociative() {
+		leftPrecedence, rightPrecedence = rightPrecedence, leftPrecedence
+	}
+
+	leftDoc := parenthesizedExpressionDoc(e.Left, leftPrecedence)
+	rightDoc := parenthesizedExpressionDoc(e.Right, rightPrecedence)
 
 	return prettier.Group{
 		Doc: prettier.Concat{
@@ -1089,6 +1381,10 @@ func (e *BinaryExpression) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (e *BinaryExpression) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, e)
+}
+
 // FunctionExpression
 
 type FunctionExpression struct {
@@ -1114,6 +1410,18 @@ func (e *FunctionExpression) Walk(walkChild func(Element)) {
 	walkChild(e.FunctionBlock)
 }
 
+func (e *FunctionExpression) Clone() Element {
+	clone := *e
+	clone.ParameterList = e.ParameterList.Clone()
+	clone.ReturnTypeAnnotation = e.ReturnTypeAnnotation.Clone()
+	clone.FunctionBlock = cloneFunctionBlock(e.FunctionBlock)
+	return &clone
+}
+
+func (e *FunctionExpression) Equal(other Element) bool {
+	return equal(e, other)
+}
+
 func (e *FunctionExpression) AcceptExp(visitor ExpressionVisitor) Repr {
 	return visitor.VisitFunctionExpression(e)
 }
@@ -1124,27 +1432,12 @@ func (e *FunctionExpression) String() string {
 }
 
 var functionExpressionFunKeywordDoc prettier.Doc = prettier.Text("fun ")
-var functionExpressionParameterSeparatorDoc prettier.Doc = prettier.Concat{
-	prettier.Text(","),
-	prettier.Line{},
-}
-
 var typeSeparatorDoc prettier.Doc = prettier.Text(": ")
 var functionExpressionEmptyBlockDoc prettier.Doc = prettier.Text(" {}")
 
 func (e *FunctionExpression) Doc() prettier.Doc {
 
-	signatureDoc := e.parametersDoc()
-
-	if e.ReturnTypeAnnotation != nil &&
-		!IsEmptyType(e.ReturnTypeAnnotation.Type) {
-
-		signatureDoc = prettier.Concat{
-			signatureDoc,
-			typeSeparatorDoc,
-			e.ReturnTypeAnnotation.Doc(),
-		}
-	}
+	signatureDoc := FunctionSignatureDoc(e.ParameterList, e.ReturnTypeAnnotation)
 
 	doc := prettier.Concat{
 		functionExpressionFunKeywordDoc,
@@ -1156,56 +1449,31 @@ func (e *FunctionExpression) Doc() prettier.Doc {
 	if e.FunctionBlock.IsEmpty() {
 		return append(doc, functionExpressionEmptyBlockDoc)
 	} else {
-		// TODO: pre-conditions
-		// TODO: post-conditions
-
-		blockDoc := e.FunctionBlock.Block.Doc()
-
 		return append(
 			doc,
 			prettier.Space,
-			blockDoc,
+			e.FunctionBlock.Doc(),
 		)
 	}
 }
 
-func (e *FunctionExpression) parametersDoc() prettier.Doc {
+// FunctionSignatureDoc returns the document for the parameter list and the return type of a function
+//
+func FunctionSignatureDoc(parameterList *ParameterList, returnTypeAnnotation *TypeAnnotation) prettier.Doc {
 
-	if e.ParameterList == nil ||
-		len(e.ParameterList.Parameters) == 0 {
+	signatureDoc := parameterList.Doc()
 
-		return prettier.Text("()")
-	}
-
-	parameterDocs := make([]prettier.Doc, 0, len(e.ParameterList.Parameters))
-
-	for _, parameter := range e.ParameterList.Parameters {
-		var parameterDoc prettier.Concat
-
-		if parameter.Label != "" {
-			parameterDoc = append(parameterDoc,
-				prettier.Text(parameter.Label),
-				prettier.Space,
-			)
-		}
+	if returnTypeAnnotation != nil &&
+		!IsEmptyType(returnTypeAnnotation.Type) {
 
-		parameterDoc = append(
-			parameterDoc,
-			prettier.Text(parameter.Identifier.Identifier),
+		signatureDoc = prettier.Concat{
+			signatureDoc,
 			typeSeparatorDoc,
-			parameter.TypeAnnotation.Doc(),
-		)
-
-		parameterDocs = append(parameterDocs, parameterDoc)
+			returnTypeAnnotation.Doc(),
+		}
 	}
 
-	return prettier.WrapParentheses(
-		prettier.Join(
-			functionExpressionParameterSeparatorDoc,
-			parameterDocs...,
-		),
-		prettier.SoftLine{},
-	)
+	return signatureDoc
 }
 
 func (e *FunctionExpression) StartPosition() Position {
@@ -1229,6 +1497,10 @@ func (e *FunctionExpression) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (e *FunctionExpression) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, e)
+}
+
 // CastingExpression
 
 type CastingExpression struct {
@@ -1252,6 +1524,21 @@ func (e *CastingExpression) Walk(walkChild func(Element)) {
 	// TODO: also walk type
 }
 
+// Clone returns a deep copy of the casting expression.
+// The parent variable declaration is not cloned,
+// it is updated when the parent variable declaration is cloned.
+//
+func (e *CastingExpression) Clone() Element {
+	clone := *e
+	clone.Expression = cloneExpression(e.Expression)
+	clone.TypeAnnotation = e.TypeAnnotation.Clone()
+	return &clone
+}
+
+func (e *CastingExpression) Equal(other Element) bool {
+	return equal(e, other)
+}
+
 func (e *CastingExpression) AcceptExp(visitor ExpressionVisitor) Repr {
 	return visitor.VisitCastingExpression(e)
 }
@@ -1264,8 +1551,7 @@ func (e *CastingExpression) String() string {
 }
 
 func (e *CastingExpression) Doc() prettier.Doc {
-	// TODO: potentially parenthesize
-	doc := e.Expression.Doc()
+	doc := parenthesizedExpressionDoc(e.Expression, precedenceCasting)
 
 	return prettier.Group{
 		Doc: prettier.Concat{
@@ -1301,6 +1587,10 @@ func (e *CastingExpression) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (e *CastingExpression) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, e)
+}
+
 // CreateExpression
 
 type CreateExpression struct {
@@ -1322,6 +1612,16 @@ func (e *CreateExpression) Walk(walkChild func(Element)) {
 	walkChild(e.InvocationExpression)
 }
 
+func (e *CreateExpression) Clone() Element {
+	clone := *e
+	clone.InvocationExpression = cloneInvocationExpression(e.InvocationExpression)
+	return &clone
+}
+
+func (e *CreateExpression) Equal(other Element) bool {
+	return equal(e, other)
+}
+
 func (e *CreateExpression) AcceptExp(visitor ExpressionVisitor) Repr {
 	return visitor.VisitCreateExpression(e)
 }
@@ -1336,7 +1636,6 @@ func (e *CreateExpression) String() string {
 func (e *CreateExpression) Doc() prettier.Doc {
 	return prettier.Concat{
 		prettier.Text("create "),
-		// TODO: potentially parenthesize
 		e.InvocationExpression.Doc(),
 	}
 }
@@ -1362,6 +1661,10 @@ func (e *CreateExpression) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (e *CreateExpression) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, e)
+}
+
 // DestroyExpression
 
 type DestroyExpression struct {
@@ -1383,6 +1686,16 @@ func (e *DestroyExpression) Walk(walkChild func(Element)) {
 	walkChild(e.Expression)
 }
 
+func (e *DestroyExpression) Clone() Element {
+	clone := *e
+	clone.Expression = cloneExpression(e.Expression)
+	return &clone
+}
+
+func (e *DestroyExpression) Equal(other Element) bool {
+	return equal(e, other)
+}
+
 func (e *DestroyExpression) AcceptExp(visitor ExpressionVisitor) Repr {
 	return visitor.VisitDestroyExpression(e)
 }
@@ -1399,8 +1712,7 @@ const destroyExpressionKeywordDoc = prettier.Text("destroy ")
 func (e *DestroyExpression) Doc() prettier.Doc {
 	return prettier.Concat{
 		destroyExpressionKeywordDoc,
-		// TODO: potentially parenthesize
-		e.Expression.Doc(),
+		parenthesizedExpressionDoc(e.Expression, precedenceUnaryPrefix),
 	}
 }
 
@@ -1425,6 +1737,175 @@ func (e *DestroyExpression) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (e *DestroyExpression) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, e)
+}
+
+// AttachExpression
+
+type AttachExpression struct {
+	Base       Expression
+	Attachment *InvocationExpression
+	StartPos   Position `json:"-"`
+}
+
+var _ Expression = &AttachExpression{}
+
+func (*AttachExpression) isExpression() {}
+
+func (*AttachExpression) isIfStatementTest() {}
+
+func (e *AttachExpression) Accept(visitor Visitor) Repr {
+	return e.AcceptExp(visitor)
+}
+
+func (e *AttachExpression) Walk(walkChild func(Element)) {
+	walkChild(e.Attachment)
+	walkChild(e.Base)
+}
+
+func (e *AttachExpression) Clone() Element {
+	clone := *e
+	clone.Base = cloneExpression(e.Base)
+	clone.Attachment = cloneInvocationExpression(e.Attachment)
+	return &clone
+}
+
+func (e *AttachExpression) Equal(other Element) bool {
+	return equal(e, other)
+}
+
+func (e *AttachExpression) AcceptExp(visitor ExpressionVisitor) Repr {
+	return visitor.VisitAttachExpression(e)
+}
+
+func (e *AttachExpression) String() string {
+	return fmt.Sprintf(
+		"(attach %s to %s)",
+		e.Attachment,
+		e.Base,
+	)
+}
+
+const attachExpressionKeywordDoc = prettier.Text("attach ")
+const attachExpressionToKeywordDoc = prettier.Text(" to ")
+
+func (e *AttachExpression) Doc() prettier.Doc {
+	return prettier.Concat{
+		attachExpressionKeywordDoc,
+		e.Attachment.Doc(),
+		attachExpressionToKeywordDoc,
+		parenthesizedExpressionDoc(e.Base, precedenceUnaryPrefix),
+	}
+}
+
+func (e *AttachExpression) StartPosition() Position {
+	return e.StartPos
+}
+
+func (e *AttachExpression) EndPosition() Position {
+	return e.Base.EndPosition()
+}
+
+func (e *AttachExpression) MarshalJSON() ([]byte, error) {
+	type Alias AttachExpression
+	return json.Marshal(&struct {
+		Type string
+		Range
+		*Alias
+	}{
+		Type:  "AttachExpression",
+		Range: NewRangeFromPositioned(e),
+		Alias: (*Alias)(e),
+	})
+}
+
+func (e *AttachExpression) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, e)
+}
+
+// RemoveExpression
+
+type RemoveExpression struct {
+	Base       Expression
+	Attachment *NominalType
+	EndPos     Position `json:"-"`
+}
+
+var _ Expression = &RemoveExpression{}
+
+func (*RemoveExpression) isExpression() {}
+
+func (*RemoveExpression) isIfStatementTest() {}
+
+func (e *RemoveExpression) Accept(visitor Visitor) Repr {
+	return e.AcceptExp(visitor)
+}
+
+func (e *RemoveExpression) Walk(walkChild func(Element)) {
+	walkChild(e.Base)
+}
+
+func (e *RemoveExpression) Clone() Element {
+	clone := *e
+	clone.Base = cloneExpression(e.Base)
+	clone.Attachment = cloneNominalType(e.Attachment)
+	return &clone
+}
+
+func (e *RemoveExpression) Equal(other Element) bool {
+	return equal(e, other)
+}
+
+func (e *RemoveExpression) AcceptExp(visitor ExpressionVisitor) Repr {
+	return visitor.VisitRemoveExpression(e)
+}
+
+func (e *RemoveExpression) String() string {
+	return fmt.Sprintf(
+		"%s.remove<%s>()",
+		e.Base,
+		e.Attachment,
+	)
+}
+
+const removeExpressionDoc = prettier.Text(".remove<")
+const removeExpressionEndDoc = prettier.Text(">()")
+
+func (e *RemoveExpression) Doc() prettier.Doc {
+	return prettier.Concat{
+		parenthesizedExpressionDoc(e.Base, precedenceAccess),
+		removeExpressionDoc,
+		e.Attachment.Doc(),
+		removeExpressionEndDoc,
+	}
+}
+
+func (e *RemoveExpression) StartPosition() Position {
+	return e.Base.StartPosition()
+}
+
+func (e *RemoveExpression) EndPosition() Position {
+	return e.EndPos
+}
+
+func (e *RemoveExpression) MarshalJSON() ([]byte, error) {
+	type Alias RemoveExpression
+	return json.Marshal(&struct {
+		Type string
+		Range
+		*Alias
+	}{
+		Type:  "RemoveExpression",
+		Range: NewRangeFromPositioned(e),
+		Alias: (*Alias)(e),
+	})
+}
+
+func (e *RemoveExpression) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, e)
+}
+
 // ReferenceExpression
 
 type ReferenceExpression struct {
@@ -1448,6 +1929,17 @@ func (e *ReferenceExpression) Walk(walkChild func(Element)) {
 	// TODO: walk type
 }
 
+func (e *ReferenceExpression) Clone() Element {
+	clone := *e
+	clone.Expression = cloneExpression(e.Expression)
+	clone.Type = cloneType(e.Type)
+	return &clone
+}
+
+func (e *ReferenceExpression) Equal(other Element) bool {
+	return equal(e, other)
+}
+
 func (e *ReferenceExpression) AcceptExp(visitor ExpressionVisitor) Repr {
 	return visitor.VisitReferenceExpression(e)
 }
@@ -1464,8 +1956,9 @@ var referenceExpressionRefOperatorDoc prettier.Doc = prettier.Text("&")
 var referenceExpressionAsOperatorDoc prettier.Doc = prettier.Text("as")
 
 func (e *ReferenceExpression) Doc() prettier.Doc {
-	// TODO: potentially parenthesize
-	doc := e.Expression.Doc()
+	// The referenced expression is parsed as the left-hand side of a casting expression,
+	// so it must be parenthesized if it is a casting expression itself
+	doc := parenthesizedExpressionDoc(e.Expression, precedenceCasting+1)
 
 	return prettier.Group{
 		Doc: prettier.Concat{
@@ -1502,6 +1995,10 @@ func (e *ReferenceExpression) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (e *ReferenceExpression) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, e)
+}
+
 // ForceExpression
 
 type ForceExpression struct {
@@ -1523,6 +2020,16 @@ func (e *ForceExpression) Walk(walkChild func(Element)) {
 	walkChild(e.Expression)
 }
 
+func (e *ForceExpression) Clone() Element {
+	clone := *e
+	clone.Expression = cloneExpression(e.Expression)
+	return &clone
+}
+
+func (e *ForceExpression) Equal(other Element) bool {
+	return equal(e, other)
+}
+
 func (e *ForceExpression) AcceptExp(visitor ExpressionVisitor) Repr {
 	return visitor.VisitForceExpression(e)
 }
@@ -1535,8 +2042,7 @@ const forceExpressionOperatorDoc = prettier.Text("!")
 
 func (e *ForceExpression) Doc() prettier.Doc {
 	return prettier.Concat{
-		// TODO: potentially parenthesize
-		e.Expression.Doc(),
+		parenthesizedExpressionDoc(e.Expression, precedenceUnaryPostfix),
 		forceExpressionOperatorDoc,
 	}
 }
@@ -1562,6 +2068,10 @@ func (e *ForceExpression) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (e *ForceExpression) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, e)
+}
+
 // PathExpression
 
 type PathExpression struct {
@@ -1584,6 +2094,15 @@ func (*PathExpression) Walk(_ func(Element)) {
 	// NO-OP
 }
 
+func (e *PathExpression) Clone() Element {
+	clone := *e
+	return &clone
+}
+
+func (e *PathExpression) Equal(other Element) bool {
+	return equal(e, other)
+}
+
 func (e *PathExpression) AcceptExp(visitor ExpressionVisitor) Repr {
 	return visitor.VisitPathExpression(e)
 }
@@ -1616,3 +2135,7 @@ func (e *PathExpression) MarshalJSON() ([]byte, error) {
 		Alias: (*Alias)(e),
 	})
 }
+
+func (e *PathExpression) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, e)
+}
@@ -44,6 +44,13 @@ type StringExtractor interface {
 	ExtractString(extractor *ExpressionExtractor, expression *StringExpression) ExpressionExtraction
 }
 
+type StringInterpolationExtractor interface {
+	ExtractStringInterpolation(
+		extractor *ExpressionExtractor,
+		expression *StringInterpolationExpression,
+	) ExpressionExtraction
+}
+
 type ArrayExtractor interface {
 	ExtractArray(extractor *ExpressionExtractor, expression *ArrayExpression) ExpressionExtraction
 }
@@ -96,6 +103,14 @@ type DestroyExtractor interface {
 	ExtractDestroy(extractor *ExpressionExtractor, expression *DestroyExpression) ExpressionExtraction
 }
 
+type AttachExtractor interface {
+	ExtractAttach(extractor *ExpressionExtractor, expression *AttachExpression) ExpressionExtraction
+}
+
+type RemoveExtractor interface {
+	ExtractRemove(extractor *ExpressionExtractor, expression *RemoveExpression) ExpressionExtraction
+}
+
 type ReferenceExtractor interface {
 	ExtractReference(extractor *ExpressionExtractor, expression *ReferenceExpression) ExpressionExtraction
 }
@@ -109,28 +124,31 @@ type PathExtractor interface {
 }
 
 type ExpressionExtractor struct {
-	nextIdentifier       int
-	BoolExtractor        BoolExtractor
-	NilExtractor         NilExtractor
-	IntExtractor         IntExtractor
-	FixedPointExtractor  FixedPointExtractor
-	StringExtractor      StringExtractor
-	ArrayExtractor       ArrayExtractor
-	DictionaryExtractor  DictionaryExtractor
-	IdentifierExtractor  IdentifierExtractor
-	InvocationExtractor  InvocationExtractor
-	MemberExtractor      MemberExtractor
-	IndexExtractor       IndexExtractor
-	ConditionalExtractor ConditionalExtractor
-	UnaryExtractor       UnaryExtractor
-	BinaryExtractor      BinaryExtractor
-	FunctionExtractor    FunctionExtractor
-	CastingExtractor     CastingExtractor
-	CreateExtractor      CreateExtractor
-	DestroyExtractor     DestroyExtractor
-	ReferenceExtractor   ReferenceExtractor
-	ForceExtractor       ForceExtractor
-	PathExtractor        PathExtractor
+	nextIdentifier               int
+	BoolExtractor                BoolExtractor
+	NilExtractor                 NilExtractor
+	IntExtractor                 IntExtractor
+	FixedPointExtractor          FixedPointExtractor
+	StringExtractor              StringExtractor
+	StringInterpolationExtractor StringInterpolationExtractor
+	ArrayExtractor               ArrayExtractor
+	DictionaryExtractor          DictionaryExtractor
+	IdentifierExtractor          IdentifierExtractor
+	InvocationExtractor          InvocationExtractor
+	MemberExtractor              MemberExtractor
+	IndexExtractor               IndexExtractor
+	ConditionalExtractor         ConditionalExtractor
+	UnaryExtractor               UnaryExtractor
+	BinaryExtractor              BinaryExtractor
+	FunctionExtractor            FunctionExtractor
+	CastingExtractor             CastingExtractor
+	CreateExtractor              CreateExtractor
+	DestroyExtractor             DestroyExtractor
+	AttachExtractor              AttachExtractor
+	RemoveExtractor              RemoveExtractor
+	ReferenceExtractor           ReferenceExtractor
+	ForceExtractor               ForceExtractor
+	PathExtractor                PathExtractor
 }
 
 func (extractor *ExpressionExtractor) Extract(expression Expression) ExpressionExtraction {
@@ -268,6 +286,37 @@ func (extractor *ExpressionExtractor) ExtractString(expression *StringExpression
 	}
 }
 
+func (extractor *ExpressionExtractor) VisitStringInterpolationExpression(expression *StringInterpolationExpression) Repr {
+
+	// delegate to child extractor, if any,
+	// or call default implementation
+
+	if extractor.StringInterpolationExtractor != nil {
+		return extractor.StringInterpolationExtractor.ExtractStringInterpolation(extractor, expression)
+	}
+	return extractor.ExtractStringInterpolation(expression)
+}
+
+func (extractor *ExpressionExtractor) ExtractStringInterpolation(
+	expression *StringInterpolationExpression,
+) ExpressionExtraction {
+
+	// copy the expression
+	newExpression := *expression
+
+	// rewrite all segment expressions
+
+	rewrittenExpressions, extractedExpressions :=
+		extractor.VisitExpressions(expression.Segments)
+
+	newExpression.Segments = rewrittenExpressions
+
+	return ExpressionExtraction{
+		RewrittenExpression:  &newExpression,
+		ExtractedExpressions: extractedExpressions,
+	}
+}
+
 func (extractor *ExpressionExtractor) VisitArrayExpression(expression *ArrayExpression) Repr {
 
 	// delegate to child extractor, if any,
@@ -719,6 +768,88 @@ func (extractor *ExpressionExtractor) ExtractDestroy(expression *DestroyExpressi
 	}
 }
 
+func (extractor *ExpressionExtractor) VisitAttachExpression(expression *AttachExpression) Repr {
+	// delegate to child extractor, if any,
+	// or call default implementation
+
+	if extractor.AttachExtractor != nil {
+		return extractor.AttachExtractor.ExtractAttach(extractor, expression)
+	}
+	return extractor.ExtractAttach(expression)
+}
+
+func (extractor *ExpressionExtractor) ExtractAttach(expression *AttachExpression) ExpressionExtraction {
+
+	// copy the expression
+	newExpression := *expression
+
+	// rewrite the sub-expressions
+
+	attachmentResult := extractor.Extract(newExpression.Attachment)
+
+	attachment, ok := attachmentResult.RewrittenExpression.(*InvocationExpression)
+	if !ok {
+		// Edge-case:
+		// The rewritten expression returned from the extractor may not be an InvocationExpression,
+		// but an expression of another type.
+		//
+		// Wrap the rewritten expression in an InvocationExpression.
+
+		attachment = &InvocationExpression{
+			InvokedExpression: attachmentResult.RewrittenExpression,
+			EndPos:            attachmentResult.RewrittenExpression.EndPosition(),
+		}
+	}
+
+	newExpression.Attachment = attachment
+
+	baseResult := extractor.Extract(newExpression.Base)
+
+	newExpression.Base = baseResult.RewrittenExpression
+
+	var extractedExpressions []ExtractedExpression
+	extractedExpressions = append(
+		extractedExpressions,
+		attachmentResult.ExtractedExpressions...,
+	)
+	extractedExpressions = append(
+		extractedExpressions,
+		baseResult.ExtractedExpressions...,
+	)
+
+	return ExpressionExtraction{
+		RewrittenExpression:  &newExpression,
+		ExtractedExpressions: extractedExpressions,
+	}
+}
+
+func (extractor *ExpressionExtractor) VisitRemoveExpression(expression *RemoveExpression) Repr {
+	// delegate to child extractor, if any,
+	// or call default implementation
+
+	if extractor.RemoveExtractor != nil {
+		return extractor.RemoveExtractor.ExtractRemove(extractor, expression)
+	}
+	return extractor.ExtractRemove(expression)
+}
+
+func (extractor *ExpressionExtractor) ExtractRemove(expression *RemoveExpression) ExpressionExtraction {
+
+	// copy the expression
+	newExpression := *expression
+
+	// rewrite the sub-expression
+
+	result := extractor.Extract(newExpression.Base)
+
+	newExpression.Base = result.RewrittenExpression
+
+	return ExpressionExtraction{
+		RewrittenExpression:  &newExpression,
+		ExtractedExpressions: result.ExtractedExpressions,
+	}
+}
+
 func (extractor *ExpressionExtractor) VisitReferenceExpression(expression *ReferenceExpression) Repr {
 	// delegate to child extractor, if any,
 	// or call default implementation
@@ -142,6 +142,106 @@ func TestStringExpression_Doc(t *testing.T) {
 	)
 }
 
+func TestStringInterpolationExpression_MarshalJSON(t *testing.T) {
+
+	t.Parallel()
+
+	expr := &StringInterpolationExpression{
+		Segments: []Expression{
+			&StringExpression{
+				Value: "a ",
+				Range: Range{
+					StartPos: Position{Offset: 1, Line: 2, Column: 3},
+					EndPos:   Position{Offset: 4, Line: 5, Column: 6},
+				},
+			},
+			&BoolExpression{
+				Value: true,
+				Range: Range{
+					StartPos: Position{Offset: 7, Line: 8, Column: 9},
+					EndPos:   Position{Offset: 10, Line: 11, Column: 12},
+				},
+			},
+			&StringExpression{
+				Value: "",
+				Range: Range{
+					StartPos: Position{Offset: 13, Line: 14, Column: 15},
+					EndPos:   Position{Offset: 16, Line: 17, Column: 18},
+				},
+			},
+		},
+		Range: Range{
+			StartPos: Position{Offset: 19, Line: 20, Column: 21},
+			EndPos:   Position{Offset: 22, Line: 23, Column: 24},
+		},
+	}
+
+	actual, err := json.Marshal(expr)
+	require.NoError(t, err)
+
+	assert.JSONEq(t,
+		`
+        {
+            "Type": "StringInterpolationExpression",
+            "Segments": [
+                {
+                    "Type": "StringExpression",
+                    "Value": "a ",
+                    "StartPos": {"Offset": 1, "Line": 2, "Column": 3},
+                    "EndPos": {"Offset": 4, "Line": 5, "Column": 6}
+                },
+                {
+                    "Type": "BoolExpression",
+                    "Value": true,
+                    "StartPos": {"Offset": 7, "Line": 8, "Column": 9},
+                    "EndPos": {"Offset": 10, "Line": 11, "Column": 12}
+                },
+                {
+                    "Type": "StringExpression",
+                    "Value": "",
+                    "StartPos": {"Offset": 13, "Line": 14, "Column": 15},
+                    "EndPos": {"Offset": 16, "Line": 17, "Column": 18}
+                }
+            ],
+            "StartPos": {"Offset": 19, "Line": 20, "Column": 21},
+            "EndPos": {"Offset": 22, "Line": 23, "Column": 24}
+        }
+        `,
+		string(actual),
+	)
+}
+
+func TestStringInterpolationExpression_Doc(t *testing.T) {
+
+	t.Parallel()
+
+	expr := &StringInterpolationExpression{
+		Segments: []Expression{
+			&StringExpression{Value: "a\n"},
+			&BoolExpression{Value: true},
+			&StringExpression{Value: ""},
+		},
+	}
+
+	assert.Equal(t,
+		prettier.Concat{
+			prettier.Text(`"`),
+			prettier.Text(`a\n`),
+			prettier.Text(`\(`),
+			prettier.Text("true"),
+			prettier.Text(")"),
+			prettier.Text(""),
+			prettier.Text(`"`),
+		},
+		expr.Doc(),
+	)
+
+	assert.Equal(t,
+		`"a\n\(true)"`,
+		expr.String(),
+	)
+}
+
 func TestIntegerExpression_MarshalJSON(t *testing.T) {
 
 	t.Parallel()
@@ -1099,6 +1199,122 @@ func TestDestroyExpression_Doc(t *testing.T) {
 	)
 }
 
+func TestAttachExpression_Doc(t *testing.T) {
+
+	t.Parallel()
+
+	expr := &AttachExpression{
+		Base: &IdentifierExpression{
+			Identifier: Identifier{
+				Identifier: "foo",
+			},
+		},
+		Attachment: &InvocationExpression{
+			InvokedExpression: &IdentifierExpression{
+				Identifier: Identifier{
+					Identifier: "A",
+				},
+			},
+		},
+	}
+
+	assert.Equal(t,
+		prettier.Concat{
+			prettier.Text("attach "),
+			prettier.Concat{
+				prettier.Text("A"),
+				prettier.Text("()"),
+			},
+			prettier.Text(" to "),
+			prettier.Text("foo"),
+		},
+		expr.Doc(),
+	)
+}
+
+func TestRemoveExpression_MarshalJSON(t *testing.T) {
+
+	t.Parallel()
+
+	expr := &RemoveExpression{
+		Base: &IdentifierExpression{
+			Identifier: Identifier{
+				Identifier: "foobar",
+				Pos:        Position{Offset: 1, Line: 2, Column: 3},
+			},
+		},
+		Attachment: &NominalType{
+			Identifier: Identifier{
+				Identifier: "AB",
+				Pos:        Position{Offset: 4, Line: 5, Column: 6},
+			},
+		},
+		EndPos: Position{Offset: 7, Line: 8, Column: 9},
+	}
+
+	actual, err := json.Marshal(expr)
+	require.NoError(t, err)
+
+	assert.JSONEq(t,
+		`
+        {
+            "Type": "RemoveExpression",
+            "Base": {
+                "Type": "IdentifierExpression",
+                "Identifier": {
+                    "Identifier": "foobar",
+                    "StartPos": {"Offset": 1, "Line": 2, "Column": 3},
+                    "EndPos": {"Offset": 6, "Line": 2, "Column": 8}
+                },
+                "StartPos": {"Offset": 1, "Line": 2, "Column": 3},
+                "EndPos": {"Offset": 6, "Line": 2, "Column": 8}
+            },
+            "Attachment": {
+                "Type": "NominalType",
+                "Identifier": {
+                    "Identifier": "AB",
+                    "StartPos": {"Offset": 4, "Line": 5, "Column": 6},
+                    "EndPos": {"Offset": 5, "Line": 5, "Column": 7}
+                },
+                "StartPos": {"Offset": 4, "Line": 5, "Column": 6},
+                "EndPos": {"Offset": 5, "Line": 5, "Column": 7}
+            },
+            "StartPos": {"Offset": 1, "Line": 2, "Column": 3},
+            "EndPos": {"Offset": 7, "Line": 8, "Column": 9}
+        }
+        `,
+		string(actual),
+	)
+}
+
+func TestRemoveExpression_Doc(t *testing.T) {
+
+	t.Parallel()
+
+	expr := &RemoveExpression{
+		Base: &IdentifierExpression{
+			Identifier: Identifier{
+				Identifier: "foo",
+			},
+		},
+		Attachment: &NominalType{
+			Identifier: Identifier{
+				Identifier: "A",
+			},
+		},
+	}
+
+	assert.Equal(t,
+		prettier.Concat{
+			prettier.Text("foo"),
+			prettier.Text(".remove<"),
+			prettier.Text("A"),
+			prettier.Text(">()"),
+		},
+		expr.Doc(),
+	)
+}
+
 func TestForceExpression_MarshalJSON(t *testing.T) {
 
 	t.Parallel()
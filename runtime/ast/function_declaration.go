@@ -21,6 +21,8 @@ package ast
 import (
 	"encoding/json"
 
+	"github.com/turbolent/prettier"
+
 	"github.com/onflow/cadence/runtime/common"
 )
 
@@ -31,7 +33,9 @@ type FunctionDeclaration struct {
 	ReturnTypeAnnotation *TypeAnnotation
 	FunctionBlock        *FunctionBlock
 	DocString            string
-	StartPos             Position `json:"-"`
+	Annotations          []*AnnotationDeclaration `json:",omitempty"`
+	IsNative             bool                     `json:",omitempty"`
+	StartPos             Position                 `json:"-"`
 }
 
 func (d *FunctionDeclaration) StartPosition() Position {
@@ -60,6 +64,19 @@ func (d *FunctionDeclaration) Walk(walkChild func(Element)) {
 	}
 }
 
+func (d *FunctionDeclaration) Clone() Element {
+	clone := *d
+	clone.Annotations = cloneAnnotations(d.Annotations)
+	clone.ParameterList = d.ParameterList.Clone()
+	clone.ReturnTypeAnnotation = d.ReturnTypeAnnotation.Clone()
+	clone.FunctionBlock = cloneFunctionBlock(d.FunctionBlock)
+	return &clone
+}
+
+func (d *FunctionDeclaration) Equal(other Element) bool {
+	return equal(d, other)
+}
+
 func (*FunctionDeclaration) isDeclaration() {}
 func (*FunctionDeclaration) isStatement()   {}
 
@@ -92,6 +109,49 @@ func (d *FunctionDeclaration) DeclarationDocString() string {
 	return d.DocString
 }
 
+var functionDeclarationFunKeywordSpaceDoc prettier.Doc = prettier.Text("fun ")
+var functionDeclarationNativeKeywordSpaceDoc prettier.Doc = prettier.Text("native ")
+
+func (d *FunctionDeclaration) Doc() prettier.Doc {
+	return d.doc(true)
+}
+
+func (d *FunctionDeclaration) String() string {
+	return declarationString(d)
+}
+
+func (d *FunctionDeclaration) doc(includeFunKeyword bool) prettier.Doc {
+	var doc prettier.Concat
+
+	if d.IsNative {
+		doc = append(doc, functionDeclarationNativeKeywordSpaceDoc)
+	}
+
+	if includeFunKeyword {
+		doc = append(doc, functionDeclarationFunKeywordSpaceDoc)
+	}
+
+	doc = append(
+		doc,
+		prettier.Text(d.Identifier.Identifier),
+		prettier.Group{
+			Doc: FunctionSignatureDoc(d.ParameterList, d.ReturnTypeAnnotation),
+		},
+	)
+
+	// Function declarations in interfaces might not have a function block
+
+	if d.FunctionBlock != nil {
+		doc = append(
+			doc,
+			prettier.Space,
+			d.FunctionBlock.Doc(),
+		)
+	}
+
+	return declarationDoc(d.DocString, d.Access, doc)
+}
+
 func (d *FunctionDeclaration) MarshalJSON() ([]byte, error) {
 	type Alias FunctionDeclaration
 	return json.Marshal(&struct {
@@ -105,6 +165,10 @@ func (d *FunctionDeclaration) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (d *FunctionDeclaration) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, d)
+}
+
 // SpecialFunctionDeclaration
 
 type SpecialFunctionDeclaration struct {
@@ -128,6 +192,16 @@ func (d *SpecialFunctionDeclaration) Walk(walkChild func(Element)) {
 	d.FunctionDeclaration.Walk(walkChild)
 }
 
+func (d *SpecialFunctionDeclaration) Clone() Element {
+	clone := *d
+	clone.FunctionDeclaration = cloneFunctionDeclaration(d.FunctionDeclaration)
+	return &clone
+}
+
+func (d *SpecialFunctionDeclaration) Equal(other Element) bool {
+	return equal(d, other)
+}
+
 func (*SpecialFunctionDeclaration) isDeclaration() {}
 func (*SpecialFunctionDeclaration) isStatement()   {}
 
@@ -151,6 +225,26 @@ func (d *SpecialFunctionDeclaration) DeclarationDocString() string {
 	return d.FunctionDeclaration.DeclarationDocString()
 }
 
+func (d *SpecialFunctionDeclaration) Doc() prettier.Doc {
+
+	// Special functions are declared without the `fun` keyword,
+	// and the execute function of transactions also has no parameter list
+
+	if d.Kind == common.DeclarationKindExecute {
+		return prettier.Concat{
+			prettier.Text(d.FunctionDeclaration.Identifier.Identifier),
+			prettier.Space,
+			d.FunctionDeclaration.FunctionBlock.Doc(),
+		}
+	}
+
+	return d.FunctionDeclaration.doc(false)
+}
+
+func (d *SpecialFunctionDeclaration) String() string {
+	return declarationString(d)
+}
+
 func (d *SpecialFunctionDeclaration) MarshalJSON() ([]byte, error) {
 	type Alias SpecialFunctionDeclaration
 	return json.Marshal(&struct {
@@ -163,3 +257,7 @@ func (d *SpecialFunctionDeclaration) MarshalJSON() ([]byte, error) {
 		Alias: (*Alias)(d),
 	})
 }
+
+func (d *SpecialFunctionDeclaration) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, d)
+}
@@ -316,3 +316,57 @@ func TestSpecialFunctionDeclaration_MarshalJSON(t *testing.T) {
 		string(actual),
 	)
 }
+
+func TestFunctionDeclaration_String(t *testing.T) {
+
+	t.Parallel()
+
+	decl := &FunctionDeclaration{
+		Access: AccessPublic,
+		Identifier: Identifier{
+			Identifier: "xyz",
+		},
+		ParameterList: &ParameterList{
+			Parameters: []*Parameter{
+				{
+					Label: "ok",
+					Identifier: Identifier{
+						Identifier: "foobar",
+					},
+					TypeAnnotation: &TypeAnnotation{
+						Type: &NominalType{
+							Identifier: Identifier{
+								Identifier: "AB",
+							},
+						},
+					},
+				},
+			},
+		},
+		ReturnTypeAnnotation: &TypeAnnotation{
+			Type: &NominalType{
+				Identifier: Identifier{
+					Identifier: "CD",
+				},
+			},
+		},
+		FunctionBlock: &FunctionBlock{
+			Block: &Block{
+				Statements: []Statement{
+					&ReturnStatement{
+						Expression: &IdentifierExpression{
+							Identifier: Identifier{
+								Identifier: "foobar",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t,
+		"pub fun xyz(ok foobar: AB): CD {\n    return foobar\n}",
+		decl.String(),
+	)
+}
@@ -51,3 +51,7 @@ func (i Identifier) MarshalJSON() ([]byte, error) {
 		Range:      NewRangeFromPositioned(i),
 	})
 }
+
+func (i *Identifier) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, i)
+}
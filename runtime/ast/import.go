@@ -21,6 +21,8 @@ package ast
 import (
 	"encoding/json"
 
+	"github.com/turbolent/prettier"
+
 	"github.com/onflow/cadence/runtime/common"
 )
 
@@ -45,6 +47,16 @@ func (*ImportDeclaration) Walk(_ func(Element)) {
 	// NO-OP
 }
 
+func (d *ImportDeclaration) Clone() Element {
+	clone := *d
+	clone.Identifiers = cloneIdentifiers(d.Identifiers)
+	return &clone
+}
+
+func (d *ImportDeclaration) Equal(other Element) bool {
+	return equal(d, other)
+}
+
 func (d *ImportDeclaration) DeclarationIdentifier() *Identifier {
 	return nil
 }
@@ -65,6 +77,58 @@ func (d *ImportDeclaration) DeclarationDocString() string {
 	return ""
 }
 
+var importDeclarationImportKeywordSpaceDoc prettier.Doc = prettier.Text("import ")
+var importDeclarationSpaceFromKeywordSpaceDoc prettier.Doc = prettier.Text(" from ")
+var importDeclarationIdentifierSeparatorDoc prettier.Doc = prettier.Text(", ")
+
+func (d *ImportDeclaration) Doc() prettier.Doc {
+	doc := prettier.Concat{
+		importDeclarationImportKeywordSpaceDoc,
+	}
+
+	if len(d.Identifiers) > 0 {
+		identifierDocs := make([]prettier.Doc, 0, len(d.Identifiers))
+		for _, identifier := range d.Identifiers {
+			identifierDocs = append(identifierDocs, prettier.Text(identifier.Identifier))
+		}
+
+		doc = append(
+			doc,
+			prettier.Join(
+				importDeclarationIdentifierSeparatorDoc,
+				identifierDocs...,
+			),
+			importDeclarationSpaceFromKeywordSpaceDoc,
+		)
+	}
+
+	return append(
+		doc,
+		importLocationDoc(d.Location),
+	)
+}
+
+func (d *ImportDeclaration) String() string {
+	return declarationString(d)
+}
+
+func importLocationDoc(location common.Location) prettier.Doc {
+	switch location := location.(type) {
+	case common.StringLocation:
+		return prettier.Text(QuoteString(string(location)))
+
+	case common.AddressLocation:
+		address := location.Address.ShortHexWithPrefix()
+		if address == "0x" {
+			address = "0x0"
+		}
+		return prettier.Text(address)
+
+	default:
+		return prettier.Text(location.String())
+	}
+}
+
 func (d *ImportDeclaration) MarshalJSON() ([]byte, error) {
 	type Alias ImportDeclaration
 	return json.Marshal(&struct {
@@ -75,3 +139,7 @@ func (d *ImportDeclaration) MarshalJSON() ([]byte, error) {
 		Alias: (*Alias)(d),
 	})
 }
+
+func (d *ImportDeclaration) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, d)
+}
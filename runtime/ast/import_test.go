@@ -73,3 +73,25 @@ func TestImportDeclaration_MarshalJSON(t *testing.T) {
 		string(actual),
 	)
 }
+
+func TestImportDeclaration_String(t *testing.T) {
+
+	t.Parallel()
+
+	decl := &ImportDeclaration{
+		Identifiers: []Identifier{
+			{
+				Identifier: "foo",
+			},
+			{
+				Identifier: "bar",
+			},
+		},
+		Location: common.StringLocation("test"),
+	}
+
+	assert.Equal(t,
+		`import foo, bar from "test"`,
+		decl.String(),
+	)
+}
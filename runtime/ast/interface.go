@@ -21,6 +21,8 @@ package ast
 import (
 	"encoding/json"
 
+	"github.com/turbolent/prettier"
+
 	"github.com/onflow/cadence/runtime/common"
 )
 
@@ -32,6 +34,7 @@ type InterfaceDeclaration struct {
 	Identifier    Identifier
 	Members       *Members
 	DocString     string
+	Annotations   []*AnnotationDeclaration `json:",omitempty"`
 	Range
 }
 
@@ -43,6 +46,17 @@ func (d *InterfaceDeclaration) Walk(walkChild func(Element)) {
 	walkDeclarations(walkChild, d.Members.declarations)
 }
 
+func (d *InterfaceDeclaration) Clone() Element {
+	clone := *d
+	clone.Annotations = cloneAnnotations(d.Annotations)
+	clone.Members = d.Members.Clone()
+	return &clone
+}
+
+func (d *InterfaceDeclaration) Equal(other Element) bool {
+	return equal(d, other)
+}
+
 func (*InterfaceDeclaration) isDeclaration() {}
 
 // NOTE: statement, so it can be represented in the AST,
@@ -70,6 +84,27 @@ func (d *InterfaceDeclaration) DeclarationDocString() string {
 	return d.DocString
 }
 
+var interfaceKeywordSpaceDoc prettier.Doc = prettier.Text("interface ")
+
+func (d *InterfaceDeclaration) Doc() prettier.Doc {
+	return declarationDoc(
+		d.DocString,
+		d.Access,
+		prettier.Concat{
+			prettier.Text(d.CompositeKind.Keyword()),
+			prettier.Space,
+			interfaceKeywordSpaceDoc,
+			prettier.Text(d.Identifier.Identifier),
+			prettier.Space,
+			d.Members.Doc(),
+		},
+	)
+}
+
+func (d *InterfaceDeclaration) String() string {
+	return declarationString(d)
+}
+
 func (d *InterfaceDeclaration) MarshalJSON() ([]byte, error) {
 	type Alias InterfaceDeclaration
 	return json.Marshal(&struct {
@@ -80,3 +115,7 @@ func (d *InterfaceDeclaration) MarshalJSON() ([]byte, error) {
 		Alias: (*Alias)(d),
 	})
 }
+
+func (d *InterfaceDeclaration) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, d)
+}
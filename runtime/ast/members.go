@@ -21,6 +21,8 @@ package ast
 import (
 	"encoding/json"
 
+	"github.com/turbolent/prettier"
+
 	"github.com/onflow/cadence/runtime/common"
 )
 
@@ -37,10 +39,24 @@ func NewMembers(declarations []Declaration) *Members {
 	}
 }
 
+// Declarations returns all member declarations,
+// i.e. fields, functions, special functions, nested types, and enum cases,
+// in the order they appear in the source
+//
 func (m *Members) Declarations() []Declaration {
 	return m.declarations
 }
 
+// Clone returns a deep copy of the members.
+// The member indices are not copied, they are recomputed on demand
+//
+func (m *Members) Clone() *Members {
+	if m == nil {
+		return nil
+	}
+	return NewMembers(cloneDeclarations(m.declarations))
+}
+
 func (m *Members) Fields() []*FieldDeclaration {
 	return m.indices.Fields(m.declarations)
 }
@@ -120,3 +136,61 @@ func (m *Members) MarshalJSON() ([]byte, error) {
 		Alias:        (*Alias)(m),
 	})
 }
+
+func (m *Members) UnmarshalJSON(data []byte) error {
+	declarations, err := unmarshalDeclarations(data)
+	if err != nil {
+		return err
+	}
+	m.declarations = declarations
+	return nil
+}
+
+// Doc returns the document for the members.
+// Consecutive fields and enum cases are rendered on consecutive lines,
+// all other members are separated by a blank line
+//
+func (m *Members) Doc() prettier.Doc {
+	if len(m.declarations) == 0 {
+		return blockEmptyDoc
+	}
+
+	var membersDoc prettier.Concat
+
+	var previous Declaration
+	for _, declaration := range m.declarations {
+		if previous != nil && !isGroupedMember(previous, declaration) {
+			membersDoc = append(membersDoc, prettier.HardLine{})
+		}
+
+		membersDoc = append(
+			membersDoc,
+			prettier.HardLine{},
+			declaration.Doc(),
+		)
+
+		previous = declaration
+	}
+
+	return prettier.Concat{
+		blockStartDoc,
+		prettier.Indent{
+			Doc: membersDoc,
+		},
+		prettier.HardLine{},
+		blockEndDoc,
+	}
+}
+
+func isGroupedMember(previous, next Declaration) bool {
+	switch previous.(type) {
+	case *FieldDeclaration:
+		_, ok := next.(*FieldDeclaration)
+		return ok
+	case *EnumCaseDeclaration:
+		_, ok := next.(*EnumCaseDeclaration)
+		return ok
+	}
+
+	return false
+}
@@ -20,6 +20,7 @@ package ast
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"github.com/onflow/cadence/runtime/errors"
 )
@@ -118,3 +119,20 @@ func (s Operation) Symbol() string {
 func (s Operation) MarshalJSON() ([]byte, error) {
 	return json.Marshal(s.String())
 }
+
+func (s *Operation) UnmarshalJSON(data []byte) error {
+	var name string
+	err := json.Unmarshal(data, &name)
+	if err != nil {
+		return err
+	}
+
+	for operation := Operation(0); operation < Operation(OperationCount()); operation++ {
+		if operation.String() == name {
+			*s = operation
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid operation: %s", name)
+}
@@ -18,6 +18,8 @@
 
 package ast
 
+import "github.com/turbolent/prettier"
+
 type Parameter struct {
 	Label          string
 	Identifier     Identifier
@@ -36,3 +38,28 @@ func (p Parameter) EffectiveArgumentLabel() string {
 	}
 	return p.Identifier.Identifier
 }
+
+func (p *Parameter) Clone() *Parameter {
+	clone := *p
+	clone.TypeAnnotation = p.TypeAnnotation.Clone()
+	return &clone
+}
+
+func (p Parameter) Doc() prettier.Doc {
+	var parameterDoc prettier.Concat
+
+	if p.Label != "" {
+		parameterDoc = append(
+			parameterDoc,
+			prettier.Text(p.Label),
+			prettier.Space,
+		)
+	}
+
+	return append(
+		parameterDoc,
+		prettier.Text(p.Identifier.Identifier),
+		typeSeparatorDoc,
+		p.TypeAnnotation.Doc(),
+	)
+}
@@ -18,7 +18,11 @@
 
 package ast
 
-import "sync"
+import (
+	"sync"
+
+	"github.com/turbolent/prettier"
+)
 
 type ParameterList struct {
 	once                    sync.Once
@@ -54,3 +58,54 @@ func (l *ParameterList) initialize() {
 	}
 	l._parametersByIdentifier = parametersByIdentifier
 }
+
+// Clone returns a deep copy of the parameter list.
+// The cached parameters by identifier are not copied
+//
+func (l *ParameterList) Clone() *ParameterList {
+	if l == nil {
+		return nil
+	}
+
+	var parameters []*Parameter
+	if l.Parameters != nil {
+		parameters = make([]*Parameter, len(l.Parameters))
+		for i, parameter := range l.Parameters {
+			parameters[i] = parameter.Clone()
+		}
+	}
+
+	return &ParameterList{
+		Parameters: parameters,
+		Range:      l.Range,
+	}
+}
+
+var parameterSeparatorDoc prettier.Doc = prettier.Concat{
+	prettier.Text(","),
+	prettier.Line{},
+}
+
+// Doc returns the document for the parameter list.
+// The parameters are wrapped onto separate lines if they do not fit on one line
+//
+func (l *ParameterList) Doc() prettier.Doc {
+
+	if l == nil || len(l.Parameters) == 0 {
+		return prettier.Text("()")
+	}
+
+	parameterDocs := make([]prettier.Doc, 0, len(l.Parameters))
+
+	for _, parameter := range l.Parameters {
+		parameterDocs = append(parameterDocs, parameter.Doc())
+	}
+
+	return prettier.WrapParentheses(
+		prettier.Join(
+			parameterSeparatorDoc,
+			parameterDocs...,
+		),
+		prettier.SoftLine{},
+	)
+}
@@ -0,0 +1,101 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ast
+
+import (
+	"strings"
+
+	"github.com/turbolent/prettier"
+)
+
+// TuplePattern is a pattern which destructures a value into its elements,
+// e.g. the `(a, _, (b, c))` in `let (a, _, (b, c)) = value`.
+//
+type TuplePattern struct {
+	Elements []*TuplePatternElement
+	Range
+}
+
+// TuplePatternElement is an element of a tuple pattern.
+// It is either an identifier, which may be the wildcard `_`,
+// or a nested tuple pattern.
+//
+type TuplePatternElement struct {
+	Identifier *Identifier   `json:",omitempty"`
+	Pattern    *TuplePattern `json:",omitempty"`
+}
+
+// Identifiers returns the identifiers bound by the pattern,
+// including the identifiers of nested patterns, in source order.
+// Wildcards are not included.
+//
+func (p *TuplePattern) Identifiers() []Identifier {
+	var identifiers []Identifier
+	for _, element := range p.Elements {
+		switch {
+		case element.Pattern != nil:
+			identifiers = append(identifiers, element.Pattern.Identifiers()...)
+		case element.Identifier != nil && element.Identifier.Identifier != "_":
+			identifiers = append(identifiers, *element.Identifier)
+		}
+	}
+	return identifiers
+}
+
+func (p *TuplePattern) String() string {
+	var builder strings.Builder
+	builder.WriteRune('(')
+	for i, element := range p.Elements {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		switch {
+		case element.Pattern != nil:
+			builder.WriteString(element.Pattern.String())
+		case element.Identifier != nil:
+			builder.WriteString(element.Identifier.Identifier)
+		}
+	}
+	builder.WriteRune(')')
+	return builder.String()
+}
+
+func (p *TuplePattern) Doc() prettier.Doc {
+	return prettier.Text(p.String())
+}
+
+func (p *TuplePattern) Clone() *TuplePattern {
+	if p == nil {
+		return nil
+	}
+	clone := *p
+	if p.Elements != nil {
+		clone.Elements = make([]*TuplePatternElement, len(p.Elements))
+		for i, element := range p.Elements {
+			elementClone := *element
+			if element.Identifier != nil {
+				identifier := *element.Identifier
+				elementClone.Identifier = &identifier
+			}
+			elementClone.Pattern = element.Pattern.Clone()
+			clone.Elements[i] = &elementClone
+		}
+	}
+	return &clone
+}
@@ -21,6 +21,8 @@ package ast
 import (
 	"encoding/json"
 
+	"github.com/turbolent/prettier"
+
 	"github.com/onflow/cadence/runtime/common"
 )
 
@@ -43,6 +45,16 @@ func (d *PragmaDeclaration) Walk(walkChild func(Element)) {
 	walkChild(d.Expression)
 }
 
+func (d *PragmaDeclaration) Clone() Element {
+	clone := *d
+	clone.Expression = cloneExpression(d.Expression)
+	return &clone
+}
+
+func (d *PragmaDeclaration) Equal(other Element) bool {
+	return equal(d, other)
+}
+
 func (d *PragmaDeclaration) DeclarationIdentifier() *Identifier {
 	return nil
 }
@@ -63,6 +75,19 @@ func (d *PragmaDeclaration) DeclarationDocString() string {
 	return ""
 }
 
+var pragmaDeclarationSymbolDoc prettier.Doc = prettier.Text("#")
+
+func (d *PragmaDeclaration) Doc() prettier.Doc {
+	return prettier.Concat{
+		pragmaDeclarationSymbolDoc,
+		d.Expression.Doc(),
+	}
+}
+
+func (d *PragmaDeclaration) String() string {
+	return declarationString(d)
+}
+
 func (d *PragmaDeclaration) MarshalJSON() ([]byte, error) {
 	type Alias PragmaDeclaration
 	return json.Marshal(&struct {
@@ -73,3 +98,7 @@ func (d *PragmaDeclaration) MarshalJSON() ([]byte, error) {
 		Alias: (*Alias)(d),
 	})
 }
+
+func (d *PragmaDeclaration) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, d)
+}
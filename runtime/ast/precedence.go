@@ -0,0 +1,192 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ast
+
+import "github.com/turbolent/prettier"
+
+// precedence is the order of importance of expressions / operations,
+// and is used when rendering expressions, to determine if parentheses are needed.
+//
+// NOTE: the order must match the expression binding powers of the parser
+//
+type precedence uint
+
+const (
+	precedenceUnknown precedence = iota
+	// precedenceTernary is the precedence of
+	// - ConditionalExpression. right associative!
+	// - ReferenceExpression, as its inner casting expression consumes all operators
+	precedenceTernary
+	// precedenceLogicalOr is the precedence of
+	// - BinaryExpression, with OperationOr. right associative!
+	precedenceLogicalOr
+	// precedenceLogicalAnd is the precedence of
+	// - BinaryExpression, with OperationAnd. right associative!
+	precedenceLogicalAnd
+	// precedenceComparison is the precedence of
+	// - BinaryExpression, with OperationEqual, OperationNotEqual,
+	//   OperationLessEqual, OperationLess,
+	//   OperationGreater, or OperationGreaterEqual
+	precedenceComparison
+	// precedenceNilCoalescing is the precedence of
+	// - BinaryExpression, with OperationNilCoalesce. right associative!
+	precedenceNilCoalescing
+	// precedenceBitwiseOr is the precedence of
+	// - BinaryExpression, with OperationBitwiseOr
+	precedenceBitwiseOr
+	// precedenceBitwiseXor is the precedence of
+	// - BinaryExpression, with OperationBitwiseXor
+	precedenceBitwiseXor
+	// precedenceBitwiseAnd is the precedence of
+	// - BinaryExpression, with OperationBitwiseAnd
+	precedenceBitwiseAnd
+	// precedenceBitwiseShift is the precedence of
+	// - BinaryExpression, with OperationBitwiseLeftShift or OperationBitwiseRightShift
+	precedenceBitwiseShift
+	// precedenceAddition is the precedence of
+	// - BinaryExpression, with OperationPlus or OperationMinus
+	precedenceAddition
+	// precedenceMultiplication is the precedence of
+	// - BinaryExpression, with OperationMul, OperationMod, or OperationDiv
+	precedenceMultiplication
+	// precedenceCasting is the precedence of
+	// - CastingExpression
+	precedenceCasting
+	// precedenceUnaryPrefix is the precedence of
+	// - UnaryExpression
+	// - CreateExpression
+	// - DestroyExpression
+	// - AttachExpression
+	// - negative IntegerExpression and FixedPointExpression
+	precedenceUnaryPrefix
+	// precedenceUnaryPostfix is the precedence of
+	// - ForceExpression
+	precedenceUnaryPostfix
+	// precedenceAccess is the precedence of
+	// - InvocationExpression
+	// - IndexExpression
+	// - MemberExpression
+	// - RemoveExpression
+	precedenceAccess
+	// precedenceLiteral is the precedence of
+	// - all other expressions, e.g. literals and identifiers
+	precedenceLiteral
+)
+
+func (s Operation) precedence() precedence {
+	switch s {
+	case OperationOr:
+		return precedenceLogicalOr
+	case OperationAnd:
+		return precedenceLogicalAnd
+	case OperationEqual,
+		OperationNotEqual,
+		OperationLessEqual,
+		OperationLess,
+		OperationGreater,
+		OperationGreaterEqual:
+		return precedenceComparison
+	case OperationNilCoalesce:
+		return precedenceNilCoalescing
+	case OperationBitwiseOr:
+		return precedenceBitwiseOr
+	case OperationBitwiseXor:
+		return precedenceBitwiseXor
+	case OperationBitwiseAnd:
+		return precedenceBitwiseAnd
+	case OperationBitwiseLeftShift,
+		OperationBitwiseRightShift:
+		return precedenceBitwiseShift
+	case OperationPlus,
+		OperationMinus:
+		return precedenceAddition
+	case OperationMul,
+		OperationMod,
+		OperationDiv:
+		return precedenceMultiplication
+	}
+
+	return precedenceUnknown
+}
+
+func (s Operation) isRightAssociative() bool {
+	switch s {
+	case OperationOr,
+		OperationAnd,
+		OperationNilCoalesce:
+		return true
+	}
+
+	return false
+}
+
+func expressionPrecedence(expression Expression) precedence {
+	switch expression := expression.(type) {
+	case *ConditionalExpression,
+		*ReferenceExpression:
+		return precedenceTernary
+
+	case *BinaryExpression:
+		return expression.Operation.precedence()
+
+	case *CastingExpression:
+		return precedenceCasting
+
+	case *UnaryExpression,
+		*CreateExpression,
+		*DestroyExpression,
+		*AttachExpression:
+		return precedenceUnaryPrefix
+
+	case *IntegerExpression:
+		if expression.Value.Sign() < 0 {
+			return precedenceUnaryPrefix
+		}
+
+	case *FixedPointExpression:
+		if expression.Negative {
+			return precedenceUnaryPrefix
+		}
+
+	case *ForceExpression:
+		return precedenceUnaryPostfix
+
+	case *InvocationExpression,
+		*IndexExpression,
+		*MemberExpression,
+		*RemoveExpression:
+		return precedenceAccess
+	}
+
+	return precedenceLiteral
+}
+
+// parenthesizedExpressionDoc returns the document for the given expression,
+// wrapped in parentheses if the precedence of the expression
+// is lower than the given minimum precedence
+//
+func parenthesizedExpressionDoc(expression Expression, minPrecedence precedence) prettier.Doc {
+	doc := expression.Doc()
+
+	if expressionPrecedence(expression) >= minPrecedence {
+		return doc
+	}
+
+	return prettier.WrapParentheses(doc, prettier.SoftLine{})
+}
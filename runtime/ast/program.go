@@ -21,6 +21,8 @@ package ast
 import (
 	"encoding/json"
 
+	"github.com/turbolent/prettier"
+
 	"github.com/onflow/cadence/runtime/common"
 )
 
@@ -36,6 +38,9 @@ func NewProgram(declarations []Declaration) *Program {
 	}
 }
 
+// Declarations returns all declarations of the program,
+// in the order they were given, i.e. in source order for parsed programs.
+//
 func (p *Program) Declarations() []Declaration {
 	return p.declarations
 }
@@ -65,6 +70,35 @@ func (d *Program) Walk(walkChild func(Element)) {
 	walkDeclarations(walkChild, d.declarations)
 }
 
+// Clone returns a deep copy of the program.
+// The program indices are not copied, they are recomputed on demand
+//
+func (p *Program) Clone() Element {
+	return NewProgram(cloneDeclarations(p.declarations))
+}
+
+func (p *Program) Equal(other Element) bool {
+	return equal(p, other)
+}
+
+var blankLineDoc prettier.Doc = prettier.Concat{
+	prettier.HardLine{},
+	prettier.HardLine{},
+}
+
+// Doc returns the document for the program.
+// The declarations are separated by exactly one blank line
+//
+func (p *Program) Doc() prettier.Doc {
+	declarationDocs := make([]prettier.Doc, 0, len(p.declarations))
+
+	for _, declaration := range p.declarations {
+		declarationDocs = append(declarationDocs, declaration.Doc())
+	}
+
+	return prettier.Join(blankLineDoc, declarationDocs...)
+}
+
 func (p *Program) PragmaDeclarations() []*PragmaDeclaration {
 	return p.indices.pragmaDeclarations(p.declarations)
 }
@@ -93,6 +127,10 @@ func (p *Program) VariableDeclarations() []*VariableDeclaration {
 	return p.indices.variableDeclarations(p.declarations)
 }
 
+func (p *Program) TypeAliasDeclarations() []*TypeAliasDeclaration {
+	return p.indices.typeAliasDeclarations(p.declarations)
+}
+
 // SoleContractDeclaration returns the sole contract declaration, if any,
 // and if there are no other actionable declarations.
 //
@@ -171,3 +209,12 @@ func (p *Program) MarshalJSON() ([]byte, error) {
 		Alias:        (*Alias)(p),
 	})
 }
+
+func (p *Program) UnmarshalJSON(data []byte) error {
+	declarations, err := unmarshalDeclarations(data)
+	if err != nil {
+		return err
+	}
+	p.declarations = declarations
+	return nil
+}
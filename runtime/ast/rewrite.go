@@ -0,0 +1,164 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ast
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Rewriter rewrites elements.
+//
+// Rewrite returns the element which replaces the given element,
+// which may be the given element itself.
+//
+type Rewriter interface {
+	Rewrite(element Element) Element
+}
+
+// NoOpRewriter is a rewriter which returns all elements unchanged.
+//
+// It can be embedded in rewriters which only rewrite some elements,
+// and delegate to it for all other elements.
+//
+type NoOpRewriter struct{}
+
+var _ Rewriter = NoOpRewriter{}
+
+func (NoOpRewriter) Rewrite(element Element) Element {
+	return element
+}
+
+// Rewrite rewrites the given element and all its children bottom-up:
+// The children of an element are rewritten before the element itself,
+// so the rewriter is called with the element which already contains the rewritten children.
+//
+// Children are replaced in place, so the given element is modified.
+// Clone the element before rewriting it to preserve the original.
+//
+// The rewriter may return nil to remove an optional element, or a declaration of a program or composite.
+// Rewrite panics if the rewriter returns an element
+// which cannot replace the original element, e.g. a statement for an expression.
+//
+func Rewrite(rewriter Rewriter, element Element) Element {
+	if element == nil {
+		return nil
+	}
+
+	if program, ok := element.(*Program); ok {
+		program = NewProgram(rewriteDeclarations(rewriter, program.Declarations()))
+		return rewriter.Rewrite(program)
+	}
+
+	rewriteChildren(rewriter, reflect.ValueOf(element))
+
+	return rewriter.Rewrite(element)
+}
+
+// rewriteDeclarations rewrites the given declarations.
+// Declarations which are rewritten to nil are removed.
+//
+func rewriteDeclarations(rewriter Rewriter, declarations []Declaration) []Declaration {
+	rewrittenDeclarations := make([]Declaration, 0, len(declarations))
+
+	for _, declaration := range declarations {
+		rewritten := Rewrite(rewriter, declaration)
+		if rewritten == nil {
+			continue
+		}
+
+		rewrittenDeclaration, ok := rewritten.(Declaration)
+		if !ok {
+			panic(fmt.Errorf("cannot rewrite %T to %T", declaration, rewritten))
+		}
+
+		rewrittenDeclarations = append(rewrittenDeclarations, rewrittenDeclaration)
+	}
+
+	return rewrittenDeclarations
+}
+
+// rewriteChildren rewrites the elements in the given value,
+// and replaces them in the value
+//
+func rewriteChildren(rewriter Rewriter, value reflect.Value) {
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if value.IsNil() {
+			return
+		}
+		rewriteChildren(rewriter, value.Elem())
+
+	case reflect.Struct:
+		ty := value.Type()
+		for i := 0; i < ty.NumField(); i++ {
+			if ty.Field(i).PkgPath != "" {
+				continue
+			}
+			rewriteValue(rewriter, value.Field(i))
+		}
+
+	case reflect.Slice:
+		for i := 0; i < value.Len(); i++ {
+			rewriteValue(rewriter, value.Index(i))
+		}
+	}
+}
+
+// rewriteValue rewrites the given settable value.
+// If the value is an element, it is replaced with the rewritten element,
+// otherwise the elements in the value are rewritten.
+//
+func rewriteValue(rewriter Rewriter, value reflect.Value) {
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if value.IsNil() {
+			return
+		}
+
+		if members, ok := value.Interface().(*Members); ok {
+			declarations := rewriteDeclarations(rewriter, members.Declarations())
+			value.Set(reflect.ValueOf(NewMembers(declarations)))
+			return
+		}
+
+		element, ok := value.Interface().(Element)
+		if !ok {
+			rewriteChildren(rewriter, value)
+			return
+		}
+
+		rewritten := Rewrite(rewriter, element)
+
+		if rewritten == nil {
+			value.Set(reflect.Zero(value.Type()))
+			return
+		}
+
+		rewrittenValue := reflect.ValueOf(rewritten)
+		if !rewrittenValue.Type().AssignableTo(value.Type()) {
+			panic(fmt.Errorf("cannot rewrite %T to %T", element, rewritten))
+		}
+
+		value.Set(rewrittenValue)
+
+	case reflect.Struct, reflect.Slice:
+		rewriteChildren(rewriter, value)
+	}
+}
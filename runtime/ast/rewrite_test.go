@@ -0,0 +1,202 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ast_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/parser2"
+)
+
+// constantFolder folds additions and multiplications of integer literals
+//
+type constantFolder struct {
+	ast.NoOpRewriter
+}
+
+func (f constantFolder) Rewrite(element ast.Element) ast.Element {
+	binaryExpression, ok := element.(*ast.BinaryExpression)
+	if !ok {
+		return f.NoOpRewriter.Rewrite(element)
+	}
+
+	left, ok := binaryExpression.Left.(*ast.IntegerExpression)
+	if !ok {
+		return element
+	}
+
+	right, ok := binaryExpression.Right.(*ast.IntegerExpression)
+	if !ok {
+		return element
+	}
+
+	value := new(big.Int)
+	switch binaryExpression.Operation {
+	case ast.OperationPlus:
+		value.Add(left.Value, right.Value)
+	case ast.OperationMul:
+		value.Mul(left.Value, right.Value)
+	default:
+		return element
+	}
+
+	return &ast.IntegerExpression{
+		PositiveLiteral: value.String(),
+		Value:           value,
+		Base:            10,
+		Range: ast.Range{
+			StartPos: left.StartPosition(),
+			EndPos:   right.EndPosition(),
+		},
+	}
+}
+
+type rewriterFunc func(element ast.Element) ast.Element
+
+func (f rewriterFunc) Rewrite(element ast.Element) ast.Element {
+	return f(element)
+}
+
+func TestRewrite(t *testing.T) {
+
+	t.Parallel()
+
+	parse := func(t *testing.T, code string) *ast.Program {
+		program, err := parser2.ParseProgram(code)
+		require.NoError(t, err)
+		return program
+	}
+
+	t.Run("no-op", func(t *testing.T) {
+
+		t.Parallel()
+
+		program := parse(t, `
+          pub contract C {
+              pub fun test(): Int {
+                  let x = [1, 2]
+                  return x[0] + 3
+              }
+          }
+        `)
+
+		rewritten := ast.Rewrite(ast.NoOpRewriter{}, program.Clone())
+
+		assert.Empty(t, ast.Diff(rewritten, program, ast.WithDiffPositions(true)))
+	})
+
+	t.Run("constant folding", func(t *testing.T) {
+
+		t.Parallel()
+
+		program := parse(t, `
+          fun test(): Int {
+              return (1 + 2 * 3) + x
+          }
+        `)
+
+		rewritten := ast.Rewrite(constantFolder{}, program).(*ast.Program)
+
+		expected := parse(t, `
+          fun test(): Int {
+              return 7 + x
+          }
+        `)
+
+		assert.Empty(t, ast.Diff(rewritten, expected))
+	})
+
+	t.Run("bottom-up", func(t *testing.T) {
+
+		t.Parallel()
+
+		expression, errs := parser2.ParseExpression(`f(!x)`)
+		require.Empty(t, errs)
+
+		var rewritten []string
+
+		ast.Rewrite(
+			rewriterFunc(func(element ast.Element) ast.Element {
+				rewritten = append(rewritten, element.(ast.Expression).String())
+				return element
+			}),
+			expression,
+		)
+
+		assert.Equal(t, []string{"f", "x", "!x", "f(!x)"}, rewritten)
+	})
+
+	t.Run("remove declaration", func(t *testing.T) {
+
+		t.Parallel()
+
+		program := parse(t, `
+          pub struct S {
+              pub fun keep() {}
+              pub fun remove() {}
+          }
+        `)
+
+		rewritten := ast.Rewrite(
+			rewriterFunc(func(element ast.Element) ast.Element {
+				function, ok := element.(*ast.FunctionDeclaration)
+				if ok && function.Identifier.Identifier == "remove" {
+					return nil
+				}
+				return element
+			}),
+			program,
+		).(*ast.Program)
+
+		functions := rewritten.CompositeDeclarations()[0].Members.Functions()
+		require.Len(t, functions, 1)
+		assert.Equal(t, "keep", functions[0].Identifier.Identifier)
+	})
+
+	t.Run("invalid replacement", func(t *testing.T) {
+
+		t.Parallel()
+
+		program := parse(t, `
+          fun test() {
+              let x = 1
+          }
+        `)
+
+		assert.PanicsWithError(t,
+			"cannot rewrite *ast.IntegerExpression to *ast.BreakStatement",
+			func() {
+				ast.Rewrite(
+					rewriterFunc(func(element ast.Element) ast.Element {
+						if _, ok := element.(*ast.IntegerExpression); ok {
+							return &ast.BreakStatement{}
+						}
+						return element
+					}),
+					program,
+				)
+			},
+		)
+	})
+}
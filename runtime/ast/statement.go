@@ -27,6 +27,7 @@ import (
 type Statement interface {
 	Element
 	isStatement()
+	Doc() prettier.Doc
 }
 
 // ReturnStatement
@@ -50,6 +51,16 @@ func (s *ReturnStatement) Walk(walkChild func(Element)) {
 	}
 }
 
+func (s *ReturnStatement) Clone() Element {
+	clone := *s
+	clone.Expression = cloneExpression(s.Expression)
+	return &clone
+}
+
+func (s *ReturnStatement) Equal(other Element) bool {
+	return equal(s, other)
+}
+
 const returnStatementKeywordDoc = prettier.Text("return")
 const returnStatementKeywordSpaceDoc = prettier.Text("return ")
 
@@ -60,7 +71,6 @@ func (s *ReturnStatement) Doc() prettier.Doc {
 
 	return prettier.Concat{
 		returnStatementKeywordSpaceDoc,
-		// TODO: potentially parenthesize
 		s.Expression.Doc(),
 	}
 }
@@ -76,6 +86,10 @@ func (s *ReturnStatement) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (s *ReturnStatement) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, s)
+}
+
 // BreakStatement
 
 type BreakStatement struct {
@@ -94,6 +108,15 @@ func (*BreakStatement) Walk(_ func(Element)) {
 	// NO-OP
 }
 
+func (s *BreakStatement) Clone() Element {
+	clone := *s
+	return &clone
+}
+
+func (s *BreakStatement) Equal(other Element) bool {
+	return equal(s, other)
+}
+
 const breakStatementKeywordDoc = prettier.Text("break")
 
 func (*BreakStatement) Doc() prettier.Doc {
@@ -111,6 +134,10 @@ func (s *BreakStatement) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (s *BreakStatement) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, s)
+}
+
 // ContinueStatement
 
 type ContinueStatement struct {
@@ -129,6 +156,15 @@ func (*ContinueStatement) Walk(_ func(Element)) {
 	// NO-OP
 }
 
+func (s *ContinueStatement) Clone() Element {
+	clone := *s
+	return &clone
+}
+
+func (s *ContinueStatement) Equal(other Element) bool {
+	return equal(s, other)
+}
+
 const continueStatementKeywordDoc = prettier.Text("continue")
 
 func (*ContinueStatement) Doc() prettier.Doc {
@@ -146,6 +182,10 @@ func (s *ContinueStatement) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (s *ContinueStatement) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, s)
+}
+
 // IfStatementTest
 
 type IfStatementTest interface {
@@ -189,6 +229,30 @@ func (s *IfStatement) Walk(walkChild func(Element)) {
 	}
 }
 
+func (s *IfStatement) Clone() Element {
+	clone := *s
+	clone.Then = cloneBlock(s.Then)
+	clone.Else = cloneBlock(s.Else)
+
+	if s.Test != nil {
+		clone.Test = s.Test.Clone().(IfStatementTest)
+
+		// Update the back-pointer of the variable declaration of an if-let statement
+
+		if variableDeclaration, ok := clone.Test.(*VariableDeclaration); ok &&
+			variableDeclaration.ParentIfStatement == s {
+
+			variableDeclaration.ParentIfStatement = &clone
+		}
+	}
+
+	return &clone
+}
+
+func (s *IfStatement) Equal(other Element) bool {
+	return equal(s, other)
+}
+
 const ifStatementIfKeywordSpaceDoc = prettier.Text("if ")
 const ifStatementSpaceElseKeywordSpaceDoc = prettier.Text(" else ")
 
@@ -245,6 +309,19 @@ func (s *IfStatement) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (s *IfStatement) UnmarshalJSON(data []byte) error {
+	err := unmarshalElement(data, s)
+	if err != nil {
+		return err
+	}
+
+	if variableDeclaration, ok := s.Test.(*VariableDeclaration); ok {
+		variableDeclaration.ParentIfStatement = s
+	}
+
+	return nil
+}
+
 // WhileStatement
 
 type WhileStatement struct {
@@ -266,6 +343,17 @@ func (s *WhileStatement) Walk(walkChild func(Element)) {
 	walkChild(s.Block)
 }
 
+func (s *WhileStatement) Clone() Element {
+	clone := *s
+	clone.Test = cloneExpression(s.Test)
+	clone.Block = cloneBlock(s.Block)
+	return &clone
+}
+
+func (s *WhileStatement) Equal(other Element) bool {
+	return equal(s, other)
+}
+
 func (s *WhileStatement) StartPosition() Position {
 	return s.StartPos
 }
@@ -300,6 +388,10 @@ func (s *WhileStatement) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (s *WhileStatement) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, s)
+}
+
 // ForStatement
 
 type ForStatement struct {
@@ -323,6 +415,18 @@ func (s *ForStatement) Walk(walkChild func(Element)) {
 	walkChild(s.Block)
 }
 
+func (s *ForStatement) Clone() Element {
+	clone := *s
+	clone.Index = cloneIdentifier(s.Index)
+	clone.Value = cloneExpression(s.Value)
+	clone.Block = cloneBlock(s.Block)
+	return &clone
+}
+
+func (s *ForStatement) Equal(other Element) bool {
+	return equal(s, other)
+}
+
 func (s *ForStatement) StartPosition() Position {
 	return s.StartPos
 }
@@ -374,6 +478,10 @@ func (s *ForStatement) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (s *ForStatement) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, s)
+}
+
 // EmitStatement
 
 type EmitStatement struct {
@@ -401,12 +509,21 @@ func (s *EmitStatement) Walk(walkChild func(Element)) {
 	walkChild(s.InvocationExpression)
 }
 
+func (s *EmitStatement) Clone() Element {
+	clone := *s
+	clone.InvocationExpression = cloneInvocationExpression(s.InvocationExpression)
+	return &clone
+}
+
+func (s *EmitStatement) Equal(other Element) bool {
+	return equal(s, other)
+}
+
 const emitStatementKeywordSpaceDoc = prettier.Text("emit ")
 
 func (s *EmitStatement) Doc() prettier.Doc {
 	return prettier.Concat{
 		emitStatementKeywordSpaceDoc,
-		// TODO: potentially parenthesize
 		s.InvocationExpression.Doc(),
 	}
 }
@@ -424,6 +541,73 @@ func (s *EmitStatement) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (s *EmitStatement) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, s)
+}
+
+// DestroyStatement
+
+type DestroyStatement struct {
+	Expression Expression
+	StartPos   Position `json:"-"`
+}
+
+var _ Statement = &DestroyStatement{}
+
+func (*DestroyStatement) isStatement() {}
+
+func (s *DestroyStatement) StartPosition() Position {
+	return s.StartPos
+}
+
+func (s *DestroyStatement) EndPosition() Position {
+	return s.Expression.EndPosition()
+}
+
+func (s *DestroyStatement) Accept(visitor Visitor) Repr {
+	return visitor.VisitDestroyStatement(s)
+}
+
+func (s *DestroyStatement) Walk(walkChild func(Element)) {
+	walkChild(s.Expression)
+}
+
+func (s *DestroyStatement) Clone() Element {
+	clone := *s
+	clone.Expression = cloneExpression(s.Expression)
+	return &clone
+}
+
+func (s *DestroyStatement) Equal(other Element) bool {
+	return equal(s, other)
+}
+
+const destroyStatementKeywordSpaceDoc = prettier.Text("destroy ")
+
+func (s *DestroyStatement) Doc() prettier.Doc {
+	return prettier.Concat{
+		destroyStatementKeywordSpaceDoc,
+		s.Expression.Doc(),
+	}
+}
+
+func (s *DestroyStatement) MarshalJSON() ([]byte, error) {
+	type Alias DestroyStatement
+	return json.Marshal(&struct {
+		Type string
+		Range
+		*Alias
+	}{
+		Type:  "DestroyStatement",
+		Range: NewRangeFromPositioned(s),
+		Alias: (*Alias)(s),
+	})
+}
+
+func (s *DestroyStatement) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, s)
+}
+
 // AssignmentStatement
 
 type AssignmentStatement struct {
@@ -453,6 +637,18 @@ func (s *AssignmentStatement) Walk(walkChild func(Element)) {
 	walkChild(s.Value)
 }
 
+func (s *AssignmentStatement) Clone() Element {
+	clone := *s
+	clone.Target = cloneExpression(s.Target)
+	clone.Transfer = s.Transfer.Clone()
+	clone.Value = cloneExpression(s.Value)
+	return &clone
+}
+
+func (s *AssignmentStatement) Equal(other Element) bool {
+	return equal(s, other)
+}
+
 func (s *AssignmentStatement) Doc() prettier.Doc {
 	return prettier.Group{
 		Doc: prettier.Concat{
@@ -482,6 +678,10 @@ func (s *AssignmentStatement) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (s *AssignmentStatement) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, s)
+}
+
 // SwapStatement
 
 type SwapStatement struct {
@@ -510,6 +710,17 @@ func (s *SwapStatement) Walk(walkChild func(Element)) {
 	walkChild(s.Right)
 }
 
+func (s *SwapStatement) Clone() Element {
+	clone := *s
+	clone.Left = cloneExpression(s.Left)
+	clone.Right = cloneExpression(s.Right)
+	return &clone
+}
+
+func (s *SwapStatement) Equal(other Element) bool {
+	return equal(s, other)
+}
+
 const swapStatementSpaceSymbolSpaceDoc = prettier.Text(" <-> ")
 
 func (s *SwapStatement) Doc() prettier.Doc {
@@ -535,6 +746,10 @@ func (s *SwapStatement) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (s *SwapStatement) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, s)
+}
+
 // ExpressionStatement
 
 type ExpressionStatement struct {
@@ -561,6 +776,16 @@ func (s *ExpressionStatement) Walk(walkChild func(Element)) {
 	walkChild(s.Expression)
 }
 
+func (s *ExpressionStatement) Clone() Element {
+	clone := *s
+	clone.Expression = cloneExpression(s.Expression)
+	return &clone
+}
+
+func (s *ExpressionStatement) Equal(other Element) bool {
+	return equal(s, other)
+}
+
 func (s *ExpressionStatement) Doc() prettier.Doc {
 	return s.Expression.Doc()
 }
@@ -578,6 +803,10 @@ func (s *ExpressionStatement) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (s *ExpressionStatement) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, s)
+}
+
 // SwitchStatement
 
 type SwitchStatement struct {
@@ -605,6 +834,17 @@ func (s *SwitchStatement) Walk(walkChild func(Element)) {
 	}
 }
 
+func (s *SwitchStatement) Clone() Element {
+	clone := *s
+	clone.Expression = cloneExpression(s.Expression)
+	clone.Cases = cloneSwitchCases(s.Cases)
+	return &clone
+}
+
+func (s *SwitchStatement) Equal(other Element) bool {
+	return equal(s, other)
+}
+
 const switchStatementKeywordSpaceDoc = prettier.Text("switch ")
 
 func (s *SwitchStatement) Doc() prettier.Doc {
@@ -652,6 +892,10 @@ func (s *SwitchStatement) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (s *SwitchStatement) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, s)
+}
+
 // SwitchCase
 
 type SwitchCase struct {
@@ -671,6 +915,17 @@ func (s *SwitchCase) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (s *SwitchCase) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, s)
+}
+
+func (s *SwitchCase) Clone() *SwitchCase {
+	clone := *s
+	clone.Expression = cloneExpression(s.Expression)
+	clone.Statements = cloneStatements(s.Statements)
+	return &clone
+}
+
 const switchCaseKeywordSpaceDoc = prettier.Text("case ")
 const switchCaseColonSymbolDoc = prettier.Text(":")
 const switchCaseDefaultKeywordSpaceDoc = prettier.Text("default:")
@@ -21,6 +21,8 @@ package ast
 import (
 	"encoding/json"
 
+	"github.com/turbolent/prettier"
+
 	"github.com/onflow/cadence/runtime/common"
 )
 
@@ -47,10 +49,26 @@ func (d *TransactionDeclaration) Walk(walkChild func(Element)) {
 	if d.Prepare != nil {
 		walkChild(d.Prepare)
 	}
+	walkConditions(walkChild, d.PreConditions)
 	if d.Execute != nil {
 		walkChild(d.Execute)
 	}
-	// TODO: walk pre and post-conditions
+	walkConditions(walkChild, d.PostConditions)
+}
+
+func (d *TransactionDeclaration) Clone() Element {
+	clone := *d
+	clone.ParameterList = d.ParameterList.Clone()
+	clone.Fields = cloneFieldDeclarations(d.Fields)
+	clone.Prepare = cloneSpecialFunctionDeclaration(d.Prepare)
+	clone.PreConditions = d.PreConditions.Clone()
+	clone.Execute = cloneSpecialFunctionDeclaration(d.Execute)
+	clone.PostConditions = d.PostConditions.Clone()
+	return &clone
+}
+
+func (d *TransactionDeclaration) Equal(other Element) bool {
+	return equal(d, other)
 }
 
 func (*TransactionDeclaration) isDeclaration() {}
@@ -76,6 +94,92 @@ func (d *TransactionDeclaration) DeclarationDocString() string {
 	return ""
 }
 
+var transactionDeclarationKeywordDoc prettier.Doc = prettier.Text("transaction")
+
+func (d *TransactionDeclaration) Doc() prettier.Doc {
+
+	doc := prettier.Concat{
+		transactionDeclarationKeywordDoc,
+	}
+
+	if d.ParameterList != nil {
+		doc = append(doc, d.ParameterList.Doc())
+	}
+
+	// The fields are rendered on consecutive lines,
+	// all other sections are separated by a blank line
+
+	var sectionDocs []prettier.Doc
+
+	if len(d.Fields) > 0 {
+		fieldsDoc := make(prettier.Concat, 0, len(d.Fields)*2)
+		for i, field := range d.Fields {
+			if i > 0 {
+				fieldsDoc = append(fieldsDoc, prettier.HardLine{})
+			}
+			fieldsDoc = append(fieldsDoc, field.Doc())
+		}
+		sectionDocs = append(sectionDocs, fieldsDoc)
+	}
+
+	if d.Prepare != nil {
+		sectionDocs = append(sectionDocs, d.Prepare.Doc())
+	}
+
+	if !d.PreConditions.IsEmpty() {
+		sectionDocs = append(
+			sectionDocs,
+			prettier.Concat{
+				preConditionsKeywordSpaceDoc,
+				d.PreConditions.Doc(),
+			},
+		)
+	}
+
+	if d.Execute != nil {
+		sectionDocs = append(sectionDocs, d.Execute.Doc())
+	}
+
+	if !d.PostConditions.IsEmpty() {
+		sectionDocs = append(
+			sectionDocs,
+			prettier.Concat{
+				postConditionsKeywordSpaceDoc,
+				d.PostConditions.Doc(),
+			},
+		)
+	}
+
+	if len(sectionDocs) == 0 {
+		return append(
+			doc,
+			prettier.Space,
+			blockEmptyDoc,
+		)
+	}
+
+	return append(
+		doc,
+		prettier.Space,
+		blockStartDoc,
+		prettier.Indent{
+			Doc: prettier.Concat{
+				prettier.HardLine{},
+				prettier.Join(
+					blankLineDoc,
+					sectionDocs...,
+				),
+			},
+		},
+		prettier.HardLine{},
+		blockEndDoc,
+	)
+}
+
+func (d *TransactionDeclaration) String() string {
+	return declarationString(d)
+}
+
 func (d *TransactionDeclaration) MarshalJSON() ([]byte, error) {
 	type Alias TransactionDeclaration
 	return json.Marshal(&struct {
@@ -86,3 +190,7 @@ func (d *TransactionDeclaration) MarshalJSON() ([]byte, error) {
 		Alias: (*Alias)(d),
 	})
 }
+
+func (d *TransactionDeclaration) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, d)
+}
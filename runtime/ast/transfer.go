@@ -41,6 +41,14 @@ func (f Transfer) EndPosition() Position {
 	return f.Pos.Shifted(length - 1)
 }
 
+func (f *Transfer) Clone() *Transfer {
+	if f == nil {
+		return nil
+	}
+	clone := *f
+	return &clone
+}
+
 func (f Transfer) MarshalJSON() ([]byte, error) {
 	type Alias Transfer
 	return json.Marshal(&struct {
@@ -54,6 +62,10 @@ func (f Transfer) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (f *Transfer) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, f)
+}
+
 var copyTransferDoc prettier.Doc = prettier.Text("=")
 var moveTransferDoc prettier.Doc = prettier.Text("<-")
 var forceMoveTransferDoc prettier.Doc = prettier.Text("<-!")
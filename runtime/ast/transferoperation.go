@@ -20,6 +20,7 @@ package ast
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"github.com/onflow/cadence/runtime/errors"
 )
@@ -64,3 +65,20 @@ func (k TransferOperation) IsMove() bool {
 func (k TransferOperation) MarshalJSON() ([]byte, error) {
 	return json.Marshal(k.String())
 }
+
+func (k *TransferOperation) UnmarshalJSON(data []byte) error {
+	var name string
+	err := json.Unmarshal(data, &name)
+	if err != nil {
+		return err
+	}
+
+	for operation := TransferOperation(0); operation < TransferOperation(TransferOperationCount()); operation++ {
+		if operation.String() == name {
+			*k = operation
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid transfer operation: %s", name)
+}
@@ -62,6 +62,15 @@ func (t *TypeAnnotation) Doc() prettier.Doc {
 	}
 }
 
+func (t *TypeAnnotation) Clone() *TypeAnnotation {
+	if t == nil {
+		return nil
+	}
+	clone := *t
+	clone.Type = cloneType(t.Type)
+	return &clone
+}
+
 func (t *TypeAnnotation) MarshalJSON() ([]byte, error) {
 	type Alias TypeAnnotation
 	return json.Marshal(&struct {
@@ -73,6 +82,10 @@ func (t *TypeAnnotation) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (t *TypeAnnotation) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, t)
+}
+
 // Type
 
 type Type interface {
@@ -81,6 +94,11 @@ type Type interface {
 	isType()
 	Doc() prettier.Doc
 	CheckEqual(other Type, checker TypeEqualityChecker) error
+	// Clone returns a deep copy of the type, preserving positions
+	Clone() Type
+	// Equal returns true if the type is structurally equal to the given type,
+	// ignoring positions
+	Equal(other Type) bool
 }
 
 func IsEmptyType(t Type) bool {
@@ -139,6 +157,10 @@ func (t *NominalType) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (t *NominalType) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, t)
+}
+
 func (t *NominalType) IsQualifiedName() bool {
 	return len(t.NestedIdentifiers) > 0
 }
@@ -147,6 +169,16 @@ func (t *NominalType) CheckEqual(other Type, checker TypeEqualityChecker) error
 	return checker.CheckNominalTypeEquality(t, other)
 }
 
+func (t *NominalType) Clone() Type {
+	clone := *t
+	clone.NestedIdentifiers = cloneIdentifiers(t.NestedIdentifiers)
+	return &clone
+}
+
+func (t *NominalType) Equal(other Type) bool {
+	return equal(t, other)
+}
+
 // OptionalType represents am optional variant of another type
 
 type OptionalType struct {
@@ -192,10 +224,24 @@ func (t *OptionalType) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (t *OptionalType) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, t)
+}
+
 func (t *OptionalType) CheckEqual(other Type, checker TypeEqualityChecker) error {
 	return checker.CheckOptionalTypeEquality(t, other)
 }
 
+func (t *OptionalType) Clone() Type {
+	clone := *t
+	clone.Type = cloneType(t.Type)
+	return &clone
+}
+
+func (t *OptionalType) Equal(other Type) bool {
+	return equal(t, other)
+}
+
 // VariableSizedType is a variable sized array type
 
 type VariableSizedType struct {
@@ -239,10 +285,24 @@ func (t *VariableSizedType) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (t *VariableSizedType) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, t)
+}
+
 func (t *VariableSizedType) CheckEqual(other Type, checker TypeEqualityChecker) error {
 	return checker.CheckVariableSizedTypeEquality(t, other)
 }
 
+func (t *VariableSizedType) Clone() Type {
+	clone := *t
+	clone.Type = cloneType(t.Type)
+	return &clone
+}
+
+func (t *VariableSizedType) Equal(other Type) bool {
+	return equal(t, other)
+}
+
 // ConstantSizedType is a constant-sized array type
 
 type ConstantSizedType struct {
@@ -288,10 +348,25 @@ func (t *ConstantSizedType) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (t *ConstantSizedType) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, t)
+}
+
 func (t *ConstantSizedType) CheckEqual(other Type, checker TypeEqualityChecker) error {
 	return checker.CheckConstantSizedTypeEquality(t, other)
 }
 
+func (t *ConstantSizedType) Clone() Type {
+	clone := *t
+	clone.Type = cloneType(t.Type)
+	clone.Size = cloneIntegerExpression(t.Size)
+	return &clone
+}
+
+func (t *ConstantSizedType) Equal(other Type) bool {
+	return equal(t, other)
+}
+
 // DictionaryType
 
 type DictionaryType struct {
@@ -339,10 +414,25 @@ func (t *DictionaryType) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (t *DictionaryType) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, t)
+}
+
 func (t *DictionaryType) CheckEqual(other Type, checker TypeEqualityChecker) error {
 	return checker.CheckDictionaryTypeEquality(t, other)
 }
 
+func (t *DictionaryType) Clone() Type {
+	clone := *t
+	clone.KeyType = cloneType(t.KeyType)
+	clone.ValueType = cloneType(t.ValueType)
+	return &clone
+}
+
+func (t *DictionaryType) Equal(other Type) bool {
+	return equal(t, other)
+}
+
 // FunctionType
 
 type FunctionType struct {
@@ -420,10 +510,25 @@ func (t *FunctionType) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (t *FunctionType) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, t)
+}
+
 func (t *FunctionType) CheckEqual(other Type, checker TypeEqualityChecker) error {
 	return checker.CheckFunctionTypeEquality(t, other)
 }
 
+func (t *FunctionType) Clone() Type {
+	clone := *t
+	clone.ParameterTypeAnnotations = cloneTypeAnnotations(t.ParameterTypeAnnotations)
+	clone.ReturnTypeAnnotation = t.ReturnTypeAnnotation.Clone()
+	return &clone
+}
+
+func (t *FunctionType) Equal(other Type) bool {
+	return equal(t, other)
+}
+
 // ReferenceType
 
 type ReferenceType struct {
@@ -483,10 +588,24 @@ func (t *ReferenceType) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (t *ReferenceType) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, t)
+}
+
 func (t *ReferenceType) CheckEqual(other Type, checker TypeEqualityChecker) error {
 	return checker.CheckReferenceTypeEquality(t, other)
 }
 
+func (t *ReferenceType) Clone() Type {
+	clone := *t
+	clone.Type = cloneType(t.Type)
+	return &clone
+}
+
+func (t *ReferenceType) Equal(other Type) bool {
+	return equal(t, other)
+}
+
 // RestrictedType
 
 type RestrictedType struct {
@@ -569,10 +688,25 @@ func (t *RestrictedType) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (t *RestrictedType) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, t)
+}
+
 func (t *RestrictedType) CheckEqual(other Type, checker TypeEqualityChecker) error {
 	return checker.CheckRestrictedTypeEquality(t, other)
 }
 
+func (t *RestrictedType) Clone() Type {
+	clone := *t
+	clone.Type = cloneType(t.Type)
+	clone.Restrictions = cloneNominalTypes(t.Restrictions)
+	return &clone
+}
+
+func (t *RestrictedType) Equal(other Type) bool {
+	return equal(t, other)
+}
+
 // InstantiationType represents an instantiation of a generic (nominal) type
 
 type InstantiationType struct {
@@ -659,10 +793,25 @@ func (t *InstantiationType) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (t *InstantiationType) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, t)
+}
+
 func (t *InstantiationType) CheckEqual(other Type, checker TypeEqualityChecker) error {
 	return checker.CheckInstantiationTypeEquality(t, other)
 }
 
+func (t *InstantiationType) Clone() Type {
+	clone := *t
+	clone.Type = cloneType(t.Type)
+	clone.TypeArguments = cloneTypeAnnotations(t.TypeArguments)
+	return &clone
+}
+
+func (t *InstantiationType) Equal(other Type) bool {
+	return equal(t, other)
+}
+
 type TypeEqualityChecker interface {
 	CheckNominalTypeEquality(*NominalType, Type) error
 	CheckOptionalTypeEquality(*OptionalType, Type) error
@@ -0,0 +1,118 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ast
+
+import (
+	"encoding/json"
+
+	"github.com/turbolent/prettier"
+
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// TypeAliasDeclaration
+
+type TypeAliasDeclaration struct {
+	Access     Access
+	Identifier Identifier
+	TargetType Type
+	DocString  string
+	Range
+}
+
+func (d *TypeAliasDeclaration) Accept(visitor Visitor) Repr {
+	return visitor.VisitTypeAliasDeclaration(d)
+}
+
+func (d *TypeAliasDeclaration) Walk(_ func(Element)) {
+	// NO-OP
+	// TODO: walk type
+}
+
+func (d *TypeAliasDeclaration) Clone() Element {
+	clone := *d
+	clone.TargetType = cloneType(d.TargetType)
+	return &clone
+}
+
+func (d *TypeAliasDeclaration) Equal(other Element) bool {
+	return equal(d, other)
+}
+
+func (*TypeAliasDeclaration) isDeclaration() {}
+
+func (*TypeAliasDeclaration) isStatement() {}
+
+func (d *TypeAliasDeclaration) DeclarationIdentifier() *Identifier {
+	return &d.Identifier
+}
+
+func (d *TypeAliasDeclaration) DeclarationKind() common.DeclarationKind {
+	return common.DeclarationKindTypeAlias
+}
+
+func (d *TypeAliasDeclaration) DeclarationAccess() Access {
+	return d.Access
+}
+
+func (d *TypeAliasDeclaration) DeclarationMembers() *Members {
+	return nil
+}
+
+func (d *TypeAliasDeclaration) DeclarationDocString() string {
+	return d.DocString
+}
+
+const typeAliasKeywordDoc = prettier.Text("typealias")
+const typeAliasEqualDoc = prettier.Text("=")
+
+func (d *TypeAliasDeclaration) Doc() prettier.Doc {
+	return declarationDoc(
+		d.DocString,
+		d.Access,
+		prettier.Concat{
+			typeAliasKeywordDoc,
+			prettier.Space,
+			prettier.Text(d.Identifier.Identifier),
+			prettier.Space,
+			typeAliasEqualDoc,
+			prettier.Space,
+			d.TargetType.Doc(),
+		},
+	)
+}
+
+func (d *TypeAliasDeclaration) String() string {
+	return declarationString(d)
+}
+
+func (d *TypeAliasDeclaration) MarshalJSON() ([]byte, error) {
+	type Alias TypeAliasDeclaration
+	return json.Marshal(&struct {
+		Type string
+		*Alias
+	}{
+		Type:  "TypeAliasDeclaration",
+		Alias: (*Alias)(d),
+	})
+}
+
+func (d *TypeAliasDeclaration) UnmarshalJSON(data []byte) error {
+	return unmarshalElement(data, d)
+}
@@ -0,0 +1,377 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ast
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// elementTypes are the types of elements which may occur in fields of interface type,
+// e.g. expressions, statements, declarations, and types,
+// by the name their JSON representation has in the `Type` field
+//
+var elementTypes = map[string]reflect.Type{}
+
+func init() {
+	for _, element := range []Element{
+		// expressions
+		&BoolExpression{},
+		&NilExpression{},
+		&StringExpression{},
+		&StringInterpolationExpression{},
+		&IntegerExpression{},
+		&FixedPointExpression{},
+		&ArrayExpression{},
+		&DictionaryExpression{},
+		&IdentifierExpression{},
+		&InvocationExpression{},
+		&MemberExpression{},
+		&IndexExpression{},
+		&ConditionalExpression{},
+		&UnaryExpression{},
+		&BinaryExpression{},
+		&FunctionExpression{},
+		&CastingExpression{},
+		&CreateExpression{},
+		&DestroyExpression{},
+		&AttachExpression{},
+		&RemoveExpression{},
+		&ReferenceExpression{},
+		&ForceExpression{},
+		&PathExpression{},
+
+		// statements
+		&ReturnStatement{},
+		&BreakStatement{},
+		&ContinueStatement{},
+		&IfStatement{},
+		&WhileStatement{},
+		&ForStatement{},
+		&EmitStatement{},
+		&DestroyStatement{},
+		&AssignmentStatement{},
+		&SwapStatement{},
+		&ExpressionStatement{},
+		&SwitchStatement{},
+
+		// declarations
+		&CompositeDeclaration{},
+		&FieldDeclaration{},
+		&EnumCaseDeclaration{},
+		&FunctionDeclaration{},
+		&SpecialFunctionDeclaration{},
+		&ImportDeclaration{},
+		&InterfaceDeclaration{},
+		&PragmaDeclaration{},
+		&TransactionDeclaration{},
+		&VariableDeclaration{},
+		&AttachmentDeclaration{},
+		&TypeAliasDeclaration{},
+	} {
+		ty := reflect.TypeOf(element).Elem()
+		elementTypes[ty.Name()] = ty
+	}
+
+	for _, ty := range []Type{
+		&NominalType{},
+		&OptionalType{},
+		&VariableSizedType{},
+		&ConstantSizedType{},
+		&DictionaryType{},
+		&FunctionType{},
+		&ReferenceType{},
+		&RestrictedType{},
+		&InstantiationType{},
+	} {
+		reflectType := reflect.TypeOf(ty).Elem()
+		elementTypes[reflectType.Name()] = reflectType
+	}
+}
+
+var locationType = reflect.TypeOf((*common.Location)(nil)).Elem()
+
+// unmarshalElement decodes the JSON representation of an element,
+// as produced by its MarshalJSON function, into the given element,
+// which must be a pointer to a struct.
+//
+// The fields are decoded by their name, or the name given in their JSON tag.
+// Fields of interface type are decoded based on the `Type` field of their JSON representation.
+// Positions which are omitted and instead encoded as a range,
+// and big integers which are encoded as strings, are decoded from the respective JSON fields.
+// All other omitted fields, e.g. references to parents, must be restored by the caller.
+//
+func unmarshalElement(data []byte, element interface{}) error {
+	var fields map[string]json.RawMessage
+	err := json.Unmarshal(data, &fields)
+	if err != nil {
+		return err
+	}
+
+	return unmarshalFields(fields, reflect.ValueOf(element).Elem())
+}
+
+// unmarshalDeclarations decodes the declarations of the JSON representation
+// of a program or members
+//
+func unmarshalDeclarations(data []byte) ([]Declaration, error) {
+	var encoded struct {
+		Declarations json.RawMessage
+	}
+	err := json.Unmarshal(data, &encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	var declarations []Declaration
+	if encoded.Declarations != nil {
+		err = unmarshalValue(encoded.Declarations, reflect.ValueOf(&declarations).Elem())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return declarations, nil
+}
+
+func unmarshalFields(fields map[string]json.RawMessage, value reflect.Value) error {
+	ty := value.Type()
+
+	for i := 0; i < ty.NumField(); i++ {
+		field := ty.Field(i)
+
+		// Unexported fields, e.g. caches, are not encoded
+
+		if field.PkgPath != "" {
+			continue
+		}
+
+		fieldValue := value.Field(i)
+
+		tag := field.Tag.Get("json")
+		name := strings.Split(tag, ",")[0]
+
+		// Embedded structs, e.g. ranges, are encoded inline
+
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			err := unmarshalFields(fields, fieldValue)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		switch {
+		case field.Type == positionType && field.Name == "Pos":
+			// Single positions are encoded as the start of a range
+			name = "StartPos"
+
+		case name == "-":
+			switch field.Type {
+			case positionType:
+				// Start and end positions are encoded as part of a range
+				name = field.Name
+
+			case bigIntType:
+				data, ok := fields[field.Name]
+				if !ok {
+					continue
+				}
+				integer, err := unmarshalBigInt(data)
+				if err != nil {
+					return err
+				}
+				fieldValue.Set(reflect.ValueOf(integer))
+				continue
+
+			default:
+				continue
+			}
+
+		case name == "":
+			name = field.Name
+		}
+
+		data, ok := fields[name]
+		if !ok {
+			continue
+		}
+
+		err := unmarshalValue(data, fieldValue)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", ty.Name(), field.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func unmarshalValue(data json.RawMessage, value reflect.Value) error {
+	ty := value.Type()
+
+	switch {
+	case ty.Kind() == reflect.Interface:
+		result, err := unmarshalInterface(data, ty)
+		if err != nil {
+			return err
+		}
+		if result.IsValid() {
+			value.Set(result)
+		}
+		return nil
+
+	case ty.Kind() == reflect.Slice && ty.Elem().Kind() == reflect.Interface:
+		var elements []json.RawMessage
+		err := json.Unmarshal(data, &elements)
+		if err != nil {
+			return err
+		}
+		if elements == nil {
+			return nil
+		}
+		result := reflect.MakeSlice(ty, len(elements), len(elements))
+		for i, element := range elements {
+			err = unmarshalValue(element, result.Index(i))
+			if err != nil {
+				return err
+			}
+		}
+		value.Set(result)
+		return nil
+
+	default:
+		return json.Unmarshal(data, value.Addr().Interface())
+	}
+}
+
+// unmarshalInterface decodes the JSON representation of a value of the given interface type.
+// It returns an invalid value if the encoded value is null.
+//
+func unmarshalInterface(data json.RawMessage, ty reflect.Type) (reflect.Value, error) {
+	if string(data) == "null" {
+		return reflect.Value{}, nil
+	}
+
+	var typed struct {
+		Type string
+	}
+	err := json.Unmarshal(data, &typed)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	if ty == locationType {
+		location, err := unmarshalLocation(typed.Type, data)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(location), nil
+	}
+
+	elementType, ok := elementTypes[typed.Type]
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("unknown element type: %q", typed.Type)
+	}
+
+	result := reflect.New(elementType)
+	if !result.Type().Implements(ty) {
+		return reflect.Value{}, fmt.Errorf("%s is not a %s", typed.Type, ty.Name())
+	}
+
+	err = json.Unmarshal(data, result.Interface())
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	return result, nil
+}
+
+func unmarshalBigInt(data json.RawMessage) (*big.Int, error) {
+	var literal string
+	err := json.Unmarshal(data, &literal)
+	if err != nil {
+		return nil, err
+	}
+
+	integer, ok := new(big.Int).SetString(literal, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid integer: %q", literal)
+	}
+
+	return integer, nil
+}
+
+func unmarshalLocation(ty string, data json.RawMessage) (common.Location, error) {
+	var location struct {
+		Address     string
+		Name        string
+		Identifier  string
+		String      string
+		Script      string
+		Transaction string
+	}
+	err := json.Unmarshal(data, &location)
+	if err != nil {
+		return nil, err
+	}
+
+	switch ty {
+	case "AddressLocation":
+		address, err := common.HexToAddress(location.Address)
+		if err != nil {
+			return nil, err
+		}
+		return common.AddressLocation{
+			Address: address,
+			Name:    location.Name,
+		}, nil
+
+	case "IdentifierLocation":
+		return common.IdentifierLocation(location.Identifier), nil
+
+	case "StringLocation":
+		return common.StringLocation(location.String), nil
+
+	case "REPLLocation":
+		return common.REPLLocation{}, nil
+
+	case "ScriptLocation":
+		script, err := hex.DecodeString(location.Script)
+		if err != nil {
+			return nil, err
+		}
+		return common.ScriptLocation(script), nil
+
+	case "TransactionLocation":
+		transaction, err := hex.DecodeString(location.Transaction)
+		if err != nil {
+			return nil, err
+		}
+		return common.TransactionLocation(transaction), nil
+
+	default:
+		return nil, fmt.Errorf("unknown location type: %q", ty)
+	}
+}
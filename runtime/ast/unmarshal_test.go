@@ -0,0 +1,401 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ast_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/parser2"
+)
+
+// programGenerator generates random, syntactically valid programs
+//
+type programGenerator struct {
+	random *rand.Rand
+}
+
+var generatedIdentifiers = []string{"a", "b", "foo", "bar", "self", "result"}
+
+func (g *programGenerator) choose(options ...string) string {
+	return options[g.random.Intn(len(options))]
+}
+
+func (g *programGenerator) identifier() string {
+	return generatedIdentifiers[g.random.Intn(len(generatedIdentifiers))]
+}
+
+func (g *programGenerator) join(count int, separator string, generate func() string) string {
+	parts := make([]string, count)
+	for i := range parts {
+		parts[i] = generate()
+	}
+	return strings.Join(parts, separator)
+}
+
+func (g *programGenerator) integer() string {
+	switch g.random.Intn(5) {
+	case 0:
+		return fmt.Sprintf("0x%x", g.random.Uint64())
+	case 1:
+		return fmt.Sprintf("0b%b", g.random.Intn(256))
+	case 2:
+		return fmt.Sprintf("0o%o", g.random.Intn(256))
+	case 3:
+		// larger than 64 bits
+		return fmt.Sprintf("%d%018d", g.random.Uint64(), g.random.Int63n(1e18))
+	default:
+		return fmt.Sprintf("1_%03d", g.random.Intn(1000))
+	}
+}
+
+func (g *programGenerator) typ(depth int) string {
+	if depth <= 0 {
+		return g.choose("Int", "String", "R", "AuthAccount")
+	}
+	depth--
+
+	switch g.random.Intn(10) {
+	case 0:
+		return g.typ(depth) + "?"
+	case 1:
+		return "[" + g.typ(depth) + "]"
+	case 2:
+		return fmt.Sprintf("[%s; %d]", g.typ(depth), g.random.Intn(10))
+	case 3:
+		return fmt.Sprintf("{%s: %s}", g.typ(depth), g.typ(depth))
+	case 4:
+		return fmt.Sprintf("((%s): %s)", g.typ(depth), g.typ(depth))
+	case 5:
+		// the referenced type is not a reference, as `&&` is an operator
+		return g.choose("&", "auth &") + g.typ(0)
+	case 6:
+		return "R{I, J}"
+	case 7:
+		return "Capability<&" + g.typ(0) + ">"
+	case 8:
+		return "A.B.C"
+	default:
+		return g.typ(0)
+	}
+}
+
+func (g *programGenerator) expression(depth int) string {
+	if depth <= 0 {
+		switch g.random.Intn(9) {
+		case 0:
+			return g.integer()
+		case 1:
+			return g.choose("1.5", "0.000_01", "-2.25")
+		case 2:
+			return g.choose(`"abc"`, `"\n\t\""`, `"\u{1F600}"`, `""`)
+		case 3:
+			return g.choose("true", "false")
+		case 4:
+			return "nil"
+		case 5:
+			return "/" + g.choose("storage", "public", "private") + "/" + g.identifier()
+		default:
+			return g.identifier()
+		}
+	}
+	depth--
+
+	operand := func() string {
+		return "(" + g.expression(depth) + ")"
+	}
+
+	switch g.random.Intn(20) {
+	case 0:
+		return "[" + g.join(g.random.Intn(3), ", ", func() string { return g.expression(depth) }) + "]"
+	case 1:
+		return "{" + g.join(g.random.Intn(3), ", ", func() string {
+			return g.expression(depth) + ": " + g.expression(depth)
+		}) + "}"
+	case 2:
+		return operand() + g.choose(".", "?.") + g.identifier()
+	case 3:
+		return operand() + "[" + g.expression(depth) + "]"
+	case 4:
+		typeArguments := ""
+		if g.random.Intn(2) == 0 {
+			typeArguments = "<" + g.typ(depth) + ">"
+		}
+		return g.identifier() + typeArguments + "(" + g.join(g.random.Intn(3), ", ", func() string {
+			if g.random.Intn(2) == 0 {
+				return g.identifier() + ": " + g.expression(depth)
+			}
+			return g.expression(depth)
+		}) + ")"
+	case 5:
+		return g.choose("-", "!", "<-") + operand()
+	case 6:
+		return operand() + " " + g.choose(
+			"+", "-", "*", "/", "%", "&&", "||", "==", "!=", "<", "<=", ">", ">=",
+			"??", "&", "|", "^", "<<", ">>",
+		) + " " + operand()
+	case 7:
+		return operand() + " ? " + operand() + " : " + operand()
+	case 8:
+		return operand() + " " + g.choose("as", "as?", "as!") + " " + g.typ(depth)
+	case 9:
+		return operand() + "!"
+	case 10:
+		return "&" + operand() + " as &" + g.typ(0)
+	case 11:
+		return "create R(" + g.expression(depth) + ")"
+	case 12:
+		return "destroy " + operand()
+	case 13:
+		return "fun (a: Int): " + g.typ(depth) + " " + g.block(depth, "")
+	default:
+		return g.expression(0)
+	}
+}
+
+func (g *programGenerator) transfer() string {
+	return g.choose("=", "<-", "<-!")
+}
+
+func (g *programGenerator) statement(depth int) string {
+	if depth <= 0 {
+		return g.identifier() + " = " + g.expression(0)
+	}
+	depth--
+
+	switch g.random.Intn(14) {
+	case 0:
+		return g.variableDeclaration(depth)
+	case 1:
+		return "if " + g.expression(depth) + " " + g.block(depth, "")
+	case 2:
+		return "if " + g.choose("let", "var") + " " + g.identifier() + " = " + g.expression(depth) +
+			" " + g.block(depth, "") + " else if " + g.expression(depth) +
+			" " + g.block(depth, "") + " else " + g.block(depth, "")
+	case 3:
+		return "while " + g.expression(depth) + " " + g.block(depth, "break")
+	case 4:
+		index := ""
+		if g.random.Intn(2) == 0 {
+			index = g.identifier() + ", "
+		}
+		return "for " + index + g.identifier() + " in " + g.expression(depth) + " " + g.block(depth, "continue")
+	case 5:
+		return "return " + g.expression(depth)
+	case 6:
+		return "return"
+	case 7:
+		// the force-move operator is only allowed in variable declarations
+		return "(" + g.expression(depth) + ") " + g.choose("=", "<-") + " " + g.expression(depth)
+	case 8:
+		return g.identifier() + " <-> " + g.identifier()
+	case 9:
+		return "emit E(" + g.expression(depth) + ")"
+	case 10:
+		return "switch " + g.expression(depth) + " {\ncase " + g.expression(depth) + ":\n" +
+			g.statements(depth) + "\ndefault:\n" + g.statements(depth) + "\n}"
+	default:
+		// the expression is parenthesized, as a leading `fun` would start a function declaration
+		return "(" + g.expression(depth) + ")"
+	}
+}
+
+// statements returns random statements.
+// The statements are separated by semicolons,
+// as a statement starting with an operator like `-` would otherwise continue the previous one
+//
+func (g *programGenerator) statements(depth int) string {
+	return g.join(g.random.Intn(3), ";\n", func() string {
+		return g.statement(depth)
+	})
+}
+
+// block returns a block of random statements, followed by the given final statement, if any
+//
+func (g *programGenerator) block(depth int, final string) string {
+	statements := g.statements(depth)
+	if final != "" {
+		if statements != "" {
+			statements += ";\n"
+		}
+		statements += final
+	}
+	return "{\n" + statements + "\n}"
+}
+
+func (g *programGenerator) variableDeclaration(depth int) string {
+	typeAnnotation := ""
+	if g.random.Intn(2) == 0 {
+		typeAnnotation = ": " + g.choose("", "@") + g.typ(depth)
+	}
+	declaration := g.choose("let", "var") + " " + g.identifier() + typeAnnotation +
+		" " + g.transfer() + " " + g.expression(depth)
+	if g.random.Intn(4) == 0 {
+		declaration += " " + g.transfer() + " " + g.expression(depth)
+	}
+	return declaration
+}
+
+func (g *programGenerator) annotations() string {
+	return g.choose("", "@deprecated ", `@deprecated(reason: "use b") `, "@inline(never) @experimental ")
+}
+
+func (g *programGenerator) functionDeclaration(depth int) string {
+	return "/// doc\n" +
+		g.annotations() +
+		g.choose("", "pub ", "priv ", "access(contract) ") +
+		"fun " + g.identifier() + "(" + g.join(g.random.Intn(3), ", ", func() string {
+		return g.choose("", "_ ", "label ") + g.identifier() + ": " + g.typ(depth)
+	}) + "): " + g.typ(depth) + " {\n" +
+		"pre { " + g.expression(depth) + ": " + g.expression(depth) + " }\n" +
+		"post { " + g.expression(depth) + " }\n" +
+		g.statements(depth) + "\n}"
+}
+
+func (g *programGenerator) declaration(depth int) string {
+	switch g.random.Intn(10) {
+	case 0:
+		return "import " + g.choose(`"imported"`, "0x1", "a, b from 0x01", "a from Foo")
+	case 1:
+		return "#" + g.expression(0)
+	case 2:
+		return g.functionDeclaration(depth)
+	case 3:
+		return g.variableDeclaration(depth)
+	case 4:
+		return g.annotations() + "pub " + g.choose("struct", "resource", "contract") + " S: I, J {\n" +
+			"pub var a: Int\n" +
+			"priv let b: @R\n" +
+			"init() " + g.block(depth, "") + "\n" +
+			"destroy() " + g.block(depth, "") + "\n" +
+			g.functionDeclaration(depth) + "\n" +
+			"pub struct Nested {}\n" +
+			"pub event E(a: Int)\n" +
+			"}"
+	case 5:
+		return "pub " + g.choose("struct", "resource", "contract") + " interface I {\n" +
+			"pub fun foo(): Int\n" +
+			"pub let a: Int\n" +
+			"}"
+	case 6:
+		return "pub enum E: UInt8 {\n pub case a\n pub case b\n}"
+	case 7:
+		return "pub event E(a: Int, b: " + g.typ(depth) + ")"
+	case 8:
+		return "transaction(a: Int) {\n" +
+			"let b: Int\n" +
+			"prepare(signer: AuthAccount) " + g.block(depth, "") + "\n" +
+			"pre { " + g.expression(depth) + " }\n" +
+			"execute " + g.block(depth, "") + "\n" +
+			"post { " + g.expression(depth) + ": \"failed\" }\n" +
+			"}"
+	default:
+		return g.functionDeclaration(0)
+	}
+}
+
+func (g *programGenerator) program(depth int) string {
+	return g.join(1+g.random.Intn(4), "\n\n", func() string {
+		return g.declaration(depth)
+	})
+}
+
+func TestUnmarshalJSONRoundTrip(t *testing.T) {
+
+	t.Parallel()
+
+	generator := &programGenerator{
+		random: rand.New(rand.NewSource(42)),
+	}
+
+	for i := 0; i < 200; i++ {
+
+		code := generator.program(3)
+
+		program, err := parser2.ParseProgram(code)
+		require.NoError(t, err, code)
+
+		encoded, err := json.Marshal(program)
+		require.NoError(t, err, code)
+
+		var decoded ast.Program
+		err = json.Unmarshal(encoded, &decoded)
+		require.NoError(t, err, code)
+
+		reencoded, err := json.Marshal(&decoded)
+		require.NoError(t, err, code)
+
+		require.JSONEq(t, string(encoded), string(reencoded), code)
+
+		// References to parents are not encoded, but restored
+
+		assert.Equal(t, program, &decoded, code)
+	}
+}
+
+func TestUnmarshalJSONErrors(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("unknown element type", func(t *testing.T) {
+
+		t.Parallel()
+
+		var statement ast.ExpressionStatement
+		err := json.Unmarshal(
+			[]byte(`{"Type": "ExpressionStatement", "Expression": {"Type": "Unknown"}}`),
+			&statement,
+		)
+		require.EqualError(t,
+			err,
+			`ExpressionStatement.Expression: unknown element type: "Unknown"`,
+		)
+	})
+
+	t.Run("element of wrong kind", func(t *testing.T) {
+
+		t.Parallel()
+
+		var statement ast.ExpressionStatement
+		err := json.Unmarshal(
+			[]byte(`{"Type": "ExpressionStatement", "Expression": {"Type": "NominalType"}}`),
+			&statement,
+		)
+		require.EqualError(t,
+			err,
+			`ExpressionStatement.Expression: NominalType is not a Expression`,
+		)
+	})
+
+	t.Run("invalid operation", func(t *testing.T) {
+
+		t.Parallel()
+
+		var operation ast.Operation
+		err := json.Unmarshal([]byte(`"OperationUnknownOperation"`), &operation)
+		require.EqualError(t, err, "invalid operation: OperationUnknownOperation")
+	})
+}
@@ -27,9 +27,12 @@ import (
 )
 
 type VariableDeclaration struct {
-	Access            Access
-	IsConstant        bool
-	Identifier        Identifier
+	Access     Access
+	IsConstant bool
+	Identifier Identifier
+	// Pattern is the tuple pattern of a destructuring declaration, if any.
+	// The identifier is empty in that case
+	Pattern           *TuplePattern `json:",omitempty"`
 	TypeAnnotation    *TypeAnnotation
 	Value             Expression
 	Transfer          *Transfer
@@ -38,6 +41,7 @@ type VariableDeclaration struct {
 	SecondValue       Expression
 	ParentIfStatement *IfStatement `json:"-"`
 	DocString         string
+	Annotations       []*AnnotationDeclaration `json:",omitempty"`
 }
 
 func (d *VariableDeclaration) StartPosition() Position {
@@ -69,6 +73,35 @@ func (d *VariableDeclaration) Walk(walkChild func(Element)) {
 	}
 }
 
+// Clone returns a deep copy of the variable declaration.
+// The parent if-statement is not cloned,
+// it is updated when the parent if-statement is cloned.
+//
+func (d *VariableDeclaration) Clone() Element {
+	clone := *d
+	clone.Annotations = cloneAnnotations(d.Annotations)
+	clone.Pattern = d.Pattern.Clone()
+	clone.TypeAnnotation = d.TypeAnnotation.Clone()
+	clone.Value = cloneExpression(d.Value)
+	clone.Transfer = d.Transfer.Clone()
+	clone.SecondTransfer = d.SecondTransfer.Clone()
+	clone.SecondValue = cloneExpression(d.SecondValue)
+
+	// Update the back-pointer of a casting expression value
+
+	if castingExpression, ok := clone.Value.(*CastingExpression); ok &&
+		castingExpression.ParentVariableDeclaration == d {
+
+		castingExpression.ParentVariableDeclaration = &clone
+	}
+
+	return &clone
+}
+
+func (d *VariableDeclaration) Equal(other Element) bool {
+	return equal(d, other)
+}
+
 func (d *VariableDeclaration) DeclarationIdentifier() *Identifier {
 	return &d.Identifier
 }
@@ -101,31 +134,64 @@ func (d *VariableDeclaration) Doc() prettier.Doc {
 		keywordDoc = letKeywordDoc
 	}
 
-	// TODO: second transfer and value (if any)
+	var targetDoc prettier.Doc = prettier.Text(d.Identifier.Identifier)
+	if d.Pattern != nil {
+		targetDoc = d.Pattern.Doc()
+	}
+
+	valueDoc := prettier.Concat{
+		targetDoc,
+	}
+
+	if d.TypeAnnotation != nil {
+		valueDoc = append(
+			valueDoc,
+			typeSeparatorDoc,
+			d.TypeAnnotation.Doc(),
+		)
+	}
 
-	// TODO: potentially parenthesize
-	valueDoc := d.Value.Doc()
+	valueDoc = append(
+		valueDoc,
+		prettier.Space,
+		d.Transfer.Doc(),
+		prettier.Space,
+		prettier.Group{
+			Doc: prettier.Indent{
+				Doc: d.Value.Doc(),
+			},
+		},
+	)
 
-	return prettier.Group{
+	if d.SecondTransfer != nil && d.SecondValue != nil {
+		valueDoc = append(
+			valueDoc,
+			prettier.Space,
+			d.SecondTransfer.Doc(),
+			prettier.Space,
+			prettier.Group{
+				Doc: prettier.Indent{
+					Doc: d.SecondValue.Doc(),
+				},
+			},
+		)
+	}
+
+	var doc prettier.Doc = prettier.Group{
 		Doc: prettier.Concat{
 			keywordDoc,
 			prettier.Space,
 			prettier.Group{
-				Doc: prettier.Concat{
-					prettier.Text(d.Identifier.Identifier),
-					prettier.Space,
-					// TODO: type annotation, if any
-					d.Transfer.Doc(),
-					prettier.Space,
-					prettier.Group{
-						Doc: prettier.Indent{
-							Doc: valueDoc,
-						},
-					},
-				},
+				Doc: valueDoc,
 			},
 		},
 	}
+
+	return declarationDoc(d.DocString, d.Access, doc)
+}
+
+func (d *VariableDeclaration) String() string {
+	return declarationString(d)
 }
 
 func (d *VariableDeclaration) MarshalJSON() ([]byte, error) {
@@ -140,3 +206,16 @@ func (d *VariableDeclaration) MarshalJSON() ([]byte, error) {
 		Alias: (*Alias)(d),
 	})
 }
+
+func (d *VariableDeclaration) UnmarshalJSON(data []byte) error {
+	err := unmarshalElement(data, d)
+	if err != nil {
+		return err
+	}
+
+	if castingExpression, ok := d.Value.(*CastingExpression); ok {
+		castingExpression.ParentVariableDeclaration = d
+	}
+
+	return nil
+}
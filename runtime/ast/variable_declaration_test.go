@@ -134,3 +134,37 @@ func TestVariableDeclaration_MarshalJSON(t *testing.T) {
 		string(actual),
 	)
 }
+
+func TestVariableDeclaration_String(t *testing.T) {
+
+	t.Parallel()
+
+	decl := &VariableDeclaration{
+		Access:     AccessPublic,
+		IsConstant: true,
+		Identifier: Identifier{
+			Identifier: "foo",
+		},
+		TypeAnnotation: &TypeAnnotation{
+			IsResource: true,
+			Type: &NominalType{
+				Identifier: Identifier{
+					Identifier: "AB",
+				},
+			},
+		},
+		Value: &IdentifierExpression{
+			Identifier: Identifier{
+				Identifier: "bar",
+			},
+		},
+		Transfer: &Transfer{
+			Operation: TransferOperationMove,
+		},
+	}
+
+	assert.Equal(t,
+		"pub let foo: @AB <- bar",
+		decl.String(),
+	)
+}
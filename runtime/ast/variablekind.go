@@ -20,6 +20,7 @@ package ast
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"github.com/onflow/cadence/runtime/errors"
 )
@@ -72,3 +73,20 @@ func (k VariableKind) Keyword() string {
 func (k VariableKind) MarshalJSON() ([]byte, error) {
 	return json.Marshal(k.String())
 }
+
+func (k *VariableKind) UnmarshalJSON(data []byte) error {
+	var name string
+	err := json.Unmarshal(data, &name)
+	if err != nil {
+		return err
+	}
+
+	for kind := VariableKind(0); kind < VariableKind(VariableKindCount()); kind++ {
+		if kind.String() == name {
+			*k = kind
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid variable kind: %s", name)
+}
@@ -24,6 +24,11 @@ type Element interface {
 	HasPosition
 	Accept(Visitor) Repr
 	Walk(walkChild func(Element))
+	// Clone returns a deep copy of the element, preserving positions
+	Clone() Element
+	// Equal returns true if the element is structurally equal to the given element,
+	// ignoring positions
+	Equal(other Element) bool
 }
 
 type NotAnElement struct{}
@@ -45,6 +50,15 @@ func (NotAnElement) Walk(_ func(Element)) {
 	// NO-OP
 }
 
+func (NotAnElement) Clone() Element {
+	return NotAnElement{}
+}
+
+func (NotAnElement) Equal(other Element) bool {
+	_, ok := other.(NotAnElement)
+	return ok
+}
+
 type StatementVisitor interface {
 	VisitReturnStatement(*ReturnStatement) Repr
 	VisitBreakStatement(*BreakStatement) Repr
@@ -54,6 +68,7 @@ type StatementVisitor interface {
 	VisitWhileStatement(*WhileStatement) Repr
 	VisitForStatement(*ForStatement) Repr
 	VisitEmitStatement(*EmitStatement) Repr
+	VisitDestroyStatement(*DestroyStatement) Repr
 	VisitVariableDeclaration(*VariableDeclaration) Repr
 	VisitAssignmentStatement(*AssignmentStatement) Repr
 	VisitSwapStatement(*SwapStatement) Repr
@@ -76,9 +91,12 @@ type ExpressionVisitor interface {
 	VisitBinaryExpression(*BinaryExpression) Repr
 	VisitFunctionExpression(*FunctionExpression) Repr
 	VisitStringExpression(*StringExpression) Repr
+	VisitStringInterpolationExpression(*StringInterpolationExpression) Repr
 	VisitCastingExpression(*CastingExpression) Repr
 	VisitCreateExpression(*CreateExpression) Repr
 	VisitDestroyExpression(*DestroyExpression) Repr
+	VisitAttachExpression(*AttachExpression) Repr
+	VisitRemoveExpression(*RemoveExpression) Repr
 	VisitReferenceExpression(*ReferenceExpression) Repr
 	VisitForceExpression(*ForceExpression) Repr
 	VisitPathExpression(*PathExpression) Repr
@@ -98,4 +116,6 @@ type Visitor interface {
 	VisitPragmaDeclaration(*PragmaDeclaration) Repr
 	VisitImportDeclaration(*ImportDeclaration) Repr
 	VisitTransactionDeclaration(*TransactionDeclaration) Repr
+	VisitAttachmentDeclaration(*AttachmentDeclaration) Repr
+	VisitTypeAliasDeclaration(*TypeAliasDeclaration) Repr
 }
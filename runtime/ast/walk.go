@@ -62,3 +62,15 @@ func walkDeclarations(walkChild func(Element), declarations []Declaration) {
 		walkChild(declaration)
 	}
 }
+
+func walkConditions(walkChild func(Element), conditions *Conditions) {
+	if conditions == nil {
+		return
+	}
+	for _, condition := range *conditions {
+		walkChild(condition.Test)
+		if condition.Message != nil {
+			walkChild(condition.Message)
+		}
+	}
+}
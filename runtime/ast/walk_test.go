@@ -0,0 +1,137 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ast_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/parser2"
+)
+
+func TestWalk(t *testing.T) {
+
+	t.Parallel()
+
+	const code = `
+      pub contract C {
+
+          pub resource R {
+              pub let id: Int
+
+              init(id: Int) {
+                  self.id = id
+              }
+          }
+
+          pub fun f(x: Int): Int {
+              pre {
+                  x > 0: "x must be positive"
+              }
+              post {
+                  result == x
+              }
+
+              let r <- create R(id: x)
+              destroy r
+              return x
+          }
+      }
+
+      transaction {
+          prepare(signer: AuthAccount) {}
+
+          pre {
+              true
+          }
+
+          execute {
+              C.f(x: 1)
+          }
+      }
+    `
+
+	program, err := parser2.ParseProgram(code)
+	require.NoError(t, err)
+
+	t.Run("count", func(t *testing.T) {
+
+		t.Parallel()
+
+		counts := map[string]int{}
+
+		ast.Inspect(program, func(element ast.Element) bool {
+			if element != nil {
+				counts[fmt.Sprintf("%T", element)]++
+			}
+			return true
+		})
+
+		assert.Equal(t,
+			map[string]int{
+				"*ast.Program":                    1,
+				"*ast.CompositeDeclaration":       2,
+				"*ast.FieldDeclaration":           1,
+				"*ast.SpecialFunctionDeclaration": 3,
+				"*ast.FunctionDeclaration":        1,
+				"*ast.TransactionDeclaration":     1,
+				"*ast.FunctionBlock":              4,
+				"*ast.Block":                      4,
+				"*ast.AssignmentStatement":        1,
+				"*ast.VariableDeclaration":        1,
+				"*ast.ExpressionStatement":        1,
+				"*ast.DestroyStatement":           1,
+				"*ast.ReturnStatement":            1,
+				"*ast.MemberExpression":           2,
+				"*ast.IdentifierExpression":       10,
+				"*ast.BinaryExpression":           2,
+				"*ast.StringExpression":           1,
+				"*ast.CreateExpression":           1,
+				"*ast.InvocationExpression":       2,
+				"*ast.IntegerExpression":          2,
+				"*ast.BoolExpression":             1,
+			},
+			counts,
+		)
+	})
+
+	t.Run("skip children", func(t *testing.T) {
+
+		t.Parallel()
+
+		var count int
+
+		ast.Inspect(program, func(element ast.Element) bool {
+			if element == nil {
+				return false
+			}
+			count++
+			_, isComposite := element.(*ast.CompositeDeclaration)
+			return !isComposite
+		})
+
+		// The program, the contract, the transaction,
+		// and the 12 elements of the transaction
+		assert.Equal(t, 15, count)
+	})
+}
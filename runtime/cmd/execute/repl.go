@@ -65,7 +65,7 @@ func RunREPL() {
 		}()
 
 		if code == "" && strings.HasPrefix(line, ".") {
-			handleCommand(line)
+			handleCommand(repl, line)
 			code = ""
 			return
 		}
@@ -125,20 +125,38 @@ const replHelpMessage = `
 Enter declarations and statements to evaluate them.
 Commands are prefixed with a dot. Valid commands are:
 
-.exit     Exit the interpreter
-.help     Print this help message
+.exit         Exit the interpreter
+.help         Print this help message
+.reset        Discard all declarations and start a new session
+.type <expr>  Print the type of an expression, without evaluating it
 
 Press ^C to abort current expression, ^D to exit
 `
 
 const replAssistanceMessage = `Type '.help' for assistance.`
 
-func handleCommand(command string) {
+func handleCommand(repl *runtime.REPL, command string) {
+	argument := ""
+	if index := strings.IndexByte(command, ' '); index >= 0 {
+		argument = strings.TrimSpace(command[index+1:])
+		command = command[:index]
+	}
+
 	switch command {
 	case ".exit":
 		os.Exit(0)
 	case ".help":
 		fmt.Println(replHelpMessage)
+	case ".reset":
+		err := repl.Reset()
+		if err != nil {
+			panic(err)
+		}
+	case ".type":
+		ty := repl.TypeOf(argument)
+		if ty != nil {
+			fmt.Println(ty.QualifiedString())
+		}
 	default:
 		fmt.Println(colorizeError(fmt.Sprintf("Unknown command. %s", replAssistanceMessage)))
 	}
@@ -20,6 +20,7 @@ package common
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"github.com/onflow/cadence/runtime/errors"
 )
@@ -180,3 +181,20 @@ func (k CompositeKind) SupportsInterfaces() bool {
 func (k CompositeKind) MarshalJSON() ([]byte, error) {
 	return json.Marshal(k.String())
 }
+
+func (k *CompositeKind) UnmarshalJSON(data []byte) error {
+	var name string
+	err := json.Unmarshal(data, &name)
+	if err != nil {
+		return err
+	}
+
+	for kind := CompositeKind(0); kind < CompositeKind(CompositeKindCount()); kind++ {
+		if kind.String() == name {
+			*k = kind
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid composite kind: %s", name)
+}
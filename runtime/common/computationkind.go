@@ -32,8 +32,8 @@ const (
 	ComputationKindStatement ComputationKind = ComputationKindRangeStart + iota
 	ComputationKindLoop
 	ComputationKindFunctionInvocation
-	_
-	_
+	ComputationKindStorageRead
+	ComputationKindStorageWrite
 	_
 	_
 	_
@@ -12,6 +12,8 @@ func _() {
 	_ = x[ComputationKindStatement-1001]
 	_ = x[ComputationKindLoop-1002]
 	_ = x[ComputationKindFunctionInvocation-1003]
+	_ = x[ComputationKindStorageRead-1004]
+	_ = x[ComputationKindStorageWrite-1005]
 	_ = x[ComputationKindCreateCompositeValue-1010]
 	_ = x[ComputationKindTransferCompositeValue-1011]
 	_ = x[ComputationKindDestroyCompositeValue-1012]
@@ -30,7 +32,7 @@ func _() {
 
 const (
 	_ComputationKind_name_0 = "Unknown"
-	_ComputationKind_name_1 = "StatementLoopFunctionInvocation"
+	_ComputationKind_name_1 = "StatementLoopFunctionInvocationStorageReadStorageWrite"
 	_ComputationKind_name_2 = "CreateCompositeValueTransferCompositeValueDestroyCompositeValue"
 	_ComputationKind_name_3 = "CreateArrayValueTransferArrayValueDestroyArrayValue"
 	_ComputationKind_name_4 = "CreateDictionaryValueTransferDictionaryValueDestroyDictionaryValue"
@@ -39,7 +41,7 @@ const (
 )
 
 var (
-	_ComputationKind_index_1 = [...]uint8{0, 9, 13, 31}
+	_ComputationKind_index_1 = [...]uint8{0, 9, 13, 31, 42, 54}
 	_ComputationKind_index_2 = [...]uint8{0, 20, 42, 63}
 	_ComputationKind_index_3 = [...]uint8{0, 16, 34, 51}
 	_ComputationKind_index_4 = [...]uint8{0, 21, 44, 66}
@@ -51,7 +53,7 @@ func (i ComputationKind) String() string {
 	switch {
 	case i == 0:
 		return _ComputationKind_name_0
-	case 1001 <= i && i <= 1003:
+	case 1001 <= i && i <= 1005:
 		i -= 1001
 		return _ComputationKind_name_1[_ComputationKind_index_1[i]:_ComputationKind_index_1[i+1]]
 	case 1010 <= i && i <= 1012:
@@ -20,6 +20,7 @@ package common
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"github.com/onflow/cadence/runtime/errors"
 )
@@ -56,6 +57,8 @@ const (
 	DeclarationKindPragma
 	DeclarationKindEnum
 	DeclarationKindEnumCase
+	DeclarationKindAttachment
+	DeclarationKindTypeAlias
 )
 
 func DeclarationKindCount() int {
@@ -72,7 +75,8 @@ func (k DeclarationKind) IsTypeDeclaration() bool {
 		DeclarationKindResourceInterface,
 		DeclarationKindContractInterface,
 		DeclarationKindTypeParameter,
-		DeclarationKindEnum:
+		DeclarationKindEnum,
+		DeclarationKindAttachment:
 
 		return true
 
@@ -135,6 +139,10 @@ func (k DeclarationKind) Name() string {
 		return "enum"
 	case DeclarationKindEnumCase:
 		return "enum case"
+	case DeclarationKindAttachment:
+		return "attachment"
+	case DeclarationKindTypeAlias:
+		return "type alias"
 	case DeclarationKindUnknown:
 		return "unknown"
 	}
@@ -182,6 +190,10 @@ func (k DeclarationKind) Keywords() string {
 		return "enum"
 	case DeclarationKindEnumCase:
 		return "case"
+	case DeclarationKindAttachment:
+		return "attachment"
+	case DeclarationKindTypeAlias:
+		return "typealias"
 	default:
 		return ""
 	}
@@ -190,3 +202,20 @@ func (k DeclarationKind) Keywords() string {
 func (k DeclarationKind) MarshalJSON() ([]byte, error) {
 	return json.Marshal(k.String())
 }
+
+func (k *DeclarationKind) UnmarshalJSON(data []byte) error {
+	var name string
+	err := json.Unmarshal(data, &name)
+	if err != nil {
+		return err
+	}
+
+	for kind := DeclarationKind(0); kind < DeclarationKind(DeclarationKindCount()); kind++ {
+		if kind.String() == name {
+			*k = kind
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid declaration kind: %s", name)
+}
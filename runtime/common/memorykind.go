@@ -0,0 +1,118 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2022 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package common
+
+//go:generate go run golang.org/x/tools/cmd/stringer -type=MemoryKind -trimprefix=MemoryKind
+
+// MemoryKind captures kind of memory that would be used for metering memory
+type MemoryKind uint
+
+const (
+	MemoryKindUnknown MemoryKind = iota
+
+	// interpreter values
+	MemoryKindFunction
+	MemoryKindOptional
+	MemoryKindNilValue
+	MemoryKindVoid
+
+	// number values.
+	//
+	// These kinds replace the former MemoryKindBigInt,
+	// which only metered the values represented as big integers,
+	// proportional to their magnitude.
+	// Arbitrary-precision values (Int, UInt) are still metered proportional to their magnitude,
+	// using MemoryKindIntValue and MemoryKindUIntValue.
+	// All fixed-size values are now metered with the byte width of their type,
+	// e.g. 1 for Int8 and 32 for UInt256.
+	// Meters which limited MemoryKindBigInt should limit the sum of these kinds.
+	MemoryKindIntValue
+	MemoryKindUIntValue
+	MemoryKindInt8Value
+	MemoryKindInt16Value
+	MemoryKindInt32Value
+	MemoryKindInt64Value
+	MemoryKindInt128Value
+	MemoryKindInt256Value
+	MemoryKindUInt8Value
+	MemoryKindUInt16Value
+	MemoryKindUInt32Value
+	MemoryKindUInt64Value
+	MemoryKindUInt128Value
+	MemoryKindUInt256Value
+	MemoryKindWord8Value
+	MemoryKindWord16Value
+	MemoryKindWord32Value
+	MemoryKindWord64Value
+	MemoryKindFix64Value
+	MemoryKindUFix64Value
+
+	// other interpreter values
+	MemoryKindEvent
+	MemoryKindCapability
+	MemoryKindLink
+	MemoryKindPath
+	MemoryKindStoragePath
+	MemoryKindPublicPath
+	MemoryKindPrivatePath
+	MemoryKindClosure
+	MemoryKindTypeValue
+	MemoryKindStorageIndex
+	MemoryKindReference
+	MemoryKindAuthAccountValue
+	MemoryKindPublicAccountValue
+	MemoryKindEphemeralReference
+	MemoryKindBoundMethod
+	MemoryKindCharacter
+
+	// account storage.
+	//
+	// Reads and writes are metered proportional
+	// to the encoded size of the read or written value.
+	MemoryKindAccountStorageRead
+	MemoryKindAccountStorageWrite
+
+	// cryptographic values
+	MemoryKindPublicKey
+
+	// paths of account storage operations
+	//
+	// Storage paths are persisted, so they are metered at a higher cost
+	// than public and private paths.
+	MemoryKindStorageCapabilityPath
+	MemoryKindTransientPath
+
+	// address values
+	MemoryKindAddressValue
+
+	// invocations of the getters of computed fields
+	MemoryKindComputedField
+
+	// code of deployed contracts
+	MemoryKindDeployedContract
+
+	// static types of type annotations
+	MemoryKindStaticType
+)
+
+// MemoryUsage captures an amount of memory of a certain kind
+type MemoryUsage struct {
+	Kind   MemoryKind
+	Amount uint64
+}
@@ -93,6 +93,11 @@ func (compiler *Compiler) VisitEmitStatement(_ *ast.EmitStatement) ast.Repr {
 	panic(errors.NewUnreachableError())
 }
 
+func (compiler *Compiler) VisitDestroyStatement(_ *ast.DestroyStatement) ast.Repr {
+	// TODO
+	panic(errors.NewUnreachableError())
+}
+
 func (compiler *Compiler) VisitSwitchStatement(_ *ast.SwitchStatement) ast.Repr {
 	// TODO
 	panic(errors.NewUnreachableError())
@@ -104,6 +109,11 @@ func (compiler *Compiler) VisitVariableDeclaration(declaration *ast.VariableDecl
 	// TODO: copy and convert
 	// TODO: second value
 
+	// Destructuring is rejected by the checker
+	if declaration.Pattern != nil {
+		panic(errors.NewUnreachableError())
+	}
+
 	identifier := declaration.Identifier.Identifier
 	targetType := compiler.Checker.Elaboration.VariableDeclarationTargetTypes[declaration]
 	valType := compileValueType(targetType)
@@ -235,6 +245,11 @@ func (compiler *Compiler) VisitStringExpression(e *ast.StringExpression) ast.Rep
 	}
 }
 
+func (compiler *Compiler) VisitStringInterpolationExpression(_ *ast.StringInterpolationExpression) ast.Repr {
+	// TODO
+	panic(errors.NewUnreachableError())
+}
+
 func (compiler *Compiler) VisitCastingExpression(_ *ast.CastingExpression) ast.Repr {
 	// TODO
 	panic(errors.NewUnreachableError())
@@ -250,6 +265,16 @@ func (compiler *Compiler) VisitDestroyExpression(_ *ast.DestroyExpression) ast.R
 	panic(errors.NewUnreachableError())
 }
 
+func (compiler *Compiler) VisitAttachExpression(_ *ast.AttachExpression) ast.Repr {
+	// TODO
+	panic(errors.NewUnreachableError())
+}
+
+func (compiler *Compiler) VisitRemoveExpression(_ *ast.RemoveExpression) ast.Repr {
+	// TODO
+	panic(errors.NewUnreachableError())
+}
+
 func (compiler *Compiler) VisitReferenceExpression(_ *ast.ReferenceExpression) ast.Repr {
 	// TODO
 	panic(errors.NewUnreachableError())
@@ -377,6 +402,16 @@ func (compiler *Compiler) VisitEnumCaseDeclaration(_ *ast.EnumCaseDeclaration) a
 	panic(errors.NewUnreachableError())
 }
 
+func (compiler *Compiler) VisitAttachmentDeclaration(_ *ast.AttachmentDeclaration) ast.Repr {
+	// TODO
+	panic(errors.NewUnreachableError())
+}
+
+func (compiler *Compiler) VisitTypeAliasDeclaration(_ *ast.TypeAliasDeclaration) ast.Repr {
+	// TODO
+	panic(errors.NewUnreachableError())
+}
+
 func compileBinaryOperation(operation ast.Operation) ir.BinOp {
 	// TODO: add remaining operations
 	switch operation {
@@ -29,6 +29,15 @@ type Context struct {
 	PredeclaredValues []ValueDeclaration
 	codes             map[common.LocationID]string
 	programs          map[common.LocationID]*ast.Program
+	// SourceMap is optional. If set, it is populated
+	// with the source positions of the executed statements
+	SourceMap *SourceMap
+	// GasTable is optional. If set, the costs of operations
+	// are deducted from its gas limit during execution
+	GasTable *GasTable
+	// CoverageReport is optional. If set, the line hits of the executed statements
+	// are recorded in it, in addition to the runtime's coverage report, if any
+	CoverageReport *CoverageReport
 }
 
 func (c Context) SetCode(location common.Location, code string) {
@@ -0,0 +1,246 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/onflow/cadence/runtime/ast"
+)
+
+// contractUpdateRulesValidator validates the rules for updates
+// of the exported declarations of a contract, see ValidateContractUpdate.
+//
+// The embedded ContractUpdateValidator is only used to compare types.
+//
+type contractUpdateRulesValidator struct {
+	*ContractUpdateValidator
+	declarationPath []string
+	updateErrors    []UpdateError
+}
+
+// ValidateContractUpdate validates the rules for updates of a contract or contract interface,
+// and returns the violations:
+// Exported fields and functions must not be removed, the access of exported declarations
+// must not be restricted, and the types of exported fields must not change.
+//
+// The rules complement the ContractUpdateValidator, which ensures that stored data stays valid.
+//
+func ValidateContractUpdate(oldProgram *ast.Program, newProgram *ast.Program) []UpdateError {
+
+	// A missing contract or contract interface is reported by the ContractUpdateValidator
+
+	oldRootDecl, err := getRootDeclaration(oldProgram)
+	if err != nil {
+		return nil
+	}
+
+	newRootDecl, err := getRootDeclaration(newProgram)
+	if err != nil {
+		return nil
+	}
+
+	validator := &contractUpdateRulesValidator{
+		ContractUpdateValidator: &ContractUpdateValidator{
+			oldProgram: oldProgram,
+			newProgram: newProgram,
+			rootDecl:   newRootDecl,
+		},
+	}
+
+	validator.checkDeclaration(oldRootDecl, newRootDecl)
+
+	return validator.updateErrors
+}
+
+func (validator *contractUpdateRulesValidator) checkDeclaration(
+	oldDeclaration ast.Declaration,
+	newDeclaration ast.Declaration,
+) {
+	validator.declarationPath = append(
+		validator.declarationPath,
+		newDeclaration.DeclarationIdentifier().Identifier,
+	)
+	defer func() {
+		validator.declarationPath = validator.declarationPath[:len(validator.declarationPath)-1]
+	}()
+
+	// Changes of the declaration kind are reported by the ContractUpdateValidator
+
+	if oldDeclaration.DeclarationKind() != newDeclaration.DeclarationKind() {
+		return
+	}
+
+	parentDecl := validator.currentDecl
+	validator.currentDecl = newDeclaration
+	defer func() {
+		validator.currentDecl = parentDecl
+	}()
+
+	validator.checkFields(oldDeclaration, newDeclaration)
+	validator.checkFunctions(oldDeclaration, newDeclaration)
+	validator.checkNestedDeclarations(oldDeclaration, newDeclaration)
+}
+
+func (validator *contractUpdateRulesValidator) checkFields(
+	oldDeclaration ast.Declaration,
+	newDeclaration ast.Declaration,
+) {
+	newFields := newDeclaration.DeclarationMembers().FieldsByIdentifier()
+
+	for _, oldField := range oldDeclaration.DeclarationMembers().Fields() {
+		if !isExportedAccess(oldField.Access) {
+			continue
+		}
+
+		name := oldField.Identifier.Identifier
+
+		newField := newFields[name]
+		if newField == nil {
+			validator.report(&ExportedDeclarationUpdateError{
+				Kind:  UpdateErrorKindRemovedField,
+				Path:  validator.memberPath(name),
+				Range: ast.NewRangeFromPositioned(newDeclaration.DeclarationIdentifier()),
+			})
+
+			continue
+		}
+
+		if !isExportedAccess(newField.Access) {
+			validator.report(&ExportedDeclarationUpdateError{
+				Kind:  UpdateErrorKindRestrictedAccess,
+				Path:  validator.memberPath(name),
+				Range: ast.NewRangeFromPositioned(newField.Identifier),
+			})
+
+			continue
+		}
+
+		err := oldField.TypeAnnotation.Type.CheckEqual(newField.TypeAnnotation.Type, validator)
+		if err != nil {
+			validator.report(&ExportedDeclarationUpdateError{
+				Kind:  UpdateErrorKindChangedFieldType,
+				Path:  validator.memberPath(name),
+				Err:   err,
+				Range: ast.NewRangeFromPositioned(newField.TypeAnnotation),
+			})
+		}
+	}
+}
+
+func (validator *contractUpdateRulesValidator) checkFunctions(
+	oldDeclaration ast.Declaration,
+	newDeclaration ast.Declaration,
+) {
+	newFunctions := newDeclaration.DeclarationMembers().FunctionsByIdentifier()
+
+	for _, oldFunction := range oldDeclaration.DeclarationMembers().Functions() {
+		if !isExportedAccess(oldFunction.Access) {
+			continue
+		}
+
+		name := oldFunction.Identifier.Identifier
+
+		newFunction := newFunctions[name]
+		if newFunction == nil {
+			validator.report(&ExportedDeclarationUpdateError{
+				Kind:  UpdateErrorKindRemovedFunction,
+				Path:  validator.memberPath(name),
+				Range: ast.NewRangeFromPositioned(newDeclaration.DeclarationIdentifier()),
+			})
+
+			continue
+		}
+
+		if !isExportedAccess(newFunction.Access) {
+			validator.report(&ExportedDeclarationUpdateError{
+				Kind:  UpdateErrorKindRestrictedAccess,
+				Path:  validator.memberPath(name),
+				Range: ast.NewRangeFromPositioned(newFunction.Identifier),
+			})
+		}
+	}
+}
+
+// checkNestedDeclarations validates the nested declarations which exist in both programs.
+// Removals of nested declarations are reported by the ContractUpdateValidator.
+//
+func (validator *contractUpdateRulesValidator) checkNestedDeclarations(
+	oldDeclaration ast.Declaration,
+	newDeclaration ast.Declaration,
+) {
+	oldNestedDecls := getNestedCompositeAndInterfaceDecls(oldDeclaration)
+	newNestedDecls := getNestedCompositeAndInterfaceDecls(newDeclaration)
+
+	names := make([]string, 0, len(oldNestedDecls))
+	for name := range oldNestedDecls { //nolint:maprangecheck
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		oldNestedDecl := oldNestedDecls[name]
+		if !isExportedAccess(oldNestedDecl.DeclarationAccess()) {
+			continue
+		}
+
+		newNestedDecl, found := newNestedDecls[name]
+		if !found {
+			continue
+		}
+
+		if !isExportedAccess(newNestedDecl.DeclarationAccess()) {
+			validator.report(&ExportedDeclarationUpdateError{
+				Kind:  UpdateErrorKindRestrictedAccess,
+				Path:  validator.memberPath(name),
+				Range: ast.NewRangeFromPositioned(newNestedDecl.DeclarationIdentifier()),
+			})
+
+			continue
+		}
+
+		validator.checkDeclaration(oldNestedDecl, newNestedDecl)
+	}
+}
+
+func (validator *contractUpdateRulesValidator) report(err UpdateError) {
+	validator.updateErrors = append(validator.updateErrors, err)
+}
+
+func (validator *contractUpdateRulesValidator) memberPath(name string) string {
+	path := make([]string, 0, len(validator.declarationPath)+1)
+	path = append(path, validator.declarationPath...)
+	path = append(path, name)
+	return strings.Join(path, ".")
+}
+
+// isExportedAccess returns true if a declaration with the given access
+// is accessible outside of the contract
+//
+func isExportedAccess(access ast.Access) bool {
+	switch access {
+	case ast.AccessAccount,
+		ast.AccessPublic,
+		ast.AccessPublicSettable:
+		return true
+	default:
+		return false
+	}
+}
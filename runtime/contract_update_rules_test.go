@@ -0,0 +1,101 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/parser2"
+)
+
+func TestValidateContractUpdate(t *testing.T) {
+
+	t.Parallel()
+
+	const oldCode = `
+      pub contract Test {
+
+          pub resource R {
+              pub let id: Int
+              pub let name: String
+              priv let secret: String
+
+              init() {
+                  self.id = 1
+                  self.name = ""
+                  self.secret = ""
+              }
+
+              pub fun foo(x: Int) {}
+              pub fun bar() {}
+              priv fun baz() {}
+          }
+
+          pub struct S {}
+
+          pub var count: Int
+
+          init() {
+              self.count = 0
+          }
+      }
+    `
+
+	const newCode = `
+      pub contract Test {
+
+          pub resource R {
+              pub let id: String
+              access(contract) let name: String
+
+              init() {
+                  self.id = ""
+                  self.name = ""
+              }
+
+              pub fun foo(y: String) {}
+          }
+
+          pub var count: Int
+
+          init() {
+              self.count = 0
+          }
+      }
+    `
+
+	oldProgram, err := parser2.ParseProgram(oldCode)
+	require.NoError(t, err)
+
+	newProgram, err := parser2.ParseProgram(newCode)
+	require.NoError(t, err)
+
+	errs := ValidateContractUpdate(oldProgram, newProgram)
+
+	// Removed private members, changed function signatures, and removed declarations
+	// are not violations of the update rules
+
+	require.Len(t, errs, 3)
+
+	assertUpdateError(t, errs[0], UpdateErrorKindChangedFieldType, "Test.R.id")
+	assertUpdateError(t, errs[1], UpdateErrorKindRestrictedAccess, "Test.R.name")
+	assertUpdateError(t, errs[2], UpdateErrorKindRemovedFunction, "Test.R.bar")
+}
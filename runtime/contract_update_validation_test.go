@@ -244,6 +244,38 @@ func TestRuntimeContractUpdateValidation(t *testing.T) {
             pub contract Test {
                 pub var a: String
 
+                init() {
+                    self.a = "hello"
+                }
+            }
+        `
+
+		err := testDeployAndUpdate(t, contractValidationEnabled, "Test", oldCode, newCode)
+
+		cause := getSingleContractUpdateErrorCause(t, err, "Test")
+		assertUpdateError(t, cause, UpdateErrorKindRemovedField, "Test.b")
+	})
+
+	t.Run("remove private field", func(t *testing.T) {
+
+		t.Parallel()
+
+		const oldCode = `
+            pub contract Test {
+                pub var a: String
+                priv var b: Int
+
+                init() {
+                    self.a = "hello"
+                    self.b = 0
+                }
+            }
+        `
+
+		const newCode = `
+            pub contract Test {
+                pub var a: String
+
                 init() {
                     self.a = "hello"
                 }
@@ -254,6 +286,133 @@ func TestRuntimeContractUpdateValidation(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("remove function", func(t *testing.T) {
+
+		t.Parallel()
+
+		const oldCode = `
+            pub contract Test {
+                pub fun foo(): Int {
+                    return 1
+                }
+
+                pub fun bar(): Int {
+                    return 2
+                }
+            }
+        `
+
+		const newCode = `
+            pub contract Test {
+                pub fun foo(): Int {
+                    return 1
+                }
+            }
+        `
+
+		err := testDeployAndUpdate(t, contractValidationEnabled, "Test", oldCode, newCode)
+
+		cause := getSingleContractUpdateErrorCause(t, err, "Test")
+		assertUpdateError(t, cause, UpdateErrorKindRemovedFunction, "Test.bar")
+	})
+
+	t.Run("remove private function", func(t *testing.T) {
+
+		t.Parallel()
+
+		const oldCode = `
+            pub contract Test {
+                pub fun foo(): Int {
+                    return 1
+                }
+
+                priv fun bar(): Int {
+                    return 2
+                }
+            }
+        `
+
+		const newCode = `
+            pub contract Test {
+                pub fun foo(): Int {
+                    return 1
+                }
+            }
+        `
+
+		err := testDeployAndUpdate(t, contractValidationEnabled, "Test", oldCode, newCode)
+		require.NoError(t, err)
+	})
+
+	t.Run("change field access", func(t *testing.T) {
+
+		t.Parallel()
+
+		const oldCode = `
+            pub contract Test {
+                pub var a: String
+
+                init() {
+                    self.a = "hello"
+                }
+            }
+        `
+
+		const newCode = `
+            pub contract Test {
+                access(contract) var a: String
+
+                init() {
+                    self.a = "hello"
+                }
+            }
+        `
+
+		err := testDeployAndUpdate(t, contractValidationEnabled, "Test", oldCode, newCode)
+
+		cause := getSingleContractUpdateErrorCause(t, err, "Test")
+		assertUpdateError(t, cause, UpdateErrorKindRestrictedAccess, "Test.a")
+	})
+
+	t.Run("storage and update rule violations", func(t *testing.T) {
+
+		t.Parallel()
+
+		const oldCode = `
+            pub contract Test {
+                pub var a: String
+
+                init() {
+                    self.a = "hello"
+                }
+
+                pub fun foo() {}
+            }
+        `
+
+		const newCode = `
+            pub contract Test {
+                pub var a: String
+                pub var b: Int
+
+                init() {
+                    self.a = "hello"
+                    self.b = 0
+                }
+            }
+        `
+
+		// Both violations are reported together
+
+		err := testDeployAndUpdate(t, contractValidationEnabled, "Test", oldCode, newCode)
+
+		updateErr := getContractUpdateError(t, err, "Test")
+		require.Len(t, updateErr.Errors, 2)
+
+		assertExtraneousFieldError(t, updateErr.Errors[0], "Test", "b")
+		assertUpdateError(t, updateErr.Errors[1], UpdateErrorKindRemovedFunction, "Test.foo")
+	})
+
 	t.Run("change nested decl field type", func(t *testing.T) {
 
 		t.Parallel()
@@ -998,8 +1157,11 @@ func TestRuntimeContractUpdateValidation(t *testing.T) {
 		err := testDeployAndUpdate(t, contractValidationEnabled, "Test", oldCode, newCode)
 		require.Error(t, err)
 
-		cause := getSingleContractUpdateErrorCause(t, err, "Test")
-		assertExtraneousFieldError(t, cause, "Test", "b")
+		updateErr := getContractUpdateError(t, err, "Test")
+		require.Len(t, updateErr.Errors, 2)
+
+		assertExtraneousFieldError(t, updateErr.Errors[0], "Test", "b")
+		assertUpdateError(t, updateErr.Errors[1], UpdateErrorKindRemovedField, "Test.a")
 	})
 
 	t.Run("multiple errors", func(t *testing.T) {
@@ -1699,8 +1861,11 @@ func TestRuntimeContractUpdateValidation(t *testing.T) {
 		err = executeTransaction(newContractUpdateTransaction("Test", updateCode2))
 		require.Error(t, err)
 
-		cause = getSingleContractUpdateErrorCause(t, err, "Test")
-		assertFieldTypeMismatchError(t, cause, "TestStruct", "a", "Int", "String")
+		updateErr := getContractUpdateError(t, err, "Test")
+		require.Len(t, updateErr.Errors, 2)
+
+		assertFieldTypeMismatchError(t, updateErr.Errors[0], "TestStruct", "a", "Int", "String")
+		assertUpdateError(t, updateErr.Errors[1], UpdateErrorKindRemovedField, "Test.TestStruct.b")
 	})
 
 	t.Run("Rename struct", func(t *testing.T) {
@@ -1918,6 +2083,16 @@ func assertMissingDeclarationError(t *testing.T, err error, declName string) boo
 	return assert.Equal(t, declName, missingDeclError.Name)
 }
 
+func assertUpdateError(t *testing.T, err error, kind UpdateErrorKind, path string) {
+	var updateErr UpdateError
+	require.ErrorAs(t, err, &updateErr)
+	assert.Equal(t, path, updateErr.DeclarationPath())
+
+	var exportedDeclarationErr *ExportedDeclarationUpdateError
+	require.ErrorAs(t, err, &exportedDeclarationErr)
+	assert.Equal(t, kind, exportedDeclarationErr.Kind)
+}
+
 func getSingleContractUpdateErrorCause(t *testing.T, err error, contractName string) error {
 	updateErr := getContractUpdateError(t, err, contractName)
 
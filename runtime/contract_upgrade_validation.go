@@ -0,0 +1,336 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/onflow/cadence/runtime/ast"
+)
+
+// ContractUpgradeValidator validates that an upgrade of a contract or contract interface
+// preserves the exported declarations of the existing program,
+// in addition to the checks performed by the ContractUpdateValidator.
+//
+type ContractUpgradeValidator struct {
+	*ContractUpdateValidator
+	declarationPath []string
+}
+
+// NewContractUpgradeValidator initializes and returns a validator, without performing any validation.
+// Invoke the `Validate()` method of the validator returned, to start validating the contract.
+func NewContractUpgradeValidator(
+	location Location,
+	contractName string,
+	oldProgram *ast.Program,
+	newProgram *ast.Program,
+) *ContractUpgradeValidator {
+
+	return &ContractUpgradeValidator{
+		ContractUpdateValidator: NewContractUpdateValidator(
+			location,
+			contractName,
+			oldProgram,
+			newProgram,
+		),
+	}
+}
+
+// Validate validates the contract upgrade, and returns an error if it is an invalid upgrade.
+//
+// Breaking changes to the exported declarations are reported first, as ContractUpgradeErrors.
+// Only if there are none, the update is validated by the ContractUpdateValidator.
+//
+func (validator *ContractUpgradeValidator) Validate() error {
+	oldRootDecl := validator.getRootDeclaration(validator.oldProgram)
+	if validator.hasErrors() {
+		return validator.getContractUpdateError()
+	}
+
+	newRootDecl := validator.getRootDeclaration(validator.newProgram)
+	if validator.hasErrors() {
+		return validator.getContractUpdateError()
+	}
+
+	validator.rootDecl = newRootDecl
+	validator.checkDeclarationUpgradability(oldRootDecl, newRootDecl)
+
+	if validator.hasErrors() {
+		return validator.getContractUpdateError()
+	}
+
+	return validator.ContractUpdateValidator.Validate()
+}
+
+func (validator *ContractUpgradeValidator) checkDeclarationUpgradability(
+	oldDeclaration ast.Declaration,
+	newDeclaration ast.Declaration,
+) {
+	validator.declarationPath = append(
+		validator.declarationPath,
+		newDeclaration.DeclarationIdentifier().Identifier,
+	)
+	defer func() {
+		validator.declarationPath = validator.declarationPath[:len(validator.declarationPath)-1]
+	}()
+
+	// Changes of the declaration kind are reported by the ContractUpdateValidator
+
+	if oldDeclaration.DeclarationKind() != newDeclaration.DeclarationKind() {
+		return
+	}
+
+	parentDecl := validator.currentDecl
+	validator.currentDecl = newDeclaration
+	defer func() {
+		validator.currentDecl = parentDecl
+	}()
+
+	validator.checkExportedFields(oldDeclaration, newDeclaration)
+
+	validator.checkExportedFunctions(oldDeclaration, newDeclaration)
+
+	if newDecl, ok := newDeclaration.(*ast.CompositeDeclaration); ok {
+		if oldDecl, ok := oldDeclaration.(*ast.CompositeDeclaration); ok {
+			validator.checkExportedConformances(oldDecl, newDecl)
+		}
+	}
+
+	validator.checkExportedNestedDeclarations(oldDeclaration, newDeclaration)
+}
+
+func (validator *ContractUpgradeValidator) checkExportedFields(
+	oldDeclaration ast.Declaration,
+	newDeclaration ast.Declaration,
+) {
+	newFields := newDeclaration.DeclarationMembers().FieldsByIdentifier()
+
+	for _, oldField := range oldDeclaration.DeclarationMembers().Fields() {
+		if !isExportedAccess(oldField.Access) {
+			continue
+		}
+
+		name := oldField.Identifier.Identifier
+
+		newField := newFields[name]
+		if newField == nil {
+			validator.report(&ContractUpgradeError{
+				Kind:  ContractUpgradeErrorKindRemovedField,
+				Path:  validator.memberPath(name),
+				Range: ast.NewRangeFromPositioned(newDeclaration.DeclarationIdentifier()),
+			})
+
+			continue
+		}
+
+		if !isExportedAccess(newField.Access) {
+			validator.report(&ContractUpgradeError{
+				Kind:  ContractUpgradeErrorKindChangedAccess,
+				Path:  validator.memberPath(name),
+				Range: ast.NewRangeFromPositioned(newField.Identifier),
+			})
+
+			continue
+		}
+
+		err := oldField.TypeAnnotation.Type.CheckEqual(newField.TypeAnnotation.Type, validator)
+		if err != nil {
+			validator.report(&ContractUpgradeError{
+				Kind:  ContractUpgradeErrorKindChangedField,
+				Path:  validator.memberPath(name),
+				Err:   err,
+				Range: ast.NewRangeFromPositioned(newField.TypeAnnotation),
+			})
+		}
+	}
+}
+
+func (validator *ContractUpgradeValidator) checkExportedFunctions(
+	oldDeclaration ast.Declaration,
+	newDeclaration ast.Declaration,
+) {
+	newFunctions := newDeclaration.DeclarationMembers().FunctionsByIdentifier()
+
+	for _, oldFunction := range oldDeclaration.DeclarationMembers().Functions() {
+		if !isExportedAccess(oldFunction.Access) {
+			continue
+		}
+
+		name := oldFunction.Identifier.Identifier
+
+		newFunction := newFunctions[name]
+		if newFunction == nil {
+			validator.report(&ContractUpgradeError{
+				Kind:  ContractUpgradeErrorKindRemovedFunction,
+				Path:  validator.memberPath(name),
+				Range: ast.NewRangeFromPositioned(newDeclaration.DeclarationIdentifier()),
+			})
+
+			continue
+		}
+
+		if !isExportedAccess(newFunction.Access) {
+			validator.report(&ContractUpgradeError{
+				Kind:  ContractUpgradeErrorKindChangedAccess,
+				Path:  validator.memberPath(name),
+				Range: ast.NewRangeFromPositioned(newFunction.Identifier),
+			})
+
+			continue
+		}
+
+		err := validator.checkFunctionSignature(oldFunction, newFunction)
+		if err != nil {
+			validator.report(&ContractUpgradeError{
+				Kind:  ContractUpgradeErrorKindChangedFunction,
+				Path:  validator.memberPath(name),
+				Err:   err,
+				Range: ast.NewRangeFromPositioned(newFunction.Identifier),
+			})
+		}
+	}
+}
+
+// checkFunctionSignature checks that the new function can be called like the old function,
+// i.e. that the argument labels, the parameter types, and the return type are unchanged.
+//
+func (validator *ContractUpgradeValidator) checkFunctionSignature(
+	oldFunction *ast.FunctionDeclaration,
+	newFunction *ast.FunctionDeclaration,
+) error {
+	oldParameters := oldFunction.ParameterList.Parameters
+	newParameters := newFunction.ParameterList.Parameters
+
+	if len(oldParameters) != len(newParameters) {
+		return &ParameterCountMismatchError{
+			Expected: len(oldParameters),
+			Found:    len(newParameters),
+			Range:    ast.NewRangeFromPositioned(newFunction.ParameterList),
+		}
+	}
+
+	for index, oldParameter := range oldParameters {
+		newParameter := newParameters[index]
+
+		oldLabel := oldParameter.EffectiveArgumentLabel()
+		newLabel := newParameter.EffectiveArgumentLabel()
+		if oldLabel != newLabel {
+			return &ArgumentLabelMismatchError{
+				ExpectedLabel: oldLabel,
+				FoundLabel:    newLabel,
+				Range:         newParameter.Range,
+			}
+		}
+
+		err := oldParameter.TypeAnnotation.Type.CheckEqual(newParameter.TypeAnnotation.Type, validator)
+		if err != nil {
+			return err
+		}
+	}
+
+	return oldFunction.ReturnTypeAnnotation.Type.CheckEqual(newFunction.ReturnTypeAnnotation.Type, validator)
+}
+
+func (validator *ContractUpgradeValidator) checkExportedConformances(
+	oldDecl *ast.CompositeDeclaration,
+	newDecl *ast.CompositeDeclaration,
+) {
+	for _, oldConformance := range oldDecl.Conformances {
+		found := false
+		for _, newConformance := range newDecl.Conformances {
+			err := oldConformance.CheckEqual(newConformance, validator)
+			if err == nil {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			validator.report(&ContractUpgradeError{
+				Kind: ContractUpgradeErrorKindRemovedConformance,
+				Path: validator.declarationPathString(),
+				Err: &MissingConformanceError{
+					Conformance: oldConformance,
+					Range:       ast.NewRangeFromPositioned(newDecl.Identifier),
+				},
+				Range: ast.NewRangeFromPositioned(newDecl.Identifier),
+			})
+		}
+	}
+}
+
+func (validator *ContractUpgradeValidator) checkExportedNestedDeclarations(
+	oldDeclaration ast.Declaration,
+	newDeclaration ast.Declaration,
+) {
+	oldNestedDecls := getNestedCompositeAndInterfaceDecls(oldDeclaration)
+	newNestedDecls := getNestedCompositeAndInterfaceDecls(newDeclaration)
+
+	exportedOldNestedDecls := make([]ast.Declaration, 0, len(oldNestedDecls))
+
+	for _, declaration := range oldNestedDecls { //nolint:maprangecheck
+		if isExportedAccess(declaration.DeclarationAccess()) {
+			exportedOldNestedDecls = append(exportedOldNestedDecls, declaration)
+		}
+	}
+
+	sort.Slice(exportedOldNestedDecls, func(i, j int) bool {
+		return exportedOldNestedDecls[i].DeclarationIdentifier().Identifier <
+			exportedOldNestedDecls[j].DeclarationIdentifier().Identifier
+	})
+
+	for _, oldNestedDecl := range exportedOldNestedDecls {
+		name := oldNestedDecl.DeclarationIdentifier().Identifier
+
+		newNestedDecl, found := newNestedDecls[name]
+		if !found {
+			validator.report(&ContractUpgradeError{
+				Kind:  ContractUpgradeErrorKindRemovedDeclaration,
+				Path:  validator.memberPath(name),
+				Range: ast.NewRangeFromPositioned(newDeclaration.DeclarationIdentifier()),
+			})
+
+			continue
+		}
+
+		if !isExportedAccess(newNestedDecl.DeclarationAccess()) {
+			validator.report(&ContractUpgradeError{
+				Kind:  ContractUpgradeErrorKindChangedAccess,
+				Path:  validator.memberPath(name),
+				Range: ast.NewRangeFromPositioned(newNestedDecl.DeclarationIdentifier()),
+			})
+
+			continue
+		}
+
+		validator.checkDeclarationUpgradability(oldNestedDecl, newNestedDecl)
+	}
+}
+
+func (validator *ContractUpgradeValidator) declarationPathString() string {
+	return strings.Join(validator.declarationPath, ".")
+}
+
+func (validator *ContractUpgradeValidator) memberPath(name string) string {
+	path := make([]string, 0, len(validator.declarationPath)+1)
+	path = append(path, validator.declarationPath...)
+	path = append(path, name)
+	return strings.Join(path, ".")
+}
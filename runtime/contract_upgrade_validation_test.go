@@ -0,0 +1,589 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// testDeployAndUpgrade deploys a contract in a transaction,
+// then upgrades the contract using the runtime,
+// and returns the resulting code of the contract
+func testDeployAndUpgrade(t *testing.T, name string, oldCode string, newCode string) ([]byte, error) {
+	result := testDeployAndUpgradeWithResult(t, name, oldCode, newCode, true)
+	return result.code, result.err
+}
+
+type contractUpgradeTestResult struct {
+	code   []byte
+	err    error
+	events []cadence.Event
+	meter  *testMemoryGauge
+}
+
+// testDeployAndUpgradeWithResult is like testDeployAndUpgrade,
+// but optionally does not authorize the upgrade,
+// and also returns the events emitted and the memory metered by the upgrade
+func testDeployAndUpgradeWithResult(
+	t *testing.T,
+	name string,
+	oldCode string,
+	newCode string,
+	authorized bool,
+) contractUpgradeTestResult {
+	rt := newTestInterpreterRuntime()
+
+	address := common.MustBytesToAddress([]byte{0x42})
+
+	signingAccounts := []Address{address}
+
+	var events []cadence.Event
+
+	accountCodes := map[common.LocationID][]byte{}
+	runtimeInterface := &testRuntimeInterface{
+		getCode: func(location Location) (bytes []byte, err error) {
+			return accountCodes[location.ID()], nil
+		},
+		storage: newTestLedger(nil, nil),
+		getSigningAccounts: func() ([]Address, error) {
+			return signingAccounts, nil
+		},
+		resolveLocation: singleIdentifierLocationResolver(t),
+		getAccountContractCode: func(address Address, name string) (code []byte, err error) {
+			location := common.AddressLocation{
+				Address: address,
+				Name:    name,
+			}
+			return accountCodes[location.ID()], nil
+		},
+		updateAccountContractCode: func(address Address, name string, code []byte) error {
+			location := common.AddressLocation{
+				Address: address,
+				Name:    name,
+			}
+			accountCodes[location.ID()] = code
+			return nil
+		},
+		emitEvent: func(event cadence.Event) error {
+			events = append(events, event)
+			return nil
+		},
+	}
+
+	nextTransactionLocation := newTransactionLocationGenerator()
+
+	if oldCode != "" {
+		err := rt.ExecuteTransaction(
+			Script{
+				Source: []byte(newContractAddTransaction(name, oldCode)),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  nextTransactionLocation(),
+			},
+		)
+		require.NoError(t, err)
+	}
+
+	// Only record the events and memory of the upgrade
+
+	events = nil
+
+	meter := newTestMemoryGauge()
+	runtimeInterface.meterMemory = meter.MeterMemory
+
+	if !authorized {
+		signingAccounts = nil
+	}
+
+	location := common.AddressLocation{
+		Address: address,
+		Name:    name,
+	}
+
+	err := rt.UpgradeContract(
+		Context{
+			Interface: runtimeInterface,
+		},
+		location,
+		[]byte(newCode),
+	)
+
+	return contractUpgradeTestResult{
+		code:   accountCodes[location.ID()],
+		err:    err,
+		events: events,
+		meter:  meter,
+	}
+}
+
+func getContractUpgradeErrors(t *testing.T, err error, contractName string) []*ContractUpgradeError {
+	require.Error(t, err)
+
+	var contractUpdateErr *ContractUpdateError
+	require.ErrorAs(t, err, &contractUpdateErr)
+
+	assert.Equal(t, contractName, contractUpdateErr.ContractName)
+
+	upgradeErrs := make([]*ContractUpgradeError, 0, len(contractUpdateErr.Errors))
+	for _, childErr := range contractUpdateErr.Errors {
+		var upgradeErr *ContractUpgradeError
+		require.ErrorAs(t, childErr, &upgradeErr)
+		upgradeErrs = append(upgradeErrs, upgradeErr)
+	}
+
+	return upgradeErrs
+}
+
+func assertContractUpgradeError(
+	t *testing.T,
+	err *ContractUpgradeError,
+	kind ContractUpgradeErrorKind,
+	path string,
+) {
+	assert.Equal(t, kind, err.Kind)
+	assert.Equal(t, path, err.Path)
+}
+
+func TestRuntimeContractUpgrade(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("compatible", func(t *testing.T) {
+
+		t.Parallel()
+
+		const oldCode = `
+            pub contract Test {
+
+                pub resource interface I {}
+
+                pub resource R {
+                    pub let id: Int
+
+                    init(id: Int) {
+                        self.id = id
+                    }
+
+                    pub fun get(index: Int): Int {
+                        return self.id
+                    }
+                }
+
+                priv var count: Int
+
+                init() {
+                    self.count = 0
+                }
+
+                priv fun helper(_ x: Int) {}
+            }
+        `
+
+		const newCode = `
+            pub contract Test {
+
+                pub resource interface I {}
+
+                pub resource R: I {
+                    pub let id: Int
+
+                    init(id: Int) {
+                        self.id = id
+                    }
+
+                    pub fun get(index: Int): Int {
+                        return self.id + index
+                    }
+
+                    pub fun other() {}
+                }
+
+                init() {}
+
+                priv fun helper(_ x: String) {}
+            }
+        `
+
+		code, err := testDeployAndUpgrade(t, "Test", oldCode, newCode)
+		require.NoError(t, err)
+
+		assert.Equal(t, []byte(newCode), code)
+	})
+
+	t.Run("remove exported field", func(t *testing.T) {
+
+		t.Parallel()
+
+		const oldCode = `
+            pub contract Test {
+
+                pub resource R {
+                    pub let id: Int
+                    access(account) let name: String
+                    pub let count: Int
+
+                    init() {
+                        self.id = 1
+                        self.name = ""
+                        self.count = 0
+                    }
+                }
+            }
+        `
+
+		const newCode = `
+            pub contract Test {
+
+                pub resource R {
+                    pub let id: Int
+                    priv let name: String
+
+                    init() {
+                        self.id = 1
+                        self.name = ""
+                    }
+                }
+            }
+        `
+
+		code, err := testDeployAndUpgrade(t, "Test", oldCode, newCode)
+		require.Error(t, err)
+
+		assert.Equal(t, []byte(oldCode), code)
+
+		errs := getContractUpgradeErrors(t, err, "Test")
+		require.Len(t, errs, 2)
+		assertContractUpgradeError(t, errs[0], ContractUpgradeErrorKindChangedAccess, "Test.R.name")
+		assertContractUpgradeError(t, errs[1], ContractUpgradeErrorKindRemovedField, "Test.R.count")
+	})
+
+	t.Run("change exported field", func(t *testing.T) {
+
+		t.Parallel()
+
+		const oldCode = `
+            pub contract Test {
+                pub var a: String
+
+                init() {
+                    self.a = "hello"
+                }
+            }
+        `
+
+		const newCode = `
+            pub contract Test {
+                pub var a: Int
+
+                init() {
+                    self.a = 0
+                }
+            }
+        `
+
+		_, err := testDeployAndUpgrade(t, "Test", oldCode, newCode)
+
+		errs := getContractUpgradeErrors(t, err, "Test")
+		require.Len(t, errs, 1)
+		assertContractUpgradeError(t, errs[0], ContractUpgradeErrorKindChangedField, "Test.a")
+
+		var typeMismatchError *TypeMismatchError
+		require.ErrorAs(t, errs[0].Err, &typeMismatchError)
+	})
+
+	t.Run("remove exported function", func(t *testing.T) {
+
+		t.Parallel()
+
+		const oldCode = `
+            pub contract Test {
+                pub fun foo() {}
+                access(account) fun bar() {}
+            }
+        `
+
+		const newCode = `
+            pub contract Test {
+                access(contract) fun bar() {}
+            }
+        `
+
+		_, err := testDeployAndUpgrade(t, "Test", oldCode, newCode)
+
+		errs := getContractUpgradeErrors(t, err, "Test")
+		require.Len(t, errs, 2)
+		assertContractUpgradeError(t, errs[0], ContractUpgradeErrorKindRemovedFunction, "Test.foo")
+		assertContractUpgradeError(t, errs[1], ContractUpgradeErrorKindChangedAccess, "Test.bar")
+	})
+
+	t.Run("change exported function signature", func(t *testing.T) {
+
+		t.Parallel()
+
+		const oldCode = `
+            pub contract Test {
+                pub fun count(a: Int) {}
+                pub fun label(a: Int) {}
+                pub fun type(a: Int) {}
+                pub fun result(a: Int): Int { return a }
+            }
+        `
+
+		const newCode = `
+            pub contract Test {
+                pub fun count(a: Int, b: Int) {}
+                pub fun label(b: Int) {}
+                pub fun type(a: String) {}
+                pub fun result(a: Int): String { return "" }
+            }
+        `
+
+		_, err := testDeployAndUpgrade(t, "Test", oldCode, newCode)
+
+		errs := getContractUpgradeErrors(t, err, "Test")
+		require.Len(t, errs, 4)
+
+		assertContractUpgradeError(t, errs[0], ContractUpgradeErrorKindChangedFunction, "Test.count")
+		var parameterCountMismatchError *ParameterCountMismatchError
+		require.ErrorAs(t, errs[0].Err, &parameterCountMismatchError)
+		assert.Equal(t, 1, parameterCountMismatchError.Expected)
+		assert.Equal(t, 2, parameterCountMismatchError.Found)
+
+		assertContractUpgradeError(t, errs[1], ContractUpgradeErrorKindChangedFunction, "Test.label")
+		var argumentLabelMismatchError *ArgumentLabelMismatchError
+		require.ErrorAs(t, errs[1].Err, &argumentLabelMismatchError)
+		assert.Equal(t, "a", argumentLabelMismatchError.ExpectedLabel)
+		assert.Equal(t, "b", argumentLabelMismatchError.FoundLabel)
+
+		assertContractUpgradeError(t, errs[2], ContractUpgradeErrorKindChangedFunction, "Test.type")
+		var typeMismatchError *TypeMismatchError
+		require.ErrorAs(t, errs[2].Err, &typeMismatchError)
+
+		assertContractUpgradeError(t, errs[3], ContractUpgradeErrorKindChangedFunction, "Test.result")
+		require.ErrorAs(t, errs[3].Err, &typeMismatchError)
+	})
+
+	t.Run("remove conformance", func(t *testing.T) {
+
+		t.Parallel()
+
+		const oldCode = `
+            pub contract Test {
+
+                pub resource interface I {}
+
+                pub resource R: I {}
+            }
+        `
+
+		const newCode = `
+            pub contract Test {
+
+                pub resource interface I {}
+
+                pub resource R {}
+            }
+        `
+
+		_, err := testDeployAndUpgrade(t, "Test", oldCode, newCode)
+
+		errs := getContractUpgradeErrors(t, err, "Test")
+		require.Len(t, errs, 1)
+		assertContractUpgradeError(t, errs[0], ContractUpgradeErrorKindRemovedConformance, "Test.R")
+
+		var missingConformanceError *MissingConformanceError
+		require.ErrorAs(t, errs[0].Err, &missingConformanceError)
+		assert.Equal(t, "I", missingConformanceError.Conformance.String())
+	})
+
+	t.Run("remove exported declaration", func(t *testing.T) {
+
+		t.Parallel()
+
+		const oldCode = `
+            pub contract Test {
+
+                pub struct interface I {
+                    pub fun foo()
+                }
+
+                pub struct S {}
+            }
+        `
+
+		const newCode = `
+            pub contract Test {
+
+                pub struct interface I {
+                    pub fun foo(x: Int)
+                }
+            }
+        `
+
+		_, err := testDeployAndUpgrade(t, "Test", oldCode, newCode)
+
+		errs := getContractUpgradeErrors(t, err, "Test")
+		require.Len(t, errs, 2)
+		assertContractUpgradeError(t, errs[0], ContractUpgradeErrorKindChangedFunction, "Test.I.foo")
+		assertContractUpgradeError(t, errs[1], ContractUpgradeErrorKindRemovedDeclaration, "Test.S")
+	})
+
+	t.Run("invalid update", func(t *testing.T) {
+
+		t.Parallel()
+
+		const oldCode = `
+            pub contract Test {}
+        `
+
+		const newCode = `
+            pub contract Test {
+                pub var a: Int
+
+                init() {
+                    self.a = 0
+                }
+            }
+        `
+
+		_, err := testDeployAndUpgrade(t, "Test", oldCode, newCode)
+		require.Error(t, err)
+
+		var contractUpdateErr *ContractUpdateError
+		require.ErrorAs(t, err, &contractUpdateErr)
+		require.Len(t, contractUpdateErr.Errors, 1)
+
+		assertExtraneousFieldError(t, contractUpdateErr.Errors[0], "Test", "a")
+	})
+
+	t.Run("invalid code", func(t *testing.T) {
+
+		t.Parallel()
+
+		const oldCode = `
+            pub contract Test {}
+        `
+
+		const newCode = `
+            pub contract Test {
+                pub fun foo(): Int {}
+            }
+        `
+
+		code, err := testDeployAndUpgrade(t, "Test", oldCode, newCode)
+		require.Error(t, err)
+
+		assert.Equal(t, []byte(oldCode), code)
+
+		var parsingCheckingErr *ParsingCheckingError
+		require.ErrorAs(t, err, &parsingCheckingErr)
+	})
+
+	t.Run("name mismatch", func(t *testing.T) {
+
+		t.Parallel()
+
+		const oldCode = `
+            pub contract Test {}
+        `
+
+		const newCode = `
+            pub contract Other {}
+        `
+
+		_, err := testDeployAndUpgrade(t, "Test", oldCode, newCode)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "the name of the declaration must match the name of the contract")
+	})
+
+	t.Run("non-existing contract", func(t *testing.T) {
+
+		t.Parallel()
+
+		const newCode = `
+            pub contract Test {}
+        `
+
+		code, err := testDeployAndUpgrade(t, "Test", "", newCode)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "cannot upgrade non-existing contract")
+
+		assert.Nil(t, code)
+	})
+
+	t.Run("event and metering", func(t *testing.T) {
+
+		t.Parallel()
+
+		const oldCode = `
+            pub contract Test {}
+        `
+
+		const newCode = `
+            pub contract Test {
+                pub fun foo() {}
+            }
+        `
+
+		result := testDeployAndUpgradeWithResult(t, "Test", oldCode, newCode, true)
+		require.NoError(t, result.err)
+
+		assert.Equal(t, []byte(newCode), result.code)
+
+		require.Len(t, result.events, 1)
+		assert.Equal(t,
+			"flow.AccountContractUpdated",
+			result.events[0].EventType.ID(),
+		)
+
+		assert.Equal(t,
+			uint64(len(newCode)),
+			result.meter.getMemory(common.MemoryKindDeployedContract),
+		)
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+
+		t.Parallel()
+
+		const oldCode = `
+            pub contract Test {}
+        `
+
+		const newCode = `
+            pub contract Test {
+                pub fun foo() {}
+            }
+        `
+
+		result := testDeployAndUpgradeWithResult(t, "Test", oldCode, newCode, false)
+		require.Error(t, result.err)
+		require.Contains(t, result.err.Error(), "account did not authorize the upgrade")
+
+		assert.Equal(t, []byte(oldCode), result.code)
+		assert.Empty(t, result.events)
+	})
+}
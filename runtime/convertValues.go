@@ -453,11 +453,11 @@ func importValue(inter *interpreter.Interpreter, value cadence.Value, expectedTy
 	case cadence.String:
 		return interpreter.NewStringValue(string(v)), nil
 	case cadence.Character:
-		return interpreter.NewCharacterValue(string(v)), nil
+		return interpreter.NewCharacterValue(inter, string(v)), nil
 	case cadence.Bytes:
 		return interpreter.ByteSliceToByteArrayValue(inter, v), nil
 	case cadence.Address:
-		return interpreter.NewAddressValue(common.Address(v)), nil
+		return interpreter.NewAddressValue(inter, common.Address(v)), nil
 	case cadence.Int:
 		return interpreter.NewIntValueFromBigInt(v.Value), nil
 	case cadence.Int8:
@@ -116,3 +116,66 @@ func TestRuntimeCoverage(t *testing.T) {
 		string(actual),
 	)
 }
+
+func TestRuntimeContextCoverage(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	script := []byte(`
+      pub fun main(): Int {
+          var i = 0
+          while i < 3 {
+            i = i + 1
+          }
+          return i
+      }
+    `)
+
+	runtimeInterface := &testRuntimeInterface{}
+
+	nextTransactionLocation := newTransactionLocationGenerator()
+
+	coverageReport := NewCoverageReport()
+
+	// Only the execution which has the report in its context is recorded
+
+	for _, report := range []*CoverageReport{coverageReport, nil} {
+
+		value, err := runtime.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface:      runtimeInterface,
+				Location:       nextTransactionLocation(),
+				CoverageReport: report,
+			},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, cadence.NewInt(3), value)
+	}
+
+	actual, err := json.Marshal(coverageReport)
+	require.NoError(t, err)
+
+	require.JSONEq(t,
+		`
+        {
+          "coverage": {
+            "t.00": {
+              "line_hits": {
+                "3": 1,
+                "4": 1,
+                "5": 3,
+                "7": 1
+              }
+            }
+          }
+        }
+        `,
+		string(actual),
+	)
+}
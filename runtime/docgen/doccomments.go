@@ -0,0 +1,179 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package docgen provides support for generating documentation from Cadence programs.
+//
+package docgen
+
+import (
+	"strings"
+
+	"github.com/onflow/cadence/runtime/ast"
+)
+
+const docCommentPrefix = "///"
+const blockDocCommentStart = "/**"
+const blockCommentEnd = "*/"
+
+// DocComments returns the documentation comments of the declarations in the given program,
+// which was parsed from the given code.
+//
+// A documentation comment consists of the `///` line comments
+// on the lines immediately preceding a declaration,
+// or of a `/** ... */` block comment ending on the line immediately preceding it.
+// The `///` prefix, followed by an optional space, is stripped from each line.
+// In block comments, a leading `*`, followed by an optional space, is stripped from each line,
+// and leading and trailing blank lines are removed.
+// A blank line between the comments and the declaration breaks the association.
+//
+// Member declarations of composites, interfaces, and attachments, e.g. fields,
+// and the fields of transactions, are included.
+// Declarations without a documentation comment are omitted.
+//
+func DocComments(code string, program *ast.Program) map[ast.Declaration]string {
+	extractor := docCommentExtractor{
+		lines:       strings.Split(code, "\n"),
+		docComments: map[ast.Declaration]string{},
+	}
+
+	extractor.extractDeclarations(program.Declarations())
+
+	return extractor.docComments
+}
+
+type docCommentExtractor struct {
+	lines       []string
+	docComments map[ast.Declaration]string
+}
+
+func (e *docCommentExtractor) extractDeclarations(declarations []ast.Declaration) {
+	for _, declaration := range declarations {
+		e.extractDeclaration(declaration)
+	}
+}
+
+func (e *docCommentExtractor) extractDeclaration(declaration ast.Declaration) {
+	docComment, ok := e.docComment(declaration.StartPosition().Line)
+	if ok {
+		e.docComments[declaration] = docComment
+	}
+
+	switch declaration := declaration.(type) {
+	case *ast.CompositeDeclaration:
+		e.extractDeclarations(declaration.Members.Declarations())
+
+	case *ast.InterfaceDeclaration:
+		e.extractDeclarations(declaration.Members.Declarations())
+
+	case *ast.AttachmentDeclaration:
+		e.extractDeclarations(declaration.Members.Declarations())
+
+	case *ast.TransactionDeclaration:
+		for _, field := range declaration.Fields {
+			e.extractDeclaration(field)
+		}
+	}
+}
+
+// docComment returns the documentation comment
+// on the lines immediately preceding the given line, if any.
+// Lines are numbered starting at 1.
+//
+func (e *docCommentExtractor) docComment(line int) (string, bool) {
+	end := line - 1
+	if end > len(e.lines) {
+		return "", false
+	}
+
+	if end > 0 && strings.HasSuffix(strings.TrimSpace(e.lines[end-1]), blockCommentEnd) {
+		return e.blockDocComment(end)
+	}
+
+	start := end
+	for start > 0 {
+		previousLine := strings.TrimSpace(e.lines[start-1])
+		if !strings.HasPrefix(previousLine, docCommentPrefix) {
+			break
+		}
+		start--
+	}
+
+	if start == end {
+		return "", false
+	}
+
+	commentLines := make([]string, 0, end-start)
+	for _, line := range e.lines[start:end] {
+		commentLine := strings.TrimSpace(line)[len(docCommentPrefix):]
+		commentLine = strings.TrimPrefix(commentLine, " ")
+		commentLines = append(commentLines, commentLine)
+	}
+
+	return strings.Join(commentLines, "\n"), true
+}
+
+// blockDocComment returns the documentation comment of the block comment
+// which ends on the given line, if it is a documentation comment, i.e. it starts with `/**`.
+// Lines are numbered starting at 1.
+//
+func (e *docCommentExtractor) blockDocComment(end int) (string, bool) {
+
+	// Find the line on which the comment starts
+
+	start := end
+	for start > 0 {
+		if strings.Contains(e.lines[start-1], "/*") {
+			break
+		}
+		start--
+	}
+
+	if start == 0 {
+		return "", false
+	}
+
+	comment := strings.TrimSpace(strings.Join(e.lines[start-1:end], "\n"))
+	if !strings.HasPrefix(comment, blockDocCommentStart) ||
+		len(comment) < len(blockDocCommentStart)+len(blockCommentEnd) {
+
+		return "", false
+	}
+
+	comment = comment[len(blockDocCommentStart) : len(comment)-len(blockCommentEnd)]
+
+	commentLines := strings.Split(comment, "\n")
+	for i, line := range commentLines {
+		line = strings.TrimSpace(line)
+		line = strings.TrimPrefix(line, "*")
+		commentLines[i] = strings.TrimPrefix(line, " ")
+	}
+
+	for len(commentLines) > 0 && strings.TrimSpace(commentLines[0]) == "" {
+		commentLines = commentLines[1:]
+	}
+
+	for len(commentLines) > 0 && strings.TrimSpace(commentLines[len(commentLines)-1]) == "" {
+		commentLines = commentLines[:len(commentLines)-1]
+	}
+
+	if len(commentLines) == 0 {
+		return "", false
+	}
+
+	return strings.Join(commentLines, "\n"), true
+}
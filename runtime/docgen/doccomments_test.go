@@ -0,0 +1,263 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package docgen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/parser2"
+)
+
+func docCommentsByIdentifier(t *testing.T, code string) map[string]string {
+	program, err := parser2.ParseProgram(code)
+	require.NoError(t, err)
+
+	result := map[string]string{}
+	for declaration, docComment := range DocComments(code, program) {
+		identifier := declaration.DeclarationIdentifier()
+		require.NotNil(t, identifier)
+		result[identifier.Identifier] = docComment
+	}
+	return result
+}
+
+func TestDocComments(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("single line", func(t *testing.T) {
+
+		t.Parallel()
+
+		docComments := docCommentsByIdentifier(t, `
+          /// Returns the answer
+          pub fun answer(): Int {
+              return 42
+          }
+        `)
+
+		assert.Equal(t,
+			map[string]string{
+				"answer": "Returns the answer",
+			},
+			docComments,
+		)
+	})
+
+	t.Run("multiple lines", func(t *testing.T) {
+
+		t.Parallel()
+
+		docComments := docCommentsByIdentifier(t, `
+          /// Returns the answer
+          ///
+          ///   to everything
+          pub fun answer(): Int {
+              return 42
+          }
+        `)
+
+		assert.Equal(t,
+			map[string]string{
+				"answer": "Returns the answer\n\n  to everything",
+			},
+			docComments,
+		)
+	})
+
+	t.Run("no doc comment", func(t *testing.T) {
+
+		t.Parallel()
+
+		docComments := docCommentsByIdentifier(t, `
+          // Not a doc comment
+          pub fun answer(): Int {
+              return 42
+          }
+
+          pub let x = 1
+        `)
+
+		assert.Empty(t, docComments)
+	})
+
+	t.Run("blank lines", func(t *testing.T) {
+
+		t.Parallel()
+
+		docComments := docCommentsByIdentifier(t, `
+          /// Detached
+
+
+          pub fun answer(): Int {
+              return 42
+          }
+        `)
+
+		assert.Empty(t, docComments)
+	})
+
+	t.Run("block, single line", func(t *testing.T) {
+
+		t.Parallel()
+
+		docComments := docCommentsByIdentifier(t, `
+          /** Returns the answer */
+          pub fun answer(): Int {
+              return 42
+          }
+        `)
+
+		assert.Equal(t,
+			map[string]string{
+				"answer": "Returns the answer",
+			},
+			docComments,
+		)
+	})
+
+	t.Run("block, multiple lines", func(t *testing.T) {
+
+		t.Parallel()
+
+		docComments := docCommentsByIdentifier(t, `
+          /**
+           * Returns the answer
+           *
+           *   to everything
+           */
+          pub fun answer(): Int {
+              return 42
+          }
+        `)
+
+		assert.Equal(t,
+			map[string]string{
+				"answer": "Returns the answer\n\n  to everything",
+			},
+			docComments,
+		)
+	})
+
+	t.Run("block, empty", func(t *testing.T) {
+
+		t.Parallel()
+
+		docComments := docCommentsByIdentifier(t, `
+          /** */
+          pub fun answer(): Int {
+              return 42
+          }
+
+          /**/
+          pub let x = 1
+        `)
+
+		assert.Empty(t, docComments)
+	})
+
+	t.Run("block, no doc comment", func(t *testing.T) {
+
+		t.Parallel()
+
+		docComments := docCommentsByIdentifier(t, `
+          /* Not a doc comment */
+          pub fun answer(): Int {
+              return 42
+          }
+
+          pub let s = "/** Not a comment */"
+          pub let x = 1
+        `)
+
+		assert.Empty(t, docComments)
+	})
+
+	t.Run("members", func(t *testing.T) {
+
+		t.Parallel()
+
+		docComments := docCommentsByIdentifier(t, `
+          /// A resource
+          pub resource R {
+
+              /// The ID
+              pub let id: UInt64
+
+              /// Creates a new resource
+              init(id: UInt64) {
+                  self.id = id
+              }
+
+              /// A nested struct
+              pub struct S {
+                  /// The name
+                  pub var name: String
+
+                  init() {
+                      self.name = ""
+                  }
+              }
+          }
+        `)
+
+		assert.Equal(t,
+			map[string]string{
+				"R":    "A resource",
+				"id":   "The ID",
+				"init": "Creates a new resource",
+				"S":    "A nested struct",
+				"name": "The name",
+			},
+			docComments,
+		)
+	})
+
+	t.Run("declaration", func(t *testing.T) {
+
+		t.Parallel()
+
+		code := `
+          pub struct S {
+              /// The name
+              pub let name: String
+
+              init() {
+                  self.name = ""
+              }
+          }
+        `
+
+		program, err := parser2.ParseProgram(code)
+		require.NoError(t, err)
+
+		field := program.CompositeDeclarations()[0].Members.Fields()[0]
+
+		assert.Equal(t,
+			map[ast.Declaration]string{
+				field: "The name",
+			},
+			DocComments(code, program),
+		)
+	})
+}
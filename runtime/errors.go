@@ -74,6 +74,19 @@ func (e CallStackLimitExceededError) Error() string {
 	)
 }
 
+// GasExhaustedError
+
+type GasExhaustedError struct {
+	Limit uint64
+}
+
+func (e GasExhaustedError) Error() string {
+	return fmt.Sprintf(
+		"gas exhausted: limit of %d reached",
+		e.Limit,
+	)
+}
+
 // InvalidTransactionCountError
 
 type InvalidTransactionCountError struct {
@@ -323,6 +336,65 @@ func (e *ContractUpdateError) ImportLocation() common.Location {
 	return e.Location
 }
 
+// UpdateError is a violation of the rules for updates
+// of the exported declarations of a contract, reported by ValidateContractUpdate.
+type UpdateError interface {
+	error
+	ast.HasPosition
+	// DeclarationPath returns the qualified name of the affected declaration,
+	// e.g. `Test.Vault.balance`
+	DeclarationPath() string
+}
+
+// UpdateErrorKind is the kind of rule violation reported by an ExportedDeclarationUpdateError.
+type UpdateErrorKind uint
+
+const (
+	UpdateErrorKindUnknown UpdateErrorKind = iota
+	UpdateErrorKindRemovedField
+	UpdateErrorKindChangedFieldType
+	UpdateErrorKindRemovedFunction
+	UpdateErrorKindRestrictedAccess
+)
+
+// ExportedDeclarationUpdateError is reported during a contract update,
+// when an exported declaration of the existing program is removed,
+// its access is restricted, or the type of an exported field is changed.
+type ExportedDeclarationUpdateError struct {
+	Kind UpdateErrorKind
+	Path string
+	Err  error
+	ast.Range
+}
+
+var _ UpdateError = &ExportedDeclarationUpdateError{}
+
+func (e *ExportedDeclarationUpdateError) Error() string {
+	switch e.Kind {
+	case UpdateErrorKindRemovedField:
+		return fmt.Sprintf("removed exported field `%s`", e.Path)
+	case UpdateErrorKindChangedFieldType:
+		return fmt.Sprintf("changed type of exported field `%s`", e.Path)
+	case UpdateErrorKindRemovedFunction:
+		return fmt.Sprintf("removed exported function `%s`", e.Path)
+	case UpdateErrorKindRestrictedAccess:
+		return fmt.Sprintf("restricted access of exported declaration `%s`", e.Path)
+	default:
+		return fmt.Sprintf("invalid update of `%s`", e.Path)
+	}
+}
+
+func (e *ExportedDeclarationUpdateError) DeclarationPath() string {
+	return e.Path
+}
+
+func (e *ExportedDeclarationUpdateError) SecondaryError() string {
+	if e.Err == nil {
+		return ""
+	}
+	return e.Err.Error()
+}
+
 // FieldMismatchError is reported during a contract update, when a type of a field
 // does not match the existing type of the same field.
 type FieldMismatchError struct {
@@ -455,3 +527,100 @@ func (e *MissingDeclarationError) Error() string {
 		e.Name,
 	)
 }
+
+// Contract upgrade related errors
+
+// ContractUpgradeErrorKind is the kind of breaking change reported by a ContractUpgradeError.
+type ContractUpgradeErrorKind uint
+
+const (
+	ContractUpgradeErrorKindUnknown ContractUpgradeErrorKind = iota
+	ContractUpgradeErrorKindRemovedField
+	ContractUpgradeErrorKindChangedField
+	ContractUpgradeErrorKindRemovedFunction
+	ContractUpgradeErrorKindChangedFunction
+	ContractUpgradeErrorKindRemovedConformance
+	ContractUpgradeErrorKindRemovedDeclaration
+	ContractUpgradeErrorKindChangedAccess
+)
+
+// ContractUpgradeError is reported during a contract upgrade,
+// when an exported declaration of the existing program is removed or changed incompatibly.
+// The path is the qualified name of the affected declaration, e.g. `Test.Vault.balance`.
+type ContractUpgradeError struct {
+	Kind ContractUpgradeErrorKind
+	Path string
+	Err  error
+	ast.Range
+}
+
+func (e *ContractUpgradeError) Error() string {
+	switch e.Kind {
+	case ContractUpgradeErrorKindRemovedField:
+		return fmt.Sprintf("removed exported field `%s`", e.Path)
+	case ContractUpgradeErrorKindChangedField:
+		return fmt.Sprintf("changed type of exported field `%s`", e.Path)
+	case ContractUpgradeErrorKindRemovedFunction:
+		return fmt.Sprintf("removed exported function `%s`", e.Path)
+	case ContractUpgradeErrorKindChangedFunction:
+		return fmt.Sprintf("changed signature of exported function `%s`", e.Path)
+	case ContractUpgradeErrorKindRemovedConformance:
+		return fmt.Sprintf("removed conformance of `%s`", e.Path)
+	case ContractUpgradeErrorKindRemovedDeclaration:
+		return fmt.Sprintf("removed exported declaration `%s`", e.Path)
+	case ContractUpgradeErrorKindChangedAccess:
+		return fmt.Sprintf("restricted access of exported declaration `%s`", e.Path)
+	default:
+		return fmt.Sprintf("invalid upgrade of `%s`", e.Path)
+	}
+}
+
+func (e *ContractUpgradeError) SecondaryError() string {
+	if e.Err == nil {
+		return ""
+	}
+	return e.Err.Error()
+}
+
+// ParameterCountMismatchError is reported during a contract upgrade,
+// when the number of parameters of a function changed.
+type ParameterCountMismatchError struct {
+	Expected int
+	Found    int
+	ast.Range
+}
+
+func (e *ParameterCountMismatchError) Error() string {
+	return fmt.Sprintf(
+		"incompatible parameter count: expected %d, found %d",
+		e.Expected,
+		e.Found,
+	)
+}
+
+// ArgumentLabelMismatchError is reported during a contract upgrade,
+// when an argument label of a function changed.
+type ArgumentLabelMismatchError struct {
+	ExpectedLabel string
+	FoundLabel    string
+	ast.Range
+}
+
+func (e *ArgumentLabelMismatchError) Error() string {
+	return fmt.Sprintf(
+		"incompatible argument label: expected `%s`, found `%s`",
+		e.ExpectedLabel,
+		e.FoundLabel,
+	)
+}
+
+// MissingConformanceError is reported during a contract upgrade,
+// when an existing conformance of a composite declaration was removed.
+type MissingConformanceError struct {
+	Conformance *ast.NominalType
+	ast.Range
+}
+
+func (e *MissingConformanceError) Error() string {
+	return fmt.Sprintf("missing conformance to `%s`", e.Conformance)
+}
@@ -0,0 +1,295 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package exporter
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// MissingTypeError is returned when the type of a declaration is not available,
+// i.e. when the program was not checked by the given checker.
+//
+type MissingTypeError struct {
+	Identifier string
+	Kind       common.DeclarationKind
+}
+
+func (e *MissingTypeError) Error() string {
+	return fmt.Sprintf(
+		"missing type of %s `%s`",
+		e.Kind.Name(),
+		e.Identifier,
+	)
+}
+
+// ExportABI exports the application binary interface (ABI) of the given checked program,
+// i.e. its public declarations, without their implementations.
+//
+// The checker must be the checker which checked the program.
+//
+func ExportABI(program *ast.Program, checker *sema.Checker) (cadence.Program, error) {
+	exporter := &abiExporter{
+		elaboration: checker.Elaboration,
+		results:     map[sema.TypeID]cadence.Type{},
+	}
+
+	for _, declaration := range program.Declarations() {
+		err := exporter.exportDeclaration(declaration)
+		if err != nil {
+			return cadence.Program{}, err
+		}
+	}
+
+	return exporter.program, nil
+}
+
+type abiExporter struct {
+	elaboration *sema.Elaboration
+	results     map[sema.TypeID]cadence.Type
+	program     cadence.Program
+}
+
+func (e *abiExporter) exportDeclaration(declaration ast.Declaration) error {
+	if !isPublicAccess(declaration.DeclarationAccess()) {
+		return nil
+	}
+
+	switch declaration := declaration.(type) {
+	case *ast.VariableDeclaration:
+		return e.exportVariableDeclaration(declaration)
+
+	case *ast.FunctionDeclaration:
+		return e.exportFunctionDeclaration(declaration)
+
+	case *ast.CompositeDeclaration:
+		return e.exportCompositeDeclaration(declaration)
+
+	case *ast.InterfaceDeclaration:
+		return e.exportInterfaceDeclaration(declaration)
+	}
+
+	return nil
+}
+
+func (e *abiExporter) exportVariableDeclaration(declaration *ast.VariableDeclaration) error {
+	identifier := declaration.Identifier.Identifier
+
+	variable, ok := e.elaboration.GlobalValues.Get(identifier)
+	if !ok {
+		return missingTypeError(declaration)
+	}
+
+	e.program.Fields = append(
+		e.program.Fields,
+		cadence.Field{
+			Identifier: identifier,
+			Type:       runtime.ExportType(variable.Type, e.results),
+		},
+	)
+
+	return nil
+}
+
+func (e *abiExporter) exportFunctionDeclaration(declaration *ast.FunctionDeclaration) error {
+	functionType, ok := e.elaboration.FunctionDeclarationFunctionTypes[declaration]
+	if !ok {
+		return missingTypeError(declaration)
+	}
+
+	e.program.Functions = append(
+		e.program.Functions,
+		e.exportFunction(declaration.Identifier.Identifier, functionType),
+	)
+
+	return nil
+}
+
+func (e *abiExporter) exportCompositeDeclaration(declaration *ast.CompositeDeclaration) error {
+	compositeType, ok := e.elaboration.CompositeDeclarationTypes[declaration]
+	if !ok {
+		return missingTypeError(declaration)
+	}
+
+	if compositeType.Kind == common.CompositeKindEvent {
+		eventType, ok := runtime.ExportType(compositeType, e.results).(*cadence.EventType)
+		if !ok {
+			return missingTypeError(declaration)
+		}
+
+		e.program.Events = append(e.program.Events, eventType)
+
+		return nil
+	}
+
+	typeDeclaration := cadence.TypeDeclaration{
+		TypeID:                string(compositeType.ID()),
+		Kind:                  declaration.DeclarationKind(),
+		InitializerParameters: e.exportParameters(compositeType.ConstructorParameters),
+	}
+
+	err := e.exportMembers(&typeDeclaration, declaration.Members, compositeType.Members)
+	if err != nil {
+		return err
+	}
+
+	e.program.Types = append(e.program.Types, typeDeclaration)
+
+	return e.exportNestedDeclarations(declaration.Members)
+}
+
+func (e *abiExporter) exportInterfaceDeclaration(declaration *ast.InterfaceDeclaration) error {
+	interfaceType, ok := e.elaboration.InterfaceDeclarationTypes[declaration]
+	if !ok {
+		return missingTypeError(declaration)
+	}
+
+	typeDeclaration := cadence.TypeDeclaration{
+		TypeID: string(interfaceType.ID()),
+		Kind:   declaration.DeclarationKind(),
+	}
+
+	err := e.exportMembers(&typeDeclaration, declaration.Members, interfaceType.Members)
+	if err != nil {
+		return err
+	}
+
+	e.program.Types = append(e.program.Types, typeDeclaration)
+
+	return e.exportNestedDeclarations(declaration.Members)
+}
+
+// exportMembers exports the public fields and functions of a composite or interface declaration.
+// The types of the members are the types of the given members of the composite or interface type.
+//
+func (e *abiExporter) exportMembers(
+	typeDeclaration *cadence.TypeDeclaration,
+	members *ast.Members,
+	typeMembers *sema.StringMemberOrderedMap,
+) error {
+	for _, field := range members.Fields() {
+		if !isPublicAccess(field.Access) {
+			continue
+		}
+
+		identifier := field.Identifier.Identifier
+
+		member, ok := typeMembers.Get(identifier)
+		if !ok {
+			return missingTypeError(field)
+		}
+
+		typeDeclaration.Fields = append(
+			typeDeclaration.Fields,
+			cadence.Field{
+				Identifier: identifier,
+				Type:       runtime.ExportType(member.TypeAnnotation.Type, e.results),
+			},
+		)
+	}
+
+	for _, function := range members.Functions() {
+		if !isPublicAccess(function.Access) {
+			continue
+		}
+
+		identifier := function.Identifier.Identifier
+
+		member, ok := typeMembers.Get(identifier)
+		if !ok {
+			return missingTypeError(function)
+		}
+
+		functionType, ok := member.TypeAnnotation.Type.(*sema.FunctionType)
+		if !ok {
+			return missingTypeError(function)
+		}
+
+		typeDeclaration.Functions = append(
+			typeDeclaration.Functions,
+			e.exportFunction(identifier, functionType),
+		)
+	}
+
+	return nil
+}
+
+func (e *abiExporter) exportNestedDeclarations(members *ast.Members) error {
+	for _, declaration := range members.Declarations() {
+		switch declaration.(type) {
+		case *ast.CompositeDeclaration, *ast.InterfaceDeclaration:
+			err := e.exportDeclaration(declaration)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (e *abiExporter) exportFunction(identifier string, functionType *sema.FunctionType) cadence.Function {
+	return cadence.Function{
+		Identifier: identifier,
+		Type:       runtime.ExportType(functionType, e.results).(cadence.FunctionType),
+	}
+}
+
+func (e *abiExporter) exportParameters(parameters []*sema.Parameter) []cadence.Parameter {
+	if parameters == nil {
+		return nil
+	}
+
+	result := make([]cadence.Parameter, len(parameters))
+
+	for i, parameter := range parameters {
+		result[i] = cadence.Parameter{
+			Label:      parameter.Label,
+			Identifier: parameter.Identifier,
+			Type:       runtime.ExportType(parameter.TypeAnnotation.Type, e.results),
+		}
+	}
+
+	return result
+}
+
+func missingTypeError(declaration ast.Declaration) *MissingTypeError {
+	return &MissingTypeError{
+		Identifier: declaration.DeclarationIdentifier().Identifier,
+		Kind:       declaration.DeclarationKind(),
+	}
+}
+
+// isPublicAccess returns true if a declaration with the given access
+// is accessible from any program
+//
+func isPublicAccess(access ast.Access) bool {
+	switch access {
+	case ast.AccessPublic,
+		ast.AccessPublicSettable:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,245 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package exporter_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+	. "github.com/onflow/cadence/runtime/exporter"
+	"github.com/onflow/cadence/runtime/tests/checker"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func exportABI(t *testing.T, code string) cadence.Program {
+	checker, err := checker.ParseAndCheck(t, code)
+	require.NoError(t, err)
+
+	program, err := ExportABI(checker.Program, checker)
+	require.NoError(t, err)
+
+	return program
+}
+
+func TestExportABI(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("global declarations", func(t *testing.T) {
+
+		t.Parallel()
+
+		program := exportABI(t, `
+          pub let x: Int = 1
+
+          priv let y: Int = 2
+
+          pub fun add(_ a: Int, to b: Int): Int {
+              return helper(a) + b
+          }
+
+          priv fun helper(_ a: Int): Int {
+              return a
+          }
+        `)
+
+		utils.AssertEqualWithDiff(t,
+			cadence.Program{
+				Fields: []cadence.Field{
+					{
+						Identifier: "x",
+						Type:       cadence.IntType{},
+					},
+				},
+				Functions: []cadence.Function{
+					{
+						Identifier: "add",
+						Type: cadence.FunctionType{
+							Parameters: []cadence.Parameter{
+								{
+									Label:      "_",
+									Identifier: "a",
+									Type:       cadence.IntType{},
+								},
+								{
+									Label:      "to",
+									Identifier: "b",
+									Type:       cadence.IntType{},
+								},
+							},
+							ReturnType: cadence.IntType{},
+						}.WithID("((Int,Int):Int)"),
+					},
+				},
+			},
+			program,
+		)
+	})
+
+	t.Run("contract", func(t *testing.T) {
+
+		t.Parallel()
+
+		program := exportABI(t, `
+          pub contract Test {
+
+              pub event Deposited(amount: UInt64)
+
+              pub resource interface Provider {
+                  pub fun withdraw(amount: UInt64): @Vault
+              }
+
+              pub resource Vault: Provider {
+                  pub var balance: UInt64
+                  priv var history: [UInt64]
+
+                  init(balance: UInt64) {
+                      self.balance = balance
+                      self.history = []
+                  }
+
+                  pub fun withdraw(amount: UInt64): @Vault {
+                      self.balance = self.balance - amount
+                      self.record(amount)
+                      emit Deposited(amount: amount)
+                      return <-create Vault(balance: amount)
+                  }
+
+                  access(contract) fun record(_ amount: UInt64) {
+                      self.history.append(amount)
+                  }
+              }
+
+              pub let name: String
+
+              access(account) let owner: Address
+
+              init() {
+                  self.name = "test"
+                  self.owner = 0x1
+              }
+
+              pub fun createEmptyVault(): @Vault {
+                  return <-create Vault(balance: 0)
+              }
+
+              access(account) fun reset() {}
+          }
+        `)
+
+		vaultType := &cadence.ResourceType{
+			Location:            utils.TestLocation,
+			QualifiedIdentifier: "Test.Vault",
+		}
+		vaultType.Fields = []cadence.Field{
+			{
+				Identifier: "uuid",
+				Type:       cadence.UInt64Type{},
+			},
+			{
+				Identifier: "balance",
+				Type:       cadence.UInt64Type{},
+			},
+			{
+				Identifier: "history",
+				Type: cadence.VariableSizedArrayType{
+					ElementType: cadence.UInt64Type{},
+				},
+			},
+		}
+
+		withdrawFunction := cadence.Function{
+			Identifier: "withdraw",
+			Type: cadence.FunctionType{
+				Parameters: []cadence.Parameter{
+					{
+						Identifier: "amount",
+						Type:       cadence.UInt64Type{},
+					},
+				},
+				ReturnType: vaultType,
+			}.WithID("((UInt64):S.test.Test.Vault)"),
+		}
+
+		utils.AssertEqualWithDiff(t,
+			cadence.Program{
+				Types: []cadence.TypeDeclaration{
+					{
+						TypeID:                "S.test.Test",
+						Kind:                  common.DeclarationKindContract,
+						InitializerParameters: []cadence.Parameter{},
+						Fields: []cadence.Field{
+							{
+								Identifier: "name",
+								Type:       cadence.StringType{},
+							},
+						},
+						Functions: []cadence.Function{
+							{
+								Identifier: "createEmptyVault",
+								Type: cadence.FunctionType{
+									Parameters: []cadence.Parameter{},
+									ReturnType: vaultType,
+								}.WithID("(():S.test.Test.Vault)"),
+							},
+						},
+					},
+					{
+						TypeID:    "S.test.Test.Provider",
+						Kind:      common.DeclarationKindResourceInterface,
+						Functions: []cadence.Function{withdrawFunction},
+					},
+					{
+						TypeID: "S.test.Test.Vault",
+						Kind:   common.DeclarationKindResource,
+						InitializerParameters: []cadence.Parameter{
+							{
+								Identifier: "balance",
+								Type:       cadence.UInt64Type{},
+							},
+						},
+						Fields: []cadence.Field{
+							{
+								Identifier: "balance",
+								Type:       cadence.UInt64Type{},
+							},
+						},
+						Functions: []cadence.Function{withdrawFunction},
+					},
+				},
+				Events: []*cadence.EventType{
+					{
+						Location:            utils.TestLocation,
+						QualifiedIdentifier: "Test.Deposited",
+						Fields: []cadence.Field{
+							{
+								Identifier: "amount",
+								Type:       cadence.UInt64Type{},
+							},
+						},
+					},
+				},
+			},
+			program,
+		)
+	})
+}
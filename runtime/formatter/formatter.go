@@ -0,0 +1,74 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package formatter formats Cadence code in a canonical way.
+//
+// The formatter renders the AST, i.e. the code must be parsed first,
+// and comments which are not doc strings are not preserved.
+//
+package formatter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/turbolent/prettier"
+
+	"github.com/onflow/cadence/runtime/ast"
+)
+
+// MaxLineWidth is the line width the formatter attempts to stay within,
+// by breaking e.g. long parameter lists and expressions onto multiple lines
+//
+const MaxLineWidth = 80
+
+// Indentation is the string used to indent nested code by one level
+//
+const Indentation = "    "
+
+// Format returns the canonical source code of the given AST element,
+// e.g. a program, a declaration, a statement, or an expression.
+//
+// Programs are terminated by a newline.
+//
+func Format(element ast.Element) (string, error) {
+	hasDoc, ok := element.(interface{ Doc() prettier.Doc })
+	if !ok {
+		return "", fmt.Errorf("cannot format element: %T", element)
+	}
+
+	var builder strings.Builder
+
+	prettier.Prettier(&builder, hasDoc.Doc(), MaxLineWidth, Indentation)
+
+	// Blank lines inside of indented blocks contain the indentation,
+	// so remove all trailing whitespace
+
+	lines := strings.Split(builder.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+
+	result := strings.Join(lines, "\n")
+
+	if _, ok := element.(*ast.Program); ok && result != "" {
+		result += "\n"
+	}
+
+	return result, nil
+}
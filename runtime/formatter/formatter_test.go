@@ -0,0 +1,335 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package formatter
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/parser2"
+)
+
+func TestFormat(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("program", func(t *testing.T) {
+
+		t.Parallel()
+
+		program, err := parser2.ParseProgram(`
+          import   FungibleToken   from 0x1
+          /// The answer
+          pub   let   x :Int=  42
+          priv fun   add(_ a: Int,b :Int):Int{ return a+b }
+          access(account) resource R: I { pub(set) var y: Int
+            init() { self.y = 1 }
+          }
+        `)
+		require.NoError(t, err)
+
+		actual, err := Format(program)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			`import FungibleToken from 0x1
+
+/// The answer
+pub let x: Int = 42
+
+priv fun add(_ a: Int, b: Int): Int {
+    return a + b
+}
+
+access(account) resource R: I {
+    pub(set) var y: Int
+
+    init() {
+        self.y = 1
+    }
+}
+`,
+			actual,
+		)
+	})
+
+	t.Run("long parameter list", func(t *testing.T) {
+
+		t.Parallel()
+
+		program, err := parser2.ParseProgram(`
+          fun test(firstParameter: String, secondParameter: String, thirdParameter: String) {}
+        `)
+		require.NoError(t, err)
+
+		actual, err := Format(program)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			`fun test(
+    firstParameter: String,
+    secondParameter: String,
+    thirdParameter: String
+) {}
+`,
+			actual,
+		)
+	})
+
+	t.Run("parenthesized expressions", func(t *testing.T) {
+
+		t.Parallel()
+
+		expression, errs := parser2.ParseExpression(`(1 + 2) * -(3 - (4 - 5)) ?? (a ?? b) ?? c`)
+		require.Empty(t, errs)
+
+		actual, err := Format(expression)
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			`(1 + 2) * -(3 - (4 - 5)) ?? (a ?? b) ?? c`,
+			actual,
+		)
+	})
+}
+
+// positionsRemoved returns the given JSON value,
+// with all positions (objects with offset, line, and column) removed
+//
+func positionsRemoved(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		if len(value) == 3 {
+			_, hasOffset := value["Offset"]
+			_, hasLine := value["Line"]
+			_, hasColumn := value["Column"]
+			if hasOffset && hasLine && hasColumn {
+				return nil
+			}
+		}
+
+		result := make(map[string]interface{}, len(value))
+		for key, element := range value {
+			result[key] = positionsRemoved(element)
+		}
+		return result
+
+	case []interface{}:
+		result := make([]interface{}, len(value))
+		for i, element := range value {
+			result[i] = positionsRemoved(element)
+		}
+		return result
+
+	default:
+		return value
+	}
+}
+
+func programWithoutPositions(t *testing.T, program *ast.Program) interface{} {
+	encoded, err := json.Marshal(program)
+	require.NoError(t, err)
+
+	var decoded interface{}
+	err = json.Unmarshal(encoded, &decoded)
+	require.NoError(t, err)
+
+	return positionsRemoved(decoded)
+}
+
+func TestFormatRoundTrip(t *testing.T) {
+
+	t.Parallel()
+
+	codes := map[string]string{
+		"imports": `
+          import "test"
+          import 0x1
+          import A, B from 0x0000000000000002
+          import C
+        `,
+		"pragmas": `
+          #allowAccountLinking
+          #version("1.0")
+        `,
+		"variables": `
+          pub let a: Int? = nil
+          var b <- create R()
+          let c = [1, 2, 3] as [Int]
+          let d: {String: Int} = {"a": 1, "b": 2}
+          let e = (1 + 2) * 3 - 4 / 5 % 6
+          let f = 1 < 2 && 2 > 1 || !true
+          let g = a ?? b ?? c
+          let h = (a ?? b) ?? c
+          let i = (a || b) || c
+          let j = 1 - (2 - 3)
+          let k = x ? y : z ? 1 : 2
+          let l = (x ? y : z) ? 1 : 2
+          let m = &x as &Int
+          let n = (&x as &Int).y
+          let o = x.y?.z![0](1, label: 2)
+          let p = (-1).foo
+          let q = /storage/test
+          let r = 0b101 | 0xff & 0o7 ^ 1 << 2 >> 3
+          let s = "escaped \"quotes\"\n"
+          let t = fun (a: Int): Int { return a }
+          let u = 1.25 as? UFix64
+          let v = x as! @R
+          let w = f<Int>(1)
+        `,
+		"functions": `
+          /// Adds two numbers
+          ///
+          /// Returns the sum
+          pub fun add(_ a: Int, to b: Int): Int {
+              pre {
+                  a > 0: "a must be positive"
+                  b > 0
+              }
+              post {
+                  result > 0
+              }
+              let x = a
+              var y = b
+              y = y + 1
+              x <-> y
+              if let z = x {
+                  return z
+              } else if x > 1 {
+                  return 1
+              } else {
+                  while true {
+                      break
+                  }
+                  for i in [1, 2] {
+                      continue
+                  }
+              }
+              switch x {
+              case 1:
+                  return 1
+              default:
+                  return 2
+              }
+              destroy r
+              emit E(x: 1)
+              fun nested() {}
+              return x + y
+          }
+        `,
+		"composites": `
+          pub contract C {
+
+              pub event E(a: Int, b: String)
+
+              pub enum Color: UInt8 {
+                  pub case red
+                  pub case green
+              }
+
+              pub resource interface I {
+                  pub fun foo(): Int
+                  pub fun bar() {
+                      pre { true }
+                  }
+              }
+
+              pub resource R: I, J {
+                  pub let x: Int
+                  access(contract) var y: @R?
+
+                  init(x: Int) {
+                      self.x = x
+                      self.y <- nil
+                  }
+
+                  pub fun foo(): Int {
+                      return self.x
+                  }
+
+                  destroy() {
+                      destroy self.y
+                  }
+              }
+
+              pub struct S {}
+          }
+        `,
+		"transaction": `
+          transaction(a: Int) {
+              let x: Int
+              let y: Int
+
+              prepare(signer: AuthAccount) {
+                  self.x = a
+              }
+
+              pre {
+                  a > 0
+              }
+
+              execute {
+                  log(self.x)
+              }
+
+              post {
+                  true
+              }
+          }
+        `,
+	}
+
+	for name, code := range codes {
+
+		// capture loop variables
+		name := name
+		code := code
+
+		t.Run(name, func(t *testing.T) {
+
+			t.Parallel()
+
+			program, err := parser2.ParseProgram(code)
+			require.NoError(t, err)
+
+			formatted, err := Format(program)
+			require.NoError(t, err)
+
+			reparsedProgram, err := parser2.ParseProgram(formatted)
+			require.NoError(t, err, formatted)
+
+			assert.Equal(t,
+				programWithoutPositions(t, program),
+				programWithoutPositions(t, reparsedProgram),
+				formatted,
+			)
+
+			// Formatting is idempotent
+
+			reformatted, err := Format(reparsedProgram)
+			require.NoError(t, err)
+
+			assert.Equal(t, formatted, reformatted)
+		})
+	}
+}
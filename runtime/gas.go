@@ -0,0 +1,88 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// GasTable configures the gas costs of operations and the gas limit of an execution.
+//
+// The costs are deducted from the limit while the program is interpreted.
+// When the limit is exhausted, execution halts with a GasExhaustedError.
+//
+type GasTable struct {
+	StatementCost     uint64
+	LoopIterationCost uint64
+	FunctionCallCost  uint64
+	StorageReadCost   uint64
+	StorageWriteCost  uint64
+	GasLimit          uint64
+}
+
+// Cost returns the cost of a single operation of the given kind.
+// Operations without a configurable cost are free.
+//
+func (t *GasTable) Cost(kind common.ComputationKind) uint64 {
+	switch kind {
+	case common.ComputationKindStatement:
+		return t.StatementCost
+	case common.ComputationKindLoop:
+		return t.LoopIterationCost
+	case common.ComputationKindFunctionInvocation:
+		return t.FunctionCallCost
+	case common.ComputationKindStorageRead:
+		return t.StorageReadCost
+	case common.ComputationKindStorageWrite:
+		return t.StorageWriteCost
+	default:
+		return 0
+	}
+}
+
+// gasMeter deducts the costs of operations from the gas limit of a gas table
+//
+type gasMeter struct {
+	table *GasTable
+	used  uint64
+}
+
+func newGasMeter(table *GasTable) *gasMeter {
+	if table == nil {
+		return nil
+	}
+	return &gasMeter{
+		table: table,
+	}
+}
+
+// consume deducts the cost of the given operation from the gas limit.
+// It panics with a GasExhaustedError if the cost exceeds the remaining gas.
+//
+func (m *gasMeter) consume(kind common.ComputationKind, intensity uint) {
+	cost := m.table.Cost(kind) * uint64(intensity)
+	remaining := m.table.GasLimit - m.used
+	if cost > remaining {
+		m.used = m.table.GasLimit
+		panic(GasExhaustedError{
+			Limit: m.table.GasLimit,
+		})
+	}
+	m.used += cost
+}
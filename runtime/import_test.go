@@ -23,6 +23,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/cadence/runtime/common"
@@ -112,6 +113,59 @@ func TestRuntimeCyclicImport(t *testing.T) {
 	errs = checker.ExpectCheckerErrors(t, checkerErr3, 1)
 
 	require.IsType(t, &sema.CyclicImportsError{}, errs[0])
+
+	assert.Equal(t,
+		[]common.Location{
+			common.IdentifierLocation("p1"),
+			common.IdentifierLocation("p2"),
+			common.IdentifierLocation("p1"),
+		},
+		errs[0].(*sema.CyclicImportsError).Cycle,
+	)
+}
+
+func TestRuntimeCyclicImportOfThreeLocations(t *testing.T) {
+
+	t.Parallel()
+
+	runtime := newTestInterpreterRuntime()
+
+	codes := map[common.Location][]byte{
+		common.IdentifierLocation("p1"): []byte(`import p2`),
+		common.IdentifierLocation("p2"): []byte(`import p3`),
+		common.IdentifierLocation("p3"): []byte(`import p1`),
+	}
+
+	script := []byte(`
+      import p1
+
+      pub fun main() {}
+    `)
+
+	runtimeInterface := &testRuntimeInterface{
+		getCode: func(location Location) (bytes []byte, err error) {
+			code, ok := codes[location]
+			if !ok {
+				return nil, fmt.Errorf("unknown import location: %s", location)
+			}
+			return code, nil
+		},
+	}
+
+	nextTransactionLocation := newTransactionLocationGenerator()
+
+	_, err := runtime.ExecuteScript(
+		Script{
+			Source: script,
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  nextTransactionLocation(),
+		},
+	)
+	require.Error(t, err)
+
+	require.Contains(t, err.Error(), "cyclic import of `p1`: `p1` -> `p2` -> `p3` -> `p1`")
 }
 
 func TestRuntimeExport(t *testing.T) {
@@ -87,6 +87,9 @@ type Interface interface {
 	// MeterComputation is a callback method for metering computation, it returns error
 	// when computation passes the limit (set by the environment)
 	MeterComputation(operationType common.ComputationKind, intensity uint) error
+	// MeterMemory is a callback method for metering memory, it returns error
+	// when memory usage passes the limit (set by the environment)
+	MeterMemory(usage common.MemoryUsage) error
 	// DecodeArgument decodes a transaction argument against the given type.
 	DecodeArgument(argument []byte, argumentType cadence.Type) (cadence.Value, error)
 	// GetCurrentBlockHeight returns the current block height.
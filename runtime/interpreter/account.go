@@ -21,6 +21,7 @@ package interpreter
 import (
 	"fmt"
 
+	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/sema"
 )
 
@@ -37,8 +38,14 @@ var authAccountFieldNames = []string{
 	sema.AuthAccountKeysField,
 }
 
+var authAccountValueMemoryUsage = common.MemoryUsage{
+	Kind:   common.MemoryKindAuthAccountValue,
+	Amount: 1,
+}
+
 // NewAuthAccountValue constructs an auth account value.
 func NewAuthAccountValue(
+	interpreter *Interpreter,
 	address AddressValue,
 	accountBalanceGet func() UFix64Value,
 	accountAvailableBalanceGet func() UFix64Value,
@@ -50,6 +57,8 @@ func NewAuthAccountValue(
 	keysConstructor func() Value,
 ) Value {
 
+	interpreter.UseMemory(authAccountValueMemoryUsage)
+
 	fields := map[string]Value{
 		sema.AuthAccountAddressField:         address,
 		sema.AuthAccountAddPublicKeyField:    addPublicKeyFunction,
@@ -148,8 +157,14 @@ var publicAccountFieldNames = []string{
 	sema.PublicAccountKeysField,
 }
 
+var publicAccountValueMemoryUsage = common.MemoryUsage{
+	Kind:   common.MemoryKindPublicAccountValue,
+	Amount: 1,
+}
+
 // NewPublicAccountValue constructs a public account value.
 func NewPublicAccountValue(
+	interpreter *Interpreter,
 	address AddressValue,
 	accountBalanceGet func() UFix64Value,
 	accountAvailableBalanceGet func() UFix64Value,
@@ -159,6 +174,8 @@ func NewPublicAccountValue(
 	contractsConstructor func() Value,
 ) Value {
 
+	interpreter.UseMemory(publicAccountValueMemoryUsage)
+
 	fields := map[string]Value{
 		sema.PublicAccountAddressField: address,
 		sema.PublicAccountGetCapabilityField: accountGetCapabilityFunction(
@@ -259,7 +259,7 @@ func (d Decoder) decodeCharacter(v string) (CharacterValue, error) {
 			v,
 		)
 	}
-	return NewCharacterValue(v), nil
+	return CharacterValue(v), nil
 }
 
 func decodeLocation(dec *cbor.StreamDecoder) (common.Location, error) {
@@ -237,6 +237,20 @@ func (e ForceNilError) Error() string {
 	return "unexpectedly found nil while forcing an Optional value"
 }
 
+// NativeFunctionNotImplementedError
+//
+type NativeFunctionNotImplementedError struct {
+	Name string
+	LocationRange
+}
+
+func (e NativeFunctionNotImplementedError) Error() string {
+	return fmt.Sprintf(
+		"native function `%s` is not implemented",
+		e.Name,
+	)
+}
+
 // ForceCastTypeMismatchError
 //
 type ForceCastTypeMismatchError struct {
@@ -24,6 +24,7 @@ import (
 	"github.com/onflow/atree"
 
 	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/errors"
 	"github.com/onflow/cadence/runtime/sema"
 )
@@ -65,6 +66,63 @@ type InterpretedFunctionValue struct {
 
 var _ Value = &InterpretedFunctionValue{}
 
+var functionMemoryUsage = common.MemoryUsage{
+	Kind:   common.MemoryKindFunction,
+	Amount: 1,
+}
+
+// newClosureMemoryUsage returns the memory usage of the environment
+// captured by a function value which is declared in the given lexical scope.
+//
+// Each variable captured from enclosing functions adds 1.
+// Variables declared outside of functions, e.g. globals, are not captured.
+//
+// Closures declared in the same activation share the captured environment,
+// so each activation is only metered when it is captured for the first time,
+// and the activations are marked as captured.
+//
+func newClosureMemoryUsage(lexicalScope *VariableActivation) common.MemoryUsage {
+	var outermostFunction *VariableActivation
+
+	for current := lexicalScope; current != nil; current = current.Parent {
+		if current.isFunction {
+			outermostFunction = current
+		}
+	}
+
+	var capturedVariables uint64
+
+	if outermostFunction != nil {
+		for current := lexicalScope; ; current = current.Parent {
+			if !current.isCaptured {
+				capturedVariables += uint64(len(current.entries))
+				current.isCaptured = true
+			}
+
+			if current == outermostFunction {
+				break
+			}
+		}
+	}
+
+	return common.MemoryUsage{
+		Kind:   common.MemoryKindClosure,
+		Amount: capturedVariables,
+	}
+}
+
+// meterFunction meters the memory used by a function value
+// which is declared in the given lexical scope
+//
+func (interpreter *Interpreter) meterFunction(lexicalScope *VariableActivation) {
+	interpreter.UseMemory(functionMemoryUsage)
+
+	closureMemoryUsage := newClosureMemoryUsage(lexicalScope)
+	if closureMemoryUsage.Amount > 0 {
+		interpreter.UseMemory(closureMemoryUsage)
+	}
+}
+
 func (*InterpretedFunctionValue) IsValue() {}
 
 func (f *InterpretedFunctionValue) String() string {
@@ -296,6 +354,26 @@ type BoundFunctionValue struct {
 
 var _ Value = BoundFunctionValue{}
 
+var boundFunctionMemoryUsage = common.MemoryUsage{
+	Kind:   common.MemoryKindBoundMethod,
+	Amount: 1,
+}
+
+// NewBoundFunctionValue returns the given function bound to the given composite value,
+// and meters the memory used by the bound function value
+//
+func NewBoundFunctionValue(
+	interpreter *Interpreter,
+	function FunctionValue,
+	self *CompositeValue,
+) BoundFunctionValue {
+	interpreter.UseMemory(boundFunctionMemoryUsage)
+	return BoundFunctionValue{
+		Function: function,
+		Self:     self,
+	}
+}
+
 func (BoundFunctionValue) IsValue() {}
 
 func (f BoundFunctionValue) String() string {
@@ -141,6 +141,9 @@ type OnMeterComputationFunc func(
 	intensity uint,
 )
 
+// OnMeterMemoryFunc is a function that is called when some memory is about to be used.
+type OnMeterMemoryFunc func(usage common.MemoryUsage)
+
 // InjectedCompositeFieldsHandlerFunc is a function that handles storage reads.
 //
 type InjectedCompositeFieldsHandlerFunc func(
@@ -339,6 +342,7 @@ type Interpreter struct {
 	onRecordTrace                  OnRecordTraceFunc
 	onResourceOwnerChange          OnResourceOwnerChangeFunc
 	onMeterComputation             OnMeterComputationFunc
+	onMeterMemory                  OnMeterMemoryFunc
 	injectedCompositeFieldsHandler InjectedCompositeFieldsHandlerFunc
 	contractValueHandler           ContractValueHandlerFunc
 	importLocationHandler          ImportLocationHandlerFunc
@@ -445,6 +449,16 @@ func WithOnMeterComputationFuncHandler(handler OnMeterComputationFunc) Option {
 	}
 }
 
+// WithOnMeterMemoryFuncHandler returns an interpreter option which sets
+// the given function as the meter memory handler.
+//
+func WithOnMeterMemoryFuncHandler(handler OnMeterMemoryFunc) Option {
+	return func(interpreter *Interpreter) error {
+		interpreter.SetOnMeterMemoryHandler(handler)
+		return nil
+	}
+}
+
 // WithPredeclaredValues returns an interpreter option which declares
 // the given the predeclared values.
 //
@@ -760,6 +774,12 @@ func (interpreter *Interpreter) SetOnMeterComputationHandler(function OnMeterCom
 	interpreter.onMeterComputation = function
 }
 
+// SetOnMeterMemoryHandler sets the function that is triggered when some memory is about to be used.
+//
+func (interpreter *Interpreter) SetOnMeterMemoryHandler(function OnMeterMemoryFunc) {
+	interpreter.onMeterMemory = function
+}
+
 // SetStorage sets the value that is used for storage operations.
 func (interpreter *Interpreter) SetStorage(storage Storage) {
 	interpreter.Storage = storage
@@ -1245,17 +1265,46 @@ func (interpreter *Interpreter) VisitFunctionDeclaration(declaration *ast.Functi
 	// make the function itself available inside the function
 	lexicalScope.Set(identifier, variable)
 
-	variable.SetValue(
-		interpreter.functionDeclarationValue(
+	var value FunctionValue
+	if declaration.IsNative {
+		value = interpreter.nativeFunctionValue(declaration, functionType)
+	} else {
+		value = interpreter.functionDeclarationValue(
 			declaration,
 			functionType,
 			lexicalScope,
-		),
-	)
+		)
+	}
+
+	variable.SetValue(value)
 
 	return nil
 }
 
+// nativeFunctionValue returns the function value for a native function declaration.
+//
+// Native functions are declared in Cadence source so their signatures are type checked,
+// but there is no implementation which can be interpreted,
+// so invoking the function value fails.
+//
+func (interpreter *Interpreter) nativeFunctionValue(
+	declaration *ast.FunctionDeclaration,
+	functionType *sema.FunctionType,
+) *HostFunctionValue {
+
+	name := declaration.Identifier.Identifier
+
+	return NewHostFunctionValue(
+		func(invocation Invocation) Value {
+			panic(NativeFunctionNotImplementedError{
+				Name:          name,
+				LocationRange: invocation.GetLocationRange(),
+			})
+		},
+		functionType,
+	)
+}
+
 func (interpreter *Interpreter) functionDeclarationValue(
 	declaration *ast.FunctionDeclaration,
 	functionType *sema.FunctionType,
@@ -1278,6 +1327,8 @@ func (interpreter *Interpreter) functionDeclarationValue(
 		beforeStatements = postConditionsRewrite.BeforeStatements
 	}
 
+	interpreter.meterFunction(lexicalScope)
+
 	return &InterpretedFunctionValue{
 		Interpreter:      interpreter,
 		ParameterList:    declaration.ParameterList,
@@ -1329,10 +1380,10 @@ func (interpreter *Interpreter) visitFunctionBody(
 		if ret, ok := result.(functionReturn); ok {
 			returnValue = ret.Value
 		} else {
-			returnValue = VoidValue{}
+			returnValue = NewVoidValue(interpreter)
 		}
 	} else {
-		returnValue = VoidValue{}
+		returnValue = NewVoidValue(interpreter)
 	}
 
 	// If there is a return type, declare the constant `result`.
@@ -1342,10 +1393,12 @@ func (interpreter *Interpreter) visitFunctionBody(
 	if returnType != sema.VoidType {
 		var resultValue Value
 		if returnType.IsResourceType() {
-			resultValue = &EphemeralReferenceValue{
-				Value:        returnValue,
-				BorrowedType: returnType,
-			}
+			resultValue = NewEphemeralReferenceValue(
+				interpreter,
+				false,
+				returnValue,
+				returnType,
+			)
 		} else {
 			resultValue = returnValue
 		}
@@ -1877,10 +1930,10 @@ func EnumConstructorFunction(
 
 			caseValue, ok := lookupTable[string(rawValueArgumentBigEndianBytes)]
 			if !ok {
-				return NilValue{}
+				return NewNilValue(invocation.Interpreter)
 			}
 
-			return NewSomeValueNonCopying(caseValue)
+			return NewSomeValue(invocation.Interpreter, caseValue)
 		},
 		sema.EnumConstructorType(enumType),
 	)
@@ -1990,6 +2043,13 @@ func (interpreter *Interpreter) compositeFunctions(
 
 	for _, functionDeclaration := range compositeDeclaration.Members.Functions() {
 		name := functionDeclaration.Identifier.Identifier
+
+		if functionDeclaration.IsNative {
+			functionType := interpreter.Program.Elaboration.FunctionDeclarationFunctionTypes[functionDeclaration]
+			functions[name] = interpreter.nativeFunctionValue(functionDeclaration, functionType)
+			continue
+		}
+
 		functions[name] =
 			interpreter.compositeFunction(
 				functionDeclaration,
@@ -2076,6 +2136,16 @@ func (interpreter *Interpreter) VisitEnumCaseDeclaration(_ *ast.EnumCaseDeclarat
 	panic(errors.NewUnreachableError())
 }
 
+func (interpreter *Interpreter) VisitAttachmentDeclaration(_ *ast.AttachmentDeclaration) ast.Repr {
+	// attachments are rejected by the checker
+	panic(errors.NewUnreachableError())
+}
+
+func (interpreter *Interpreter) VisitTypeAliasDeclaration(_ *ast.TypeAliasDeclaration) ast.Repr {
+	// NO-OP: type aliases are resolved by the checker
+	return nil
+}
+
 func (interpreter *Interpreter) checkValueTransferTargetType(value Value, targetType sema.Type) bool {
 
 	if targetType == nil {
@@ -2305,7 +2375,7 @@ func (interpreter *Interpreter) BoxOptional(
 			return inner
 
 		default:
-			value = NewSomeValueNonCopying(value)
+			value = NewSomeValue(interpreter, value)
 		}
 
 		targetType = optionalType.Type
@@ -2647,6 +2717,7 @@ func (interpreter *Interpreter) NewSubInterpreter(
 		WithOnRecordTraceHandler(interpreter.onRecordTrace),
 		WithOnResourceOwnerChangeHandler(interpreter.onResourceOwnerChange),
 		WithOnMeterComputationFuncHandler(interpreter.onMeterComputation),
+		WithOnMeterMemoryFuncHandler(interpreter.onMeterMemory),
 	}
 
 	return NewInterpreter(
@@ -2673,6 +2744,8 @@ func (interpreter *Interpreter) ReadStored(
 	domain string,
 	identifier string,
 ) Value {
+	interpreter.ReportComputation(common.ComputationKindStorageRead, 1)
+
 	accountStorage := interpreter.Storage.GetStorageMap(storageAddress, domain)
 	return accountStorage.ReadValue(identifier)
 }
@@ -2683,16 +2756,47 @@ func (interpreter *Interpreter) writeStored(
 	identifier string,
 	value Value,
 ) {
+	interpreter.ReportComputation(common.ComputationKindStorageWrite, 1)
+
 	accountStorage := interpreter.Storage.GetStorageMap(storageAddress, domain)
 	accountStorage.WriteValue(interpreter, identifier, value)
 }
 
+// meterAccountStorage meters the memory used by reading or writing
+// the given stored value from or to account storage,
+// proportional to the encoded size of the value, as known to the storage
+//
+func (interpreter *Interpreter) meterAccountStorage(kind common.MemoryKind, value Value) {
+	if interpreter.onMeterMemory == nil {
+		return
+	}
+
+	interpreter.UseMemory(common.MemoryUsage{
+		Kind:   kind,
+		Amount: storedValueSize(interpreter.Storage, value),
+	})
+}
+
+// meterStoragePath meters the memory used by the path of an account storage operation.
+// Storage paths are persisted, so they are metered at a higher cost
+// than public and private paths
+//
+func (interpreter *Interpreter) meterStoragePath(path PathValue) {
+	if interpreter.onMeterMemory == nil {
+		return
+	}
+
+	interpreter.UseMemory(newStoragePathMemoryUsage(path))
+}
+
 type ValueConverterDeclaration struct {
 	name         string
 	convert      func(Value) Value
 	min          Value
 	max          Value
 	functionType *sema.FunctionType
+	// memoryUsage is the memory usage of the converted value, if it is metered
+	memoryUsage *common.MemoryUsage
 }
 
 // It would be nice if return types in Go's function types would be covariant
@@ -2827,8 +2931,9 @@ var ConverterDeclarations = []ValueConverterDeclaration{
 		convert: func(value Value) Value {
 			return ConvertWord8(value)
 		},
-		min: Word8Value(0),
-		max: Word8Value(math.MaxUint8),
+		memoryUsage: &word8MemoryUsage,
+		min:         Word8Value(0),
+		max:         Word8Value(math.MaxUint8),
 	},
 	{
 		name:         sema.Word16TypeName,
@@ -2836,8 +2941,9 @@ var ConverterDeclarations = []ValueConverterDeclaration{
 		convert: func(value Value) Value {
 			return ConvertWord16(value)
 		},
-		min: Word16Value(0),
-		max: Word16Value(math.MaxUint16),
+		memoryUsage: &word16MemoryUsage,
+		min:         Word16Value(0),
+		max:         Word16Value(math.MaxUint16),
 	},
 	{
 		name:         sema.Word32TypeName,
@@ -2845,8 +2951,9 @@ var ConverterDeclarations = []ValueConverterDeclaration{
 		convert: func(value Value) Value {
 			return ConvertWord32(value)
 		},
-		min: Word32Value(0),
-		max: Word32Value(math.MaxUint32),
+		memoryUsage: &word32MemoryUsage,
+		min:         Word32Value(0),
+		max:         Word32Value(math.MaxUint32),
 	},
 	{
 		name:         sema.Word64TypeName,
@@ -2854,8 +2961,9 @@ var ConverterDeclarations = []ValueConverterDeclaration{
 		convert: func(value Value) Value {
 			return ConvertWord64(value)
 		},
-		min: Word64Value(0),
-		max: Word64Value(math.MaxUint64),
+		memoryUsage: &word64MemoryUsage,
+		min:         Word64Value(0),
+		max:         Word64Value(math.MaxUint64),
 	},
 	{
 		name:         sema.Fix64TypeName,
@@ -2881,6 +2989,7 @@ var ConverterDeclarations = []ValueConverterDeclaration{
 		convert: func(value Value) Value {
 			return ConvertAddress(value)
 		},
+		memoryUsage: &addressValueMemoryUsage,
 	},
 	{
 		name:         sema.PublicPathType.Name,
@@ -2976,14 +3085,16 @@ func init() {
 				// if the given key is not a valid dictionary key, it wouldn't make sense to create this type
 				if keyType == nil ||
 					!sema.IsValidDictionaryKeyType(invocation.Interpreter.MustConvertStaticToSemaType(keyType)) {
-					return NilValue{}
+					return NewNilValue(invocation.Interpreter)
 				}
 
-				return NewSomeValueNonCopying(TypeValue{
-					Type: DictionaryStaticType{
+				return NewSomeValue(invocation.Interpreter, NewTypeValue(
+					invocation.Interpreter,
+					DictionaryStaticType{
 						KeyType:   keyType,
 						ValueType: valueType,
-					}})
+					},
+				))
 			},
 			sema.DictionaryTypeFunctionType,
 		))
@@ -3001,12 +3112,13 @@ func init() {
 
 				composite, err := lookupComposite(invocation.Interpreter, typeID)
 				if err != nil {
-					return NilValue{}
+					return NewNilValue(invocation.Interpreter)
 				}
 
-				return NewSomeValueNonCopying(TypeValue{
-					Type: ConvertSemaToStaticType(composite),
-				})
+				return NewSomeValue(invocation.Interpreter, NewTypeValue(
+					invocation.Interpreter,
+					ConvertSemaToStaticType(composite),
+				))
 			},
 			sema.CompositeTypeFunctionType,
 		),
@@ -3025,12 +3137,13 @@ func init() {
 
 				interfaceType, err := lookupInterface(invocation.Interpreter, typeID)
 				if err != nil {
-					return NilValue{}
+					return NewNilValue(invocation.Interpreter)
 				}
 
-				return NewSomeValueNonCopying(TypeValue{
-					Type: ConvertSemaToStaticType(interfaceType),
-				})
+				return NewSomeValue(invocation.Interpreter, NewTypeValue(
+					invocation.Interpreter,
+					ConvertSemaToStaticType(interfaceType),
+				))
 			},
 			sema.InterfaceTypeFunctionType,
 		),
@@ -3065,13 +3178,15 @@ func init() {
 					// Continue iteration
 					return true
 				})
-				return TypeValue{
-					Type: FunctionStaticType{
+				return NewTypeValue(
+					invocation.Interpreter,
+					FunctionStaticType{
 						Type: &sema.FunctionType{
 							ReturnTypeAnnotation: sema.NewTypeAnnotation(returnType),
 							Parameters:           parameterTypes,
 						},
-					}}
+					},
+				)
 			},
 			sema.FunctionTypeFunctionType,
 		),
@@ -3124,7 +3239,7 @@ func RestrictedTypeFunction(invocation Invocation) Value {
 	// If there are any invalid restrictions,
 	// then return nil
 	if invalidRestrictionID {
-		return NilValue{}
+		return NewNilValue(invocation.Interpreter)
 	}
 
 	var semaType sema.Type
@@ -3137,7 +3252,7 @@ func RestrictedTypeFunction(invocation Invocation) Value {
 		innerValue := typeID.InnerValue(interpreter, invocation.GetLocationRange)
 		semaType, err = lookupComposite(interpreter, innerValue.(*StringValue).Str)
 		if err != nil {
-			return NilValue{}
+			return NewNilValue(invocation.Interpreter)
 		}
 	default:
 		panic(errors.NewUnreachableError())
@@ -3155,15 +3270,16 @@ func RestrictedTypeFunction(invocation Invocation) Value {
 	// If the restricted type would have failed to type-check statically,
 	// then return nil
 	if invalidRestrictedType {
-		return NilValue{}
+		return NewNilValue(invocation.Interpreter)
 	}
 
-	return NewSomeValueNonCopying(TypeValue{
-		Type: &RestrictedStaticType{
+	return NewSomeValue(invocation.Interpreter, NewTypeValue(
+		invocation.Interpreter,
+		&RestrictedStaticType{
 			Type:         ConvertSemaToStaticType(ty),
 			Restrictions: staticRestrictions,
 		},
-	})
+	))
 }
 
 func defineBaseFunctions(activation *VariableActivation) {
@@ -3187,8 +3303,12 @@ var converterFunctionValues = func() []converterFunction {
 	for index, declaration := range ConverterDeclarations {
 		// NOTE: declare in loop, as captured in closure below
 		convert := declaration.convert
+		memoryUsage := declaration.memoryUsage
 		converterFunctionValue := NewHostFunctionValue(
 			func(invocation Invocation) Value {
+				if memoryUsage != nil {
+					invocation.Interpreter.UseMemory(*memoryUsage)
+				}
 				return convert(invocation.Arguments[0])
 			},
 			declaration.functionType,
@@ -3241,12 +3361,12 @@ var runtimeTypeConstructors = []runtimeTypeConstructor{
 					panic(errors.NewUnreachableError())
 				}
 
-				return TypeValue{
-					//nolint:gosimple
-					Type: OptionalStaticType{
+				return NewTypeValue(
+					invocation.Interpreter,
+					OptionalStaticType{
 						Type: typeValue.Type,
 					},
-				}
+				)
 			},
 			sema.OptionalTypeFunctionType,
 		),
@@ -3260,12 +3380,12 @@ var runtimeTypeConstructors = []runtimeTypeConstructor{
 					panic(errors.NewUnreachableError())
 				}
 
-				return TypeValue{
-					//nolint:gosimple
-					Type: VariableSizedStaticType{
+				return NewTypeValue(
+					invocation.Interpreter,
+					VariableSizedStaticType{
 						Type: typeValue.Type,
 					},
-				}
+				)
 			},
 			sema.VariableSizedArrayTypeFunctionType,
 		),
@@ -3284,12 +3404,13 @@ var runtimeTypeConstructors = []runtimeTypeConstructor{
 					panic(errors.NewUnreachableError())
 				}
 
-				return TypeValue{
-					Type: ConstantSizedStaticType{
+				return NewTypeValue(
+					invocation.Interpreter,
+					ConstantSizedStaticType{
 						Type: typeValue.Type,
 						Size: int64(sizeValue.ToInt()),
 					},
-				}
+				)
 			},
 			sema.ConstantSizedArrayTypeFunctionType,
 		),
@@ -3308,12 +3429,13 @@ var runtimeTypeConstructors = []runtimeTypeConstructor{
 					panic(errors.NewUnreachableError())
 				}
 
-				return TypeValue{
-					Type: ReferenceStaticType{
+				return NewTypeValue(
+					invocation.Interpreter,
+					ReferenceStaticType{
 						Authorized: bool(authorizedValue),
 						Type:       typeValue.Type,
 					},
-				}
+				)
 			},
 			sema.ReferenceTypeFunctionType,
 		),
@@ -3331,15 +3453,17 @@ var runtimeTypeConstructors = []runtimeTypeConstructor{
 				// Capabilities must hold references
 				_, ok = ty.(ReferenceStaticType)
 				if !ok {
-					return NilValue{}
+					return NewNilValue(invocation.Interpreter)
 				}
 
-				return NewSomeValueNonCopying(
-					TypeValue{
-						Type: CapabilityStaticType{
+				return NewSomeValue(
+					invocation.Interpreter,
+					NewTypeValue(
+						invocation.Interpreter,
+						CapabilityStaticType{
 							BorrowType: ty,
 						},
-					},
+					),
 				)
 			},
 			sema.CapabilityTypeFunctionType,
@@ -3365,9 +3489,10 @@ var typeFunction = NewHostFunctionValue(
 
 		ty := typeParameterPair.Value
 
-		return TypeValue{
-			Type: ConvertSemaToStaticType(ty),
-		}
+		return NewTypeValue(
+			invocation.Interpreter,
+			ConvertSemaToStaticType(ty),
+		)
 	},
 	&sema.FunctionType{
 		ReturnTypeAnnotation: sema.NewTypeAnnotation(sema.MetaType),
@@ -3687,6 +3812,8 @@ func (interpreter *Interpreter) authAccountSaveFunction(addressValue AddressValu
 				panic(errors.NewUnreachableError())
 			}
 
+			interpreter.meterStoragePath(path)
+
 			domain := path.Domain.Identifier()
 			identifier := path.Identifier
 
@@ -3720,7 +3847,9 @@ func (interpreter *Interpreter) authAccountSaveFunction(addressValue AddressValu
 
 			interpreter.writeStored(address, domain, identifier, value)
 
-			return VoidValue{}
+			interpreter.meterAccountStorage(common.MemoryKindAccountStorageWrite, value)
+
+			return NewVoidValue(invocation.Interpreter)
 		},
 		sema.AuthAccountTypeSaveFunctionType,
 	)
@@ -3738,19 +3867,23 @@ func (interpreter *Interpreter) authAccountTypeFunction(addressValue AddressValu
 				panic(errors.NewUnreachableError())
 			}
 
+			interpreter.meterStoragePath(path)
+
 			domain := path.Domain.Identifier()
 			identifier := path.Identifier
 
 			value := interpreter.ReadStored(address, domain, identifier)
 
 			if value == nil {
-				return NilValue{}
+				return NewNilValue(invocation.Interpreter)
 			}
 
-			return NewSomeValueNonCopying(
-				TypeValue{
-					Type: value.StaticType(),
-				},
+			return NewSomeValue(
+				invocation.Interpreter,
+				NewTypeValue(
+					invocation.Interpreter,
+					value.StaticType(),
+				),
 			)
 		},
 
@@ -3778,13 +3911,15 @@ func (interpreter *Interpreter) authAccountReadFunction(addressValue AddressValu
 				panic(errors.NewUnreachableError())
 			}
 
+			interpreter.meterStoragePath(path)
+
 			domain := path.Domain.Identifier()
 			identifier := path.Identifier
 
 			value := interpreter.ReadStored(address, domain, identifier)
 
 			if value == nil {
-				return NilValue{}
+				return NewNilValue(invocation.Interpreter)
 			}
 
 			// If there is value stored for the given path,
@@ -3805,6 +3940,8 @@ func (interpreter *Interpreter) authAccountReadFunction(addressValue AddressValu
 				})
 			}
 
+			interpreter.meterAccountStorage(common.MemoryKindAccountStorageRead, value)
+
 			inter := invocation.Interpreter
 			getLocationRange := invocation.GetLocationRange
 
@@ -3825,7 +3962,7 @@ func (interpreter *Interpreter) authAccountReadFunction(addressValue AddressValu
 				interpreter.writeStored(address, domain, identifier, nil)
 			}
 
-			return NewSomeValueNonCopying(transferredValue)
+			return NewSomeValue(invocation.Interpreter, transferredValue)
 		},
 
 		// same as sema.AuthAccountTypeCopyFunctionType
@@ -3845,6 +3982,8 @@ func (interpreter *Interpreter) authAccountBorrowFunction(addressValue AddressVa
 				panic(errors.NewUnreachableError())
 			}
 
+			interpreter.meterStoragePath(path)
+
 			typeParameterPair := invocation.TypeParameterTypes.Oldest()
 			if typeParameterPair == nil {
 				panic(errors.NewUnreachableError())
@@ -3857,12 +3996,13 @@ func (interpreter *Interpreter) authAccountBorrowFunction(addressValue AddressVa
 				panic(errors.NewUnreachableError())
 			}
 
-			reference := &StorageReferenceValue{
-				Authorized:           referenceType.Authorized,
-				TargetStorageAddress: address,
-				TargetPath:           path,
-				BorrowedType:         referenceType.Type,
-			}
+			reference := NewStorageReferenceValue(
+				invocation.Interpreter,
+				referenceType.Authorized,
+				address,
+				path,
+				referenceType.Type,
+			)
 
 			// Attempt to dereference,
 			// which reads the stored value
@@ -3873,10 +4013,10 @@ func (interpreter *Interpreter) authAccountBorrowFunction(addressValue AddressVa
 				panic(err)
 			}
 			if value == nil {
-				return NilValue{}
+				return NewNilValue(invocation.Interpreter)
 			}
 
-			return NewSomeValueNonCopying(reference)
+			return NewSomeValue(invocation.Interpreter, reference)
 		},
 		sema.AuthAccountTypeBorrowFunctionType,
 	)
@@ -3911,6 +4051,8 @@ func (interpreter *Interpreter) authAccountLinkFunction(addressValue AddressValu
 				panic(errors.NewUnreachableError())
 			}
 
+			interpreter.meterStoragePath(newCapabilityPath)
+
 			newCapabilityDomain := newCapabilityPath.Domain.Identifier()
 			newCapabilityIdentifier := newCapabilityPath.Identifier
 
@@ -3919,17 +4061,18 @@ func (interpreter *Interpreter) authAccountLinkFunction(addressValue AddressValu
 				newCapabilityDomain,
 				newCapabilityIdentifier,
 			) {
-				return NilValue{}
+				return NewNilValue(invocation.Interpreter)
 			}
 
 			// Write new value
 
 			borrowStaticType := ConvertSemaToStaticType(borrowType)
 
-			linkValue := LinkValue{
-				TargetPath: targetPath,
-				Type:       borrowStaticType,
-			}
+			linkValue := NewLinkValue(
+				invocation.Interpreter,
+				targetPath,
+				borrowStaticType,
+			)
 
 			interpreter.writeStored(
 				address,
@@ -3938,12 +4081,14 @@ func (interpreter *Interpreter) authAccountLinkFunction(addressValue AddressValu
 				linkValue,
 			)
 
-			return NewSomeValueNonCopying(
-				&CapabilityValue{
-					Address:    addressValue,
-					Path:       newCapabilityPath,
-					BorrowType: borrowStaticType,
-				},
+			return NewSomeValue(
+				invocation.Interpreter,
+				NewCapabilityValue(
+					invocation.Interpreter,
+					addressValue,
+					newCapabilityPath,
+					borrowStaticType,
+				),
 			)
 
 		},
@@ -3964,21 +4109,23 @@ func (interpreter *Interpreter) accountGetLinkTargetFunction(addressValue Addres
 				panic(errors.NewUnreachableError())
 			}
 
+			interpreter.meterStoragePath(capabilityPath)
+
 			domain := capabilityPath.Domain.Identifier()
 			identifier := capabilityPath.Identifier
 
 			value := interpreter.ReadStored(address, domain, identifier)
 
 			if value == nil {
-				return NilValue{}
+				return NewNilValue(invocation.Interpreter)
 			}
 
 			link, ok := value.(LinkValue)
 			if !ok {
-				return NilValue{}
+				return NewNilValue(invocation.Interpreter)
 			}
 
-			return NewSomeValueNonCopying(link.TargetPath)
+			return NewSomeValue(invocation.Interpreter, link.TargetPath)
 		},
 		sema.AccountTypeGetLinkTargetFunctionType,
 	)
@@ -3997,6 +4144,8 @@ func (interpreter *Interpreter) authAccountUnlinkFunction(addressValue AddressVa
 				panic(errors.NewUnreachableError())
 			}
 
+			interpreter.meterStoragePath(capabilityPath)
+
 			domain := capabilityPath.Domain.Identifier()
 			identifier := capabilityPath.Identifier
 
@@ -4004,7 +4153,7 @@ func (interpreter *Interpreter) authAccountUnlinkFunction(addressValue AddressVa
 
 			interpreter.writeStored(address, domain, identifier, nil)
 
-			return VoidValue{}
+			return NewVoidValue(invocation.Interpreter)
 		},
 		sema.AuthAccountTypeUnlinkFunctionType,
 	)
@@ -4051,15 +4200,16 @@ func (interpreter *Interpreter) capabilityBorrowFunction(
 			}
 
 			if targetPath == EmptyPathValue {
-				return NilValue{}
+				return NewNilValue(invocation.Interpreter)
 			}
 
-			reference := &StorageReferenceValue{
-				Authorized:           authorized,
-				TargetStorageAddress: address,
-				TargetPath:           targetPath,
-				BorrowedType:         borrowType.Type,
-			}
+			reference := NewStorageReferenceValue(
+				invocation.Interpreter,
+				authorized,
+				address,
+				targetPath,
+				borrowType.Type,
+			)
 
 			// Attempt to dereference,
 			// which reads the stored value
@@ -4070,10 +4220,10 @@ func (interpreter *Interpreter) capabilityBorrowFunction(
 				panic(err)
 			}
 			if value == nil {
-				return NilValue{}
+				return NewNilValue(invocation.Interpreter)
 			}
 
-			return NewSomeValueNonCopying(reference)
+			return NewSomeValue(invocation.Interpreter, reference)
 		},
 		sema.CapabilityTypeBorrowFunctionType(borrowType),
 	)
@@ -4124,12 +4274,13 @@ func (interpreter *Interpreter) capabilityCheckFunction(
 				return BoolValue(false)
 			}
 
-			reference := &StorageReferenceValue{
-				Authorized:           authorized,
-				TargetStorageAddress: address,
-				TargetPath:           targetPath,
-				BorrowedType:         borrowType.Type,
-			}
+			reference := NewStorageReferenceValue(
+				invocation.Interpreter,
+				authorized,
+				address,
+				targetPath,
+				borrowType.Type,
+			)
 
 			// Attempt to dereference,
 			// which reads the stored value
@@ -4358,6 +4509,12 @@ func (interpreter *Interpreter) ReportComputation(compKind common.ComputationKin
 	}
 }
 
+func (interpreter *Interpreter) UseMemory(usage common.MemoryUsage) {
+	if interpreter.onMeterMemory != nil {
+		interpreter.onMeterMemory(usage)
+	}
+}
+
 // getMember gets the member value by the given identifier from the given Value depending on its type.
 // May return nil if the member does not exist.
 func (interpreter *Interpreter) getMember(self Value, getLocationRange func() LocationRange, identifier string) Value {
@@ -4415,9 +4572,10 @@ func (interpreter *Interpreter) isInstanceFunction(self Value) *HostFunctionValu
 func (interpreter *Interpreter) getTypeFunction(self Value) *HostFunctionValue {
 	return NewHostFunctionValue(
 		func(invocation Invocation) Value {
-			return TypeValue{
-				Type: self.StaticType(),
-			}
+			return NewTypeValue(
+				invocation.Interpreter,
+				self.StaticType(),
+			)
 		},
 		sema.GetTypeFunctionType,
 	)
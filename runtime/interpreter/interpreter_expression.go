@@ -20,6 +20,7 @@ package interpreter
 
 import (
 	"math/big"
+	"strings"
 	"time"
 
 	"github.com/onflow/cadence/fixedpoint"
@@ -149,7 +150,7 @@ func (interpreter *Interpreter) memberExpressionGetterSetter(memberExpression *a
 
 			if isOptional {
 				if _, ok := resultValue.(OptionalValue); !ok {
-					resultValue = NewSomeValueNonCopying(resultValue)
+					resultValue = NewSomeValue(interpreter, resultValue)
 				}
 			}
 
@@ -434,7 +435,7 @@ func (interpreter *Interpreter) VisitBoolExpression(expression *ast.BoolExpressi
 }
 
 func (interpreter *Interpreter) VisitNilExpression(_ *ast.NilExpression) ast.Repr {
-	return NilValue{}
+	return NewNilValue(interpreter)
 }
 
 func (interpreter *Interpreter) VisitIntegerExpression(expression *ast.IntegerExpression) ast.Repr {
@@ -443,61 +444,82 @@ func (interpreter *Interpreter) VisitIntegerExpression(expression *ast.IntegerEx
 	value := expression.Value
 
 	if _, ok := typ.(*sema.AddressType); ok {
+		interpreter.UseMemory(addressValueMemoryUsage)
 		return NewAddressValueFromBytes(value.Bytes())
 	}
 
 	// The ranges are checked at the checker level.
 	// Hence it is safe to create the value without validation.
-	return NewIntValue(value, typ)
+	return NewIntValue(interpreter, value, typ)
 
 }
 
 // NewIntValue creates a Cadence interpreter value of a given subtype.
 // This method assumes the range validations are done prior to calling this method. (i.e: at semantic level)
 //
-func NewIntValue(value *big.Int, intSubType sema.Type) Value {
+// The memory of the value is metered.
+//
+func NewIntValue(interpreter *Interpreter, value *big.Int, intSubType sema.Type) Value {
 	switch intSubType {
 	case sema.IntType, sema.IntegerType, sema.SignedIntegerType:
+		interpreter.UseMemory(newBigIntMemoryUsage(common.MemoryKindIntValue, value))
 		return NewIntValueFromBigInt(value)
 	case sema.UIntType:
+		interpreter.UseMemory(newBigIntMemoryUsage(common.MemoryKindUIntValue, value))
 		return NewUIntValueFromBigInt(value)
 
 	// Int*
 	case sema.Int8Type:
+		interpreter.UseMemory(int8MemoryUsage)
 		return Int8Value(value.Int64())
 	case sema.Int16Type:
+		interpreter.UseMemory(int16MemoryUsage)
 		return Int16Value(value.Int64())
 	case sema.Int32Type:
+		interpreter.UseMemory(int32MemoryUsage)
 		return Int32Value(value.Int64())
 	case sema.Int64Type:
+		interpreter.UseMemory(int64MemoryUsage)
 		return Int64Value(value.Int64())
 	case sema.Int128Type:
+		interpreter.UseMemory(int128MemoryUsage)
 		return NewInt128ValueFromBigInt(value)
 	case sema.Int256Type:
+		interpreter.UseMemory(int256MemoryUsage)
 		return NewInt256ValueFromBigInt(value)
 
 	// UInt*
 	case sema.UInt8Type:
+		interpreter.UseMemory(uint8MemoryUsage)
 		return UInt8Value(value.Int64())
 	case sema.UInt16Type:
+		interpreter.UseMemory(uint16MemoryUsage)
 		return UInt16Value(value.Int64())
 	case sema.UInt32Type:
+		interpreter.UseMemory(uint32MemoryUsage)
 		return UInt32Value(value.Int64())
 	case sema.UInt64Type:
+		interpreter.UseMemory(uint64MemoryUsage)
 		return UInt64Value(value.Int64())
 	case sema.UInt128Type:
+		interpreter.UseMemory(uint128MemoryUsage)
 		return NewUInt128ValueFromBigInt(value)
 	case sema.UInt256Type:
+		interpreter.UseMemory(uint256MemoryUsage)
 		return NewUInt256ValueFromBigInt(value)
 
 	// Word*
 	case sema.Word8Type:
+		interpreter.UseMemory(word8MemoryUsage)
 		return Word8Value(value.Int64())
 	case sema.Word16Type:
+		interpreter.UseMemory(word16MemoryUsage)
 		return Word16Value(value.Int64())
 	case sema.Word32Type:
+		interpreter.UseMemory(word32MemoryUsage)
 		return Word32Value(value.Int64())
 	case sema.Word64Type:
+		interpreter.UseMemory(word64MemoryUsage)
 		return Word64Value(value.Int64())
 
 	default:
@@ -519,13 +541,17 @@ func (interpreter *Interpreter) VisitFixedPointExpression(expression *ast.FixedP
 	)
 	switch fixedPointSubType {
 	case sema.Fix64Type, sema.SignedFixedPointType:
+		interpreter.UseMemory(fix64MemoryUsage)
 		return Fix64Value(value.Int64())
 	case sema.UFix64Type:
+		interpreter.UseMemory(ufix64MemoryUsage)
 		return UFix64Value(value.Uint64())
 	case sema.FixedPointType:
 		if expression.Negative {
+			interpreter.UseMemory(fix64MemoryUsage)
 			return Fix64Value(value.Int64())
 		} else {
+			interpreter.UseMemory(ufix64MemoryUsage)
 			return UFix64Value(value.Uint64())
 		}
 	default:
@@ -538,12 +564,61 @@ func (interpreter *Interpreter) VisitStringExpression(expression *ast.StringExpr
 
 	switch stringType {
 	case sema.CharacterType:
-		return NewCharacterValue(expression.Value)
+		return NewCharacterValue(interpreter, expression.Value)
 	}
 
 	return NewStringValue(expression.Value)
 }
 
+func (interpreter *Interpreter) VisitStringInterpolationExpression(expression *ast.StringInterpolationExpression) ast.Repr {
+	var builder strings.Builder
+
+	for _, segment := range expression.Segments {
+		if stringSegment, ok := segment.(*ast.StringExpression); ok {
+			builder.WriteString(stringSegment.Value)
+			continue
+		}
+
+		value := interpreter.evalExpression(segment)
+		builder.WriteString(interpreter.interpolatedString(value, segment))
+	}
+
+	return NewStringValue(builder.String())
+}
+
+// interpolatedString returns the string representation of the given interpolated value:
+// Strings are interpolated as-is, all other values are converted using their `toString` function.
+//
+func (interpreter *Interpreter) interpolatedString(value Value, expression ast.Expression) string {
+	if stringValue, ok := value.(*StringValue); ok {
+		return stringValue.Str
+	}
+
+	getLocationRange := locationRangeGetter(interpreter.Location, expression)
+
+	function, ok := interpreter.getMember(value, getLocationRange, sema.ToStringFunctionName).(FunctionValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	result := interpreter.invokeFunctionValue(
+		function,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		expression,
+	)
+
+	stringValue, ok := result.(*StringValue)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+
+	return stringValue.Str
+}
+
 func (interpreter *Interpreter) VisitArrayExpression(expression *ast.ArrayExpression) ast.Repr {
 	values := interpreter.visitExpressionsNonCopying(expression.Values)
 
@@ -719,7 +794,7 @@ func (interpreter *Interpreter) VisitInvocationExpression(invocationExpression *
 	// If this is invocation is optional chaining, wrap the result
 	// as an optional, as the result is expected to be an optional
 	if isOptionalChaining {
-		resultValue = NewSomeValueNonCopying(resultValue)
+		resultValue = NewSomeValue(interpreter, resultValue)
 	}
 
 	return resultValue
@@ -780,6 +855,8 @@ func (interpreter *Interpreter) VisitFunctionExpression(expression *ast.Function
 
 	statements := expression.FunctionBlock.Block.Statements
 
+	interpreter.meterFunction(lexicalScope)
+
 	return &InterpretedFunctionValue{
 		Interpreter:      interpreter,
 		ParameterList:    expression.ParameterList,
@@ -807,13 +884,13 @@ func (interpreter *Interpreter) VisitCastingExpression(expression *ast.CastingEx
 		switch expression.Operation {
 		case ast.OperationFailableCast:
 			if !isSubType {
-				return NilValue{}
+				return NewNilValue(interpreter)
 			}
 
 			// The failable cast may upcast to an optional type, e.g. `1 as? Int?`, so box
 			value = interpreter.BoxOptional(getLocationRange, value, expectedType)
 
-			return NewSomeValueNonCopying(value)
+			return NewSomeValue(interpreter, value)
 
 		case ast.OperationForceCast:
 			if !isSubType {
@@ -846,15 +923,32 @@ func (interpreter *Interpreter) VisitCreateExpression(expression *ast.CreateExpr
 }
 
 func (interpreter *Interpreter) VisitDestroyExpression(expression *ast.DestroyExpression) ast.Repr {
-	value := interpreter.evalExpression(expression.Expression)
+	interpreter.destroy(expression.Expression, expression)
+
+	return NewVoidValue(interpreter)
+}
+
+// destroy evaluates the given destroyed expression, and destroys the resulting resource.
+// The location range of the destruction is the range of the given destroy expression or statement.
+//
+func (interpreter *Interpreter) destroy(expression ast.Expression, destruction ast.HasPosition) {
+	value := interpreter.evalExpression(expression)
 
 	interpreter.invalidateResource(value)
 
-	getLocationRange := locationRangeGetter(interpreter.Location, expression)
+	getLocationRange := locationRangeGetter(interpreter.Location, destruction)
 
 	value.(ResourceKindedValue).Destroy(interpreter, getLocationRange)
+}
+
+func (interpreter *Interpreter) VisitAttachExpression(_ *ast.AttachExpression) ast.Repr {
+	// attachments are rejected by the checker
+	panic(errors.NewUnreachableError())
+}
 
-	return VoidValue{}
+func (interpreter *Interpreter) VisitRemoveExpression(_ *ast.RemoveExpression) ast.Repr {
+	// attachments are rejected by the checker
+	panic(errors.NewUnreachableError())
 }
 
 func (interpreter *Interpreter) VisitReferenceExpression(referenceExpression *ast.ReferenceExpression) ast.Repr {
@@ -880,26 +974,32 @@ func (interpreter *Interpreter) VisitReferenceExpression(referenceExpression *as
 		case *SomeValue:
 			getLocationRange := locationRangeGetter(interpreter.Location, referenceExpression.Expression)
 
-			return NewSomeValueNonCopying(&EphemeralReferenceValue{
-				Authorized:   innerBorrowType.Authorized,
-				Value:        result.InnerValue(interpreter, getLocationRange),
-				BorrowedType: innerBorrowType.Type,
-			})
+			return NewSomeValue(
+				interpreter,
+				NewEphemeralReferenceValue(
+					interpreter,
+					innerBorrowType.Authorized,
+					result.InnerValue(interpreter, getLocationRange),
+					innerBorrowType.Type,
+				),
+			)
 		case NilValue:
-			return NilValue{}
+			return NewNilValue(interpreter)
 		default:
-			return &EphemeralReferenceValue{
-				Authorized:   innerBorrowType.Authorized,
-				Value:        result,
-				BorrowedType: innerBorrowType.Type,
-			}
+			return NewEphemeralReferenceValue(
+				interpreter,
+				innerBorrowType.Authorized,
+				result,
+				innerBorrowType.Type,
+			)
 		}
 	case *sema.ReferenceType:
-		return &EphemeralReferenceValue{
-			Authorized:   typ.Authorized,
-			Value:        result,
-			BorrowedType: typ.Type,
-		}
+		return NewEphemeralReferenceValue(
+			interpreter,
+			typ.Authorized,
+			result,
+			typ.Type,
+		)
 	}
 	panic(errors.NewUnreachableError())
 }
@@ -933,8 +1033,9 @@ func (interpreter *Interpreter) VisitForceExpression(expression *ast.ForceExpres
 func (interpreter *Interpreter) VisitPathExpression(expression *ast.PathExpression) ast.Repr {
 	domain := common.PathDomainFromIdentifier(expression.Domain.Identifier)
 
-	return PathValue{
-		Domain:     domain,
-		Identifier: expression.Identifier.Identifier,
-	}
+	return NewPathValue(
+		interpreter,
+		domain,
+		expression.Identifier.Identifier,
+	)
 }
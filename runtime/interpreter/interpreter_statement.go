@@ -24,6 +24,7 @@ import (
 	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/errors"
+	"github.com/onflow/cadence/runtime/sema"
 )
 
 func (interpreter *Interpreter) evalStatement(statement ast.Statement) interface{} {
@@ -69,7 +70,7 @@ func (interpreter *Interpreter) VisitReturnStatement(statement *ast.ReturnStatem
 
 	var value Value
 	if statement.Expression == nil {
-		value = VoidValue{}
+		value = NewVoidValue(interpreter)
 	} else {
 		value = interpreter.evalExpression(statement.Expression)
 
@@ -357,6 +358,19 @@ func (interpreter *Interpreter) VisitForStatement(statement *ast.ForStatement) a
 	}
 }
 
+// newEventMemoryUsage returns the memory usage of an emitted event of the given type.
+//
+// Events are metered separately from other composite values,
+// as they are passed to the host environment and discarded immediately.
+// The event itself has a base cost of 1, and each field adds 1.
+//
+func newEventMemoryUsage(eventType *sema.CompositeType) common.MemoryUsage {
+	return common.MemoryUsage{
+		Kind:   common.MemoryKindEvent,
+		Amount: 1 + uint64(len(eventType.ConstructorParameters)),
+	}
+}
+
 func (interpreter *Interpreter) VisitEmitStatement(statement *ast.EmitStatement) ast.Repr {
 	event, ok := interpreter.evalExpression(statement.InvocationExpression).(*CompositeValue)
 	if !ok {
@@ -373,6 +387,8 @@ func (interpreter *Interpreter) VisitEmitStatement(statement *ast.EmitStatement)
 		})
 	}
 
+	interpreter.UseMemory(newEventMemoryUsage(eventType))
+
 	err := interpreter.onEventEmitted(interpreter, getLocationRange, event, eventType)
 	if err != nil {
 		panic(err)
@@ -381,6 +397,12 @@ func (interpreter *Interpreter) VisitEmitStatement(statement *ast.EmitStatement)
 	return nil
 }
 
+func (interpreter *Interpreter) VisitDestroyStatement(statement *ast.DestroyStatement) ast.Repr {
+	interpreter.destroy(statement.Expression, statement)
+
+	return nil
+}
+
 func (interpreter *Interpreter) VisitPragmaDeclaration(_ *ast.PragmaDeclaration) ast.Repr {
 	return nil
 }
@@ -411,6 +433,11 @@ func (interpreter *Interpreter) visitVariableDeclaration(
 	valueCallback func(identifier string, value Value),
 ) {
 
+	// Destructuring is rejected by the checker
+	if declaration.Pattern != nil {
+		panic(errors.NewUnreachableError())
+	}
+
 	targetType := interpreter.Program.Elaboration.VariableDeclarationTargetTypes[declaration]
 	valueType := interpreter.Program.Elaboration.VariableDeclarationValueTypes[declaration]
 	secondValueType := interpreter.Program.Elaboration.VariableDeclarationSecondValueTypes[declaration]
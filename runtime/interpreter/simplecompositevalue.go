@@ -114,6 +114,7 @@ func (v *SimpleCompositeValue) GetMember(
 	if v.ComputedFields != nil {
 		computedField, ok := v.ComputedFields[name]
 		if ok {
+			interpreter.UseMemory(computedFieldMemoryUsage)
 			return computedField(interpreter, getLocationRange)
 		}
 	}
@@ -265,3 +265,29 @@ func maybeLargeImmutableStorable(
 
 	return atree.StorageIDStorable(storageID), nil
 }
+
+// storedValueSize returns the size in bytes of the encoding of the given stored value,
+// as already known to the storage.
+//
+// The size of a container value, e.g. a composite, array, or dictionary,
+// is the size of its root slab. Nested slabs are not loaded.
+//
+func storedValueSize(storage atree.SlabStorage, value Value) uint64 {
+	switch value := value.(type) {
+	case interface{ StorageID() atree.StorageID }:
+		slab, found, err := storage.Retrieve(value.StorageID())
+		if err != nil {
+			panic(err)
+		}
+		if !found {
+			return 0
+		}
+		return uint64(slab.ByteSize())
+
+	case atree.Storable:
+		return uint64(value.ByteSize())
+
+	default:
+		return 0
+	}
+}
@@ -20,6 +20,8 @@ package interpreter
 
 import (
 	"github.com/onflow/atree"
+
+	"github.com/onflow/cadence/runtime/common"
 )
 
 // StorageMap is an ordered map which stores values in an account.
@@ -108,8 +110,17 @@ func (s StorageMap) WriteValue(interpreter *Interpreter, key string, value atree
 	}
 }
 
+// storageIndexMemoryUsage is the memory usage of an entry in a storage map,
+// which maps a key to the stored value
+//
+var storageIndexMemoryUsage = common.MemoryUsage{
+	Kind:   common.MemoryKindStorageIndex,
+	Amount: 1,
+}
+
 // SetValue sets a value in the storage map.
 // If the given key already stores a value, it is overwritten.
+// If the given key does not exist yet, the memory used by the new entry is metered.
 //
 func (s StorageMap) SetValue(interpreter *Interpreter, key string, value atree.Value) {
 	existingStorable, err := s.orderedMap.Set(
@@ -123,7 +134,9 @@ func (s StorageMap) SetValue(interpreter *Interpreter, key string, value atree.V
 	}
 	interpreter.maybeValidateAtreeValue(s.orderedMap)
 
-	if existingStorable != nil {
+	if existingStorable == nil {
+		interpreter.UseMemory(storageIndexMemoryUsage)
+	} else {
 		existingValue := StoredValue(existingStorable, interpreter.Storage)
 		existingValue.DeepRemove(interpreter)
 		interpreter.RemoveReferencedSlab(existingStorable)
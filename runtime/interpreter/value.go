@@ -184,6 +184,21 @@ type TypeValue struct {
 	Type StaticType
 }
 
+var typeValueMemoryUsage = common.MemoryUsage{
+	Kind:   common.MemoryKindTypeValue,
+	Amount: 1,
+}
+
+// NewTypeValue returns a type value,
+// and meters the memory used by the value
+//
+func NewTypeValue(interpreter *Interpreter, staticType StaticType) TypeValue {
+	interpreter.UseMemory(typeValueMemoryUsage)
+	return TypeValue{
+		Type: staticType,
+	}
+}
+
 var _ Value = TypeValue{}
 var _ atree.Storable = TypeValue{}
 var _ EquatableValue = TypeValue{}
@@ -377,6 +392,24 @@ func (v TypeValue) HashInput(interpreter *Interpreter, _ func() LocationRange, s
 
 type VoidValue struct{}
 
+// voidValueMemoryUsage is the memory usage of the void value.
+//
+// The void value currently does not use any memory,
+// but it is still reported, so memory gauges can observe its creation.
+//
+var voidValueMemoryUsage = common.MemoryUsage{
+	Kind:   common.MemoryKindVoid,
+	Amount: 0,
+}
+
+// NewVoidValue returns the void value,
+// and meters the memory used by it
+//
+func NewVoidValue(interpreter *Interpreter) VoidValue {
+	interpreter.UseMemory(voidValueMemoryUsage)
+	return VoidValue{}
+}
+
 var _ Value = VoidValue{}
 var _ atree.Storable = VoidValue{}
 var _ EquatableValue = VoidValue{}
@@ -594,7 +627,16 @@ func (BoolValue) ChildStorables() []atree.Storable {
 //
 type CharacterValue string
 
-func NewCharacterValue(r string) CharacterValue {
+var characterValueMemoryUsage = common.MemoryUsage{
+	Kind:   common.MemoryKindCharacter,
+	Amount: 1,
+}
+
+// NewCharacterValue returns a character value,
+// and meters the memory used by the value
+//
+func NewCharacterValue(interpreter *Interpreter, r string) CharacterValue {
+	interpreter.UseMemory(characterValueMemoryUsage)
 	return CharacterValue(r)
 }
 
@@ -896,7 +938,7 @@ func (v *StringValue) checkBounds(index int, getLocationRange func() LocationRan
 	}
 }
 
-func (v *StringValue) GetKey(_ *Interpreter, getLocationRange func() LocationRange, key Value) Value {
+func (v *StringValue) GetKey(interpreter *Interpreter, getLocationRange func() LocationRange, key Value) Value {
 	index := key.(NumberValue).ToInt()
 	v.checkBounds(index, getLocationRange)
 
@@ -907,7 +949,7 @@ func (v *StringValue) GetKey(_ *Interpreter, getLocationRange func() LocationRan
 	}
 
 	char := v.graphemes.Str()
-	return NewCharacterValue(char)
+	return NewCharacterValue(interpreter, char)
 }
 
 func (*StringValue) SetKey(_ *Interpreter, _ func() LocationRange, _ Value, _ Value) {
@@ -1610,9 +1652,9 @@ func (v *ArrayValue) FirstIndex(interpreter *Interpreter, getLocationRange func(
 
 	if result {
 		value := NewIntValueFromInt64(counter)
-		return NewSomeValueNonCopying(value)
+		return NewSomeValue(interpreter, value)
 	}
-	return NilValue{}
+	return NewNilValue(interpreter)
 }
 
 func (v *ArrayValue) Contains(interpreter *Interpreter, getLocationRange func() LocationRange, needleValue Value) BoolValue {
@@ -1654,7 +1696,7 @@ func (v *ArrayValue) GetMember(interpreter *Interpreter, getLocationRange func()
 					invocation.GetLocationRange,
 					invocation.Arguments[0],
 				)
-				return VoidValue{}
+				return NewVoidValue(invocation.Interpreter)
 			},
 			sema.ArrayAppendFunctionType(
 				v.SemaType(interpreter).ElementType(false),
@@ -1673,7 +1715,7 @@ func (v *ArrayValue) GetMember(interpreter *Interpreter, getLocationRange func()
 					invocation.GetLocationRange,
 					otherArray,
 				)
-				return VoidValue{}
+				return NewVoidValue(invocation.Interpreter)
 			},
 			sema.ArrayAppendAllFunctionType(
 				v.SemaType(interpreter),
@@ -1715,7 +1757,7 @@ func (v *ArrayValue) GetMember(interpreter *Interpreter, getLocationRange func()
 					index,
 					element,
 				)
-				return VoidValue{}
+				return NewVoidValue(invocation.Interpreter)
 			},
 			sema.ArrayInsertFunctionType(
 				v.SemaType(interpreter).ElementType(false),
@@ -2390,6 +2432,40 @@ func NewIntValueFromBigInt(value *big.Int) IntValue {
 	return IntValue{BigInt: value}
 }
 
+// newBigIntMemoryUsage returns the memory usage of an arbitrary-precision integer of the given kind,
+// which is proportional to the number of bytes needed to represent its magnitude
+//
+func newBigIntMemoryUsage(kind common.MemoryKind, value *big.Int) common.MemoryUsage {
+	return common.MemoryUsage{
+		Kind:   kind,
+		Amount: uint64((value.BitLen() + 7) / 8),
+	}
+}
+
+// The memory usages of fixed-size number values are their byte widths
+
+var int8MemoryUsage = common.MemoryUsage{Kind: common.MemoryKindInt8Value, Amount: 1}
+var int16MemoryUsage = common.MemoryUsage{Kind: common.MemoryKindInt16Value, Amount: 2}
+var int32MemoryUsage = common.MemoryUsage{Kind: common.MemoryKindInt32Value, Amount: 4}
+var int64MemoryUsage = common.MemoryUsage{Kind: common.MemoryKindInt64Value, Amount: 8}
+var int128MemoryUsage = common.MemoryUsage{Kind: common.MemoryKindInt128Value, Amount: 16}
+var int256MemoryUsage = common.MemoryUsage{Kind: common.MemoryKindInt256Value, Amount: 32}
+
+var uint8MemoryUsage = common.MemoryUsage{Kind: common.MemoryKindUInt8Value, Amount: 1}
+var uint16MemoryUsage = common.MemoryUsage{Kind: common.MemoryKindUInt16Value, Amount: 2}
+var uint32MemoryUsage = common.MemoryUsage{Kind: common.MemoryKindUInt32Value, Amount: 4}
+var uint64MemoryUsage = common.MemoryUsage{Kind: common.MemoryKindUInt64Value, Amount: 8}
+var uint128MemoryUsage = common.MemoryUsage{Kind: common.MemoryKindUInt128Value, Amount: 16}
+var uint256MemoryUsage = common.MemoryUsage{Kind: common.MemoryKindUInt256Value, Amount: 32}
+
+var word8MemoryUsage = common.MemoryUsage{Kind: common.MemoryKindWord8Value, Amount: 1}
+var word16MemoryUsage = common.MemoryUsage{Kind: common.MemoryKindWord16Value, Amount: 2}
+var word32MemoryUsage = common.MemoryUsage{Kind: common.MemoryKindWord32Value, Amount: 4}
+var word64MemoryUsage = common.MemoryUsage{Kind: common.MemoryKindWord64Value, Amount: 8}
+
+var fix64MemoryUsage = common.MemoryUsage{Kind: common.MemoryKindFix64Value, Amount: 8}
+var ufix64MemoryUsage = common.MemoryUsage{Kind: common.MemoryKindUFix64Value, Amount: 8}
+
 func ConvertInt(value Value) IntValue {
 	switch value := value.(type) {
 	case BigNumberValue:
@@ -11656,6 +11732,14 @@ type CompositeValue struct {
 
 type ComputedField func(*Interpreter, func() LocationRange) Value
 
+// computedFieldMemoryUsage is the memory usage of an invocation of a computed field getter,
+// which may create intermediate values
+//
+var computedFieldMemoryUsage = common.MemoryUsage{
+	Kind:   common.MemoryKindComputedField,
+	Amount: 1,
+}
+
 type CompositeField struct {
 	Name  string
 	Value Value
@@ -11902,6 +11986,7 @@ func (v *CompositeValue) GetMember(interpreter *Interpreter, getLocationRange fu
 
 	if v.ComputedFields != nil {
 		if computedField, ok := v.ComputedFields[name]; ok {
+			interpreter.UseMemory(computedFieldMemoryUsage)
 			return computedField(interpreter, getLocationRange)
 		}
 	}
@@ -11929,10 +12014,7 @@ func (v *CompositeValue) GetMember(interpreter *Interpreter, getLocationRange fu
 
 	function, ok := v.Functions[name]
 	if ok {
-		return BoundFunctionValue{
-			Self:     v,
-			Function: function,
-		}
+		return NewBoundFunctionValue(interpreter, function, v)
 	}
 
 	return nil
@@ -11972,7 +12054,7 @@ func (v *CompositeValue) OwnerValue(interpreter *Interpreter, getLocationRange f
 	address := v.StorageID().Address
 
 	if address == (atree.Address{}) {
-		return NilValue{}
+		return NewNilValue(interpreter)
 	}
 
 	ownerAccount := interpreter.publicAccountHandler(interpreter, AddressValue(address))
@@ -11980,7 +12062,7 @@ func (v *CompositeValue) OwnerValue(interpreter *Interpreter, getLocationRange f
 	// Owner must be of `PublicAccount` type.
 	interpreter.ExpectType(ownerAccount, sema.PublicAccountType, getLocationRange)
 
-	return NewSomeValueNonCopying(ownerAccount)
+	return NewSomeValue(interpreter, ownerAccount)
 }
 
 func (v *CompositeValue) RemoveMember(
@@ -12316,6 +12398,7 @@ func (v *CompositeValue) ConformsToDynamicType(
 				return false
 			}
 
+			interpreter.UseMemory(computedFieldMemoryUsage)
 			value = fieldGetter(interpreter, getLocationRange)
 		}
 
@@ -12985,10 +13068,10 @@ func (v *DictionaryValue) GetKey(interpreter *Interpreter, getLocationRange func
 
 	value, ok := v.Get(interpreter, getLocationRange, keyValue)
 	if ok {
-		return NewSomeValueNonCopying(value)
+		return NewSomeValue(interpreter, value)
 	}
 
-	return NilValue{}
+	return NewNilValue(interpreter)
 }
 
 func (v *DictionaryValue) SetKey(
@@ -13246,7 +13329,7 @@ func (v *DictionaryValue) Remove(
 	)
 	if err != nil {
 		if _, ok := err.(*atree.KeyNotFoundError); ok {
-			return NilValue{}
+			return NewNilValue(interpreter)
 		}
 		panic(ExternalError{err})
 	}
@@ -13271,7 +13354,7 @@ func (v *DictionaryValue) Remove(
 			existingValueStorable,
 		)
 
-	return NewSomeValueNonCopying(existingValue)
+	return NewSomeValue(interpreter, existingValue)
 }
 
 func (v *DictionaryValue) InsertKey(
@@ -13326,7 +13409,7 @@ func (v *DictionaryValue) Insert(
 	interpreter.maybeValidateAtreeValue(v.dictionary)
 
 	if existingValueStorable == nil {
-		return NilValue{}
+		return NewNilValue(interpreter)
 	}
 
 	existingValue := StoredValue(existingValueStorable, interpreter.Storage).
@@ -13338,7 +13421,7 @@ func (v *DictionaryValue) Insert(
 			existingValueStorable,
 		)
 
-	return NewSomeValueNonCopying(existingValue)
+	return NewSomeValue(interpreter, existingValue)
 }
 
 type DictionaryEntryValues struct {
@@ -13743,6 +13826,19 @@ type OptionalValue interface {
 
 type NilValue struct{}
 
+var nilValueMemoryUsage = common.MemoryUsage{
+	Kind:   common.MemoryKindNilValue,
+	Amount: 1,
+}
+
+// NewNilValue returns the nil value,
+// and meters the memory used by it
+//
+func NewNilValue(interpreter *Interpreter) NilValue {
+	interpreter.UseMemory(nilValueMemoryUsage)
+	return NilValue{}
+}
+
 var _ Value = NilValue{}
 var _ atree.Storable = NilValue{}
 var _ EquatableValue = NilValue{}
@@ -13790,7 +13886,7 @@ func (v NilValue) RecursiveString(_ SeenReferences) string {
 
 var nilValueMapFunction = NewHostFunctionValue(
 	func(invocation Invocation) Value {
-		return NilValue{}
+		return NewNilValue(invocation.Interpreter)
 	},
 	&sema.FunctionType{
 		ReturnTypeAnnotation: sema.NewTypeAnnotation(
@@ -13897,6 +13993,19 @@ func NewSomeValueNonCopying(value Value) *SomeValue {
 	}
 }
 
+var someValueMemoryUsage = common.MemoryUsage{
+	Kind:   common.MemoryKindOptional,
+	Amount: 1,
+}
+
+// NewSomeValue returns an optional value wrapping the given value,
+// and meters the memory used by the optional value
+//
+func NewSomeValue(interpreter *Interpreter, value Value) *SomeValue {
+	interpreter.UseMemory(someValueMemoryUsage)
+	return NewSomeValueNonCopying(value)
+}
+
 var _ Value = &SomeValue{}
 var _ EquatableValue = &SomeValue{}
 var _ MemberAccessibleValue = &SomeValue{}
@@ -13995,7 +14104,7 @@ func (v *SomeValue) GetMember(interpreter *Interpreter, getLocationRange func()
 
 				newValue := transformFunction.invoke(transformInvocation)
 
-				return NewSomeValueNonCopying(newValue)
+				return NewSomeValue(invocation.Interpreter, newValue)
 			},
 			sema.OptionalTypeMapFunctionType(interpreter.MustConvertStaticToSemaType(v.value.StaticType())),
 		)
@@ -14215,6 +14324,30 @@ type StorageReferenceValue struct {
 	BorrowedType         sema.Type
 }
 
+var storageReferenceValueMemoryUsage = common.MemoryUsage{
+	Kind:   common.MemoryKindReference,
+	Amount: 1,
+}
+
+// NewStorageReferenceValue returns a reference to the value stored at the given path,
+// and meters the memory used by the reference value
+//
+func NewStorageReferenceValue(
+	interpreter *Interpreter,
+	authorized bool,
+	targetStorageAddress common.Address,
+	targetPath PathValue,
+	borrowedType sema.Type,
+) *StorageReferenceValue {
+	interpreter.UseMemory(storageReferenceValueMemoryUsage)
+	return &StorageReferenceValue{
+		Authorized:           authorized,
+		TargetStorageAddress: targetStorageAddress,
+		TargetPath:           targetPath,
+		BorrowedType:         borrowedType,
+	}
+}
+
 var _ Value = &StorageReferenceValue{}
 var _ EquatableValue = &StorageReferenceValue{}
 var _ ValueIndexableValue = &StorageReferenceValue{}
@@ -14538,6 +14671,28 @@ type EphemeralReferenceValue struct {
 	BorrowedType sema.Type
 }
 
+var ephemeralReferenceValueMemoryUsage = common.MemoryUsage{
+	Kind:   common.MemoryKindEphemeralReference,
+	Amount: 1,
+}
+
+// NewEphemeralReferenceValue returns a reference to the given value,
+// and meters the memory used by the reference value
+//
+func NewEphemeralReferenceValue(
+	interpreter *Interpreter,
+	authorized bool,
+	value Value,
+	borrowedType sema.Type,
+) *EphemeralReferenceValue {
+	interpreter.UseMemory(ephemeralReferenceValueMemoryUsage)
+	return &EphemeralReferenceValue{
+		Authorized:   authorized,
+		Value:        value,
+		BorrowedType: borrowedType,
+	}
+}
+
 var _ Value = &EphemeralReferenceValue{}
 var _ EquatableValue = &EphemeralReferenceValue{}
 var _ ValueIndexableValue = &EphemeralReferenceValue{}
@@ -14877,7 +15032,19 @@ func (*EphemeralReferenceValue) DeepRemove(_ *Interpreter) {
 //
 type AddressValue common.Address
 
-func NewAddressValue(a common.Address) AddressValue {
+// addressValueMemoryUsage is the memory usage of an address value,
+// which is the byte size of an address
+//
+var addressValueMemoryUsage = common.MemoryUsage{
+	Kind:   common.MemoryKindAddressValue,
+	Amount: common.AddressLength,
+}
+
+// NewAddressValue returns an address value,
+// and meters the memory used by the address
+//
+func NewAddressValue(interpreter *Interpreter, a common.Address) AddressValue {
+	interpreter.UseMemory(addressValueMemoryUsage)
 	return NewAddressValueFromBytes(a[:])
 }
 
@@ -15097,11 +15264,12 @@ func accountGetCapabilityFunction(
 				borrowStaticType = ConvertSemaToStaticType(borrowType)
 			}
 
-			return &CapabilityValue{
-				Address:    addressValue,
-				Path:       path,
-				BorrowType: borrowStaticType,
-			}
+			return NewCapabilityValue(
+				invocation.Interpreter,
+				addressValue,
+				path,
+				borrowStaticType,
+			)
 		},
 		funcType,
 	)
@@ -15116,6 +15284,87 @@ type PathValue struct {
 
 var EmptyPathValue = PathValue{}
 
+// newPathMemoryUsage returns the memory usage of a path value,
+// which is proportional to the length of its domain and its identifier,
+// plus one for the separator.
+// All path values are metered as MemoryKindPath.
+//
+func newPathMemoryUsage(domain common.PathDomain, identifier string) common.MemoryUsage {
+	return common.MemoryUsage{
+		Kind:   common.MemoryKindPath,
+		Amount: pathMemoryAmount(domain, identifier),
+	}
+}
+
+// newPathDomainMemoryUsage returns the memory usage of a path value in its domain,
+// i.e. the same amount as newPathMemoryUsage,
+// metered as a separate kind for each path domain.
+//
+func newPathDomainMemoryUsage(domain common.PathDomain, identifier string) common.MemoryUsage {
+	var kind common.MemoryKind
+	switch domain {
+	case common.PathDomainStorage:
+		kind = common.MemoryKindStoragePath
+	case common.PathDomainPublic:
+		kind = common.MemoryKindPublicPath
+	case common.PathDomainPrivate:
+		kind = common.MemoryKindPrivatePath
+	default:
+		panic(errors.NewUnreachableError())
+	}
+
+	return common.MemoryUsage{
+		Kind:   kind,
+		Amount: pathMemoryAmount(domain, identifier),
+	}
+}
+
+func pathMemoryAmount(domain common.PathDomain, identifier string) uint64 {
+	return uint64(len(domain.Identifier()) + len(identifier) + 1)
+}
+
+// storagePathCostFactor is the factor by which the memory usage of a storage path
+// used in an account storage operation is multiplied, as storage paths are persisted
+//
+const storagePathCostFactor = 2
+
+// newStoragePathMemoryUsage returns the memory usage of a path
+// used in an account storage operation.
+// Storage paths are metered as MemoryKindStorageCapabilityPath, at a higher cost,
+// public and private paths are metered as MemoryKindTransientPath.
+//
+func newStoragePathMemoryUsage(path PathValue) common.MemoryUsage {
+	amount := pathMemoryAmount(path.Domain, path.Identifier)
+
+	if path.Domain == common.PathDomainStorage {
+		return common.MemoryUsage{
+			Kind:   common.MemoryKindStorageCapabilityPath,
+			Amount: amount * storagePathCostFactor,
+		}
+	}
+
+	return common.MemoryUsage{
+		Kind:   common.MemoryKindTransientPath,
+		Amount: amount,
+	}
+}
+
+// NewPathValue returns a path value,
+// and meters the memory used by the path
+//
+func NewPathValue(
+	interpreter *Interpreter,
+	domain common.PathDomain,
+	identifier string,
+) PathValue {
+	interpreter.UseMemory(newPathMemoryUsage(domain, identifier))
+	interpreter.UseMemory(newPathDomainMemoryUsage(domain, identifier))
+	return PathValue{
+		Domain:     domain,
+		Identifier: identifier,
+	}
+}
+
 var _ Value = PathValue{}
 var _ atree.Storable = PathValue{}
 var _ EquatableValue = PathValue{}
@@ -15346,6 +15595,28 @@ type CapabilityValue struct {
 	BorrowType StaticType
 }
 
+var capabilityValueMemoryUsage = common.MemoryUsage{
+	Kind:   common.MemoryKindCapability,
+	Amount: 1,
+}
+
+// NewCapabilityValue returns a capability value,
+// and meters the memory used by the capability value
+//
+func NewCapabilityValue(
+	interpreter *Interpreter,
+	address AddressValue,
+	path PathValue,
+	borrowType StaticType,
+) *CapabilityValue {
+	interpreter.UseMemory(capabilityValueMemoryUsage)
+	return &CapabilityValue{
+		Address:    address,
+		Path:       path,
+		BorrowType: borrowType,
+	}
+}
+
 var _ Value = &CapabilityValue{}
 var _ atree.Storable = &CapabilityValue{}
 var _ EquatableValue = &CapabilityValue{}
@@ -15536,6 +15807,31 @@ type LinkValue struct {
 	Type       StaticType
 }
 
+// newLinkMemoryUsage returns the memory usage of a link to the given target path,
+// which is proportional to the length of the target path string, e.g. `/storage/x`
+//
+func newLinkMemoryUsage(targetPath PathValue) common.MemoryUsage {
+	return common.MemoryUsage{
+		Kind:   common.MemoryKindLink,
+		Amount: uint64(len(targetPath.String())),
+	}
+}
+
+// NewLinkValue returns a link value,
+// and meters the memory used by the link
+//
+func NewLinkValue(
+	interpreter *Interpreter,
+	targetPath PathValue,
+	linkType StaticType,
+) LinkValue {
+	interpreter.UseMemory(newLinkMemoryUsage(targetPath))
+	return LinkValue{
+		TargetPath: targetPath,
+		Type:       linkType,
+	}
+}
+
 var _ Value = LinkValue{}
 var _ atree.Value = LinkValue{}
 var _ EquatableValue = LinkValue{}
@@ -15645,6 +15941,16 @@ func (v LinkValue) ChildStorables() []atree.Storable {
 	}
 }
 
+// newPublicKeyMemoryUsage returns the memory usage of a public key value,
+// which is proportional to the length of the key bytes.
+//
+func newPublicKeyMemoryUsage(length int) common.MemoryUsage {
+	return common.MemoryUsage{
+		Kind:   common.MemoryKindPublicKey,
+		Amount: uint64(length),
+	}
+}
+
 // NewPublicKeyValue constructs a PublicKey value.
 func NewPublicKeyValue(
 	interpreter *Interpreter,
@@ -15654,6 +15960,8 @@ func NewPublicKeyValue(
 	validatePublicKey PublicKeyValidationHandlerFunc,
 ) *CompositeValue {
 
+	interpreter.UseMemory(newPublicKeyMemoryUsage(publicKey.Count()))
+
 	fields := []CompositeField{
 		{
 			Name:  sema.PublicKeySignAlgoField,
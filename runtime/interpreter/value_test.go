@@ -961,7 +961,7 @@ func TestStringer(t *testing.T) {
 			expected: `{"b": 99, "a": 42}`,
 		},
 		"Address": {
-			value:    NewAddressValue(common.Address{0, 0, 0, 0, 0, 0, 0, 1}),
+			value:    AddressValue{0, 0, 0, 0, 0, 0, 0, 1},
 			expected: "0x0000000000000001",
 		},
 		"composite": {
@@ -1423,14 +1423,14 @@ func TestGetHashInput(t *testing.T) {
 			),
 		},
 		"Character": {
-			value: NewCharacterValue("ᄀᄀᄀ각ᆨᆨ"),
+			value: CharacterValue("ᄀᄀᄀ각ᆨᆨ"),
 			expected: []byte{
 				byte(HashInputTypeCharacter),
 				0xe1, 0x84, 0x80, 0xe1, 0x84, 0x80, 0xe1, 0x84, 0x80, 0xea, 0xb0, 0x81, 0xe1, 0x86, 0xa8, 0xe1, 0x86, 0xa8,
 			},
 		},
 		"Address": {
-			value:    NewAddressValue(common.Address{0, 0, 0, 0, 0, 0, 0, 1}),
+			value:    AddressValue{0, 0, 0, 0, 0, 0, 0, 1},
 			expected: []byte{byte(HashInputTypeAddress), 0, 0, 0, 0, 0, 0, 0, 1},
 		},
 		"enum": {
@@ -32,6 +32,8 @@ type VariableActivation struct {
 	Depth      int
 	Parent     *VariableActivation
 	isFunction bool
+	// isCaptured is true if the activation was captured by a closure
+	isCaptured bool
 }
 
 func NewVariableActivation(parent *VariableActivation) *VariableActivation {
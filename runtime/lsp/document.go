@@ -0,0 +1,288 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lsp
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	runtimeErrors "github.com/onflow/cadence/runtime/errors"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/stdlib"
+	"github.com/onflow/cadence/runtime/tools"
+)
+
+// lintOptions are the checker options used to lint documents.
+// Like the runtime, they declare the standard library
+//
+var lintOptions = []sema.Option{
+	sema.WithPredeclaredValues(
+		append(
+			append(
+				stdlib.FlowBuiltInFunctions(stdlib.DefaultFlowBuiltinImpls()),
+				stdlib.BuiltinFunctions...,
+			).ToSemaValueDeclarations(),
+			stdlib.BuiltinValues.ToSemaValueDeclarations()...,
+		),
+	),
+	sema.WithPredeclaredTypes(
+		append(
+			stdlib.FlowBuiltInTypes,
+			stdlib.BuiltinTypes...,
+		).ToTypeDeclarations(),
+	),
+}
+
+// document is an open text document
+//
+type document struct {
+	uri  string
+	text string
+	// program is the parsed program, or nil if the document could not be parsed
+	program *ast.Program
+	// elaboration is the elaboration of the checked program,
+	// or nil if the document could not be checked
+	elaboration *sema.Elaboration
+	// symbolTable is nil if the program could not be parsed or has redeclarations
+	symbolTable *tools.SymbolTable
+	diagnostics []Diagnostic
+}
+
+// symbolAt returns the symbol which the identifier at the given position refers to
+//
+func (d *document) symbolAt(position Position) (*tools.Symbol, bool) {
+	if d.symbolTable == nil {
+		return nil, false
+	}
+
+	return d.symbolTable.LookupAt(ast.Position{
+		Offset: d.offset(position),
+	})
+}
+
+// symbolType returns the type of the given symbol,
+// or the empty string if the type is not known.
+//
+func (d *document) symbolType(symbol *tools.Symbol) string {
+	if symbol.Parameter != nil {
+		return symbol.Parameter.TypeAnnotation.String()
+	}
+
+	switch declaration := symbol.Declaration.(type) {
+	case *ast.FieldDeclaration:
+		return declaration.TypeAnnotation.String()
+
+	case *ast.VariableDeclaration:
+		if d.elaboration != nil {
+			if ty := d.elaboration.VariableDeclarationTargetTypes[declaration]; ty != nil {
+				return ty.QualifiedString()
+			}
+		}
+		if declaration.TypeAnnotation != nil {
+			return declaration.TypeAnnotation.String()
+		}
+
+	case *ast.FunctionDeclaration:
+		if d.elaboration != nil {
+			if ty := d.elaboration.FunctionDeclarationFunctionTypes[declaration]; ty != nil {
+				return ty.QualifiedString()
+			}
+		}
+
+	case *ast.CompositeDeclaration:
+		if d.elaboration != nil {
+			if ty := d.elaboration.CompositeDeclarationTypes[declaration]; ty != nil {
+				return ty.QualifiedString()
+			}
+		}
+
+	case *ast.InterfaceDeclaration:
+		if d.elaboration != nil {
+			if ty := d.elaboration.InterfaceDeclarationTypes[declaration]; ty != nil {
+				return ty.QualifiedString()
+			}
+		}
+	}
+
+	return ""
+}
+
+// offset returns the byte offset of the given position in the text.
+// Positions after the end of a line or the text are clamped.
+//
+func (d *document) offset(position Position) int {
+	text := d.text
+
+	offset := 0
+	for line := 0; line < position.Line; line++ {
+		index := strings.IndexByte(text[offset:], '\n')
+		if index < 0 {
+			return len(text)
+		}
+		offset += index + 1
+	}
+
+	for character := 0; character < position.Character && offset < len(text); {
+		r, size := utf8.DecodeRuneInString(text[offset:])
+		if r == '\n' {
+			break
+		}
+		character += utf16Length(r)
+		offset += size
+	}
+
+	return offset
+}
+
+// position returns the position of the given byte offset in the text
+//
+func (d *document) position(offset int) Position {
+	if offset > len(d.text) {
+		offset = len(d.text)
+	}
+
+	prefix := d.text[:offset]
+	lineStart := strings.LastIndexByte(prefix, '\n') + 1
+
+	character := 0
+	for _, r := range prefix[lineStart:] {
+		character += utf16Length(r)
+	}
+
+	return Position{
+		Line:      strings.Count(prefix, "\n"),
+		Character: character,
+	}
+}
+
+// astRange returns the range of the given AST positions.
+// The end position of AST ranges is inclusive, the end position of protocol ranges is exclusive.
+//
+func (d *document) astRange(startPos, endPos ast.Position) Range {
+	endOffset := endPos.Offset
+	if endOffset < len(d.text) {
+		_, size := utf8.DecodeRuneInString(d.text[endOffset:])
+		endOffset += size
+	}
+
+	return Range{
+		Start: d.position(startPos.Offset),
+		End:   d.position(endOffset),
+	}
+}
+
+func utf16Length(r rune) int {
+	if r >= 0x10000 {
+		return 2
+	}
+	return 1
+}
+
+// errorDiagnostics returns the diagnostics for the given error
+// returned by the runtime when parsing and checking the document
+//
+func (d *document) errorDiagnostics(err error) []Diagnostic {
+	var errs []error
+
+	var parsingCheckingErr *runtime.ParsingCheckingError
+	if errors.As(err, &parsingCheckingErr) {
+		errs = flattenErrors(parsingCheckingErr.Err)
+	} else {
+		errs = []error{err}
+	}
+
+	diagnostics := make([]Diagnostic, 0, len(errs))
+
+	for _, err := range errs {
+		var diagnosticRange Range
+		if positioned, ok := err.(ast.HasPosition); ok {
+			diagnosticRange = d.astRange(
+				positioned.StartPosition(),
+				positioned.EndPosition(),
+			)
+		}
+
+		diagnostics = append(
+			diagnostics,
+			Diagnostic{
+				Range:    diagnosticRange,
+				Severity: DiagnosticSeverityError,
+				Source:   diagnosticSource,
+				Message:  err.Error(),
+			},
+		)
+	}
+
+	return diagnostics
+}
+
+// hintDiagnostics returns the diagnostics for the hints
+// which the checker reports for the program of the document.
+//
+// The program is also linted if it is invalid, or was only partially parsed.
+// The errors of the linter are not reported,
+// the errors of the runtime are reported by errorDiagnostics.
+//
+func (d *document) hintDiagnostics(location common.Location) []Diagnostic {
+	hints, _ := sema.Lint(d.program, location, lintOptions...)
+
+	diagnostics := make([]Diagnostic, 0, len(hints))
+
+	for _, hint := range hints {
+		diagnostics = append(
+			diagnostics,
+			Diagnostic{
+				Range: d.astRange(
+					hint.StartPosition(),
+					hint.EndPosition(),
+				),
+				Severity: DiagnosticSeverityHint,
+				Source:   diagnosticSource,
+				Message:  hint.Hint(),
+			},
+		)
+	}
+
+	return diagnostics
+}
+
+// flattenErrors returns all errors in the given error tree.
+// Errors in imported programs are not flattened,
+// as they are not located in the document.
+//
+func flattenErrors(err error) []error {
+	if _, ok := err.(*sema.ImportedProgramError); ok {
+		return []error{err}
+	}
+
+	parentErr, ok := err.(runtimeErrors.ParentError)
+	if !ok {
+		return []error{err}
+	}
+
+	var errs []error
+	for _, childErr := range parentErr.ChildErrors() {
+		errs = append(errs, flattenErrors(childErr)...)
+	}
+	return errs
+}
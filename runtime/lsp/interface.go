@@ -0,0 +1,73 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lsp
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+// checkingInterface is the default runtime interface of the server.
+//
+// It only implements the functions which are needed to parse and check a document:
+// Programs are not cached, and imports are reported as errors.
+// All other functions are unimplemented and must not be called.
+//
+type checkingInterface struct {
+	runtime.Interface
+}
+
+func (checkingInterface) ResolveLocation(
+	identifiers []runtime.Identifier,
+	location runtime.Location,
+) ([]runtime.ResolvedLocation, error) {
+	return []runtime.ResolvedLocation{
+		{
+			Location:    location,
+			Identifiers: identifiers,
+		},
+	}, nil
+}
+
+func (checkingInterface) GetCode(location runtime.Location) ([]byte, error) {
+	return nil, fmt.Errorf("cannot import %s: imports are not supported", location)
+}
+
+func (checkingInterface) GetAccountContractCode(address runtime.Address, name string) ([]byte, error) {
+	location := common.AddressLocation{
+		Address: address,
+		Name:    name,
+	}
+	return nil, fmt.Errorf("cannot import %s: imports are not supported", location)
+}
+
+func (checkingInterface) GetProgram(_ runtime.Location) (*interpreter.Program, error) {
+	return nil, nil
+}
+
+func (checkingInterface) SetProgram(_ runtime.Location, _ *interpreter.Program) error {
+	return nil
+}
+
+func (checkingInterface) MeterMemory(_ common.MemoryUsage) error {
+	return nil
+}
@@ -0,0 +1,352 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package lsp implements a server for the Language Server Protocol (LSP),
+// which provides hover information, go to definition, and diagnostics
+// for Cadence documents.
+//
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/parser2"
+	"github.com/onflow/cadence/runtime/tools"
+)
+
+const diagnosticSource = "cadence"
+
+// Server is a language server for Cadence documents.
+//
+// Documents are parsed and checked by the runtime when they are opened or changed,
+// and are synced by always sending their full content.
+//
+type Server struct {
+	runtime          runtime.Runtime
+	runtimeInterface runtime.Interface
+	documents        map[string]*document
+}
+
+// Option is a function that configures the server.
+type Option func(*Server)
+
+// WithRuntimeInterface returns a server option which sets
+// the runtime interface used to parse and check documents,
+// e.g. to resolve imports.
+//
+// By default, imports are not supported.
+//
+func WithRuntimeInterface(runtimeInterface runtime.Interface) Option {
+	return func(server *Server) {
+		server.runtimeInterface = runtimeInterface
+	}
+}
+
+func NewServer(runtime runtime.Runtime, options ...Option) *Server {
+	server := &Server{
+		runtime:          runtime,
+		runtimeInterface: checkingInterface{},
+		documents:        map[string]*document{},
+	}
+
+	for _, option := range options {
+		option(server)
+	}
+
+	return server
+}
+
+// Run serves the protocol over stdin and stdout
+//
+func (s *Server) Run() error {
+	return s.Serve(os.Stdin, os.Stdout)
+}
+
+// Serve reads messages from the given reader and writes responses to the given writer,
+// until the reader is exhausted or the client sends the exit notification.
+//
+func (s *Server) Serve(reader io.Reader, writer io.Writer) error {
+	bufferedReader := bufio.NewReader(reader)
+
+	for {
+		content, err := readMessage(bufferedReader)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var req request
+		err = json.Unmarshal(content, &req)
+		if err != nil {
+			err = writeMessage(writer, response{
+				JSONRPC: jsonRPCVersion,
+				Error: &responseError{
+					Code:    codeParseError,
+					Message: err.Error(),
+				},
+			})
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		if req.Method == "exit" {
+			return nil
+		}
+
+		result, err := s.handle(req.Method, req.Params)
+
+		// Notifications have no response
+		if req.ID == nil {
+			continue
+		}
+
+		res := response{
+			JSONRPC: jsonRPCVersion,
+			ID:      req.ID,
+		}
+
+		if err == nil {
+			res.Result, err = json.Marshal(result)
+		}
+
+		if err != nil {
+			var resErr *responseError
+			if !errors.As(err, &resErr) {
+				resErr = &responseError{
+					Code:    codeInvalidRequest,
+					Message: err.Error(),
+				}
+			}
+			res.Result = nil
+			res.Error = resErr
+		}
+
+		err = writeMessage(writer, res)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) handle(method string, params json.RawMessage) (interface{}, error) {
+	switch method {
+	case "initialize":
+		return s.initialize(), nil
+
+	case "initialized", "shutdown":
+		return nil, nil
+
+	case "textDocument/didOpen":
+		var didOpenParams DidOpenTextDocumentParams
+		err := decodeParams(params, &didOpenParams)
+		if err != nil {
+			return nil, err
+		}
+		textDocument := didOpenParams.TextDocument
+		s.update(textDocument.URI, textDocument.Text)
+		return nil, nil
+
+	case "textDocument/didChange":
+		var didChangeParams DidChangeTextDocumentParams
+		err := decodeParams(params, &didChangeParams)
+		if err != nil {
+			return nil, err
+		}
+		changes := didChangeParams.ContentChanges
+		if len(changes) > 0 {
+			// Each change is the full content, so only the last change is relevant
+			s.update(didChangeParams.TextDocument.URI, changes[len(changes)-1].Text)
+		}
+		return nil, nil
+
+	case "textDocument/didClose":
+		var didCloseParams DidCloseTextDocumentParams
+		err := decodeParams(params, &didCloseParams)
+		if err != nil {
+			return nil, err
+		}
+		delete(s.documents, didCloseParams.TextDocument.URI)
+		return nil, nil
+
+	case "textDocument/hover":
+		var positionParams TextDocumentPositionParams
+		err := decodeParams(params, &positionParams)
+		if err != nil {
+			return nil, err
+		}
+		return s.hover(positionParams), nil
+
+	case "textDocument/definition":
+		var positionParams TextDocumentPositionParams
+		err := decodeParams(params, &positionParams)
+		if err != nil {
+			return nil, err
+		}
+		return s.definition(positionParams), nil
+
+	case "textDocument/diagnostic":
+		var diagnosticParams DocumentDiagnosticParams
+		err := decodeParams(params, &diagnosticParams)
+		if err != nil {
+			return nil, err
+		}
+		return s.diagnostic(diagnosticParams), nil
+
+	default:
+		return nil, &responseError{
+			Code:    codeMethodNotFound,
+			Message: fmt.Sprintf("method not found: %s", method),
+		}
+	}
+}
+
+func decodeParams(params json.RawMessage, result interface{}) error {
+	err := json.Unmarshal(params, result)
+	if err != nil {
+		return &responseError{
+			Code:    codeInvalidParams,
+			Message: err.Error(),
+		}
+	}
+	return nil
+}
+
+func (s *Server) initialize() InitializeResult {
+	return InitializeResult{
+		Capabilities: ServerCapabilities{
+			TextDocumentSync:   TextDocumentSyncKindFull,
+			HoverProvider:      true,
+			DefinitionProvider: true,
+			DiagnosticProvider: &DiagnosticOptions{},
+		},
+	}
+}
+
+// update parses and checks the given text of the document with the given URI
+//
+func (s *Server) update(uri string, text string) {
+	doc := &document{
+		uri:         uri,
+		text:        text,
+		diagnostics: []Diagnostic{},
+	}
+
+	location := common.StringLocation(uri)
+
+	program, err := s.runtime.ParseAndCheckProgram(
+		[]byte(text),
+		runtime.Context{
+			Interface: s.runtimeInterface,
+			Location:  location,
+		},
+	)
+	if err == nil {
+		doc.program = program.Program
+		doc.elaboration = program.Elaboration
+	} else {
+		doc.diagnostics = doc.errorDiagnostics(err)
+
+		// The program may still be parsed, at least partially, even if it is invalid,
+		// so definitions can be looked up and hints can be reported
+		doc.program, _ = parser2.ParseProgram(text, parser2.WithErrorRecovery(true))
+	}
+
+	if doc.program != nil {
+		doc.symbolTable, _ = tools.BuildSymbolTable(doc.program)
+		doc.diagnostics = append(doc.diagnostics, doc.hintDiagnostics(location)...)
+	}
+
+	s.documents[uri] = doc
+}
+
+func (s *Server) hover(params TextDocumentPositionParams) *Hover {
+	doc, ok := s.documents[params.TextDocument.URI]
+	if !ok {
+		return nil
+	}
+
+	symbol, ok := doc.symbolAt(params.Position)
+	if !ok {
+		return nil
+	}
+
+	ty := doc.symbolType(symbol)
+	if ty == "" {
+		return nil
+	}
+
+	return &Hover{
+		Contents: MarkupContent{
+			Kind:  "plaintext",
+			Value: ty,
+		},
+	}
+}
+
+func (s *Server) definition(params TextDocumentPositionParams) *Location {
+	doc, ok := s.documents[params.TextDocument.URI]
+	if !ok {
+		return nil
+	}
+
+	symbol, ok := doc.symbolAt(params.Position)
+	if !ok {
+		return nil
+	}
+
+	identifier := symbol.Identifier
+
+	// Some symbols are not declared by an identifier,
+	// e.g. `self` in transactions
+	if identifier.Identifier == "" {
+		return nil
+	}
+
+	return &Location{
+		URI: doc.uri,
+		Range: doc.astRange(
+			identifier.StartPosition(),
+			identifier.EndPosition(),
+		),
+	}
+}
+
+func (s *Server) diagnostic(params DocumentDiagnosticParams) FullDocumentDiagnosticReport {
+	report := FullDocumentDiagnosticReport{
+		Kind:  "full",
+		Items: []Diagnostic{},
+	}
+
+	doc, ok := s.documents[params.TextDocument.URI]
+	if ok {
+		report.Items = doc.diagnostics
+	}
+
+	return report
+}
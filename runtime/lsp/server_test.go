@@ -0,0 +1,386 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime"
+)
+
+const testURI = "file:///test.cdc"
+
+type testClient struct {
+	input  bytes.Buffer
+	nextID int
+}
+
+func (c *testClient) request(t *testing.T, method string, params interface{}) int {
+	c.nextID++
+	c.write(t, method, params, c.nextID)
+	return c.nextID
+}
+
+func (c *testClient) notify(t *testing.T, method string, params interface{}) {
+	c.write(t, method, params, nil)
+}
+
+func (c *testClient) write(t *testing.T, method string, params interface{}, id interface{}) {
+	message := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  method,
+		"params":  params,
+	}
+	if id != nil {
+		message["id"] = id
+	}
+
+	err := writeMessage(&c.input, message)
+	require.NoError(t, err)
+}
+
+type testResponse struct {
+	ID     int             `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *responseError  `json:"error"`
+}
+
+// serve runs the server with the requests of the client,
+// and returns the responses by ID
+//
+func (c *testClient) serve(t *testing.T) map[int]testResponse {
+	server := NewServer(runtime.NewInterpreterRuntime())
+
+	var output bytes.Buffer
+	err := server.Serve(&c.input, &output)
+	require.NoError(t, err)
+
+	responses := map[int]testResponse{}
+
+	reader := bufio.NewReader(&output)
+	for {
+		content, err := readMessage(reader)
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		var res testResponse
+		err = json.Unmarshal(content, &res)
+		require.NoError(t, err)
+
+		responses[res.ID] = res
+	}
+
+	return responses
+}
+
+func decodeResult(t *testing.T, res testResponse, result interface{}) {
+	require.Nil(t, res.Error)
+	err := json.Unmarshal(res.Result, result)
+	require.NoError(t, err)
+}
+
+func openParams(text string) DidOpenTextDocumentParams {
+	return DidOpenTextDocumentParams{
+		TextDocument: TextDocumentItem{
+			URI:        testURI,
+			LanguageID: "cadence",
+			Version:    1,
+			Text:       text,
+		},
+	}
+}
+
+func positionParams(line, character int) TextDocumentPositionParams {
+	return TextDocumentPositionParams{
+		TextDocument: TextDocumentIdentifier{URI: testURI},
+		Position:     Position{Line: line, Character: character},
+	}
+}
+
+func TestServer(t *testing.T) {
+
+	t.Parallel()
+
+	const code = `
+pub struct S {
+    pub let x: Int
+
+    init(x: Int) {
+        self.x = x
+    }
+}
+
+pub fun test(): Int {
+    let s = S(x: 1)
+    return s.x
+}
+`
+
+	t.Run("initialize", func(t *testing.T) {
+
+		t.Parallel()
+
+		client := &testClient{}
+		id := client.request(t, "initialize", map[string]interface{}{})
+		client.notify(t, "exit", nil)
+
+		var result InitializeResult
+		decodeResult(t, client.serve(t)[id], &result)
+
+		assert.True(t, result.Capabilities.HoverProvider)
+		assert.True(t, result.Capabilities.DefinitionProvider)
+		assert.NotNil(t, result.Capabilities.DiagnosticProvider)
+	})
+
+	t.Run("hover", func(t *testing.T) {
+
+		t.Parallel()
+
+		client := &testClient{}
+		client.notify(t, "textDocument/didOpen", openParams(code))
+
+		// `s` in `return s.x`
+		variableID := client.request(t, "textDocument/hover", positionParams(11, 11))
+		// `x` in `self.x = x`
+		parameterID := client.request(t, "textDocument/hover", positionParams(5, 17))
+		// `test`
+		functionID := client.request(t, "textDocument/hover", positionParams(9, 9))
+		// `Int`
+		builtinID := client.request(t, "textDocument/hover", positionParams(2, 16))
+
+		responses := client.serve(t)
+
+		var hover *Hover
+
+		decodeResult(t, responses[variableID], &hover)
+		assert.Equal(t, "S", hover.Contents.Value)
+
+		decodeResult(t, responses[parameterID], &hover)
+		assert.Equal(t, "Int", hover.Contents.Value)
+
+		decodeResult(t, responses[functionID], &hover)
+		assert.Equal(t, "((): Int)", hover.Contents.Value)
+
+		hover = nil
+		decodeResult(t, responses[builtinID], &hover)
+		assert.Nil(t, hover)
+	})
+
+	t.Run("definition", func(t *testing.T) {
+
+		t.Parallel()
+
+		client := &testClient{}
+		client.notify(t, "textDocument/didOpen", openParams(code))
+
+		// `x` in `self.x`
+		fieldID := client.request(t, "textDocument/definition", positionParams(5, 13))
+		// `S` in `S(x: 1)`
+		compositeID := client.request(t, "textDocument/definition", positionParams(10, 12))
+
+		responses := client.serve(t)
+
+		var location Location
+
+		decodeResult(t, responses[fieldID], &location)
+		assert.Equal(t,
+			Location{
+				URI: testURI,
+				Range: Range{
+					Start: Position{Line: 2, Character: 12},
+					End:   Position{Line: 2, Character: 13},
+				},
+			},
+			location,
+		)
+
+		decodeResult(t, responses[compositeID], &location)
+		assert.Equal(t,
+			Location{
+				URI: testURI,
+				Range: Range{
+					Start: Position{Line: 1, Character: 11},
+					End:   Position{Line: 1, Character: 12},
+				},
+			},
+			location,
+		)
+	})
+
+	t.Run("diagnostics", func(t *testing.T) {
+
+		t.Parallel()
+
+		client := &testClient{}
+
+		client.notify(t, "textDocument/didOpen", openParams(code))
+		validID := client.request(t, "textDocument/diagnostic", DocumentDiagnosticParams{
+			TextDocument: TextDocumentIdentifier{URI: testURI},
+		})
+
+		client.notify(t, "textDocument/didChange", DidChangeTextDocumentParams{
+			TextDocument: TextDocumentIdentifier{URI: testURI},
+			ContentChanges: []TextDocumentContentChangeEvent{
+				{Text: "pub let x: Int = \"\"\npub let y: Bool = 1"},
+			},
+		})
+		invalidID := client.request(t, "textDocument/diagnostic", DocumentDiagnosticParams{
+			TextDocument: TextDocumentIdentifier{URI: testURI},
+		})
+
+		client.notify(t, "textDocument/didChange", DidChangeTextDocumentParams{
+			TextDocument: TextDocumentIdentifier{URI: testURI},
+			ContentChanges: []TextDocumentContentChangeEvent{
+				{Text: "pub let x = "},
+			},
+		})
+		syntaxErrorID := client.request(t, "textDocument/diagnostic", DocumentDiagnosticParams{
+			TextDocument: TextDocumentIdentifier{URI: testURI},
+		})
+
+		responses := client.serve(t)
+
+		var report FullDocumentDiagnosticReport
+
+		decodeResult(t, responses[validID], &report)
+		assert.Equal(t, "full", report.Kind)
+		assert.Empty(t, report.Items)
+
+		decodeResult(t, responses[invalidID], &report)
+		require.Len(t, report.Items, 2)
+		assert.Equal(t,
+			Range{
+				Start: Position{Line: 0, Character: 17},
+				End:   Position{Line: 0, Character: 19},
+			},
+			report.Items[0].Range,
+		)
+		assert.Equal(t,
+			Range{
+				Start: Position{Line: 1, Character: 18},
+				End:   Position{Line: 1, Character: 19},
+			},
+			report.Items[1].Range,
+		)
+
+		decodeResult(t, responses[syntaxErrorID], &report)
+		require.Len(t, report.Items, 1)
+		assert.Equal(t, DiagnosticSeverityError, report.Items[0].Severity)
+	})
+
+	t.Run("error recovery and hints", func(t *testing.T) {
+
+		t.Parallel()
+
+		// The first declaration is invalid,
+		// but the rest of the program is still parsed and linted
+
+		const invalidCode = `fun broken( {}
+
+pub fun test(): Int {
+    let unused = 1
+    let x = 2
+    return x
+}
+`
+
+		client := &testClient{}
+		client.notify(t, "textDocument/didOpen", openParams(invalidCode))
+
+		diagnosticID := client.request(t, "textDocument/diagnostic", DocumentDiagnosticParams{
+			TextDocument: TextDocumentIdentifier{URI: testURI},
+		})
+		// `x` in `return x`
+		definitionID := client.request(t, "textDocument/definition", positionParams(5, 11))
+
+		responses := client.serve(t)
+
+		var report FullDocumentDiagnosticReport
+		decodeResult(t, responses[diagnosticID], &report)
+		require.Len(t, report.Items, 2)
+
+		assert.Equal(t, DiagnosticSeverityError, report.Items[0].Severity)
+		assert.Equal(t, 0, report.Items[0].Range.Start.Line)
+
+		assert.Equal(t, DiagnosticSeverityHint, report.Items[1].Severity)
+		assert.Contains(t, report.Items[1].Message, "unused")
+		assert.Equal(t,
+			Range{
+				Start: Position{Line: 3, Character: 8},
+				End:   Position{Line: 3, Character: 14},
+			},
+			report.Items[1].Range,
+		)
+
+		var location Location
+		decodeResult(t, responses[definitionID], &location)
+		assert.Equal(t,
+			Location{
+				URI: testURI,
+				Range: Range{
+					Start: Position{Line: 4, Character: 8},
+					End:   Position{Line: 4, Character: 9},
+				},
+			},
+			location,
+		)
+	})
+
+	t.Run("unknown method", func(t *testing.T) {
+
+		t.Parallel()
+
+		client := &testClient{}
+		id := client.request(t, "unknown", nil)
+
+		res := client.serve(t)[id]
+		require.NotNil(t, res.Error)
+		assert.Equal(t, codeMethodNotFound, res.Error.Code)
+	})
+}
+
+func TestDocumentPositions(t *testing.T) {
+
+	t.Parallel()
+
+	doc := &document{
+		text: "a\n😀b\nc",
+	}
+
+	for offset, position := range map[int]Position{
+		0: {Line: 0, Character: 0},
+		2: {Line: 1, Character: 0},
+		// the emoji is 4 bytes in UTF-8, and 2 code units in UTF-16
+		6: {Line: 1, Character: 2},
+		8: {Line: 2, Character: 0},
+	} {
+		assert.Equal(t, position, doc.position(offset), fmt.Sprintf("offset %d", offset))
+		assert.Equal(t, offset, doc.offset(position), fmt.Sprintf("position %v", position))
+	}
+}
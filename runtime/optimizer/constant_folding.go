@@ -0,0 +1,191 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package optimizer
+
+import (
+	"math/big"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// ConstantFoldingPass is a rewriter which folds arithmetic on integer literals,
+// e.g. `4 * 1024 * 1024` is replaced with `4194304`.
+//
+// Only addition, subtraction, and multiplication are folded,
+// and only if the result type is an integer type which checks for overflow and underflow,
+// e.g. `Int`, `Int8`, or `UInt64`, but not `Word8`.
+//
+// The pass operates on a checked program: The types of expressions are determined from the elaboration,
+// and the types of the folded integer literals are recorded in it.
+//
+// If the result of a folded expression is not in the range of its type,
+// the expression is not folded, and an OverflowError or UnderflowError is recorded instead.
+//
+type ConstantFoldingPass struct {
+	elaboration *sema.Elaboration
+	err         error
+}
+
+var _ ast.Rewriter = &ConstantFoldingPass{}
+
+func NewConstantFoldingPass(elaboration *sema.Elaboration) *ConstantFoldingPass {
+	return &ConstantFoldingPass{
+		elaboration: elaboration,
+	}
+}
+
+// Err returns the first error which occurred while folding, if any
+//
+func (p *ConstantFoldingPass) Err() error {
+	return p.err
+}
+
+func (p *ConstantFoldingPass) Rewrite(element ast.Element) ast.Element {
+	binaryExpression, ok := element.(*ast.BinaryExpression)
+	if !ok {
+		return element
+	}
+
+	folded := p.foldBinaryExpression(binaryExpression)
+	if folded == nil {
+		return element
+	}
+
+	return folded
+}
+
+func (p *ConstantFoldingPass) foldBinaryExpression(expression *ast.BinaryExpression) *ast.IntegerExpression {
+
+	left, ok := expression.Left.(*ast.IntegerExpression)
+	if !ok {
+		return nil
+	}
+
+	right, ok := expression.Right.(*ast.IntegerExpression)
+	if !ok {
+		return nil
+	}
+
+	// The operands of arithmetic operations have the same type,
+	// which is also the type of the result
+
+	resultType := p.elaboration.IntegerExpressionType[left]
+	if resultType == nil ||
+		!resultType.Equal(p.elaboration.IntegerExpressionType[right]) ||
+		!isOverflowCheckedIntegerType(resultType) {
+
+		return nil
+	}
+
+	result := new(big.Int)
+
+	switch expression.Operation {
+	case ast.OperationPlus:
+		result.Add(left.Value, right.Value)
+
+	case ast.OperationMinus:
+		result.Sub(left.Value, right.Value)
+
+	case ast.OperationMul:
+		result.Mul(left.Value, right.Value)
+
+	default:
+		return nil
+	}
+
+	expressionRange := ast.NewRangeFromPositioned(expression)
+
+	rangedType := resultType.(sema.IntegerRangedType)
+
+	if minInt := rangedType.MinInt(); minInt != nil && result.Cmp(minInt) < 0 {
+		p.report(&UnderflowError{
+			Type:  resultType,
+			Range: expressionRange,
+		})
+		return nil
+	}
+
+	if maxInt := rangedType.MaxInt(); maxInt != nil && result.Cmp(maxInt) > 0 {
+		p.report(&OverflowError{
+			Type:  resultType,
+			Range: expressionRange,
+		})
+		return nil
+	}
+
+	folded := &ast.IntegerExpression{
+		PositiveLiteral: new(big.Int).Abs(result).String(),
+		Value:           result,
+		Base:            10,
+		Range:           expressionRange,
+	}
+
+	p.elaboration.IntegerExpressionType[folded] = resultType
+
+	return folded
+}
+
+func (p *ConstantFoldingPass) report(err error) {
+	if p.err != nil {
+		return
+	}
+	p.err = err
+}
+
+// isOverflowCheckedIntegerType returns true if the given type is a concrete integer type
+// which checks for overflow and underflow.
+//
+// Word types wrap around instead, and are therefore not folded.
+//
+func isOverflowCheckedIntegerType(ty sema.Type) bool {
+	rangedType, ok := ty.(sema.IntegerRangedType)
+	if !ok || rangedType.IsSuperType() {
+		return false
+	}
+
+	switch ty {
+	case sema.Word8Type,
+		sema.Word16Type,
+		sema.Word32Type,
+		sema.Word64Type:
+
+		return false
+	}
+
+	return sema.IsSubType(ty, sema.IntegerType)
+}
+
+// FoldConstants folds the arithmetic on integer literals in the given checked program,
+// using a ConstantFoldingPass.
+//
+// The program is rewritten in place, and the elaboration is updated.
+// If a folded expression overflows or underflows, the error is returned.
+//
+func FoldConstants(program *ast.Program, elaboration *sema.Elaboration) (*ast.Program, error) {
+	pass := NewConstantFoldingPass(elaboration)
+
+	folded := ast.Rewrite(pass, program).(*ast.Program)
+
+	if err := pass.Err(); err != nil {
+		return nil, err
+	}
+
+	return folded, nil
+}
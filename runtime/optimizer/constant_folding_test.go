@@ -0,0 +1,298 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package optimizer_test
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/optimizer"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/tests/checker"
+)
+
+func parseCheckAndFold(t *testing.T, code string) (*sema.Checker, *ast.Program, error) {
+	checker, err := checker.ParseAndCheck(t, code)
+	require.NoError(t, err)
+
+	program, err := optimizer.FoldConstants(checker.Program, checker.Elaboration)
+
+	return checker, program, err
+}
+
+func invokeTest(t *testing.T, checker *sema.Checker, program *ast.Program) (interpreter.Value, error) {
+	inter, err := interpreter.NewInterpreter(
+		&interpreter.Program{
+			Program:     program,
+			Elaboration: checker.Elaboration,
+		},
+		checker.Location,
+		interpreter.WithStorage(interpreter.NewInMemoryStorage()),
+	)
+	require.NoError(t, err)
+
+	err = inter.Interpret()
+	require.NoError(t, err)
+
+	return inter.Invoke("test")
+}
+
+// foldedReturnExpression returns the expression returned by the function `test`
+//
+func foldedReturnExpression(program *ast.Program) ast.Expression {
+	function := program.FunctionDeclarations()[0]
+	statement := function.FunctionBlock.Block.Statements[0].(*ast.ReturnStatement)
+	return statement.Expression
+}
+
+func TestConstantFolding(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("nested", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, program, err := parseCheckAndFold(t, `
+          fun test(): Int {
+              return 4 * 1024 * 1024 - 2 + 3
+          }
+        `)
+		require.NoError(t, err)
+
+		expression := foldedReturnExpression(program)
+		require.IsType(t, &ast.IntegerExpression{}, expression)
+
+		integerExpression := expression.(*ast.IntegerExpression)
+		assert.Equal(t, big.NewInt(4194305), integerExpression.Value)
+		assert.Equal(t, "4194305", integerExpression.PositiveLiteral)
+		assert.Equal(t, 10, integerExpression.Base)
+		assert.Equal(t, sema.IntType, checker.Elaboration.IntegerExpressionType[integerExpression])
+
+		value, err := invokeTest(t, checker, program)
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.NewIntValueFromInt64(4194305), value)
+	})
+
+	t.Run("negative result", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, program, err := parseCheckAndFold(t, `
+          fun test(): Int8 {
+              return 3 - 5
+          }
+        `)
+		require.NoError(t, err)
+
+		expression := foldedReturnExpression(program)
+		require.IsType(t, &ast.IntegerExpression{}, expression)
+
+		integerExpression := expression.(*ast.IntegerExpression)
+		assert.Equal(t, big.NewInt(-2), integerExpression.Value)
+		assert.Equal(t, "2", integerExpression.PositiveLiteral)
+		assert.Equal(
+			t,
+			ast.Range{
+				StartPos: ast.Position{Offset: 51, Line: 3, Column: 21},
+				EndPos:   ast.Position{Offset: 55, Line: 3, Column: 25},
+			},
+			integerExpression.Range,
+		)
+
+		value, err := invokeTest(t, checker, program)
+		require.NoError(t, err)
+		assert.Equal(t, interpreter.Int8Value(-2), value)
+	})
+
+	t.Run("partially constant", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, program, err := parseCheckAndFold(t, `
+          fun test(x: Int): Int {
+              return x + 2 * 3
+          }
+        `)
+		require.NoError(t, err)
+
+		expression := foldedReturnExpression(program)
+		require.IsType(t, &ast.BinaryExpression{}, expression)
+
+		binaryExpression := expression.(*ast.BinaryExpression)
+		assert.IsType(t, &ast.IdentifierExpression{}, binaryExpression.Left)
+		require.IsType(t, &ast.IntegerExpression{}, binaryExpression.Right)
+		assert.Equal(t, big.NewInt(6), binaryExpression.Right.(*ast.IntegerExpression).Value)
+	})
+
+	t.Run("division is not folded", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, program, err := parseCheckAndFold(t, `
+          fun test(): Int {
+              return 7 / 2
+          }
+        `)
+		require.NoError(t, err)
+
+		assert.IsType(t, &ast.BinaryExpression{}, foldedReturnExpression(program))
+	})
+
+	t.Run("word types are not folded", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, program, err := parseCheckAndFold(t, `
+          fun test(): Word8 {
+              return 255 + 1
+          }
+        `)
+		require.NoError(t, err)
+
+		assert.IsType(t, &ast.BinaryExpression{}, foldedReturnExpression(program))
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, _, err := parseCheckAndFold(t, `
+          fun test(): UInt8 {
+              return 200 + 100
+          }
+        `)
+		require.Error(t, err)
+
+		var overflowErr *optimizer.OverflowError
+		require.ErrorAs(t, err, &overflowErr)
+		assert.Equal(t, sema.UInt8Type, overflowErr.Type)
+		assert.Equal(t, 3, overflowErr.StartPos.Line)
+	})
+
+	t.Run("underflow", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, _, err := parseCheckAndFold(t, `
+          fun test(): Int8 {
+              return -100 - 100
+          }
+        `)
+		require.Error(t, err)
+
+		var underflowErr *optimizer.UnderflowError
+		require.ErrorAs(t, err, &underflowErr)
+		assert.Equal(t, sema.Int8Type, underflowErr.Type)
+	})
+}
+
+type constantFoldingTestType struct {
+	ty       sema.IntegerRangedType
+	min, max int64
+}
+
+var constantFoldingTestTypes = []constantFoldingTestType{
+	{sema.IntType, -1000000, 1000000},
+	{sema.Int8Type, -128, 127},
+	{sema.Int16Type, -32768, 32767},
+	{sema.Int64Type, -(1 << 40), 1 << 40},
+	{sema.UInt8Type, 0, 255},
+	{sema.UInt32Type, 0, 1 << 20},
+	{sema.UInt64Type, 0, 1 << 40},
+}
+
+var constantFoldingTestOperations = []string{"+", "-", "*"}
+
+func randomConstantExpression(random *rand.Rand, testType constantFoldingTestType, depth int) string {
+	if depth == 0 || random.Intn(4) == 0 {
+		return fmt.Sprint(testType.min + random.Int63n(testType.max-testType.min+1))
+	}
+
+	return fmt.Sprintf(
+		"(%s %s %s)",
+		randomConstantExpression(random, testType, depth-1),
+		constantFoldingTestOperations[random.Intn(len(constantFoldingTestOperations))],
+		randomConstantExpression(random, testType, depth-1),
+	)
+}
+
+// TestConstantFoldingPreservesSemantics checks that randomly generated constant expressions
+// evaluate to the same result when folded and when not folded,
+// and that folding reports an error if and only if the evaluation overflows or underflows
+//
+func TestConstantFoldingPreservesSemantics(t *testing.T) {
+
+	t.Parallel()
+
+	const iterations = 200
+
+	random := rand.New(rand.NewSource(42))
+
+	for i := 0; i < iterations; i++ {
+
+		testType := constantFoldingTestTypes[random.Intn(len(constantFoldingTestTypes))]
+
+		code := fmt.Sprintf(
+			`
+              fun test(): %s {
+                  return %s
+              }
+            `,
+			testType.ty,
+			randomConstantExpression(random, testType, 3),
+		)
+
+		// Check the program twice,
+		// as folding rewrites the program in place
+
+		unfoldedChecker, err := checker.ParseAndCheck(t, code)
+		require.NoError(t, err, code)
+
+		expected, expectedErr := invokeTest(t, unfoldedChecker, unfoldedChecker.Program)
+
+		foldedChecker, foldedProgram, foldErr := parseCheckAndFold(t, code)
+
+		if expectedErr != nil {
+			overflow := errors.As(expectedErr, &interpreter.OverflowError{})
+			underflow := errors.As(expectedErr, &interpreter.UnderflowError{})
+			require.True(t, overflow || underflow, code)
+
+			require.Error(t, foldErr, code)
+			continue
+		}
+
+		require.NoError(t, foldErr, code)
+
+		assert.IsType(t, &ast.IntegerExpression{}, foldedReturnExpression(foldedProgram), code)
+
+		actual, err := invokeTest(t, foldedChecker, foldedProgram)
+		require.NoError(t, err, code)
+
+		assert.Equal(t, expected, actual, code)
+	}
+}
@@ -0,0 +1,56 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package optimizer
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// OverflowError is reported when the result of a folded constant expression
+// is greater than the maximum value of its type
+//
+type OverflowError struct {
+	Type sema.Type
+	ast.Range
+}
+
+func (e *OverflowError) Error() string {
+	return fmt.Sprintf(
+		"constant expression overflows type `%s`",
+		e.Type.QualifiedString(),
+	)
+}
+
+// UnderflowError is reported when the result of a folded constant expression
+// is less than the minimum value of its type
+//
+type UnderflowError struct {
+	Type sema.Type
+	ast.Range
+}
+
+func (e *UnderflowError) Error() string {
+	return fmt.Sprintf(
+		"constant expression underflows type `%s`",
+		e.Type.QualifiedString(),
+	)
+}
@@ -19,7 +19,7 @@
 package parser2
 
 import (
-	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/onflow/cadence/runtime/parser2/lexer"
@@ -45,7 +45,8 @@ func (p *parser) parseCommentContent() (comment string) {
 
 				switch p.current.Type {
 				case lexer.TokenEOF:
-					p.report(fmt.Errorf(
+					p.report(p.codedSyntaxError(
+						SyntaxErrorCodeUnterminatedComment,
 						"missing comment end %q",
 						lexer.TokenBlockCommentEnd,
 					))
@@ -66,7 +67,10 @@ func (p *parser) parseCommentContent() (comment string) {
 					return []trampoline{t, t}
 
 				default:
-					p.report(fmt.Errorf(
+					p.report(p.expectedError(
+						SyntaxErrorCodeUnexpectedToken,
+						"",
+						strconv.Quote(p.current.Type.String()),
 						"unexpected token in comment: %q",
 						p.current.Type,
 					))
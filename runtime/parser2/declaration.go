@@ -47,9 +47,18 @@ func parseDeclarations(p *parser, endTokenType lexer.TokenType) (declarations []
 			return
 
 		default:
-			declaration := parseDeclaration(p, docString)
-			if declaration == nil {
-				return
+			var declaration ast.Declaration
+
+			if p.errorRecovery {
+				declaration = parseDeclarationOrRecover(p, docString)
+				if declaration == nil {
+					continue
+				}
+			} else {
+				declaration = parseDeclaration(p, docString)
+				if declaration == nil {
+					return
+				}
 			}
 
 			declarations = append(declarations, declaration)
@@ -57,10 +66,272 @@ func parseDeclarations(p *parser, endTokenType lexer.TokenType) (declarations []
 	}
 }
 
+// parseAnnotations parses the annotations preceding a declaration, if any.
+//
+//     annotation : '@' identifier ( '(' arguments ')' )?
+//
+func parseAnnotations(p *parser) (annotations []*ast.AnnotationDeclaration) {
+	for {
+		p.skipSpaceAndComments(true)
+
+		if !p.current.Is(lexer.TokenAt) {
+			return
+		}
+
+		annotations = append(annotations, parseAnnotation(p))
+	}
+}
+
+func parseAnnotation(p *parser) *ast.AnnotationDeclaration {
+	startPos := p.current.StartPos
+
+	// Skip the `@`
+	p.next()
+
+	if !p.current.Is(lexer.TokenIdentifier) {
+		panic(p.expectedError(
+			SyntaxErrorCodeMissingIdentifier,
+			lexer.TokenIdentifier.String(),
+			p.current.Type.String(),
+			"expected identifier after '@', got %s",
+			p.current.Type,
+		))
+	}
+
+	identifier := tokenToIdentifier(p.current)
+	endPos := p.current.EndPos
+
+	// Skip the identifier
+	p.next()
+
+	// The argument list must directly follow the identifier
+
+	var arguments ast.Arguments
+	if p.current.Is(lexer.TokenParenOpen) {
+		// Skip the opening paren
+		p.next()
+
+		arguments, endPos = parseArgumentListRemainder(p)
+		if arguments == nil {
+			arguments = ast.Arguments{}
+		}
+	}
+
+	return &ast.AnnotationDeclaration{
+		Identifier: identifier,
+		Arguments:  arguments,
+		Range: ast.Range{
+			StartPos: startPos,
+			EndPos:   endPos,
+		},
+	}
+}
+
+func annotationsStartPos(annotations []*ast.AnnotationDeclaration) *ast.Position {
+	if len(annotations) == 0 {
+		return nil
+	}
+	return &annotations[0].StartPos
+}
+
+// attachAnnotations attaches the given annotations to the given declaration,
+// which is parsed after the annotations.
+//
+func attachAnnotations(
+	p *parser,
+	declaration ast.Declaration,
+	annotations []*ast.AnnotationDeclaration,
+) ast.Declaration {
+
+	if len(annotations) == 0 {
+		return declaration
+	}
+
+	switch declaration := declaration.(type) {
+	case nil:
+		panic(p.expectedError(
+			SyntaxErrorCodeMissingDeclaration,
+			"declaration",
+			p.current.Type.String(),
+			"expected declaration after annotation, got %s",
+			p.current.Type,
+		))
+
+	case *ast.CompositeDeclaration:
+		declaration.Annotations = annotations
+
+	case *ast.InterfaceDeclaration:
+		declaration.Annotations = annotations
+
+	case *ast.AttachmentDeclaration:
+		declaration.Annotations = annotations
+
+	case *ast.FunctionDeclaration:
+		declaration.Annotations = annotations
+
+	case *ast.SpecialFunctionDeclaration:
+		declaration.FunctionDeclaration.Annotations = annotations
+
+	case *ast.FieldDeclaration:
+		declaration.Annotations = annotations
+
+	case *ast.EnumCaseDeclaration:
+		declaration.Annotations = annotations
+
+	case *ast.VariableDeclaration:
+		declaration.Annotations = annotations
+
+	default:
+		panic(p.codedSyntaxError(
+			SyntaxErrorCodeInvalidAnnotation,
+			"invalid annotation for %s",
+			declaration.DeclarationKind().Name(),
+		))
+	}
+
+	return declaration
+}
+
+// parseDeclarationOrRecover parses a declaration like parseDeclaration,
+// but does not abort parsing if the declaration is malformed.
+//
+// Instead, the error is reported, tokens are skipped until the next recovery point,
+// and nil is returned, so parsing can continue with the next declaration.
+//
+func parseDeclarationOrRecover(p *parser, docString string) (declaration ast.Declaration) {
+
+	startOffset := p.current.StartPos.Offset
+	bufferingDepth := len(p.backtrackingCursorStack)
+
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+
+		err, ok := r.(error)
+		if !ok {
+			err = fmt.Errorf("parser: %v", r)
+		}
+
+		// The declaration might have been aborted while buffering:
+		// Abandon all buffering started since, but keep the buffered errors
+
+		p.abandonBuffering(bufferingDepth)
+
+		p.report(err)
+		p.skipToRecoveryPoint(startOffset)
+
+		declaration = nil
+	}()
+
+	declaration = parseDeclaration(p, docString)
+	if declaration == nil {
+		p.report(p.expectedError(
+			SyntaxErrorCodeUnexpectedToken,
+			"",
+			p.current.Type.String(),
+			"unexpected token: %s",
+			p.current.Type,
+		))
+		p.skipToRecoveryPoint(startOffset)
+	}
+
+	return declaration
+}
+
+// skipToRecoveryPoint skips tokens until the parsing of a declaration can be attempted again,
+// i.e. after a semicolon or closing brace, before a declaration keyword, annotation, or pragma,
+// or at the end of the input.
+//
+// Nested blocks are skipped as a whole.
+// At least one token is skipped if the current token is still at the given start offset,
+// so the parser always makes progress.
+//
+func (p *parser) skipToRecoveryPoint(startOffset int) {
+
+	if p.current.StartPos.Offset == startOffset &&
+		!p.current.Is(lexer.TokenEOF) {
+
+		p.next()
+	}
+
+	depth := 0
+
+	for {
+		switch p.current.Type {
+		case lexer.TokenEOF:
+			return
+
+		case lexer.TokenBraceOpen:
+			depth++
+
+		case lexer.TokenBraceClose:
+			if depth == 0 {
+				// Skip the closing brace
+				p.next()
+				return
+			}
+			depth--
+
+		case lexer.TokenSemicolon:
+			if depth == 0 {
+				// Skip the semicolon
+				p.next()
+				return
+			}
+
+		case lexer.TokenPragma, lexer.TokenAt:
+			if depth == 0 {
+				return
+			}
+
+		case lexer.TokenIdentifier:
+			if depth == 0 && isDeclarationKeyword(p.current.Value) {
+				return
+			}
+		}
+
+		p.next()
+	}
+}
+
+func isDeclarationKeyword(value interface{}) bool {
+	switch value {
+	case keywordLet, keywordVar,
+		keywordFun, keywordNative,
+		keywordImport,
+		keywordEvent,
+		keywordStruct, keywordResource, keywordContract, keywordEnum,
+		KeywordTransaction,
+		keywordAttachment,
+		keywordTypeAlias,
+		keywordPriv, keywordPub, keywordAccess:
+
+		return true
+	}
+
+	return false
+}
+
+// parseDeclaration parses a declaration, including the annotations preceding it, if any.
+//
+//     declaration : annotation* unannotatedDeclaration
+//
 func parseDeclaration(p *parser, docString string) ast.Declaration {
+	annotations := parseAnnotations(p)
+	declaration := parseUnannotatedDeclaration(p, annotationsStartPos(annotations), docString)
+	return attachAnnotations(p, declaration, annotations)
+}
+
+// parseUnannotatedDeclaration parses a declaration.
+//
+// The given start position is the start position of the annotations of the declaration, if any.
+//
+func parseUnannotatedDeclaration(p *parser, startPos *ast.Position, docString string) ast.Declaration {
 
 	access := ast.AccessNotSpecified
-	var accessPos *ast.Position
+	accessPos := startPos
 
 	for {
 		p.skipSpaceAndComments(true)
@@ -68,7 +339,10 @@ func parseDeclaration(p *parser, docString string) ast.Declaration {
 		switch p.current.Type {
 		case lexer.TokenPragma:
 			if access != ast.AccessNotSpecified {
-				panic(fmt.Errorf("invalid access modifier for pragma"))
+				panic(p.codedSyntaxError(
+					SyntaxErrorCodeInvalidAccessModifier,
+					"invalid access modifier for pragma",
+				))
 			}
 			return parsePragmaDeclaration(p)
 		case lexer.TokenIdentifier:
@@ -79,6 +353,9 @@ func parseDeclaration(p *parser, docString string) ast.Declaration {
 			case keywordFun:
 				return parseFunctionDeclaration(p, false, access, accessPos, docString)
 
+			case keywordNative:
+				return parseNativeFunctionDeclaration(p, access, accessPos, docString)
+
 			case keywordImport:
 				return parseImportDeclaration(p)
 
@@ -88,18 +365,35 @@ func parseDeclaration(p *parser, docString string) ast.Declaration {
 			case keywordStruct, keywordResource, keywordContract, keywordEnum:
 				return parseCompositeOrInterfaceDeclaration(p, access, accessPos, docString)
 
+			case keywordInterface:
+				panic(p.missingInterfaceCompositeKindError())
+
+			case keywordAttachment:
+				return parseAttachmentDeclaration(p, access, accessPos, docString)
+
+			case keywordTypeAlias:
+				return parseTypeAliasDeclaration(p, access, accessPos, docString)
+
 			case KeywordTransaction:
 				if access != ast.AccessNotSpecified {
-					panic(fmt.Errorf("invalid access modifier for transaction"))
+					panic(p.codedSyntaxError(
+						SyntaxErrorCodeInvalidAccessModifier,
+						"invalid access modifier for transaction",
+					))
 				}
 				return parseTransactionDeclaration(p, docString)
 
 			case keywordPriv, keywordPub, keywordAccess:
 				if access != ast.AccessNotSpecified {
-					panic(fmt.Errorf("invalid second access modifier"))
+					panic(p.codedSyntaxError(
+						SyntaxErrorCodeInvalidAccessModifier,
+						"invalid second access modifier",
+					))
+				}
+				if accessPos == nil {
+					pos := p.current.StartPos
+					accessPos = &pos
 				}
-				pos := p.current.StartPos
-				accessPos = &pos
 				access = parseAccess(p)
 				continue
 			}
@@ -116,6 +410,11 @@ func parseDeclaration(p *parser, docString string) ast.Declaration {
 //         | 'pub' ( '(' 'set' ')' )?
 //         | 'access' '(' ( 'self' | 'contract' | 'account' | 'all' ) ')'
 //
+// `access(all)` is equivalent to `pub`. Only `set` is allowed in `pub(...)`,
+// so `pub(all)` is rejected with a hint to use either `pub` or `access(all)`.
+//
+// In strict access mode, a warning is reported when `pub` is used instead of `access(all)`.
+//
 func parseAccess(p *parser) ast.Access {
 
 	switch p.current.Value {
@@ -125,10 +424,17 @@ func parseAccess(p *parser) ast.Access {
 		return ast.AccessPrivate
 
 	case keywordPub:
+		pubRange := p.current.Range
+
 		// Skip the `pub` keyword
 		p.next()
 		p.skipSpaceAndComments(true)
 		if !p.current.Is(lexer.TokenParenOpen) {
+			if p.strictAccess {
+				p.warn(&PubAccessWarning{
+					Range: pubRange,
+				})
+			}
 			return ast.AccessPublic
 		}
 
@@ -137,14 +443,34 @@ func parseAccess(p *parser) ast.Access {
 		p.skipSpaceAndComments(true)
 
 		if !p.current.Is(lexer.TokenIdentifier) {
-			panic(fmt.Errorf(
+			panic(p.expectedError(
+				SyntaxErrorCodeMissingKeyword,
+				strconv.Quote(keywordSet),
+				p.current.Type.String(),
 				"expected keyword %q, got %s",
 				keywordSet,
 				p.current.Type,
 			))
 		}
 		if p.current.Value != keywordSet {
-			panic(fmt.Errorf(
+			if p.current.Value == keywordAll {
+				panic(p.expectedError(
+					SyntaxErrorCodeMissingKeyword,
+					strconv.Quote(keywordSet),
+					fmt.Sprintf("%q", p.current.Value),
+					"expected keyword %q, got %q; use %q or %q instead of %q",
+					keywordSet,
+					p.current.Value,
+					keywordPub,
+					"access(all)",
+					"pub(all)",
+				))
+			}
+
+			panic(p.expectedError(
+				SyntaxErrorCodeMissingKeyword,
+				strconv.Quote(keywordSet),
+				fmt.Sprintf("%q", p.current.Value),
 				"expected keyword %q, got %q",
 				keywordSet,
 				p.current.Value,
@@ -168,18 +494,23 @@ func parseAccess(p *parser) ast.Access {
 
 		p.skipSpaceAndComments(true)
 
+		expectedKeywords := common.EnumerateWords(
+			[]string{
+				strconv.Quote(keywordAll),
+				strconv.Quote(keywordAccount),
+				strconv.Quote(keywordContract),
+				strconv.Quote(keywordSelf),
+			},
+			"or",
+		)
+
 		if !p.current.Is(lexer.TokenIdentifier) {
-			panic(fmt.Errorf(
+			panic(p.expectedError(
+				SyntaxErrorCodeMissingKeyword,
+				expectedKeywords,
+				p.current.Type.String(),
 				"expected keyword %s, got %s",
-				common.EnumerateWords(
-					[]string{
-						strconv.Quote(keywordAll),
-						strconv.Quote(keywordAccount),
-						strconv.Quote(keywordContract),
-						strconv.Quote(keywordSelf),
-					},
-					"or",
-				),
+				expectedKeywords,
 				p.current.Type,
 			))
 		}
@@ -200,17 +531,12 @@ func parseAccess(p *parser) ast.Access {
 			access = ast.AccessPrivate
 
 		default:
-			panic(fmt.Errorf(
+			panic(p.expectedError(
+				SyntaxErrorCodeMissingKeyword,
+				expectedKeywords,
+				fmt.Sprintf("%q", p.current.Value),
 				"expected keyword %s, got %q",
-				common.EnumerateWords(
-					[]string{
-						strconv.Quote(keywordAll),
-						strconv.Quote(keywordAccount),
-						strconv.Quote(keywordContract),
-						strconv.Quote(keywordSelf),
-					},
-					"or",
-				),
+				expectedKeywords,
 				p.current.Value,
 			))
 		}
@@ -233,7 +559,7 @@ func parseAccess(p *parser) ast.Access {
 //     variableKind : 'var' | 'let'
 //
 //     variableDeclaration :
-//         variableKind identifier ( ':' typeAnnotation )?
+//         variableKind ( identifier | tuplePattern ) ( ':' typeAnnotation )?
 //         transfer expression
 //         ( transfer expression )?
 //
@@ -255,17 +581,30 @@ func parseVariableDeclaration(
 	p.next()
 
 	p.skipSpaceAndComments(true)
-	if !p.current.Is(lexer.TokenIdentifier) {
-		panic(fmt.Errorf(
+
+	var identifier ast.Identifier
+	var pattern *ast.TuplePattern
+
+	switch p.current.Type {
+	case lexer.TokenIdentifier:
+		identifier = tokenToIdentifier(p.current)
+
+		// Skip the identifier
+		p.next()
+
+	case lexer.TokenParenOpen:
+		pattern = parseTuplePattern(p)
+
+	default:
+		panic(p.expectedError(
+			SyntaxErrorCodeMissingIdentifier,
+			lexer.TokenIdentifier.String(),
+			p.current.Type.String(),
 			"expected identifier after start of variable declaration, got %s",
 			p.current.Type,
 		))
 	}
 
-	identifier := tokenToIdentifier(p.current)
-
-	// Skip the identifier
-	p.next()
 	p.skipSpaceAndComments(true)
 
 	var typeAnnotation *ast.TypeAnnotation
@@ -281,7 +620,12 @@ func parseVariableDeclaration(
 	p.skipSpaceAndComments(true)
 	transfer := parseTransfer(p)
 	if transfer == nil {
-		panic(fmt.Errorf("expected transfer"))
+		panic(p.expectedError(
+			SyntaxErrorCodeMissingTransfer,
+			"transfer",
+			"",
+			"expected transfer",
+		))
 	}
 
 	value := parseExpression(p, lowestBindingPower)
@@ -298,6 +642,7 @@ func parseVariableDeclaration(
 		Access:         access,
 		IsConstant:     isLet,
 		Identifier:     identifier,
+		Pattern:        pattern,
 		TypeAnnotation: typeAnnotation,
 		Value:          value,
 		Transfer:       transfer,
@@ -315,6 +660,81 @@ func parseVariableDeclaration(
 	return variableDeclaration
 }
 
+// parseTuplePattern parses a tuple pattern of a destructuring variable declaration.
+//
+//     tuplePattern : '(' tuplePatternElement ( ',' tuplePatternElement )* ')'
+//
+//     tuplePatternElement : identifier | tuplePattern
+//
+func parseTuplePattern(p *parser) *ast.TuplePattern {
+	startPos := p.current.StartPos
+
+	// Skip the opening paren
+	p.next()
+
+	var elements []*ast.TuplePatternElement
+
+	for {
+		p.skipSpaceAndComments(true)
+
+		switch p.current.Type {
+		case lexer.TokenIdentifier:
+			identifier := tokenToIdentifier(p.current)
+			elements = append(elements, &ast.TuplePatternElement{
+				Identifier: &identifier,
+			})
+
+			// Skip the identifier
+			p.next()
+
+		case lexer.TokenParenOpen:
+			elements = append(elements, &ast.TuplePatternElement{
+				Pattern: parseTuplePattern(p),
+			})
+
+		default:
+			panic(p.expectedError(
+				SyntaxErrorCodeMissingIdentifier,
+				"identifier or pattern",
+				p.current.Type.String(),
+				"expected identifier or pattern in tuple pattern, got %s",
+				p.current.Type,
+			))
+		}
+
+		p.skipSpaceAndComments(true)
+
+		switch p.current.Type {
+		case lexer.TokenComma:
+			// Skip the comma
+			p.next()
+
+		case lexer.TokenParenClose:
+			endPos := p.current.EndPos
+
+			// Skip the closing paren
+			p.next()
+
+			return &ast.TuplePattern{
+				Elements: elements,
+				Range: ast.Range{
+					StartPos: startPos,
+					EndPos:   endPos,
+				},
+			}
+
+		default:
+			panic(p.expectedError(
+				SyntaxErrorCodeUnexpectedToken,
+				"comma or end of tuple pattern",
+				p.current.Type.String(),
+				"expected comma or end of tuple pattern, got %s",
+				p.current.Type,
+			))
+		}
+	}
+}
+
 // parseTransfer parses a transfer.
 //
 //     transfer : '=' | '<-' | '<-!'
@@ -383,12 +803,25 @@ func parseImportDeclaration(p *parser) *ast.ImportDeclaration {
 
 		switch p.current.Type {
 		case lexer.TokenString:
-			parsedString, errs := parseStringLiteral(p.current.Value.(string))
+			parsedString, errs := parseStringLiteral(p, p.current.Value.(string))
+			p.report(errs...)
+			location = common.StringLocation(parsedString)
+
+		case lexer.TokenMultilineString:
+			parsedString, errs := parseMultilineStringLiteral(p, p.current.Value.(string))
 			p.report(errs...)
 			location = common.StringLocation(parsedString)
 
 		case lexer.TokenHexadecimalIntegerLiteral:
-			location = parseHexadecimalLocation(p.current.Value.(string))
+			addressLocation, err := parseHexadecimalLocation(p.current.Value.(string))
+			if err != nil {
+				p.report(p.codedSyntaxError(
+					SyntaxErrorCodeInvalidAddress,
+					"%s",
+					err,
+				))
+			}
+			location = addressLocation
 
 		default:
 			panic(errors.NewUnreachableError())
@@ -406,7 +839,7 @@ func parseImportDeclaration(p *parser) *ast.ImportDeclaration {
 
 	parseLocation := func() {
 		switch p.current.Type {
-		case lexer.TokenString, lexer.TokenHexadecimalIntegerLiteral:
+		case lexer.TokenString, lexer.TokenMultilineString, lexer.TokenHexadecimalIntegerLiteral:
 			parseStringOrAddressLocation()
 
 		case lexer.TokenIdentifier:
@@ -415,7 +848,10 @@ func parseImportDeclaration(p *parser) *ast.ImportDeclaration {
 			p.next()
 
 		default:
-			panic(fmt.Errorf(
+			panic(p.expectedError(
+				SyntaxErrorCodeUnexpectedToken,
+				"string, address, or identifier",
+				p.current.Type.String(),
 				"unexpected token in import declaration: got %s, expected string, address, or identifier",
 				p.current.Type,
 			))
@@ -433,7 +869,10 @@ func parseImportDeclaration(p *parser) *ast.ImportDeclaration {
 			switch p.current.Type {
 			case lexer.TokenComma:
 				if !expectCommaOrFrom {
-					panic(fmt.Errorf(
+					panic(p.expectedError(
+						SyntaxErrorCodeUnexpectedToken,
+						fmt.Sprintf("%s or keyword %q", lexer.TokenIdentifier, keywordFrom),
+						p.current.Type.String(),
 						"expected %s or keyword %q, got %s",
 						lexer.TokenIdentifier,
 						keywordFrom,
@@ -457,7 +896,10 @@ func parseImportDeclaration(p *parser) *ast.ImportDeclaration {
 					}
 
 					if !isNextTokenCommaOrFrom(p) {
-						panic(fmt.Errorf(
+						panic(p.expectedError(
+							SyntaxErrorCodeMissingIdentifier,
+							lexer.TokenIdentifier.String(),
+							fmt.Sprintf("keyword %q", p.current.Value),
 							"expected %s, got keyword %q",
 							lexer.TokenIdentifier,
 							p.current.Value,
@@ -474,14 +916,20 @@ func parseImportDeclaration(p *parser) *ast.ImportDeclaration {
 				expectCommaOrFrom = true
 
 			case lexer.TokenEOF:
-				panic(fmt.Errorf(
+				panic(p.expectedError(
+					SyntaxErrorCodeUnexpectedEnd,
+					fmt.Sprintf("%s or %s", lexer.TokenIdentifier, lexer.TokenComma),
+					"",
 					"unexpected end in import declaration: expected %s or %s",
 					lexer.TokenIdentifier,
 					lexer.TokenComma,
 				))
 
 			default:
-				panic(fmt.Errorf(
+				panic(p.expectedError(
+					SyntaxErrorCodeUnexpectedToken,
+					fmt.Sprintf("keyword %q or %s", keywordFrom, lexer.TokenComma),
+					p.current.Type.String(),
 					"unexpected token in import declaration: got %s, expected keyword %q or %s",
 					p.current.Type,
 					keywordFrom,
@@ -517,7 +965,7 @@ func parseImportDeclaration(p *parser) *ast.ImportDeclaration {
 	p.skipSpaceAndComments(true)
 
 	switch p.current.Type {
-	case lexer.TokenString, lexer.TokenHexadecimalIntegerLiteral:
+	case lexer.TokenString, lexer.TokenMultilineString, lexer.TokenHexadecimalIntegerLiteral:
 		parseStringOrAddressLocation()
 
 	case lexer.TokenIdentifier:
@@ -536,12 +984,15 @@ func parseImportDeclaration(p *parser) *ast.ImportDeclaration {
 		case lexer.TokenIdentifier:
 			maybeParseFromIdentifier(identifier)
 
-		case lexer.TokenEOF:
+		case lexer.TokenEOF, lexer.TokenSemicolon, lexer.TokenPragma:
 			// The previous identifier is the identifier location
 			setIdentifierLocation(identifier)
 
 		default:
-			panic(fmt.Errorf(
+			panic(p.expectedError(
+				SyntaxErrorCodeUnexpectedToken,
+				fmt.Sprintf("keyword %q or %s", keywordFrom, lexer.TokenComma),
+				p.current.Type.String(),
 				"unexpected token in import declaration: got %s, expected keyword %q or %s",
 				p.current.Type,
 				keywordFrom,
@@ -550,10 +1001,18 @@ func parseImportDeclaration(p *parser) *ast.ImportDeclaration {
 		}
 
 	case lexer.TokenEOF:
-		panic(fmt.Errorf("unexpected end in import declaration: expected string, address, or identifier"))
+		panic(p.expectedError(
+			SyntaxErrorCodeUnexpectedEnd,
+			"string, address, or identifier",
+			"",
+			"unexpected end in import declaration: expected string, address, or identifier",
+		))
 
 	default:
-		panic(fmt.Errorf(
+		panic(p.expectedError(
+			SyntaxErrorCodeUnexpectedToken,
+			"string, address, or identifier",
+			p.current.Type.String(),
 			"unexpected token in import declaration: got %s, expected string, address, or identifier",
 			p.current.Type,
 		))
@@ -590,7 +1049,10 @@ func isNextTokenCommaOrFrom(p *parser) bool {
 	}
 }
 
-func parseHexadecimalLocation(literal string) common.AddressLocation {
+// parseHexadecimalLocation parses the given hexadecimal literal as an address location.
+// If the literal is not a valid address, an error and an empty location are returned.
+//
+func parseHexadecimalLocation(literal string) (common.AddressLocation, error) {
 	bytes := []byte(strings.ReplaceAll(literal[2:], "_", ""))
 
 	length := len(bytes)
@@ -602,18 +1064,17 @@ func parseHexadecimalLocation(literal string) common.AddressLocation {
 	rawAddress := make([]byte, hex.DecodedLen(length))
 	_, err := hex.Decode(rawAddress, bytes)
 	if err != nil {
-		// unreachable, hex literal should always be valid
-		panic(err)
+		return common.AddressLocation{}, fmt.Errorf("invalid address: %w", err)
 	}
 
 	address, err := common.BytesToAddress(rawAddress)
 	if err != nil {
-		panic(err)
+		return common.AddressLocation{}, err
 	}
 
 	return common.AddressLocation{
 		Address: address,
-	}
+	}, nil
 }
 
 // parseEventDeclaration parses an event declaration.
@@ -637,7 +1098,10 @@ func parseEventDeclaration(
 
 	p.skipSpaceAndComments(true)
 	if !p.current.Is(lexer.TokenIdentifier) {
-		panic(fmt.Errorf(
+		panic(p.expectedError(
+			SyntaxErrorCodeMissingIdentifier,
+			lexer.TokenIdentifier.String(),
+			p.current.Type.String(),
 			"expected identifier after start of event declaration, got %s",
 			p.current.Type,
 		))
@@ -675,6 +1139,22 @@ func parseEventDeclaration(
 	}
 }
 
+// missingInterfaceCompositeKindError returns the error for an interface declaration
+// which is not preceded by a composite kind, e.g. `interface I {}` instead of `struct interface I {}`
+//
+func (p *parser) missingInterfaceCompositeKindError() error {
+	return p.expectedError(
+		SyntaxErrorCodeMissingCompositeKind,
+		fmt.Sprintf("%q, %q, or %q", keywordStruct, keywordResource, keywordContract),
+		"",
+		"missing composite kind before keyword %q: expected %q, %q, or %q",
+		keywordInterface,
+		keywordStruct,
+		keywordResource,
+		keywordContract,
+	)
+}
+
 // parseCompositeKind parses a composite kind.
 //
 //     compositeKind : 'struct' | 'resource' | 'contract' | 'enum'
@@ -732,7 +1212,10 @@ func parseFieldWithVariableKind(
 
 	p.skipSpaceAndComments(true)
 	if !p.current.Is(lexer.TokenIdentifier) {
-		panic(fmt.Errorf(
+		panic(p.expectedError(
+			SyntaxErrorCodeMissingIdentifier,
+			lexer.TokenIdentifier.String(),
+			p.current.Type.String(),
 			"expected identifier after start of field declaration, got %s",
 			p.current.Type,
 		))
@@ -762,9 +1245,7 @@ func parseFieldWithVariableKind(
 	}
 }
 
-// parseCompositeOrInterfaceDeclaration parses an event declaration.
-//
-//     conformances : ':' nominalType ( ',' nominalType )*
+// parseCompositeOrInterfaceDeclaration parses a composite or interface declaration.
 //
 //     compositeDeclaration : compositeKind identifier conformances?
 //                            '{' membersAndNestedDeclarations '}'
@@ -795,7 +1276,10 @@ func parseCompositeOrInterfaceDeclaration(
 	for {
 		p.skipSpaceAndComments(true)
 		if !p.current.Is(lexer.TokenIdentifier) {
-			panic(fmt.Errorf(
+			panic(p.expectedError(
+				SyntaxErrorCodeMissingIdentifier,
+				lexer.TokenIdentifier.String(),
+				p.current.Type.String(),
 				"expected %s, got %s",
 				lexer.TokenIdentifier,
 				p.current.Type,
@@ -807,7 +1291,10 @@ func parseCompositeOrInterfaceDeclaration(
 		if p.current.Value == keywordInterface {
 			isInterface = true
 			if wasInterface {
-				panic(fmt.Errorf(
+				panic(p.expectedError(
+					SyntaxErrorCodeMissingIdentifier,
+					"interface name",
+					fmt.Sprintf("keyword %q", keywordInterface),
 					"expected interface name, got keyword %q",
 					keywordInterface,
 				))
@@ -825,21 +1312,7 @@ func parseCompositeOrInterfaceDeclaration(
 
 	p.skipSpaceAndComments(true)
 
-	var conformances []*ast.NominalType
-
-	if p.current.Is(lexer.TokenColon) {
-		// Skip the colon
-		p.next()
-
-		conformances, _ = parseNominalTypes(p, lexer.TokenBraceOpen)
-
-		if len(conformances) < 1 {
-			panic(fmt.Errorf(
-				"expected at least one conformance after %s",
-				lexer.TokenColon,
-			))
-		}
-	}
+	conformances := parseConformances(p)
 
 	p.skipSpaceAndComments(true)
 
@@ -860,7 +1333,10 @@ func parseCompositeOrInterfaceDeclaration(
 		// TODO: remove once interface conformances are supported
 		if len(conformances) > 0 {
 			// TODO: improve
-			panic(fmt.Errorf("unexpected conformances"))
+			panic(p.codedSyntaxError(
+				SyntaxErrorCodeUnexpectedConformances,
+				"unexpected conformances",
+			))
 		}
 
 		return &ast.InterfaceDeclaration{
@@ -884,6 +1360,175 @@ func parseCompositeOrInterfaceDeclaration(
 	}
 }
 
+// parseConformances parses the conformances of a composite or interface declaration, if any.
+//
+//     conformances : ':' nominalType ( ',' nominalType )*
+//
+func parseConformances(p *parser) []*ast.NominalType {
+	if !p.current.Is(lexer.TokenColon) {
+		return nil
+	}
+
+	// Skip the colon
+	p.next()
+
+	conformances, _ := parseNominalTypes(p, lexer.TokenBraceOpen)
+
+	if len(conformances) < 1 {
+		panic(p.expectedError(
+			SyntaxErrorCodeMissingConformance,
+			"conformance",
+			"",
+			"expected at least one conformance after %s",
+			lexer.TokenColon,
+		))
+	}
+
+	return conformances
+}
+
+// parseAttachmentDeclaration parses an attachment declaration.
+//
+//     attachmentDeclaration : 'attachment' identifier 'for' nominalType
+//                             '{' membersAndNestedDeclarations '}'
+//
+func parseAttachmentDeclaration(
+	p *parser,
+	access ast.Access,
+	accessPos *ast.Position,
+	docString string,
+) *ast.AttachmentDeclaration {
+
+	startPos := p.current.StartPos
+	if accessPos != nil {
+		startPos = *accessPos
+	}
+
+	// Skip the `attachment` keyword
+	p.next()
+
+	p.skipSpaceAndComments(true)
+	if !p.current.Is(lexer.TokenIdentifier) {
+		panic(p.expectedError(
+			SyntaxErrorCodeMissingIdentifier,
+			lexer.TokenIdentifier.String(),
+			p.current.Type.String(),
+			"expected identifier after start of attachment declaration, got %s",
+			p.current.Type,
+		))
+	}
+
+	identifier := tokenToIdentifier(p.current)
+	// Skip the identifier
+	p.next()
+
+	p.skipSpaceAndComments(true)
+	if !p.current.IsString(lexer.TokenIdentifier, keywordFor) {
+		panic(p.expectedError(
+			SyntaxErrorCodeMissingKeyword,
+			strconv.Quote(keywordFor),
+			p.current.Type.String(),
+			"expected keyword %q, got %s",
+			keywordFor,
+			p.current.Type,
+		))
+	}
+
+	// Skip the `for` keyword
+	p.next()
+
+	p.skipSpaceAndComments(true)
+	if !p.current.Is(lexer.TokenIdentifier) {
+		panic(p.expectedError(
+			SyntaxErrorCodeMissingType,
+			"base type of attachment declaration",
+			p.current.Type.String(),
+			"expected base type of attachment declaration, got %s",
+			p.current.Type,
+		))
+	}
+
+	baseTypeToken := p.current
+	// Skip the identifier
+	p.next()
+
+	baseType := parseNominalTypeRemainder(p, baseTypeToken)
+
+	p.skipSpaceAndComments(true)
+
+	p.mustOne(lexer.TokenBraceOpen)
+
+	members := parseMembersAndNestedDeclarations(p, lexer.TokenBraceClose)
+
+	p.skipSpaceAndComments(true)
+
+	endToken := p.mustOne(lexer.TokenBraceClose)
+
+	return &ast.AttachmentDeclaration{
+		Access:     access,
+		Identifier: identifier,
+		BaseType:   baseType,
+		Members:    members,
+		DocString:  docString,
+		Range: ast.Range{
+			StartPos: startPos,
+			EndPos:   endToken.EndPos,
+		},
+	}
+}
+
+// parseTypeAliasDeclaration parses a type alias declaration.
+//
+//     typeAliasDeclaration : 'typealias' identifier '=' type
+//
+func parseTypeAliasDeclaration(
+	p *parser,
+	access ast.Access,
+	accessPos *ast.Position,
+	docString string,
+) *ast.TypeAliasDeclaration {
+
+	startPos := p.current.StartPos
+	if accessPos != nil {
+		startPos = *accessPos
+	}
+
+	// Skip the `typealias` keyword
+	p.next()
+
+	p.skipSpaceAndComments(true)
+	if !p.current.Is(lexer.TokenIdentifier) {
+		panic(p.expectedError(
+			SyntaxErrorCodeMissingIdentifier,
+			lexer.TokenIdentifier.String(),
+			p.current.Type.String(),
+			"expected identifier after start of type alias declaration, got %s",
+			p.current.Type,
+		))
+	}
+
+	identifier := tokenToIdentifier(p.current)
+	// Skip the identifier
+	p.next()
+
+	p.skipSpaceAndComments(true)
+	p.mustOne(lexer.TokenEqual)
+
+	p.skipSpaceAndComments(true)
+	targetType := parseType(p, lowestBindingPower)
+
+	return &ast.TypeAliasDeclaration{
+		Access:     access,
+		Identifier: identifier,
+		TargetType: targetType,
+		DocString:  docString,
+		Range: ast.Range{
+			StartPos: startPos,
+			EndPos:   targetType.EndPosition(),
+		},
+	}
+}
+
 // parseMembersAndNestedDeclarations parses composite or interface members,
 // and nested declarations.
 //
@@ -920,22 +1565,35 @@ func parseMembersAndNestedDeclarations(p *parser, endTokenType lexer.TokenType)
 }
 
 // parseMemberOrNestedDeclaration parses a composite or interface member,
-// or a declaration nested in it.
+// or a declaration nested in it, including the annotations preceding it, if any.
 //
-//     memberOrNestedDeclaration : field
-//                               | specialFunctionDeclaration
-//                               | functionDeclaration
-//                               | interfaceDeclaration
-//                               | compositeDeclaration
-//                               | eventDeclaration
-//                               | enumCase
+//     memberOrNestedDeclaration : annotation* unannotatedMemberOrNestedDeclaration
+//
+//     unannotatedMemberOrNestedDeclaration : field
+//                                          | specialFunctionDeclaration
+//                                          | functionDeclaration
+//                                          | interfaceDeclaration
+//                                          | compositeDeclaration
+//                                          | eventDeclaration
+//                                          | enumCase
 //
 func parseMemberOrNestedDeclaration(p *parser, docString string) ast.Declaration {
+	annotations := parseAnnotations(p)
+	declaration := parseUnannotatedMemberOrNestedDeclaration(p, annotationsStartPos(annotations), docString)
+	return attachAnnotations(p, declaration, annotations)
+}
+
+// parseUnannotatedMemberOrNestedDeclaration parses a composite or interface member,
+// or a declaration nested in it.
+//
+// The given start position is the start position of the annotations of the declaration, if any.
+//
+func parseUnannotatedMemberOrNestedDeclaration(p *parser, startPos *ast.Position, docString string) ast.Declaration {
 
 	const functionBlockIsOptional = true
 
 	access := ast.AccessNotSpecified
-	var accessPos *ast.Position
+	accessPos := startPos
 
 	var previousIdentifierToken *lexer.Token
 
@@ -954,24 +1612,41 @@ func parseMemberOrNestedDeclaration(p *parser, docString string) ast.Declaration
 			case keywordFun:
 				return parseFunctionDeclaration(p, functionBlockIsOptional, access, accessPos, docString)
 
+			case keywordNative:
+				return parseNativeFunctionDeclaration(p, access, accessPos, docString)
+
 			case keywordEvent:
 				return parseEventDeclaration(p, access, accessPos, docString)
 
 			case keywordStruct, keywordResource, keywordContract, keywordEnum:
 				return parseCompositeOrInterfaceDeclaration(p, access, accessPos, docString)
 
+			case keywordInterface:
+				panic(p.missingInterfaceCompositeKindError())
+
 			case keywordPriv, keywordPub, keywordAccess:
 				if access != ast.AccessNotSpecified {
-					panic(fmt.Errorf("unexpected access modifier"))
+					panic(p.codedSyntaxError(
+						SyntaxErrorCodeInvalidAccessModifier,
+						"unexpected access modifier",
+					))
+				}
+				if accessPos == nil {
+					pos := p.current.StartPos
+					accessPos = &pos
 				}
-				pos := p.current.StartPos
-				accessPos = &pos
 				access = parseAccess(p)
 				continue
 
 			default:
 				if previousIdentifierToken != nil {
-					panic(fmt.Errorf("unexpected %s", p.current.Type))
+					panic(p.expectedError(
+						SyntaxErrorCodeUnexpectedToken,
+						"",
+						p.current.Type.String(),
+						"unexpected %s",
+						p.current.Type,
+					))
 				}
 
 				t := p.current
@@ -983,7 +1658,13 @@ func parseMemberOrNestedDeclaration(p *parser, docString string) ast.Declaration
 
 		case lexer.TokenColon:
 			if previousIdentifierToken == nil {
-				panic(fmt.Errorf("unexpected %s", p.current.Type))
+				panic(p.expectedError(
+					SyntaxErrorCodeUnexpectedToken,
+					"",
+					p.current.Type.String(),
+					"unexpected %s",
+					p.current.Type,
+				))
 			}
 
 			identifier := tokenToIdentifier(*previousIdentifierToken)
@@ -991,7 +1672,13 @@ func parseMemberOrNestedDeclaration(p *parser, docString string) ast.Declaration
 
 		case lexer.TokenParenOpen:
 			if previousIdentifierToken == nil {
-				panic(fmt.Errorf("unexpected %s", p.current.Type))
+				panic(p.expectedError(
+					SyntaxErrorCodeUnexpectedToken,
+					"",
+					p.current.Type.String(),
+					"unexpected %s",
+					p.current.Type,
+				))
 			}
 
 			identifier := tokenToIdentifier(*previousIdentifierToken)
@@ -1107,7 +1794,10 @@ func parseEnumCase(
 
 	p.skipSpaceAndComments(true)
 	if !p.current.Is(lexer.TokenIdentifier) {
-		panic(fmt.Errorf(
+		panic(p.expectedError(
+			SyntaxErrorCodeMissingIdentifier,
+			lexer.TokenIdentifier.String(),
+			p.current.Type.String(),
 			"expected identifier after start of enum case declaration, got %s",
 			p.current.Type,
 		))
@@ -21,8 +21,11 @@ package parser2
 import (
 	"fmt"
 	"math/big"
+	"math/rand"
+	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/cadence/runtime/ast"
@@ -259,6 +262,158 @@ func TestParseVariableDeclaration(t *testing.T) {
 		)
 	})
 
+	t.Run("let, tuple pattern", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations("let (a, b) = pair")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Declaration{
+				&ast.VariableDeclaration{
+					IsConstant: true,
+					Pattern: &ast.TuplePattern{
+						Elements: []*ast.TuplePatternElement{
+							{
+								Identifier: &ast.Identifier{
+									Identifier: "a",
+									Pos:        ast.Position{Line: 1, Column: 5, Offset: 5},
+								},
+							},
+							{
+								Identifier: &ast.Identifier{
+									Identifier: "b",
+									Pos:        ast.Position{Line: 1, Column: 8, Offset: 8},
+								},
+							},
+						},
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 4, Offset: 4},
+							EndPos:   ast.Position{Line: 1, Column: 9, Offset: 9},
+						},
+					},
+					Value: &ast.IdentifierExpression{
+						Identifier: ast.Identifier{
+							Identifier: "pair",
+							Pos:        ast.Position{Line: 1, Column: 13, Offset: 13},
+						},
+					},
+					Transfer: &ast.Transfer{
+						Operation: ast.TransferOperationCopy,
+						Pos:       ast.Position{Line: 1, Column: 11, Offset: 11},
+					},
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("let, nested tuple pattern with wildcard", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations("let (a, (b, _)) = x")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Declaration{
+				&ast.VariableDeclaration{
+					IsConstant: true,
+					Pattern: &ast.TuplePattern{
+						Elements: []*ast.TuplePatternElement{
+							{
+								Identifier: &ast.Identifier{
+									Identifier: "a",
+									Pos:        ast.Position{Line: 1, Column: 5, Offset: 5},
+								},
+							},
+							{
+								Pattern: &ast.TuplePattern{
+									Elements: []*ast.TuplePatternElement{
+										{
+											Identifier: &ast.Identifier{
+												Identifier: "b",
+												Pos:        ast.Position{Line: 1, Column: 9, Offset: 9},
+											},
+										},
+										{
+											Identifier: &ast.Identifier{
+												Identifier: "_",
+												Pos:        ast.Position{Line: 1, Column: 12, Offset: 12},
+											},
+										},
+									},
+									Range: ast.Range{
+										StartPos: ast.Position{Line: 1, Column: 8, Offset: 8},
+										EndPos:   ast.Position{Line: 1, Column: 13, Offset: 13},
+									},
+								},
+							},
+						},
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 4, Offset: 4},
+							EndPos:   ast.Position{Line: 1, Column: 14, Offset: 14},
+						},
+					},
+					Value: &ast.IdentifierExpression{
+						Identifier: ast.Identifier{
+							Identifier: "x",
+							Pos:        ast.Position{Line: 1, Column: 18, Offset: 18},
+						},
+					},
+					Transfer: &ast.Transfer{
+						Operation: ast.TransferOperationCopy,
+						Pos:       ast.Position{Line: 1, Column: 16, Offset: 16},
+					},
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("let, empty tuple pattern", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := ParseDeclarations("let () = x")
+
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Code:     SyntaxErrorCodeMissingIdentifier,
+					Message:  "expected identifier or pattern in tuple pattern, got ')'",
+					Pos:      ast.Position{Line: 1, Column: 5, Offset: 5},
+					Expected: "identifier or pattern",
+					Got:      "')'",
+				},
+			},
+			errs,
+		)
+	})
+
+	t.Run("let, tuple pattern with trailing comma", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := ParseDeclarations("let (a, ) = x")
+
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Code:     SyntaxErrorCodeMissingIdentifier,
+					Message:  "expected identifier or pattern in tuple pattern, got ')'",
+					Pos:      ast.Position{Line: 1, Column: 8, Offset: 8},
+					Expected: "identifier or pattern",
+					Got:      "')'",
+				},
+			},
+			errs,
+		)
+	})
+
 }
 
 func TestParseParameterList(t *testing.T) {
@@ -462,8 +617,11 @@ func TestParseParameterList(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
-					Message: "parser: expected comma, got start of parameter",
-					Pos:     ast.Position{Offset: 14, Line: 1, Column: 14},
+					Code:     SyntaxErrorCodeUnexpectedToken,
+					Message:  "expected comma, got start of parameter",
+					Pos:      ast.Position{Offset: 14, Line: 1, Column: 14},
+					Expected: "','",
+					Got:      "start of parameter",
 				},
 			},
 			errs,
@@ -890,6 +1048,20 @@ func TestParseFunctionDeclaration(t *testing.T) {
 		)
 	})
 
+	t.Run("with empty block comment", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations("/**/\nfun foo() {}")
+		require.Empty(t, errs)
+
+		require.Len(t, result, 1)
+		require.IsType(t, &ast.FunctionDeclaration{}, result[0])
+
+		declaration := result[0].(*ast.FunctionDeclaration)
+		assert.Equal(t, "", declaration.DocString)
+	})
+
 	t.Run("without space after return type", func(t *testing.T) {
 
 		// A brace after the return type is ambiguous:
@@ -958,6 +1130,149 @@ func TestParseFunctionDeclaration(t *testing.T) {
 	})
 }
 
+func TestParseNativeFunctionDeclaration(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("without access modifier", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations("native fun foo()")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Declaration{
+				&ast.FunctionDeclaration{
+					Identifier: ast.Identifier{
+						Identifier: "foo",
+						Pos:        ast.Position{Line: 1, Column: 11, Offset: 11},
+					},
+					ParameterList: &ast.ParameterList{
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 14, Offset: 14},
+							EndPos:   ast.Position{Line: 1, Column: 15, Offset: 15},
+						},
+					},
+					ReturnTypeAnnotation: &ast.TypeAnnotation{
+						Type: &ast.NominalType{
+							Identifier: ast.Identifier{
+								Pos: ast.Position{Line: 1, Column: 15, Offset: 15},
+							},
+						},
+						StartPos: ast.Position{Line: 1, Column: 15, Offset: 15},
+					},
+					IsNative: true,
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("with access modifier", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations("pub native fun foo(): Int")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Declaration{
+				&ast.FunctionDeclaration{
+					Access: ast.AccessPublic,
+					Identifier: ast.Identifier{
+						Identifier: "foo",
+						Pos:        ast.Position{Line: 1, Column: 15, Offset: 15},
+					},
+					ParameterList: &ast.ParameterList{
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 18, Offset: 18},
+							EndPos:   ast.Position{Line: 1, Column: 19, Offset: 19},
+						},
+					},
+					ReturnTypeAnnotation: &ast.TypeAnnotation{
+						Type: &ast.NominalType{
+							Identifier: ast.Identifier{
+								Identifier: "Int",
+								Pos:        ast.Position{Line: 1, Column: 22, Offset: 22},
+							},
+						},
+						StartPos: ast.Position{Line: 1, Column: 22, Offset: 22},
+					},
+					IsNative: true,
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("member", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations(`
+          struct S {
+              pub native fun foo(): Int
+              native fun bar()
+          }
+        `)
+		require.Empty(t, errs)
+
+		require.Len(t, result, 1)
+		require.IsType(t, &ast.CompositeDeclaration{}, result[0])
+
+		functions := result[0].(*ast.CompositeDeclaration).Members.Functions()
+		require.Len(t, functions, 2)
+
+		assert.True(t, functions[0].IsNative)
+		assert.Equal(t, ast.AccessPublic, functions[0].Access)
+		assert.Nil(t, functions[0].FunctionBlock)
+
+		assert.True(t, functions[1].IsNative)
+		assert.Nil(t, functions[1].FunctionBlock)
+	})
+
+	t.Run("with function block", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := ParseDeclarations("native fun foo() {}")
+
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Code:    SyntaxErrorCodeUnexpectedFunctionBlock,
+					Message: "native function declarations must not have a function block",
+					Pos:     ast.Position{Line: 1, Column: 17, Offset: 17},
+				},
+			},
+			errs,
+		)
+	})
+
+	t.Run("not a function", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := ParseDeclarations("native let x = 1")
+
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Code:     SyntaxErrorCodeMissingKeyword,
+					Message:  "expected keyword \"fun\", got identifier",
+					Pos:      ast.Position{Line: 1, Column: 7, Offset: 7},
+					Expected: "\"fun\"",
+					Got:      "identifier",
+				},
+			},
+			errs,
+		)
+	})
+}
+
 func TestParseAccess(t *testing.T) {
 
 	t.Parallel()
@@ -1005,8 +1320,11 @@ func TestParseAccess(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
-					Message: "expected keyword \"set\", got EOF",
-					Pos:     ast.Position{Offset: 6, Line: 1, Column: 6},
+					Code:     SyntaxErrorCodeMissingKeyword,
+					Message:  "expected keyword \"set\", got EOF",
+					Pos:      ast.Position{Offset: 6, Line: 1, Column: 6},
+					Expected: "\"set\"",
+					Got:      "EOF",
 				},
 			},
 			errs,
@@ -1026,8 +1344,10 @@ func TestParseAccess(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
-					Message: "expected token ')'",
-					Pos:     ast.Position{Offset: 10, Line: 1, Column: 10},
+					Code:     SyntaxErrorCodeUnexpectedToken,
+					Message:  "expected token ')'",
+					Pos:      ast.Position{Offset: 10, Line: 1, Column: 10},
+					Expected: "')'",
 				},
 			},
 			errs,
@@ -1047,8 +1367,35 @@ func TestParseAccess(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
-					Message: "expected keyword \"set\", got \"foo\"",
-					Pos:     ast.Position{Offset: 6, Line: 1, Column: 6},
+					Code:     SyntaxErrorCodeMissingKeyword,
+					Message:  "expected keyword \"set\", got \"foo\"",
+					Pos:      ast.Position{Offset: 6, Line: 1, Column: 6},
+					Expected: "\"set\"",
+					Got:      "\"foo\"",
+				},
+			},
+			errs,
+		)
+
+		utils.AssertEqualWithDiff(t,
+			nil,
+			result,
+		)
+	})
+
+	t.Run("pub(all)", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := parse("pub ( all )")
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Code:     SyntaxErrorCodeMissingKeyword,
+					Message:  "expected keyword \"set\", got \"all\"; use \"pub\" or \"access(all)\" instead of \"pub(all)\"",
+					Pos:      ast.Position{Offset: 6, Line: 1, Column: 6},
+					Expected: "\"set\"",
+					Got:      "\"all\"",
 				},
 			},
 			errs,
@@ -1086,6 +1433,19 @@ func TestParseAccess(t *testing.T) {
 		)
 	})
 
+	t.Run("pub and access(all) are equivalent", func(t *testing.T) {
+
+		t.Parallel()
+
+		pubResult, errs := parse("pub")
+		require.Empty(t, errs)
+
+		accessAllResult, errs := parse("access(all)")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t, pubResult, accessAllResult)
+	})
+
 	t.Run("access(account)", func(t *testing.T) {
 
 		t.Parallel()
@@ -1133,8 +1493,11 @@ func TestParseAccess(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
-					Message: "expected keyword \"all\", \"account\", \"contract\", or \"self\", got EOF",
-					Pos:     ast.Position{Offset: 9, Line: 1, Column: 9},
+					Code:     SyntaxErrorCodeMissingKeyword,
+					Message:  "expected keyword \"all\", \"account\", \"contract\", or \"self\", got EOF",
+					Pos:      ast.Position{Offset: 9, Line: 1, Column: 9},
+					Expected: "\"all\", \"account\", \"contract\", or \"self\"",
+					Got:      "EOF",
 				},
 			},
 			errs,
@@ -1154,8 +1517,10 @@ func TestParseAccess(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
-					Message: "expected token ')'",
-					Pos:     ast.Position{Offset: 14, Line: 1, Column: 14},
+					Code:     SyntaxErrorCodeUnexpectedToken,
+					Message:  "expected token ')'",
+					Pos:      ast.Position{Offset: 14, Line: 1, Column: 14},
+					Expected: "')'",
 				},
 			},
 			errs,
@@ -1175,8 +1540,11 @@ func TestParseAccess(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
-					Message: "expected keyword \"all\", \"account\", \"contract\", or \"self\", got \"foo\"",
-					Pos:     ast.Position{Offset: 9, Line: 1, Column: 9},
+					Code:     SyntaxErrorCodeMissingKeyword,
+					Message:  "expected keyword \"all\", \"account\", \"contract\", or \"self\", got \"foo\"",
+					Pos:      ast.Position{Offset: 9, Line: 1, Column: 9},
+					Expected: "\"all\", \"account\", \"contract\", or \"self\"",
+					Got:      "\"foo\"",
 				},
 			},
 			errs,
@@ -1189,28 +1557,78 @@ func TestParseAccess(t *testing.T) {
 	})
 }
 
-func TestParseImportDeclaration(t *testing.T) {
+func TestParseStrictAccess(t *testing.T) {
 
 	t.Parallel()
 
-	t.Run("no identifiers, missing location", func(t *testing.T) {
+	const code = `
+      pub fun f() {}
+      access(all) let x = 1
+      pub(set) var y = 2
+      priv let z = 3
+	`
+
+	parse := func(strictAccess bool) (warnings []ParseWarning) {
+		_, errs := ParseDeclarations(
+			code,
+			WithStrictAccess(strictAccess),
+			WithWarningHandler(func(warning ParseWarning) {
+				warnings = append(warnings, warning)
+			}),
+		)
+		require.Empty(t, errs)
+		return
+	}
+
+	t.Run("enabled", func(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseDeclarations(` import`)
 		utils.AssertEqualWithDiff(t,
-			[]error{
-				&SyntaxError{
-					Message: "unexpected end in import declaration: expected string, address, or identifier",
-					Pos:     ast.Position{Offset: 7, Line: 1, Column: 7},
+			[]ParseWarning{
+				&PubAccessWarning{
+					Range: ast.Range{
+						StartPos: ast.Position{Offset: 7, Line: 2, Column: 6},
+						EndPos:   ast.Position{Offset: 9, Line: 2, Column: 8},
+					},
 				},
 			},
-			errs,
+			parse(true),
 		)
+	})
 
-		var expected []ast.Declaration
+	t.Run("disabled", func(t *testing.T) {
 
-		utils.AssertEqualWithDiff(t,
+		t.Parallel()
+
+		require.Empty(t, parse(false))
+	})
+}
+
+func TestParseImportDeclaration(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("no identifiers, missing location", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations(` import`)
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Code:     SyntaxErrorCodeUnexpectedEnd,
+					Message:  "unexpected end in import declaration: expected string, address, or identifier",
+					Pos:      ast.Position{Offset: 7, Line: 1, Column: 7},
+					Expected: "string, address, or identifier",
+				},
+			},
+			errs,
+		)
+
+		var expected []ast.Declaration
+
+		utils.AssertEqualWithDiff(t,
 			expected,
 			result,
 		)
@@ -1272,6 +1690,7 @@ func TestParseImportDeclaration(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
+					Code:    SyntaxErrorCodeInvalidAddress,
 					Message: "address too large",
 					Pos:     ast.Position{Offset: 8, Line: 1, Column: 8},
 				},
@@ -1279,10 +1698,20 @@ func TestParseImportDeclaration(t *testing.T) {
 			errs,
 		)
 
-		var expected []ast.Declaration
+		// The error is reported, and parsing continues with an empty address
 
 		utils.AssertEqualWithDiff(t,
-			expected,
+			[]ast.Declaration{
+				&ast.ImportDeclaration{
+					Identifiers: nil,
+					Location:    common.AddressLocation{},
+					LocationPos: ast.Position{Offset: 8, Line: 1, Column: 8},
+					Range: ast.Range{
+						StartPos: ast.Position{Offset: 1, Line: 1, Column: 1},
+						EndPos:   ast.Position{Offset: 26, Line: 1, Column: 26},
+					},
+				},
+			},
 			result,
 		)
 	})
@@ -1295,9 +1724,12 @@ func TestParseImportDeclaration(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
+					Code: SyntaxErrorCodeUnexpectedToken,
 					Message: "unexpected token in import declaration: " +
 						"got decimal integer, expected string, address, or identifier",
-					Pos: ast.Position{Offset: 8, Line: 1, Column: 8},
+					Pos:      ast.Position{Offset: 8, Line: 1, Column: 8},
+					Expected: "string, address, or identifier",
+					Got:      "decimal integer",
 				},
 			},
 			errs,
@@ -1348,9 +1780,12 @@ func TestParseImportDeclaration(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
+					Code: SyntaxErrorCodeUnexpectedToken,
 					Message: "unexpected token in import declaration: " +
 						"got string, expected keyword \"from\" or ','",
-					Pos: ast.Position{Offset: 12, Line: 1, Column: 12},
+					Pos:      ast.Position{Offset: 12, Line: 1, Column: 12},
+					Expected: "keyword \"from\" or ','",
+					Got:      "string",
 				},
 			},
 			errs,
@@ -1410,8 +1845,11 @@ func TestParseImportDeclaration(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
-					Message: `expected identifier, got keyword "from"`,
-					Pos:     ast.Position{Offset: 20, Line: 1, Column: 20},
+					Code:     SyntaxErrorCodeMissingIdentifier,
+					Message:  `expected identifier, got keyword "from"`,
+					Pos:      ast.Position{Offset: 20, Line: 1, Column: 20},
+					Expected: "identifier",
+					Got:      "keyword \"from\"",
 				},
 			},
 			errs,
@@ -1448,6 +1886,89 @@ func TestParseImportDeclaration(t *testing.T) {
 		)
 	})
 
+	t.Run("no identifiers, identifier location, semicolon", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations(` import foo; let x = 1`)
+		require.Empty(t, errs)
+
+		require.Len(t, result, 2)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.ImportDeclaration{
+				Identifiers: nil,
+				Location:    common.IdentifierLocation("foo"),
+				LocationPos: ast.Position{Line: 1, Column: 8, Offset: 8},
+				Range: ast.Range{
+					StartPos: ast.Position{Line: 1, Column: 1, Offset: 1},
+					EndPos:   ast.Position{Line: 1, Column: 10, Offset: 10},
+				},
+			},
+			result[0],
+		)
+	})
+
+	t.Run("one identifier, identifier location", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations(` import foo from bar`)
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Declaration{
+				&ast.ImportDeclaration{
+					Identifiers: []ast.Identifier{
+						{
+							Identifier: "foo",
+							Pos:        ast.Position{Line: 1, Column: 8, Offset: 8},
+						},
+					},
+					Location:    common.IdentifierLocation("bar"),
+					LocationPos: ast.Position{Line: 1, Column: 17, Offset: 17},
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 1, Offset: 1},
+						EndPos:   ast.Position{Line: 1, Column: 19, Offset: 19},
+					},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("two identifiers, identifier location", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations(` import foo, bar from baz`)
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Declaration{
+				&ast.ImportDeclaration{
+					Identifiers: []ast.Identifier{
+						{
+							Identifier: "foo",
+							Pos:        ast.Position{Line: 1, Column: 8, Offset: 8},
+						},
+						{
+							Identifier: "bar",
+							Pos:        ast.Position{Line: 1, Column: 13, Offset: 13},
+						},
+					},
+					Location:    common.IdentifierLocation("baz"),
+					LocationPos: ast.Position{Line: 1, Column: 22, Offset: 22},
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 1, Offset: 1},
+						EndPos:   ast.Position{Line: 1, Column: 24, Offset: 24},
+					},
+				},
+			},
+			result,
+		)
+	})
+
 	t.Run("from keyword as second identifier", func(t *testing.T) {
 
 		t.Parallel()
@@ -1510,6 +2031,70 @@ func TestParseImportDeclaration(t *testing.T) {
 	})
 }
 
+func TestParseHexadecimalLocation(t *testing.T) {
+
+	t.Parallel()
+
+	// Generate random hexadecimal literals, like the lexer produces them,
+	// i.e. with a prefix, and hexadecimal digits and underscores of random length,
+	// and check they either result in the address, or in an error if they are too long
+
+	const characters = "0123456789abcdefABCDEF_"
+
+	random := rand.New(rand.NewSource(42))
+
+	for i := 0; i < 10_000; i++ {
+
+		length := random.Intn(24)
+
+		var builder strings.Builder
+		builder.WriteString("0x")
+		for j := 0; j < length; j++ {
+			builder.WriteByte(characters[random.Intn(len(characters))])
+		}
+		literal := builder.String()
+
+		digits := strings.ReplaceAll(literal[2:], "_", "")
+		if digits == "" {
+			digits = "0"
+		}
+		expected, ok := new(big.Int).SetString(digits, 16)
+		require.True(t, ok)
+
+		var location common.AddressLocation
+		var err error
+		require.NotPanics(t,
+			func() {
+				location, err = parseHexadecimalLocation(literal)
+			},
+			literal,
+		)
+
+		// The length of the address is determined by the number of digits,
+		// not by the value, i.e. leading zeros count
+
+		if (len(digits)+1)/2 > common.AddressLength {
+			require.Error(t, err, literal)
+			require.Equal(t, common.AddressLocation{}, location, literal)
+		} else {
+			require.NoError(t, err, literal)
+			require.Equal(t,
+				expected.Text(16),
+				new(big.Int).SetBytes(location.Address.Bytes()).Text(16),
+				literal,
+			)
+		}
+
+		require.NotPanics(t,
+			func() {
+				_, errs := ParseDeclarations("import " + literal)
+				require.LessOrEqual(t, len(errs), 1, literal)
+			},
+			literal,
+		)
+	}
+}
+
 func TestParseEvent(t *testing.T) {
 
 	t.Parallel()
@@ -2014,6 +2599,111 @@ func TestParseInterfaceDeclaration(t *testing.T) {
 		)
 	})
 
+	t.Run("resource and contract, no conformances", func(t *testing.T) {
+
+		t.Parallel()
+
+		for _, compositeKind := range []common.CompositeKind{
+			common.CompositeKindResource,
+			common.CompositeKindContract,
+		} {
+
+			code := fmt.Sprintf("pub %s interface I {}", compositeKind.Keyword())
+
+			result, errs := ParseDeclarations(code)
+			require.Empty(t, errs)
+
+			require.Len(t, result, 1)
+			require.IsType(t, &ast.InterfaceDeclaration{}, result[0])
+
+			declaration := result[0].(*ast.InterfaceDeclaration)
+			assert.Equal(t, compositeKind, declaration.CompositeKind)
+			assert.Equal(t, "I", declaration.Identifier.Identifier)
+			assert.Equal(t, ast.AccessPublic, declaration.Access)
+		}
+	})
+
+	t.Run("resource, members without bodies", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations(`
+          pub resource interface R {
+              pub let x: Int
+              pub fun foo(a: Int): Int
+              init(x: Int)
+              destroy()
+          }
+        `)
+		require.Empty(t, errs)
+
+		require.Len(t, result, 1)
+		require.IsType(t, &ast.InterfaceDeclaration{}, result[0])
+
+		members := result[0].(*ast.InterfaceDeclaration).Members
+
+		require.Len(t, members.Fields(), 1)
+
+		functions := members.Functions()
+		require.Len(t, functions, 1)
+		assert.Nil(t, functions[0].FunctionBlock)
+
+		specialFunctions := members.SpecialFunctions()
+		require.Len(t, specialFunctions, 2)
+		for _, specialFunction := range specialFunctions {
+			assert.Nil(t, specialFunction.FunctionDeclaration.FunctionBlock)
+		}
+	})
+
+	t.Run("contract, function with body", func(t *testing.T) {
+
+		t.Parallel()
+
+		// Function implementations in interfaces are syntactically valid,
+		// they are rejected in semantic analysis
+
+		result, errs := ParseDeclarations(`
+          pub contract interface C {
+              pub fun foo(): Int {
+                  return 1
+              }
+          }
+        `)
+		require.Empty(t, errs)
+
+		require.Len(t, result, 1)
+		require.IsType(t, &ast.InterfaceDeclaration{}, result[0])
+
+		functions := result[0].(*ast.InterfaceDeclaration).Members.Functions()
+		require.Len(t, functions, 1)
+		require.NotNil(t, functions[0].FunctionBlock)
+		assert.Len(t, functions[0].FunctionBlock.Block.Statements, 1)
+	})
+
+	t.Run("duplicate functions", func(t *testing.T) {
+
+		t.Parallel()
+
+		// Duplicate declarations are syntactically valid,
+		// they are rejected in semantic analysis
+
+		result, errs := ParseDeclarations(`
+          pub resource interface R {
+              pub fun foo()
+              pub fun foo()
+          }
+        `)
+		require.Empty(t, errs)
+
+		require.Len(t, result, 1)
+		require.IsType(t, &ast.InterfaceDeclaration{}, result[0])
+
+		functions := result[0].(*ast.InterfaceDeclaration).Members.Functions()
+		require.Len(t, functions, 2)
+		assert.Equal(t, "foo", functions[0].Identifier.Identifier)
+		assert.Equal(t, "foo", functions[1].Identifier.Identifier)
+	})
+
 	t.Run("struct, interface keyword as name", func(t *testing.T) {
 
 		t.Parallel()
@@ -2022,8 +2712,36 @@ func TestParseInterfaceDeclaration(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
-					Message: "expected interface name, got keyword \"interface\"",
-					Pos:     ast.Position{Offset: 22, Line: 1, Column: 22},
+					Code:     SyntaxErrorCodeMissingIdentifier,
+					Message:  "expected interface name, got keyword \"interface\"",
+					Pos:      ast.Position{Offset: 22, Line: 1, Column: 22},
+					Expected: "interface name",
+					Got:      "keyword \"interface\"",
+				},
+			},
+			errs,
+		)
+
+		var expected []ast.Declaration
+
+		utils.AssertEqualWithDiff(t,
+			expected,
+			result,
+		)
+	})
+
+	t.Run("missing composite kind", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations(" pub interface S { }")
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Code:     SyntaxErrorCodeMissingCompositeKind,
+					Message:  "missing composite kind before keyword \"interface\": expected \"struct\", \"resource\", or \"contract\"",
+					Pos:      ast.Position{Offset: 5, Line: 1, Column: 5},
+					Expected: "\"struct\", \"resource\", or \"contract\"",
 				},
 			},
 			errs,
@@ -2037,6 +2755,24 @@ func TestParseInterfaceDeclaration(t *testing.T) {
 		)
 	})
 
+	t.Run("nested, missing composite kind", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := ParseDeclarations(" pub contract C { pub interface S { } }")
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Code:     SyntaxErrorCodeMissingCompositeKind,
+					Message:  "missing composite kind before keyword \"interface\": expected \"struct\", \"resource\", or \"contract\"",
+					Pos:      ast.Position{Offset: 22, Line: 1, Column: 22},
+					Expected: "\"struct\", \"resource\", or \"contract\"",
+				},
+			},
+			errs,
+		)
+	})
+
 	t.Run("struct, with fields, functions, and special functions; with and without blocks", func(t *testing.T) {
 
 		t.Parallel()
@@ -2265,45 +3001,51 @@ func TestParseInterfaceDeclaration(t *testing.T) {
 			result,
 		)
 	})
-}
-
-func TestParseTransactionDeclaration(t *testing.T) {
 
-	t.Parallel()
-
-	t.Run("no prepare, execute", func(t *testing.T) {
+	t.Run("enum, raw type", func(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseDeclarations("transaction { execute {} }")
+		result, errs := ParseDeclarations("enum E: UInt8 { case a\n case b }")
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
 			[]ast.Declaration{
-				&ast.TransactionDeclaration{
-					Execute: &ast.SpecialFunctionDeclaration{
-						Kind: common.DeclarationKindExecute,
-						FunctionDeclaration: &ast.FunctionDeclaration{
-							Access: ast.AccessNotSpecified,
+				&ast.CompositeDeclaration{
+					CompositeKind: common.CompositeKindEnum,
+					Identifier: ast.Identifier{
+						Identifier: "E",
+						Pos:        ast.Position{Line: 1, Column: 5, Offset: 5},
+					},
+					Conformances: []*ast.NominalType{
+						{
 							Identifier: ast.Identifier{
-								Identifier: "execute",
-								Pos:        ast.Position{Offset: 14, Line: 1, Column: 14},
+								Identifier: "UInt8",
+								Pos:        ast.Position{Line: 1, Column: 8, Offset: 8},
 							},
-							ParameterList: &ast.ParameterList{},
-							FunctionBlock: &ast.FunctionBlock{
-								Block: &ast.Block{
-									Range: ast.Range{
-										StartPos: ast.Position{Offset: 22, Line: 1, Column: 22},
-										EndPos:   ast.Position{Offset: 23, Line: 1, Column: 23},
-									},
+						},
+					},
+					Members: ast.NewMembers(
+						[]ast.Declaration{
+							&ast.EnumCaseDeclaration{
+								Identifier: ast.Identifier{
+									Identifier: "a",
+									Pos:        ast.Position{Line: 1, Column: 21, Offset: 21},
 								},
+								StartPos: ast.Position{Line: 1, Column: 16, Offset: 16},
+							},
+							&ast.EnumCaseDeclaration{
+								Identifier: ast.Identifier{
+									Identifier: "b",
+									Pos:        ast.Position{Line: 2, Column: 6, Offset: 29},
+								},
+								StartPos: ast.Position{Line: 2, Column: 1, Offset: 24},
 							},
-							StartPos: ast.Position{Offset: 14, Line: 1, Column: 14},
 						},
-					},
+					),
 					Range: ast.Range{
 						StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
-						EndPos:   ast.Position{Line: 1, Column: 25, Offset: 25},
+						EndPos:   ast.Position{Line: 2, Column: 8, Offset: 31},
 					},
 				},
 			},
@@ -2311,16 +3053,309 @@ func TestParseTransactionDeclaration(t *testing.T) {
 		)
 	})
 
-	t.Run("EmptyTransaction", func(t *testing.T) {
+	t.Run("enum, invalid case with value", func(t *testing.T) {
 
-		result, errs := ParseProgram(`
-		  transaction {}
-		`)
-		require.Empty(t, errs)
+		t.Parallel()
+
+		// Raw values of enum cases are implicit
 
+		_, errs := ParseDeclarations("enum E: UInt8 { case a = 1 }")
 		utils.AssertEqualWithDiff(t,
-			[]ast.Declaration{
-				&ast.TransactionDeclaration{
+			[]error{
+				&SyntaxError{
+					Code:     SyntaxErrorCodeUnexpectedToken,
+					Message:  "expected token '}'",
+					Pos:      ast.Position{Offset: 23, Line: 1, Column: 23},
+					Expected: "'}'",
+				},
+			},
+			errs,
+		)
+	})
+}
+
+func TestParseAttachmentDeclaration(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("no members, public", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations(" pub attachment A for B { }")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Declaration{
+				&ast.AttachmentDeclaration{
+					Access: ast.AccessPublic,
+					Identifier: ast.Identifier{
+						Identifier: "A",
+						Pos:        ast.Position{Line: 1, Column: 16, Offset: 16},
+					},
+					BaseType: &ast.NominalType{
+						Identifier: ast.Identifier{
+							Identifier: "B",
+							Pos:        ast.Position{Line: 1, Column: 22, Offset: 22},
+						},
+					},
+					Members: &ast.Members{},
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 1, Offset: 1},
+						EndPos:   ast.Position{Line: 1, Column: 26, Offset: 26},
+					},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("nested base type", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations("attachment A for C.D {}")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Declaration{
+				&ast.AttachmentDeclaration{
+					Access: ast.AccessNotSpecified,
+					Identifier: ast.Identifier{
+						Identifier: "A",
+						Pos:        ast.Position{Line: 1, Column: 11, Offset: 11},
+					},
+					BaseType: &ast.NominalType{
+						Identifier: ast.Identifier{
+							Identifier: "C",
+							Pos:        ast.Position{Line: 1, Column: 17, Offset: 17},
+						},
+						NestedIdentifiers: []ast.Identifier{
+							{
+								Identifier: "D",
+								Pos:        ast.Position{Line: 1, Column: 19, Offset: 19},
+							},
+						},
+					},
+					Members: &ast.Members{},
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+						EndPos:   ast.Position{Line: 1, Column: 22, Offset: 22},
+					},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("access modifiers", func(t *testing.T) {
+
+		t.Parallel()
+
+		for _, access := range ast.BasicAccesses {
+
+			access := access
+
+			t.Run(access.String(), func(t *testing.T) {
+
+				t.Parallel()
+
+				code := strings.TrimSpace(
+					fmt.Sprintf("%s attachment A for B {}", access.Keyword()),
+				)
+
+				result, errs := ParseDeclarations(code)
+				require.Empty(t, errs)
+
+				require.Len(t, result, 1)
+				require.IsType(t, &ast.AttachmentDeclaration{}, result[0])
+
+				declaration := result[0].(*ast.AttachmentDeclaration)
+				assert.Equal(t, access, declaration.Access)
+				assert.Equal(t,
+					ast.Position{Line: 1, Column: 0, Offset: 0},
+					declaration.StartPos,
+				)
+			})
+		}
+	})
+
+	t.Run("initializer with parameters", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations(`
+          attachment A for R {
+              let x: Int
+              init(x: Int, y: String) {
+                  self.x = x
+              }
+          }
+        `)
+		require.Empty(t, errs)
+
+		require.Len(t, result, 1)
+		require.IsType(t, &ast.AttachmentDeclaration{}, result[0])
+
+		members := result[0].(*ast.AttachmentDeclaration).Members
+
+		fields := members.Fields()
+		require.Len(t, fields, 1)
+		assert.Equal(t, "x", fields[0].Identifier.Identifier)
+
+		initializers := members.Initializers()
+		require.Len(t, initializers, 1)
+
+		parameters := initializers[0].FunctionDeclaration.ParameterList.Parameters
+		require.Len(t, parameters, 2)
+		assert.Equal(t, "x", parameters[0].Identifier.Identifier)
+		assert.Equal(t, "y", parameters[1].Identifier.Identifier)
+	})
+
+	t.Run("self and base member access", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations(`
+          attachment A for R {
+              let y: Int
+              pub fun sum(): Int {
+                  return base.x + self.y
+              }
+          }
+        `)
+		require.Empty(t, errs)
+
+		require.Len(t, result, 1)
+		require.IsType(t, &ast.AttachmentDeclaration{}, result[0])
+
+		functions := result[0].(*ast.AttachmentDeclaration).Members.Functions()
+		require.Len(t, functions, 1)
+
+		statements := functions[0].FunctionBlock.Block.Statements
+		require.Len(t, statements, 1)
+		require.IsType(t, &ast.ReturnStatement{}, statements[0])
+
+		returnExpression := statements[0].(*ast.ReturnStatement).Expression
+		require.IsType(t, &ast.BinaryExpression{}, returnExpression)
+
+		binaryExpression := returnExpression.(*ast.BinaryExpression)
+
+		for _, pair := range []struct {
+			expression ast.Expression
+			object     string
+			member     string
+		}{
+			{binaryExpression.Left, "base", "x"},
+			{binaryExpression.Right, "self", "y"},
+		} {
+			require.IsType(t, &ast.MemberExpression{}, pair.expression)
+			memberExpression := pair.expression.(*ast.MemberExpression)
+
+			require.IsType(t, &ast.IdentifierExpression{}, memberExpression.Expression)
+			assert.Equal(t,
+				pair.object,
+				memberExpression.Expression.(*ast.IdentifierExpression).Identifier.Identifier,
+			)
+			assert.Equal(t, pair.member, memberExpression.Identifier.Identifier)
+		}
+	})
+
+	t.Run("missing for", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := ParseDeclarations("attachment A B {}")
+
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Code:     SyntaxErrorCodeMissingKeyword,
+					Message:  "expected keyword \"for\", got identifier",
+					Pos:      ast.Position{Offset: 13, Line: 1, Column: 13},
+					Expected: "\"for\"",
+					Got:      "identifier",
+				},
+			},
+			errs,
+		)
+	})
+
+	t.Run("missing base type", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := ParseDeclarations("attachment A for {}")
+
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Code:     SyntaxErrorCodeMissingType,
+					Message:  "expected base type of attachment declaration, got '{'",
+					Pos:      ast.Position{Offset: 17, Line: 1, Column: 17},
+					Expected: "base type of attachment declaration",
+					Got:      "'{'",
+				},
+			},
+			errs,
+		)
+	})
+}
+
+func TestParseTransactionDeclaration(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("no prepare, execute", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations("transaction { execute {} }")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Declaration{
+				&ast.TransactionDeclaration{
+					Execute: &ast.SpecialFunctionDeclaration{
+						Kind: common.DeclarationKindExecute,
+						FunctionDeclaration: &ast.FunctionDeclaration{
+							Access: ast.AccessNotSpecified,
+							Identifier: ast.Identifier{
+								Identifier: "execute",
+								Pos:        ast.Position{Offset: 14, Line: 1, Column: 14},
+							},
+							ParameterList: &ast.ParameterList{},
+							FunctionBlock: &ast.FunctionBlock{
+								Block: &ast.Block{
+									Range: ast.Range{
+										StartPos: ast.Position{Offset: 22, Line: 1, Column: 22},
+										EndPos:   ast.Position{Offset: 23, Line: 1, Column: 23},
+									},
+								},
+							},
+							StartPos: ast.Position{Offset: 14, Line: 1, Column: 14},
+						},
+					},
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+						EndPos:   ast.Position{Line: 1, Column: 25, Offset: 25},
+					},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("EmptyTransaction", func(t *testing.T) {
+
+		result, errs := ParseProgram(`
+		  transaction {}
+		`)
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Declaration{
+				&ast.TransactionDeclaration{
 					Fields:         nil,
 					Prepare:        nil,
 					PreConditions:  nil,
@@ -3003,6 +4038,87 @@ func TestParseTransactionDeclaration(t *testing.T) {
 			result.Declarations(),
 		)
 	})
+
+	t.Run("optional blocks", func(t *testing.T) {
+
+		t.Parallel()
+
+		// Parse all combinations of the optional parameter list and blocks,
+		// with the execute block before and after the post-conditions
+
+		for combination := 0; combination < 1<<5; combination++ {
+
+			hasParameterList := combination&1 != 0
+			hasPrepare := combination&2 != 0
+			hasPre := combination&4 != 0
+			hasExecute := combination&8 != 0
+			hasPost := combination&16 != 0
+
+			for _, executeFirst := range []bool{true, false} {
+
+				if executeFirst && !(hasExecute && hasPost) {
+					continue
+				}
+
+				var code strings.Builder
+				code.WriteString("transaction")
+				if hasParameterList {
+					code.WriteString("(a: Int)")
+				}
+				code.WriteString(" {\n")
+				if hasPrepare {
+					code.WriteString("prepare(signer: AuthAccount) {}\n")
+				}
+				if hasPre {
+					code.WriteString("pre { true }\n")
+				}
+				if hasExecute && executeFirst {
+					code.WriteString("execute {}\n")
+				}
+				if hasPost {
+					code.WriteString("post { true }\n")
+				}
+				if hasExecute && !executeFirst {
+					code.WriteString("execute {}\n")
+				}
+				code.WriteString("}")
+
+				t.Run(code.String(), func(t *testing.T) {
+
+					result, errs := ParseDeclarations(code.String())
+					require.Empty(t, errs)
+					require.Len(t, result, 1)
+
+					transaction := result[0].(*ast.TransactionDeclaration)
+
+					assert.Equal(t, hasParameterList, transaction.ParameterList != nil)
+					assert.Equal(t, hasPrepare, transaction.Prepare != nil)
+					assert.Equal(t, hasPre, transaction.PreConditions != nil)
+					assert.Equal(t, hasExecute, transaction.Execute != nil)
+					assert.Equal(t, hasPost, transaction.PostConditions != nil)
+				})
+			}
+		}
+	})
+
+	t.Run("unexpected identifier", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := ParseDeclarations("transaction { foo }")
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Code:     SyntaxErrorCodeUnexpectedToken,
+					Message:  `unexpected identifier, expected keyword "prepare", "pre", "execute", or "post", got "foo"`,
+					Pos:      ast.Position{Offset: 14, Line: 1, Column: 14},
+					Expected: "keyword \"prepare\", \"pre\", \"execute\", or \"post\"",
+					Got:      "\"foo\"",
+				},
+			},
+			errs,
+		)
+	})
 }
 
 func TestParseFunctionAndBlock(t *testing.T) {
@@ -3432,57 +4548,140 @@ func TestParseStructureWithConformances(t *testing.T) {
 	)
 }
 
-func TestParsePreAndPostConditions(t *testing.T) {
+func TestParseConformances(t *testing.T) {
 
 	t.Parallel()
 
-	result, errs := ParseProgram(`
-        fun test(n: Int) {
-            pre {
-                n != 0
-                n > 0
-            }
-            post {
-                result == 0
-            }
-            return 0
-        }
-	`)
-	require.Empty(t, errs)
+	t.Run("single", func(t *testing.T) {
 
-	utils.AssertEqualWithDiff(t,
-		[]ast.Declaration{
-			&ast.FunctionDeclaration{
-				Access: ast.AccessNotSpecified,
-				Identifier: ast.Identifier{
-					Identifier: "test",
-					Pos:        ast.Position{Offset: 13, Line: 2, Column: 12},
-				},
-				ParameterList: &ast.ParameterList{
-					Parameters: []*ast.Parameter{
-						{
-							Label: "",
-							Identifier: ast.Identifier{
-								Identifier: "n",
-								Pos:        ast.Position{Offset: 18, Line: 2, Column: 17},
-							},
-							TypeAnnotation: &ast.TypeAnnotation{
-								IsResource: false,
-								Type: &ast.NominalType{
-									Identifier: ast.Identifier{
-										Identifier: "Int",
-										Pos:        ast.Position{Offset: 21, Line: 2, Column: 20},
-									},
-								},
-								StartPos: ast.Position{Offset: 21, Line: 2, Column: 20},
-							},
-							Range: ast.Range{
-								StartPos: ast.Position{Offset: 18, Line: 2, Column: 17},
-								EndPos:   ast.Position{Offset: 23, Line: 2, Column: 22},
-							},
-						},
+		t.Parallel()
+
+		result, errs := ParseDeclarations("struct S: I {}")
+		require.Empty(t, errs)
+
+		require.Len(t, result, 1)
+		require.IsType(t, &ast.CompositeDeclaration{}, result[0])
+
+		utils.AssertEqualWithDiff(t,
+			[]*ast.NominalType{
+				{
+					Identifier: ast.Identifier{
+						Identifier: "I",
+						Pos:        ast.Position{Offset: 10, Line: 1, Column: 10},
 					},
-					Range: ast.Range{
+				},
+			},
+			result[0].(*ast.CompositeDeclaration).Conformances,
+		)
+	})
+
+	t.Run("missing type after comma", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := ParseDeclarations("struct S: I, {}")
+
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Code:    SyntaxErrorCodeMissingType,
+					Message: "missing type after comma",
+					Pos:     ast.Position{Offset: 13, Line: 1, Column: 13},
+				},
+			},
+			errs,
+		)
+	})
+
+	t.Run("missing conformance after colon", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := ParseDeclarations("struct S: {}")
+
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Code:     SyntaxErrorCodeMissingConformance,
+					Message:  "expected at least one conformance after ':'",
+					Pos:      ast.Position{Offset: 10, Line: 1, Column: 10},
+					Expected: "conformance",
+				},
+			},
+			errs,
+		)
+	})
+
+	t.Run("generic conformance", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := ParseDeclarations("struct S: I<Int> {}")
+
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Code:    SyntaxErrorCodeInvalidType,
+					Message: "unexpected non-nominal type: I<Int>",
+					Pos:     ast.Position{Offset: 16, Line: 1, Column: 16},
+				},
+			},
+			errs,
+		)
+	})
+}
+
+func TestParsePreAndPostConditions(t *testing.T) {
+
+	t.Parallel()
+
+	result, errs := ParseProgram(`
+        fun test(n: Int) {
+            pre {
+                n != 0
+                n > 0
+            }
+            post {
+                result == 0
+            }
+            return 0
+        }
+	`)
+	require.Empty(t, errs)
+
+	utils.AssertEqualWithDiff(t,
+		[]ast.Declaration{
+			&ast.FunctionDeclaration{
+				Access: ast.AccessNotSpecified,
+				Identifier: ast.Identifier{
+					Identifier: "test",
+					Pos:        ast.Position{Offset: 13, Line: 2, Column: 12},
+				},
+				ParameterList: &ast.ParameterList{
+					Parameters: []*ast.Parameter{
+						{
+							Label: "",
+							Identifier: ast.Identifier{
+								Identifier: "n",
+								Pos:        ast.Position{Offset: 18, Line: 2, Column: 17},
+							},
+							TypeAnnotation: &ast.TypeAnnotation{
+								IsResource: false,
+								Type: &ast.NominalType{
+									Identifier: ast.Identifier{
+										Identifier: "Int",
+										Pos:        ast.Position{Offset: 21, Line: 2, Column: 20},
+									},
+								},
+								StartPos: ast.Position{Offset: 21, Line: 2, Column: 20},
+							},
+							Range: ast.Range{
+								StartPos: ast.Position{Offset: 18, Line: 2, Column: 17},
+								EndPos:   ast.Position{Offset: 23, Line: 2, Column: 22},
+							},
+						},
+					},
+					Range: ast.Range{
 						StartPos: ast.Position{Offset: 17, Line: 2, Column: 16},
 						EndPos:   ast.Position{Offset: 24, Line: 2, Column: 23},
 					},
@@ -3917,6 +5116,30 @@ func TestPragmaArguments(t *testing.T) {
 	)
 }
 
+// TestPragmaDirectiveSyntax pins down that pragmas are written as `#name` or `#name(arguments)`,
+// e.g. `#version("1.0")`. The directive syntax `#pragma name value` is not supported:
+// `#pragma` is parsed as a pragma without arguments, and the name that follows is rejected
+//
+func TestPragmaDirectiveSyntax(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseProgram(`#pragma version "1.0"`)
+	require.Error(t, err)
+
+	utils.AssertEqualWithDiff(t,
+		[]error{
+			&SyntaxError{
+				Code:    SyntaxErrorCodeUnexpectedToken,
+				Message: "unexpected token: identifier",
+				Pos:     ast.Position{Offset: 8, Line: 1, Column: 8},
+				Got:     "identifier",
+			},
+		},
+		err.(Error).Errors,
+	)
+}
+
 func TestParseImportWithString(t *testing.T) {
 
 	t.Parallel()
@@ -4753,6 +5976,36 @@ func TestParseCompositeDeclarationWithSemicolonSeparatedMembers(t *testing.T) {
 	)
 }
 
+func TestParseCompositeDeclarationMembersInSourceOrder(t *testing.T) {
+
+	t.Parallel()
+
+	result, errs := ParseProgram(`
+        struct S {
+            let a: Int
+            fun b() {}
+            init() { self.a = 1; self.c = 2 }
+            let c: Int
+            struct D {}
+            fun e() {}
+        }
+    `)
+	require.Empty(t, errs)
+
+	compositeDeclarations := result.CompositeDeclarations()
+	require.Len(t, compositeDeclarations, 1)
+
+	var identifiers []string
+	for _, declaration := range compositeDeclarations[0].Members.Declarations() {
+		identifiers = append(identifiers, declaration.DeclarationIdentifier().Identifier)
+	}
+
+	assert.Equal(t,
+		[]string{"a", "b", "init", "c", "D", "e"},
+		identifiers,
+	)
+}
+
 // TODO:
 //func TestParseAccessModifiers(t *testing.T) {
 //
@@ -4831,6 +6084,158 @@ func TestParseCompositeDeclarationWithSemicolonSeparatedMembers(t *testing.T) {
 //	}
 //}
 
+func TestParseFunctionConditions(t *testing.T) {
+
+	t.Parallel()
+
+	parseFunctionBlock := func(t *testing.T, code string) *ast.FunctionBlock {
+		result, errs := ParseDeclarations(code)
+		require.Empty(t, errs)
+		require.Len(t, result, 1)
+
+		return result[0].(*ast.FunctionDeclaration).FunctionBlock
+	}
+
+	t.Run("pre only", func(t *testing.T) {
+
+		t.Parallel()
+
+		functionBlock := parseFunctionBlock(t, `
+          fun test(n: Int) {
+              pre {
+                  n > 0: "n must be positive"
+              }
+          }
+        `)
+
+		require.NotNil(t, functionBlock.PreConditions)
+		require.Len(t, *functionBlock.PreConditions, 1)
+		assert.Nil(t, functionBlock.PostConditions)
+
+		condition := (*functionBlock.PreConditions)[0]
+		assert.Equal(t, ast.ConditionKindPre, condition.Kind)
+		assert.Equal(t, "(n > 0)", condition.Test.String())
+		assert.Equal(t, `"n must be positive"`, condition.Message.String())
+	})
+
+	t.Run("post only", func(t *testing.T) {
+
+		t.Parallel()
+
+		functionBlock := parseFunctionBlock(t, `
+          fun test(n: Int): Int {
+              post {
+                  result > 0
+                  result < 10: "too large"
+              }
+              return n
+          }
+        `)
+
+		assert.Nil(t, functionBlock.PreConditions)
+		require.NotNil(t, functionBlock.PostConditions)
+		require.Len(t, *functionBlock.PostConditions, 2)
+
+		postConditions := *functionBlock.PostConditions
+
+		assert.Equal(t, ast.ConditionKindPost, postConditions[0].Kind)
+		assert.Nil(t, postConditions[0].Message)
+		assert.Equal(t, ast.ConditionKindPost, postConditions[1].Kind)
+		assert.NotNil(t, postConditions[1].Message)
+
+		require.Len(t, functionBlock.Block.Statements, 1)
+	})
+
+	t.Run("pre and post", func(t *testing.T) {
+
+		t.Parallel()
+
+		functionBlock := parseFunctionBlock(t, `
+          fun test() {
+              pre { true }
+              post { false }
+          }
+        `)
+
+		require.NotNil(t, functionBlock.PreConditions)
+		require.Len(t, *functionBlock.PreConditions, 1)
+		require.NotNil(t, functionBlock.PostConditions)
+		require.Len(t, *functionBlock.PostConditions, 1)
+	})
+
+	t.Run("post with before", func(t *testing.T) {
+
+		t.Parallel()
+
+		functionBlock := parseFunctionBlock(t, `
+          fun test() {
+              post {
+                  self.x == before(self.x) + 1
+              }
+          }
+        `)
+
+		require.NotNil(t, functionBlock.PostConditions)
+		require.Len(t, *functionBlock.PostConditions, 1)
+
+		test := (*functionBlock.PostConditions)[0].Test
+		require.IsType(t, &ast.BinaryExpression{}, test)
+
+		right := test.(*ast.BinaryExpression).Right
+		require.IsType(t, &ast.BinaryExpression{}, right)
+
+		// The before pseudo-function is parsed as a normal invocation
+
+		invocation := right.(*ast.BinaryExpression).Left
+		require.IsType(t, &ast.InvocationExpression{}, invocation)
+		assert.Equal(t, "before(self.x)", invocation.String())
+	})
+
+	t.Run("in interface", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations(`
+          pub struct interface I {
+              pub fun test(n: Int): Int {
+                  pre {
+                      n > 0
+                  }
+                  post {
+                      result < n: "result must be less than n"
+                  }
+              }
+          }
+        `)
+		require.Empty(t, errs)
+		require.Len(t, result, 1)
+		require.IsType(t, &ast.InterfaceDeclaration{}, result[0])
+
+		functions := result[0].(*ast.InterfaceDeclaration).Members.Functions()
+		require.Len(t, functions, 1)
+
+		functionBlock := functions[0].FunctionBlock
+		require.NotNil(t, functionBlock)
+		assert.Empty(t, functionBlock.Block.Statements)
+
+		require.NotNil(t, functionBlock.PreConditions)
+		require.Len(t, *functionBlock.PreConditions, 1)
+
+		preCondition := (*functionBlock.PreConditions)[0]
+		assert.Equal(t, ast.ConditionKindPre, preCondition.Kind)
+		assert.Equal(t, "(n > 0)", preCondition.Test.String())
+		assert.Nil(t, preCondition.Message)
+
+		require.NotNil(t, functionBlock.PostConditions)
+		require.Len(t, *functionBlock.PostConditions, 1)
+
+		postCondition := (*functionBlock.PostConditions)[0]
+		assert.Equal(t, ast.ConditionKindPost, postCondition.Kind)
+		assert.Equal(t, "(result < n)", postCondition.Test.String())
+		assert.Equal(t, `"result must be less than n"`, postCondition.Message.String())
+	})
+}
+
 func TestParsePreconditionWithUnaryNegation(t *testing.T) {
 
 	t.Parallel()
@@ -4934,6 +6339,7 @@ func TestParseInvalidAccessModifiers(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
+					Code:    SyntaxErrorCodeInvalidAccessModifier,
 					Message: "invalid access modifier for pragma",
 					Pos:     ast.Position{Offset: 4, Line: 1, Column: 4},
 				},
@@ -4950,6 +6356,7 @@ func TestParseInvalidAccessModifiers(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
+					Code:    SyntaxErrorCodeInvalidAccessModifier,
 					Message: "invalid access modifier for transaction",
 					Pos:     ast.Position{Offset: 4, Line: 1, Column: 4},
 				},
@@ -4966,6 +6373,7 @@ func TestParseInvalidAccessModifiers(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
+					Code:    SyntaxErrorCodeInvalidAccessModifier,
 					Message: "invalid second access modifier",
 					Pos:     ast.Position{Offset: 4, Line: 1, Column: 4},
 				},
@@ -4974,3 +6382,505 @@ func TestParseInvalidAccessModifiers(t *testing.T) {
 		)
 	})
 }
+
+func TestParseAnnotations(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("single", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations("@deprecated fun foo() {}")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Declaration{
+				&ast.FunctionDeclaration{
+					Annotations: []*ast.AnnotationDeclaration{
+						{
+							Identifier: ast.Identifier{
+								Identifier: "deprecated",
+								Pos:        ast.Position{Line: 1, Column: 1, Offset: 1},
+							},
+							Range: ast.Range{
+								StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+								EndPos:   ast.Position{Line: 1, Column: 10, Offset: 10},
+							},
+						},
+					},
+					Identifier: ast.Identifier{
+						Identifier: "foo",
+						Pos:        ast.Position{Line: 1, Column: 16, Offset: 16},
+					},
+					ParameterList: &ast.ParameterList{
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 19, Offset: 19},
+							EndPos:   ast.Position{Line: 1, Column: 20, Offset: 20},
+						},
+					},
+					ReturnTypeAnnotation: &ast.TypeAnnotation{
+						Type: &ast.NominalType{
+							Identifier: ast.Identifier{
+								Pos: ast.Position{Line: 1, Column: 20, Offset: 20},
+							},
+						},
+						StartPos: ast.Position{Line: 1, Column: 20, Offset: 20},
+					},
+					FunctionBlock: &ast.FunctionBlock{
+						Block: &ast.Block{
+							Range: ast.Range{
+								StartPos: ast.Position{Line: 1, Column: 22, Offset: 22},
+								EndPos:   ast.Position{Line: 1, Column: 23, Offset: 23},
+							},
+						},
+					},
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("multiple", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations(`
+          /// Docs
+          @deprecated
+          @inline(never) pub let x = 1
+        `)
+		require.Empty(t, errs)
+		require.Len(t, result, 1)
+
+		declaration := result[0].(*ast.VariableDeclaration)
+
+		utils.AssertEqualWithDiff(t,
+			[]*ast.AnnotationDeclaration{
+				{
+					Identifier: ast.Identifier{
+						Identifier: "deprecated",
+						Pos:        ast.Position{Line: 3, Column: 11, Offset: 31},
+					},
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 3, Column: 10, Offset: 30},
+						EndPos:   ast.Position{Line: 3, Column: 20, Offset: 40},
+					},
+				},
+				{
+					Identifier: ast.Identifier{
+						Identifier: "inline",
+						Pos:        ast.Position{Line: 4, Column: 11, Offset: 53},
+					},
+					Arguments: ast.Arguments{
+						{
+							Expression: &ast.IdentifierExpression{
+								Identifier: ast.Identifier{
+									Identifier: "never",
+									Pos:        ast.Position{Line: 4, Column: 18, Offset: 60},
+								},
+							},
+							TrailingSeparatorPos: ast.Position{Line: 4, Column: 23, Offset: 65},
+						},
+					},
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 4, Column: 10, Offset: 52},
+						EndPos:   ast.Position{Line: 4, Column: 23, Offset: 65},
+					},
+				},
+			},
+			declaration.Annotations,
+		)
+
+		assert.Equal(t, ast.AccessPublic, declaration.Access)
+		assert.Equal(t, " Docs", declaration.DocString)
+		assert.Equal(t,
+			ast.Position{Line: 3, Column: 10, Offset: 30},
+			declaration.StartPosition(),
+		)
+	})
+
+	t.Run("nested", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations(`
+          @experimental
+          contract C {
+              @deprecated
+              init() {}
+
+              @deprecated(reason: "use y")
+              let x: Int
+
+              @inline(never)
+              fun f() {}
+          }
+        `)
+		require.Empty(t, errs)
+		require.Len(t, result, 1)
+
+		composite := result[0].(*ast.CompositeDeclaration)
+		require.Len(t, composite.Annotations, 1)
+		assert.Equal(t, "@experimental", composite.Annotations[0].String())
+		assert.Equal(t, composite.Annotations[0].StartPos, composite.StartPos)
+
+		members := composite.Members
+
+		initializers := members.Initializers()
+		require.Len(t, initializers, 1)
+		initializerAnnotations := initializers[0].FunctionDeclaration.Annotations
+		require.Len(t, initializerAnnotations, 1)
+		assert.Equal(t, "@deprecated", initializerAnnotations[0].String())
+		assert.Equal(t,
+			initializerAnnotations[0].StartPos,
+			initializers[0].FunctionDeclaration.StartPos,
+		)
+
+		fields := members.Fields()
+		require.Len(t, fields, 1)
+		require.Len(t, fields[0].Annotations, 1)
+		assert.Equal(t, `@deprecated(reason: "use y")`, fields[0].Annotations[0].String())
+
+		functions := members.Functions()
+		require.Len(t, functions, 1)
+		require.Len(t, functions[0].Annotations, 1)
+		assert.Equal(t, "@inline(never)", functions[0].Annotations[0].String())
+	})
+
+	t.Run("missing identifier", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := ParseDeclarations("@ fun foo() {}")
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Code:     SyntaxErrorCodeMissingIdentifier,
+					Message:  "expected identifier after '@', got space",
+					Pos:      ast.Position{Offset: 1, Line: 1, Column: 1},
+					Expected: "identifier",
+					Got:      "space",
+				},
+			},
+			errs,
+		)
+	})
+
+	t.Run("missing declaration", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := ParseDeclarations("@deprecated")
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Code:     SyntaxErrorCodeMissingDeclaration,
+					Message:  "expected declaration after annotation, got EOF",
+					Pos:      ast.Position{Offset: 11, Line: 1, Column: 11},
+					Expected: "declaration",
+					Got:      "EOF",
+				},
+			},
+			errs,
+		)
+	})
+
+	t.Run("import", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := ParseDeclarations("@deprecated import x")
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Code:    SyntaxErrorCodeInvalidAnnotation,
+					Message: "invalid annotation for import",
+					Pos:     ast.Position{Offset: 20, Line: 1, Column: 20},
+				},
+			},
+			errs,
+		)
+	})
+}
+
+func TestParseDeclarationsWithErrorRecovery(t *testing.T) {
+
+	t.Parallel()
+
+	declarationIdentifiers := func(declarations []ast.Declaration) []string {
+		identifiers := make([]string, 0, len(declarations))
+		for _, declaration := range declarations {
+			identifiers = append(identifiers, declaration.DeclarationIdentifier().Identifier)
+		}
+		return identifiers
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations("let x = 1\nlet = 2\nfun f() {}")
+		require.Nil(t, result)
+
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Code:     SyntaxErrorCodeMissingIdentifier,
+					Message:  "expected identifier after start of variable declaration, got '='",
+					Pos:      ast.Position{Offset: 14, Line: 2, Column: 4},
+					Expected: "identifier",
+					Got:      "'='",
+				},
+			},
+			errs,
+		)
+	})
+
+	t.Run("malformed declarations", func(t *testing.T) {
+
+		t.Parallel()
+
+		const code = `
+          let x = 1
+          let = 2
+          fun f() {}
+          pub pub fun g() {}
+          struct S { fun h( }
+          let y = 3
+        `
+
+		result, errs := ParseDeclarations(code, WithErrorRecovery(true))
+
+		assert.Equal(t,
+			[]string{"x", "f", "g", "y"},
+			declarationIdentifiers(result),
+		)
+
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Code:     SyntaxErrorCodeMissingIdentifier,
+					Message:  "expected identifier after start of variable declaration, got '='",
+					Pos:      ast.Position{Offset: 35, Line: 3, Column: 14},
+					Expected: "identifier",
+					Got:      "'='",
+				},
+				&SyntaxError{
+					Code:    SyntaxErrorCodeInvalidAccessModifier,
+					Message: "invalid second access modifier",
+					Pos:     ast.Position{Offset: 74, Line: 5, Column: 14},
+				},
+				&SyntaxError{
+					Code:     SyntaxErrorCodeUnexpectedToken,
+					Message:  "expected parameter or end of parameter list, got '}'",
+					Pos:      ast.Position{Offset: 117, Line: 6, Column: 28},
+					Expected: "parameter or end of parameter list",
+					Got:      "'}'",
+				},
+			},
+			errs,
+		)
+	})
+
+	t.Run("unexpected tokens", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations("X Y; let a = 1 }", WithErrorRecovery(true))
+
+		assert.Equal(t,
+			[]string{"a"},
+			declarationIdentifiers(result),
+		)
+
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Code:    SyntaxErrorCodeUnexpectedToken,
+					Message: "unexpected token: identifier",
+					Pos:     ast.Position{Offset: 0, Line: 1, Column: 0},
+					Got:     "identifier",
+				},
+				&SyntaxError{
+					Code:    SyntaxErrorCodeUnexpectedToken,
+					Message: "unexpected token: '}'",
+					Pos:     ast.Position{Offset: 15, Line: 1, Column: 15},
+					Got:     "'}'",
+				},
+			},
+			errs,
+		)
+	})
+
+	t.Run("annotation", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations("X Y\n@foo let a = 1", WithErrorRecovery(true))
+
+		assert.Equal(t,
+			[]string{"a"},
+			declarationIdentifiers(result),
+		)
+
+		require.IsType(t, &ast.VariableDeclaration{}, result[0])
+
+		utils.AssertEqualWithDiff(t,
+			[]*ast.AnnotationDeclaration{
+				{
+					Identifier: ast.Identifier{
+						Identifier: "foo",
+						Pos:        ast.Position{Offset: 5, Line: 2, Column: 1},
+					},
+					Range: ast.Range{
+						StartPos: ast.Position{Offset: 4, Line: 2, Column: 0},
+						EndPos:   ast.Position{Offset: 7, Line: 2, Column: 3},
+					},
+				},
+			},
+			result[0].(*ast.VariableDeclaration).Annotations,
+		)
+
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Code:    SyntaxErrorCodeUnexpectedToken,
+					Message: "unexpected token: identifier",
+					Pos:     ast.Position{Offset: 0, Line: 1, Column: 0},
+					Got:     "identifier",
+				},
+			},
+			errs,
+		)
+	})
+
+	t.Run("program", func(t *testing.T) {
+
+		t.Parallel()
+
+		program, err := ParseProgram("fun f( {}\nlet x = 1", WithErrorRecovery(true))
+		require.Error(t, err)
+
+		require.IsType(t, Error{}, err)
+		require.Len(t, err.(Error).Errors, 1)
+
+		require.NotNil(t, program)
+		assert.Equal(t,
+			[]string{"x"},
+			declarationIdentifiers(program.Declarations()),
+		)
+	})
+}
+
+func TestParseTypeAliasDeclaration(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("optional type", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations("typealias A = Int?")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Declaration{
+				&ast.TypeAliasDeclaration{
+					Access: ast.AccessNotSpecified,
+					Identifier: ast.Identifier{
+						Identifier: "A",
+						Pos:        ast.Position{Line: 1, Column: 10, Offset: 10},
+					},
+					TargetType: &ast.OptionalType{
+						Type: &ast.NominalType{
+							Identifier: ast.Identifier{
+								Identifier: "Int",
+								Pos:        ast.Position{Line: 1, Column: 14, Offset: 14},
+							},
+						},
+						EndPos: ast.Position{Line: 1, Column: 17, Offset: 17},
+					},
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+						EndPos:   ast.Position{Line: 1, Column: 17, Offset: 17},
+					},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("function type, pub", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseDeclarations("pub typealias F = ((Int): String)")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Declaration{
+				&ast.TypeAliasDeclaration{
+					Access: ast.AccessPublic,
+					Identifier: ast.Identifier{
+						Identifier: "F",
+						Pos:        ast.Position{Line: 1, Column: 14, Offset: 14},
+					},
+					TargetType: &ast.FunctionType{
+						ParameterTypeAnnotations: []*ast.TypeAnnotation{
+							{
+								IsResource: false,
+								Type: &ast.NominalType{
+									Identifier: ast.Identifier{
+										Identifier: "Int",
+										Pos:        ast.Position{Line: 1, Column: 20, Offset: 20},
+									},
+								},
+								StartPos: ast.Position{Line: 1, Column: 20, Offset: 20},
+							},
+						},
+						ReturnTypeAnnotation: &ast.TypeAnnotation{
+							IsResource: false,
+							Type: &ast.NominalType{
+								Identifier: ast.Identifier{
+									Identifier: "String",
+									Pos:        ast.Position{Line: 1, Column: 26, Offset: 26},
+								},
+							},
+							StartPos: ast.Position{Line: 1, Column: 26, Offset: 26},
+						},
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 18, Offset: 18},
+							EndPos:   ast.Position{Line: 1, Column: 32, Offset: 32},
+						},
+					},
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+						EndPos:   ast.Position{Line: 1, Column: 32, Offset: 32},
+					},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("missing equal sign", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := ParseDeclarations("typealias A Int")
+
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Code:     SyntaxErrorCodeUnexpectedToken,
+					Message:  "expected token '='",
+					Pos:      ast.Position{Line: 1, Column: 12, Offset: 12},
+					Expected: "'='",
+				},
+			},
+			errs,
+		)
+	})
+}
@@ -31,15 +31,24 @@ import (
 // Error
 
 type Error struct {
-	Code   string
+	Code string
+	// URI is the URI of the source file which failed to parse, if any
+	URI    string
 	Errors []error
 }
 
 func (e Error) Error() string {
+	var location common.Location
+	var locationID common.LocationID
+	if e.URI != "" {
+		location = common.StringLocation(e.URI)
+		locationID = location.ID()
+	}
+
 	var sb strings.Builder
 	sb.WriteString("Parsing failed:\n")
 	printErr := pretty.NewErrorPrettyPrinter(&sb, false).
-		PrettyPrintError(e, nil, map[common.LocationID]string{"": e.Code})
+		PrettyPrintError(e, location, map[common.LocationID]string{locationID: e.Code})
 	if printErr != nil {
 		panic(printErr)
 	}
@@ -61,8 +70,13 @@ type ParseError interface {
 // SyntaxError
 
 type SyntaxError struct {
-	Pos     ast.Position
+	Code    SyntaxErrorCode
 	Message string
+	Pos     ast.Position
+	// Expected describes what was expected, as stated in the message, if any
+	Expected string
+	// Got describes what was found instead, as stated in the message, if any
+	Got string
 }
 
 func (*SyntaxError) isParseError() {}
@@ -79,6 +93,25 @@ func (e *SyntaxError) Error() string {
 	return e.Message
 }
 
+// NewSyntaxError returns a syntax error at the given position,
+// with the message formatted according to the given format specifier.
+//
+func NewSyntaxError(pos ast.Position, message string, params ...interface{}) *SyntaxError {
+	return &SyntaxError{
+		Pos:     pos,
+		Message: fmt.Sprintf(message, params...),
+	}
+}
+
+// NewCodedSyntaxError returns a syntax error with the given code at the given position,
+// with the message formatted according to the given format specifier.
+//
+func NewCodedSyntaxError(code SyntaxErrorCode, pos ast.Position, message string, params ...interface{}) *SyntaxError {
+	err := NewSyntaxError(pos, message, params...)
+	err.Code = code
+	return err
+}
+
 // JuxtaposedUnaryOperatorsError
 
 type JuxtaposedUnaryOperatorsError struct {
@@ -99,6 +132,30 @@ func (e *JuxtaposedUnaryOperatorsError) Error() string {
 	return "unary operators must not be juxtaposed; parenthesize inner expression"
 }
 
+// NestingDepthExceededError
+
+type NestingDepthExceededError struct {
+	MaxDepth int
+	Pos      ast.Position
+}
+
+func (*NestingDepthExceededError) isParseError() {}
+
+func (e *NestingDepthExceededError) StartPosition() ast.Position {
+	return e.Pos
+}
+
+func (e *NestingDepthExceededError) EndPosition() ast.Position {
+	return e.Pos
+}
+
+func (e *NestingDepthExceededError) Error() string {
+	return fmt.Sprintf(
+		"expression is nested too deeply: maximum nesting depth is %d",
+		e.MaxDepth,
+	)
+}
+
 // InvalidIntegerLiteralError
 
 type InvalidIntegerLiteralError struct {
@@ -21,6 +21,7 @@ package parser2
 import (
 	"fmt"
 	"math/big"
+	"strconv"
 	"strings"
 	"unicode/utf8"
 
@@ -451,7 +452,24 @@ func init() {
 	defineExpr(literalExpr{
 		tokenType: lexer.TokenString,
 		nullDenotation: func(p *parser, token lexer.Token) ast.Expression {
-			parsedString, errs := parseStringLiteral(token.Value.(string))
+			parsedString, errs := parseStringLiteral(p, token.Value.(string))
+			p.report(errs...)
+			return &ast.StringExpression{
+				Value: parsedString,
+				Range: token.Range,
+			}
+		},
+	})
+
+	defineExpr(literalExpr{
+		tokenType:      lexer.TokenStringPart,
+		nullDenotation: parseStringInterpolation,
+	})
+
+	defineExpr(literalExpr{
+		tokenType: lexer.TokenMultilineString,
+		nullDenotation: func(p *parser, token lexer.Token) ast.Expression {
+			parsedString, errs := parseMultilineStringLiteral(p, token.Value.(string))
 			p.report(errs...)
 			return &ast.StringExpression{
 				Value: parsedString,
@@ -525,7 +543,12 @@ func init() {
 	defineIdentifierExpression()
 
 	setExprNullDenotation(lexer.TokenEOF, func(parser *parser, token lexer.Token) ast.Expression {
-		panic(fmt.Errorf("expected expression"))
+		panic(parser.expectedError(
+			SyntaxErrorCodeMissingExpression,
+			"expression",
+			"",
+			"expected expression",
+		))
 	})
 }
 
@@ -615,6 +638,10 @@ func defineLessThanOrTypeArgumentsExpression() {
 
 				arguments, endPos := parseArgumentListRemainder(p)
 
+				if removeExpression := asRemoveExpression(left, typeArguments, arguments, endPos); removeExpression != nil {
+					return removeExpression, false
+				}
+
 				invocationExpression := &ast.InvocationExpression{
 					InvokedExpression: left,
 					TypeArguments:     typeArguments,
@@ -663,6 +690,47 @@ func defineLessThanOrTypeArgumentsExpression() {
 		})
 }
 
+// asRemoveExpression returns a remove expression (e.g. `a.remove<A>()`)
+// for an invocation of the member `remove` with a single nominal type argument
+// and no arguments, or nil otherwise.
+//
+// Other invocations of members named `remove`,
+// e.g. `dictionary.remove(key: k)`, remain normal invocations.
+//
+func asRemoveExpression(
+	invokedExpression ast.Expression,
+	typeArguments []*ast.TypeAnnotation,
+	arguments ast.Arguments,
+	endPos ast.Position,
+) *ast.RemoveExpression {
+
+	memberExpression, ok := invokedExpression.(*ast.MemberExpression)
+	if !ok ||
+		memberExpression.Optional ||
+		memberExpression.Identifier.Identifier != keywordRemove ||
+		len(typeArguments) != 1 ||
+		len(arguments) != 0 {
+
+		return nil
+	}
+
+	typeArgument := typeArguments[0]
+	if typeArgument.IsResource {
+		return nil
+	}
+
+	attachment, ok := typeArgument.Type.(*ast.NominalType)
+	if !ok {
+		return nil
+	}
+
+	return &ast.RemoveExpression{
+		Base:       memberExpression.Expression,
+		Attachment: attachment,
+		EndPos:     endPos,
+	}
+}
+
 // defineGreaterThanOrBitwiseRightShiftExpression parses
 // the greater-than expression (operator `>`, e.g. `1 > 2`)
 // and the bitwise right shift expression (operator `>>`, e.g. `1 >> 3`).
@@ -787,10 +855,18 @@ func defineIdentifierExpression() {
 				return parseCreateExpressionRemainder(p, token)
 
 			case keywordDestroy:
-				expression := parseExpression(p, lowestBindingPower)
-				return &ast.DestroyExpression{
-					Expression: expression,
-					StartPos:   token.Range.StartPos,
+				return parseDestroyExpressionRemainder(p, token)
+
+			case keywordAttach:
+				// `attach` is only a keyword if it is followed by
+				// an attachment type invocation and the `to` keyword,
+				// otherwise it is an identifier
+				if isAttachExpressionRemainder(p) {
+					return parseAttachExpressionRemainder(p, token)
+				}
+
+				return &ast.IdentifierExpression{
+					Identifier: tokenToIdentifier(token),
 				}
 
 			case keywordFun:
@@ -881,6 +957,82 @@ func parseCreateExpressionRemainder(p *parser, token lexer.Token) *ast.CreateExp
 	}
 }
 
+// parseDestroyExpressionRemainder parses the destroyed expression,
+// after the `destroy` keyword was already parsed.
+//
+// The destroyed expression extends as far as possible,
+// so `destroy` is always the outermost expression,
+// e.g. `destroy a.b()` destroys the result of the invocation.
+//
+func parseDestroyExpressionRemainder(p *parser, token lexer.Token) *ast.DestroyExpression {
+	expression := parseExpression(p, lowestBindingPower)
+	return &ast.DestroyExpression{
+		Expression: expression,
+		StartPos:   token.StartPos,
+	}
+}
+
+// parseAttachExpressionRemainder parses the attachment constructor invocation,
+// the `to` keyword, and the base expression,
+// after the `attach` keyword was already parsed.
+//
+// Like the destroyed expression of a destroy expression,
+// the base expression extends as far as possible,
+// e.g. `attach A() to b.c()` attaches to the result of the invocation.
+//
+func parseAttachExpressionRemainder(p *parser, token lexer.Token) *ast.AttachExpression {
+	attachment := parseNominalTypeInvocationRemainder(p)
+
+	p.skipSpaceAndComments(true)
+	if !p.current.IsString(lexer.TokenIdentifier, keywordTo) {
+		panic(p.expectedError(
+			SyntaxErrorCodeMissingKeyword,
+			strconv.Quote(keywordTo),
+			p.current.Type.String(),
+			"expected keyword %q, got %s",
+			keywordTo,
+			p.current.Type,
+		))
+	}
+
+	// Skip the `to` keyword
+	p.next()
+	p.skipSpaceAndComments(true)
+
+	base := parseExpression(p, lowestBindingPower)
+
+	return &ast.AttachExpression{
+		Base:       base,
+		Attachment: attachment,
+		StartPos:   token.StartPos,
+	}
+}
+
+// isAttachExpressionRemainder checks whether the tokens to follow
+// are a nominal type invocation and the `to` keyword.
+//
+func isAttachExpressionRemainder(p *parser) (result bool) {
+	// The attachment type must start with an identifier.
+	// NOTE: also avoids buffering at the end of the token stream
+	if !p.current.Is(lexer.TokenIdentifier) {
+		return false
+	}
+
+	p.startBuffering()
+	defer p.replayBuffered()
+
+	defer func() {
+		if recover() != nil {
+			result = false
+		}
+	}()
+
+	parseNominalTypeInvocationRemainder(p)
+
+	p.skipSpaceAndComments(true)
+	return p.current.IsString(lexer.TokenIdentifier, keywordTo)
+}
+
 // Invocation Expression Grammar:
 //
 //     invocation : '(' ( argument ( ',' argument )* )? ')'
@@ -910,7 +1062,10 @@ func parseArgumentListRemainder(p *parser) (arguments []*ast.Argument, endPos as
 		switch p.current.Type {
 		case lexer.TokenComma:
 			if expectArgument {
-				panic(fmt.Errorf(
+				panic(p.expectedError(
+					SyntaxErrorCodeUnexpectedToken,
+					"argument or end of argument list",
+					p.current.Type.String(),
 					"expected argument or end of argument list, got %s",
 					p.current.Type,
 				))
@@ -926,11 +1081,19 @@ func parseArgumentListRemainder(p *parser) (arguments []*ast.Argument, endPos as
 			atEnd = true
 
 		case lexer.TokenEOF:
-			panic(fmt.Errorf("missing ')' at end of invocation argument list"))
+			panic(p.expectedError(
+				SyntaxErrorCodeUnclosedParen,
+				lexer.TokenParenClose.String(),
+				"",
+				"missing ')' at end of invocation argument list",
+			))
 
 		default:
 			if !expectArgument {
-				panic(fmt.Errorf(
+				panic(p.expectedError(
+					SyntaxErrorCodeUnexpectedToken,
+					"delimiter or end of argument list",
+					p.current.Type.String(),
 					"unexpected argument in argument list (expecting delimiter or end of argument list), got %s",
 					p.current.Type,
 				))
@@ -964,7 +1127,10 @@ func parseArgument(p *parser) *ast.Argument {
 	if p.current.Is(lexer.TokenColon) {
 		identifier, ok := expr.(*ast.IdentifierExpression)
 		if !ok {
-			panic(fmt.Errorf(
+			panic(p.expectedError(
+				SyntaxErrorCodeMissingIdentifier,
+				lexer.TokenIdentifier.String(),
+				fmt.Sprint(expr),
 				"expected identifier for label, got %s",
 				expr,
 			))
@@ -1124,7 +1290,12 @@ func defineReferenceExpression() {
 
 			castingExpression, ok := expression.(*ast.CastingExpression)
 			if !ok {
-				panic(fmt.Errorf("expected casting expression"))
+				panic(p.expectedError(
+					SyntaxErrorCodeMissingExpression,
+					"casting expression",
+					"",
+					"expected casting expression",
+				))
 			}
 
 			return &ast.ReferenceExpression{
@@ -1163,13 +1334,12 @@ func parseMemberAccess(p *parser, token lexer.Token, left ast.Expression, option
 	if p.current.Is(lexer.TokenSpace) {
 		errorPos := p.current.StartPos
 		p.skipSpaceAndComments(true)
-		p.report(&SyntaxError{
-			Message: fmt.Sprintf(
-				"invalid whitespace after %s",
-				lexer.TokenDot,
-			),
-			Pos: errorPos,
-		})
+		p.report(NewCodedSyntaxError(
+			SyntaxErrorCodeInvalidWhitespace,
+			errorPos,
+			"invalid whitespace after %s",
+			lexer.TokenDot,
+		))
 	}
 
 	// If there is an identifier, use it.
@@ -1180,7 +1350,10 @@ func parseMemberAccess(p *parser, token lexer.Token, left ast.Expression, option
 		identifier = tokenToIdentifier(p.current)
 		p.next()
 	} else {
-		p.report(fmt.Errorf(
+		p.report(p.expectedError(
+			SyntaxErrorCodeMissingIdentifier,
+			"member name",
+			p.current.Type.String(),
 			"expected member name, got %s",
 			p.current.Type,
 		))
@@ -1231,6 +1404,19 @@ func exprLeftDenotationAllowsWhitespaceAfterToken(tokenType lexer.TokenType) boo
 func parseExpression(p *parser, rightBindingPower int) ast.Expression {
 
 	p.skipSpaceAndComments(true)
+
+	p.expressionDepth++
+	defer func() {
+		p.expressionDepth--
+	}()
+
+	if p.maxNestingDepth > 0 && p.expressionDepth > p.maxNestingDepth {
+		panic(&NestingDepthExceededError{
+			MaxDepth: p.maxNestingDepth,
+			Pos:      p.current.StartPos,
+		})
+	}
+
 	t := p.current
 	p.next()
 
@@ -1327,7 +1513,13 @@ func applyExprNullDenotation(p *parser, token lexer.Token) ast.Expression {
 	tokenType := token.Type
 	nullDenotation := exprNullDenotations[tokenType]
 	if nullDenotation == nil {
-		panic(fmt.Errorf("unexpected token in expression: %s", tokenType))
+		panic(p.expectedError(
+			SyntaxErrorCodeUnexpectedToken,
+			"",
+			tokenType.String(),
+			"unexpected token in expression: %s",
+			tokenType,
+		))
 	}
 	return nullDenotation(p, token)
 }
@@ -1335,28 +1527,45 @@ func applyExprNullDenotation(p *parser, token lexer.Token) ast.Expression {
 func applyExprLeftDenotation(p *parser, token lexer.Token, left ast.Expression) ast.Expression {
 	leftDenotation := exprLeftDenotations[token.Type]
 	if leftDenotation == nil {
-		panic(fmt.Errorf("unexpected token in expression: %s", token.Type))
+		panic(p.expectedError(
+			SyntaxErrorCodeUnexpectedToken,
+			"",
+			token.Type.String(),
+			"unexpected token in expression: %s",
+			token.Type,
+		))
 	}
 	return leftDenotation(p, token, left)
 }
 
 // parseStringLiteral parses a whole string literal, including start and end quotes
 //
-func parseStringLiteral(literal string) (result string, errs []error) {
+func parseStringLiteral(p *parser, literal string) (result string, errs []error) {
 	report := func(err error) {
 		errs = append(errs, err)
 	}
 
 	length := len(literal)
 	if length == 0 {
-		report(fmt.Errorf("missing start of string literal: expected '\"'"))
+		report(p.expectedError(
+			SyntaxErrorCodeInvalidStringLiteral,
+			"'\"'",
+			"",
+			"missing start of string literal: expected '\"'",
+		))
 		return
 	}
 
 	if length >= 1 {
 		first := literal[0]
 		if first != '"' {
-			report(fmt.Errorf("invalid start of string literal: expected '\"', got %q", first))
+			report(p.expectedError(
+				SyntaxErrorCodeInvalidStringLiteral,
+				"'\"'",
+				fmt.Sprintf("%q", first),
+				"invalid start of string literal: expected '\"', got %q",
+				first,
+			))
 		}
 	}
 
@@ -1375,19 +1584,247 @@ func parseStringLiteral(literal string) (result string, errs []error) {
 	}
 
 	var innerErrs []error
-	result, innerErrs = parseStringLiteralContent(literal[1:endOffset])
+	result, innerErrs = parseStringLiteralContent(p, literal[1:endOffset])
+	errs = append(errs, innerErrs...)
+
+	if missingEnd {
+		report(p.codedSyntaxError(
+			SyntaxErrorCodeInvalidStringLiteral,
+			"invalid end of string literal: missing '\"'",
+		))
+	}
+
+	return
+}
+
+// parseStringInterpolation parses a string literal with interpolations, e.g. `"a \(b) c"`.
+//
+// The given token is the first string part, including the start quote.
+// The lexer emits the parts of the string literal and the tokens of the interpolated expressions
+// between the interpolation start (`\(`) and end (`)`) tokens.
+// The last string part includes the end quote.
+// Empty string parts, e.g. between two interpolations, are not emitted by the lexer.
+//
+func parseStringInterpolation(p *parser, startToken lexer.Token) ast.Expression {
+
+	// The first string part is always followed by an interpolation
+
+	firstPart := startToken.Value.(string)[1:]
+	segments := []ast.Expression{
+		parseStringPart(p, firstPart, startToken.Range),
+	}
+
+	endPos := startToken.EndPos
+	terminated := false
+
+	for p.current.Is(lexer.TokenInterpolationStart) {
+
+		// Skip the interpolation start token
+		p.next()
+
+		expression := parseExpression(p, lowestBindingPower)
+		p.skipSpaceAndComments(true)
+		interpolationEndToken := p.mustOne(lexer.TokenInterpolationEnd)
+
+		segments = append(segments, expression)
+		endPos = interpolationEndToken.EndPos
+
+		if !p.current.Is(lexer.TokenStringPart) {
+			segments = append(
+				segments,
+				&ast.StringExpression{
+					Range: ast.Range{
+						StartPos: endPos,
+						EndPos:   endPos,
+					},
+				},
+			)
+			continue
+		}
+
+		partToken := p.current
+		p.next()
+
+		part := partToken.Value.(string)
+		endPos = partToken.EndPos
+
+		// The last string part ends with the end quote
+
+		if !p.current.Is(lexer.TokenInterpolationStart) && strings.HasSuffix(part, `"`) {
+			part = part[:len(part)-1]
+			terminated = true
+		}
+
+		segments = append(
+			segments,
+			parseStringPart(p, part, partToken.Range),
+		)
+	}
+
+	if !terminated {
+		p.report(p.codedSyntaxError(
+			SyntaxErrorCodeInvalidStringLiteral,
+			"invalid end of string literal: missing '\"'",
+		))
+	}
+
+	return &ast.StringInterpolationExpression{
+		Segments: segments,
+		Range: ast.Range{
+			StartPos: startToken.StartPos,
+			EndPos:   endPos,
+		},
+	}
+}
+
+// parseStringPart parses the content of a string part of a string interpolation,
+// excluding quotes
+//
+func parseStringPart(p *parser, content string, tokenRange ast.Range) *ast.StringExpression {
+	parsedString, errs := parseStringLiteralContent(p, content)
+	p.report(errs...)
+	return &ast.StringExpression{
+		Value: parsedString,
+		Range: tokenRange,
+	}
+}
+
+const multilineStringQuotes = `"""`
+
+// parseMultilineStringLiteral parses a whole multi-line string literal,
+// including start and end quotes (`"""`).
+//
+// Like in Swift, the content starts on the line after the start quotes,
+// and ends on the line before the end quotes.
+// The indentation common to all lines, including the line of the end quotes,
+// is stripped from each line.
+// Escape sequences are handled like in single-line string literals.
+//
+func parseMultilineStringLiteral(p *parser, literal string) (result string, errs []error) {
+	report := func(err error) {
+		errs = append(errs, err)
+	}
+
+	if !strings.HasPrefix(literal, multilineStringQuotes) {
+		report(p.expectedError(
+			SyntaxErrorCodeInvalidStringLiteral,
+			multilineStringQuotes,
+			"",
+			"invalid start of multi-line string literal: expected %s",
+			multilineStringQuotes,
+		))
+		return
+	}
+
+	content := literal[len(multilineStringQuotes):]
+
+	missingEnd := true
+	if strings.HasSuffix(content, multilineStringQuotes) {
+		content = content[:len(content)-len(multilineStringQuotes)]
+		missingEnd = false
+	}
+
+	// The content must start on a new line
+
+	switch {
+	case strings.HasPrefix(content, "\n"):
+		content = content[1:]
+	case strings.HasPrefix(content, "\r\n"):
+		content = content[2:]
+	default:
+		report(p.expectedError(
+			SyntaxErrorCodeInvalidStringLiteral,
+			fmt.Sprintf("new line after %s", multilineStringQuotes),
+			"",
+			"invalid multi-line string literal: expected new line after %s",
+			multilineStringQuotes,
+		))
+	}
+
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+
+	// If the end quotes are on their own line,
+	// the line only contains indentation, which is not part of the content
+
+	lastIndex := len(lines) - 1
+	lastLine := lines[lastIndex]
+	endQuotesOnOwnLine := lastIndex > 0 && isBlank(lastLine)
+	if endQuotesOnOwnLine {
+		lines = lines[:lastIndex]
+	}
+
+	// Determine the indentation common to all lines,
+	// ignoring blank lines, which might be shorter
+
+	var indentation string
+	hasIndentation := false
+
+	if endQuotesOnOwnLine {
+		indentation = lastLine
+		hasIndentation = true
+	}
+
+	for _, line := range lines {
+		if isBlank(line) {
+			continue
+		}
+
+		lineIndentation := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+
+		if !hasIndentation {
+			indentation = lineIndentation
+			hasIndentation = true
+			continue
+		}
+
+		indentation = commonPrefix(indentation, lineIndentation)
+	}
+
+	for i, line := range lines {
+		if isBlank(line) && len(line) < len(indentation) {
+			lines[i] = ""
+		} else {
+			lines[i] = line[len(indentation):]
+		}
+	}
+
+	var innerErrs []error
+	result, innerErrs = parseStringLiteralContent(p, strings.Join(lines, "\n"))
 	errs = append(errs, innerErrs...)
 
 	if missingEnd {
-		report(fmt.Errorf("invalid end of string literal: missing '\"'"))
+		report(p.codedSyntaxError(
+			SyntaxErrorCodeInvalidStringLiteral,
+			"invalid end of multi-line string literal: missing %s",
+			multilineStringQuotes,
+		))
 	}
 
 	return
 }
 
+func isBlank(s string) bool {
+	return strings.TrimLeft(s, " \t") == ""
+}
+
+func commonPrefix(a, b string) string {
+	length := len(a)
+	if len(b) < length {
+		length = len(b)
+	}
+
+	for i := 0; i < length; i++ {
+		if a[i] != b[i] {
+			return a[:i]
+		}
+	}
+
+	return a[:length]
+}
+
 // parseStringLiteralContent parses the string literalExpr contents, excluding start and end quotes
 //
-func parseStringLiteralContent(s string) (result string, errs []error) {
+func parseStringLiteralContent(p *parser, s string) (result string, errs []error) {
 
 	var builder strings.Builder
 	defer func() {
@@ -1427,7 +1864,10 @@ func parseStringLiteralContent(s string) (result string, errs []error) {
 		}
 
 		if atEnd {
-			report(fmt.Errorf("incomplete escape sequence: missing character after escape character"))
+			report(p.codedSyntaxError(
+				SyntaxErrorCodeInvalidEscapeSequence,
+				"incomplete escape sequence: missing character after escape character",
+			))
 			return
 		}
 
@@ -1450,14 +1890,21 @@ func parseStringLiteralContent(s string) (result string, errs []error) {
 			builder.WriteByte('\\')
 		case 'u':
 			if atEnd {
-				report(fmt.Errorf(
+				report(p.codedSyntaxError(
+					SyntaxErrorCodeInvalidEscapeSequence,
 					"incomplete Unicode escape sequence: missing character '{' after escape character",
 				))
 				return
 			}
 			advance()
 			if r != '{' {
-				report(fmt.Errorf("invalid Unicode escape sequence: expected '{', got %q", r))
+				report(p.expectedError(
+					SyntaxErrorCodeInvalidEscapeSequence,
+					"'{'",
+					fmt.Sprintf("%q", r),
+					"invalid Unicode escape sequence: expected '{', got %q",
+					r,
+				))
 				continue
 			}
 
@@ -1473,7 +1920,13 @@ func parseStringLiteralContent(s string) (result string, errs []error) {
 				parsed := parseHex(r)
 
 				if parsed < 0 {
-					report(fmt.Errorf("invalid Unicode escape sequence: expected hex digit, got %q", r))
+					report(p.expectedError(
+						SyntaxErrorCodeInvalidEscapeSequence,
+						"hex digit",
+						fmt.Sprintf("%q", r),
+						"invalid Unicode escape sequence: expected hex digit, got %q",
+						r,
+					))
 					valid = false
 				} else {
 					r2 = r2<<4 | parsed
@@ -1492,16 +1945,26 @@ func parseStringLiteralContent(s string) (result string, errs []error) {
 			case '}':
 				break
 			case lexer.EOF:
-				report(fmt.Errorf(
+				report(p.codedSyntaxError(
+					SyntaxErrorCodeInvalidEscapeSequence,
 					"incomplete Unicode escape sequence: missing character '}' after escape character",
 				))
 			default:
-				report(fmt.Errorf("incomplete Unicode escape sequence: expected '}', got %q", r))
+				report(p.expectedError(
+					SyntaxErrorCodeInvalidEscapeSequence,
+					"'}'",
+					fmt.Sprintf("%q", r),
+					"incomplete Unicode escape sequence: expected '}', got %q",
+					r,
+				))
 			}
 
 		default:
 			// TODO: include index/column in error
-			report(fmt.Errorf("invalid escape character: %q", r))
+			report(p.codedSyntaxError(
+				SyntaxErrorCodeInvalidEscapeSequence,
+				"invalid escape character: %q", r,
+			))
 			// skip invalid escape character, don't write to result
 		}
 	}
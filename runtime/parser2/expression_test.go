@@ -783,6 +783,7 @@ func TestParseString(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
+					Code:    SyntaxErrorCodeInvalidStringLiteral,
 					Message: "invalid end of string literal: missing '\"'",
 					Pos:     ast.Position{Offset: 1, Line: 1, Column: 1},
 				},
@@ -810,6 +811,7 @@ func TestParseString(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
+					Code:    SyntaxErrorCodeInvalidStringLiteral,
 					Message: "invalid end of string literal: missing '\"'",
 					Pos:     ast.Position{Line: 2, Column: 0, Offset: 2},
 				},
@@ -836,6 +838,7 @@ func TestParseString(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
+					Code:    SyntaxErrorCodeInvalidStringLiteral,
 					Message: "invalid end of string literal: missing '\"'",
 					Pos:     ast.Position{Offset: 2, Line: 1, Column: 2},
 				},
@@ -863,6 +866,7 @@ func TestParseString(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
+					Code:    SyntaxErrorCodeInvalidStringLiteral,
 					Message: "invalid end of string literal: missing '\"'",
 					Pos:     ast.Position{Line: 2, Column: 0, Offset: 3},
 				},
@@ -890,10 +894,12 @@ func TestParseString(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
+					Code:    SyntaxErrorCodeInvalidEscapeSequence,
 					Message: "incomplete escape sequence: missing character after escape character",
 					Pos:     ast.Position{Offset: 2, Line: 1, Column: 2},
 				},
 				&SyntaxError{
+					Code:    SyntaxErrorCodeInvalidStringLiteral,
 					Message: "invalid end of string literal: missing '\"'",
 					Pos:     ast.Position{Offset: 2, Line: 1, Column: 2},
 				},
@@ -940,6 +946,7 @@ func TestParseString(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
+					Code:    SyntaxErrorCodeInvalidEscapeSequence,
 					Message: "invalid escape character: 'X'",
 					Pos:     ast.Position{Offset: 8, Line: 1, Column: 8},
 				},
@@ -967,10 +974,12 @@ func TestParseString(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
+					Code:    SyntaxErrorCodeInvalidEscapeSequence,
 					Message: "incomplete Unicode escape sequence: missing character '{' after escape character",
 					Pos:     ast.Position{Offset: 5, Line: 1, Column: 5},
 				},
 				&SyntaxError{
+					Code:    SyntaxErrorCodeInvalidStringLiteral,
 					Message: "invalid end of string literal: missing '\"'",
 					Pos:     ast.Position{Offset: 5, Line: 1, Column: 5},
 				},
@@ -998,10 +1007,14 @@ func TestParseString(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
-					Message: "invalid Unicode escape sequence: expected '{', got 's'",
-					Pos:     ast.Position{Offset: 6, Line: 1, Column: 6},
+					Code:     SyntaxErrorCodeInvalidEscapeSequence,
+					Message:  "invalid Unicode escape sequence: expected '{', got 's'",
+					Pos:      ast.Position{Offset: 6, Line: 1, Column: 6},
+					Expected: "'{'",
+					Got:      "'s'",
 				},
 				&SyntaxError{
+					Code:    SyntaxErrorCodeInvalidStringLiteral,
 					Message: "invalid end of string literal: missing '\"'",
 					Pos:     ast.Position{Offset: 6, Line: 1, Column: 6},
 				},
@@ -1029,10 +1042,12 @@ func TestParseString(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
+					Code:    SyntaxErrorCodeInvalidEscapeSequence,
 					Message: "incomplete Unicode escape sequence: missing character '}' after escape character",
 					Pos:     ast.Position{Offset: 6, Line: 1, Column: 6},
 				},
 				&SyntaxError{
+					Code:    SyntaxErrorCodeInvalidStringLiteral,
 					Message: "invalid end of string literal: missing '\"'",
 					Pos:     ast.Position{Offset: 6, Line: 1, Column: 6},
 				},
@@ -1106,8 +1121,11 @@ func TestParseString(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
-					Message: "invalid Unicode escape sequence: expected hex digit, got 'X'",
-					Pos:     ast.Position{Offset: 11, Line: 1, Column: 11},
+					Code:     SyntaxErrorCodeInvalidEscapeSequence,
+					Message:  "invalid Unicode escape sequence: expected hex digit, got 'X'",
+					Pos:      ast.Position{Offset: 11, Line: 1, Column: 11},
+					Expected: "hex digit",
+					Got:      "'X'",
 				},
 			},
 			errs,
@@ -1126,6 +1144,293 @@ func TestParseString(t *testing.T) {
 	})
 }
 
+func TestParseStringInterpolation(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("literal only", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseExpression(`"a (b)"`)
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.StringExpression{
+				Value: "a (b)",
+				Range: ast.Range{
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+					EndPos:   ast.Position{Line: 1, Column: 6, Offset: 6},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("single interpolation", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseExpression(`"a \(b) c"`)
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.StringInterpolationExpression{
+				Segments: []ast.Expression{
+					&ast.StringExpression{
+						Value: "a ",
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+							EndPos:   ast.Position{Line: 1, Column: 2, Offset: 2},
+						},
+					},
+					&ast.IdentifierExpression{
+						Identifier: ast.Identifier{
+							Identifier: "b",
+							Pos:        ast.Position{Line: 1, Column: 5, Offset: 5},
+						},
+					},
+					&ast.StringExpression{
+						Value: " c",
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 7, Offset: 7},
+							EndPos:   ast.Position{Line: 1, Column: 9, Offset: 9},
+						},
+					},
+				},
+				Range: ast.Range{
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+					EndPos:   ast.Position{Line: 1, Column: 9, Offset: 9},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("adjacent interpolations", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseExpression(`"\(a)\(b)"`)
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.StringInterpolationExpression{
+				Segments: []ast.Expression{
+					&ast.StringExpression{
+						Value: "",
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+							EndPos:   ast.Position{Line: 1, Column: 0, Offset: 0},
+						},
+					},
+					&ast.IdentifierExpression{
+						Identifier: ast.Identifier{
+							Identifier: "a",
+							Pos:        ast.Position{Line: 1, Column: 3, Offset: 3},
+						},
+					},
+					&ast.StringExpression{
+						Value: "",
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 4, Offset: 4},
+							EndPos:   ast.Position{Line: 1, Column: 4, Offset: 4},
+						},
+					},
+					&ast.IdentifierExpression{
+						Identifier: ast.Identifier{
+							Identifier: "b",
+							Pos:        ast.Position{Line: 1, Column: 7, Offset: 7},
+						},
+					},
+					&ast.StringExpression{
+						Value: "",
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 9, Offset: 9},
+							EndPos:   ast.Position{Line: 1, Column: 9, Offset: 9},
+						},
+					},
+				},
+				Range: ast.Range{
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+					EndPos:   ast.Position{Line: 1, Column: 9, Offset: 9},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("nested interpolation", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseExpression(`"\("\(a)")"`)
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.StringInterpolationExpression{
+				Segments: []ast.Expression{
+					&ast.StringExpression{
+						Value: "",
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+							EndPos:   ast.Position{Line: 1, Column: 0, Offset: 0},
+						},
+					},
+					&ast.StringInterpolationExpression{
+						Segments: []ast.Expression{
+							&ast.StringExpression{
+								Value: "",
+								Range: ast.Range{
+									StartPos: ast.Position{Line: 1, Column: 3, Offset: 3},
+									EndPos:   ast.Position{Line: 1, Column: 3, Offset: 3},
+								},
+							},
+							&ast.IdentifierExpression{
+								Identifier: ast.Identifier{
+									Identifier: "a",
+									Pos:        ast.Position{Line: 1, Column: 6, Offset: 6},
+								},
+							},
+							&ast.StringExpression{
+								Value: "",
+								Range: ast.Range{
+									StartPos: ast.Position{Line: 1, Column: 8, Offset: 8},
+									EndPos:   ast.Position{Line: 1, Column: 8, Offset: 8},
+								},
+							},
+						},
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 3, Offset: 3},
+							EndPos:   ast.Position{Line: 1, Column: 8, Offset: 8},
+						},
+					},
+					&ast.StringExpression{
+						Value: "",
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 10, Offset: 10},
+							EndPos:   ast.Position{Line: 1, Column: 10, Offset: 10},
+						},
+					},
+				},
+				Range: ast.Range{
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+					EndPos:   ast.Position{Line: 1, Column: 10, Offset: 10},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("escaped backslash", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseExpression(`"a\\(b)"`)
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.StringExpression{
+				Value: `a\(b)`,
+				Range: ast.Range{
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+					EndPos:   ast.Position{Line: 1, Column: 7, Offset: 7},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("escape sequences around interpolation", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseExpression(`"\\\(a)\n"`)
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.StringInterpolationExpression{
+				Segments: []ast.Expression{
+					&ast.StringExpression{
+						Value: `\`,
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+							EndPos:   ast.Position{Line: 1, Column: 2, Offset: 2},
+						},
+					},
+					&ast.IdentifierExpression{
+						Identifier: ast.Identifier{
+							Identifier: "a",
+							Pos:        ast.Position{Line: 1, Column: 5, Offset: 5},
+						},
+					},
+					&ast.StringExpression{
+						Value: "\n",
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 7, Offset: 7},
+							EndPos:   ast.Position{Line: 1, Column: 9, Offset: 9},
+						},
+					},
+				},
+				Range: ast.Range{
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+					EndPos:   ast.Position{Line: 1, Column: 9, Offset: 9},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("missing end", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseExpression(`"a \(b)`)
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Code:    SyntaxErrorCodeInvalidStringLiteral,
+					Message: "invalid end of string literal: missing '\"'",
+					Pos:     ast.Position{Line: 1, Column: 7, Offset: 7},
+				},
+			},
+			errs,
+		)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.StringInterpolationExpression{
+				Segments: []ast.Expression{
+					&ast.StringExpression{
+						Value: "a ",
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+							EndPos:   ast.Position{Line: 1, Column: 2, Offset: 2},
+						},
+					},
+					&ast.IdentifierExpression{
+						Identifier: ast.Identifier{
+							Identifier: "b",
+							Pos:        ast.Position{Line: 1, Column: 5, Offset: 5},
+						},
+					},
+					&ast.StringExpression{
+						Value: "",
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 6, Offset: 6},
+							EndPos:   ast.Position{Line: 1, Column: 6, Offset: 6},
+						},
+					},
+				},
+				Range: ast.Range{
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+					EndPos:   ast.Position{Line: 1, Column: 6, Offset: 6},
+				},
+			},
+			result,
+		)
+	})
+}
+
 func TestInvocation(t *testing.T) {
 
 	t.Parallel()
@@ -1387,8 +1692,11 @@ func TestInvocation(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
-					Message: "expected argument or end of argument list, got ','",
-					Pos:     ast.Position{Offset: 2, Line: 1, Column: 2},
+					Code:     SyntaxErrorCodeUnexpectedToken,
+					Message:  "expected argument or end of argument list, got ','",
+					Pos:      ast.Position{Offset: 2, Line: 1, Column: 2},
+					Expected: "argument or end of argument list",
+					Got:      "','",
 				},
 			},
 			errs,
@@ -1403,8 +1711,11 @@ func TestInvocation(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
-					Message: "expected argument or end of argument list, got ','",
-					Pos:     ast.Position{Offset: 4, Line: 1, Column: 4},
+					Code:     SyntaxErrorCodeUnexpectedToken,
+					Message:  "expected argument or end of argument list, got ','",
+					Pos:      ast.Position{Offset: 4, Line: 1, Column: 4},
+					Expected: "argument or end of argument list",
+					Got:      "','",
 				},
 			},
 			errs,
@@ -1419,9 +1730,12 @@ func TestInvocation(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
+					Code: SyntaxErrorCodeUnexpectedToken,
 					Message: "unexpected argument in argument list (expecting delimiter or end of argument list)," +
 						" got decimal integer",
-					Pos: ast.Position{Offset: 4, Line: 1, Column: 4},
+					Pos:      ast.Position{Offset: 4, Line: 1, Column: 4},
+					Expected: "delimiter or end of argument list",
+					Got:      "decimal integer",
 				},
 			},
 			errs,
@@ -1621,8 +1935,11 @@ func TestMemberExpression(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
-					Message: "expected member name, got EOF",
-					Pos:     ast.Position{Offset: 2, Line: 1, Column: 2},
+					Code:     SyntaxErrorCodeMissingIdentifier,
+					Message:  "expected member name, got EOF",
+					Pos:      ast.Position{Offset: 2, Line: 1, Column: 2},
+					Expected: "member name",
+					Got:      "EOF",
 				},
 			},
 			errs,
@@ -1817,6 +2134,68 @@ func TestParseBlockComment(t *testing.T) {
 			result,
 		)
 	})
+
+	t.Run("empty", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseExpression("/**/ true")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.BoolExpression{
+				Value: true,
+				Range: ast.Range{
+					StartPos: ast.Position{Line: 1, Column: 6, Offset: 5},
+					EndPos:   ast.Position{Line: 1, Column: 9, Offset: 8},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("in string literal", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseExpression(`"/* not a comment */"`)
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.StringExpression{
+				Value: "/* not a comment */",
+				Range: ast.Range{
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+					EndPos:   ast.Position{Line: 1, Column: 20, Offset: 20},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("unterminated", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := ParseExpression("/* true")
+
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Code:    SyntaxErrorCodeUnterminatedComment,
+					Message: `missing comment end "'*/'"`,
+					Pos:     ast.Position{Line: 1, Column: 7, Offset: 7},
+				},
+				&SyntaxError{
+					Code:     SyntaxErrorCodeMissingExpression,
+					Message:  "expected expression",
+					Pos:      ast.Position{Line: 1, Column: 7, Offset: 7},
+					Expected: "expression",
+				},
+			},
+			errs,
+		)
+	})
 }
 
 func BenchmarkParseInfix(b *testing.B) {
@@ -2152,6 +2531,7 @@ func TestParseForceExpression(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
+					Code:    SyntaxErrorCodeInvalidWhitespace,
 					Message: "invalid whitespace after '.'",
 					Pos:     ast.Position{Offset: 2, Line: 1, Column: 2},
 				},
@@ -2212,22 +2592,224 @@ func TestParseCreate(t *testing.T) {
 	})
 }
 
-func TestParseNil(t *testing.T) {
+func TestParseNil(t *testing.T) {
+
+	t.Parallel()
+
+	result, errs := ParseExpression(" nil")
+	require.Empty(t, errs)
+
+	utils.AssertEqualWithDiff(t,
+		&ast.NilExpression{
+			Pos: ast.Position{Line: 1, Column: 1, Offset: 1},
+		},
+		result,
+	)
+}
+
+func TestParseDestroy(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("simple", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseExpression("destroy t")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.DestroyExpression{
+				Expression: &ast.IdentifierExpression{
+					Identifier: ast.Identifier{
+						Identifier: "t",
+						Pos:        ast.Position{Line: 1, Column: 8, Offset: 8},
+					},
+				},
+				StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+			},
+			result,
+		)
+	})
+
+	t.Run("invocation", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseExpression("destroy a.b()")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.DestroyExpression{
+				Expression: &ast.InvocationExpression{
+					InvokedExpression: &ast.MemberExpression{
+						Expression: &ast.IdentifierExpression{
+							Identifier: ast.Identifier{
+								Identifier: "a",
+								Pos:        ast.Position{Line: 1, Column: 8, Offset: 8},
+							},
+						},
+						AccessPos: ast.Position{Line: 1, Column: 9, Offset: 9},
+						Identifier: ast.Identifier{
+							Identifier: "b",
+							Pos:        ast.Position{Line: 1, Column: 10, Offset: 10},
+						},
+					},
+					ArgumentsStartPos: ast.Position{Line: 1, Column: 11, Offset: 11},
+					EndPos:            ast.Position{Line: 1, Column: 12, Offset: 12},
+				},
+				StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+			},
+			result,
+		)
+	})
+}
+
+func TestParseAttach(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("simple", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseExpression("attach A() to b")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.AttachExpression{
+				Base: &ast.IdentifierExpression{
+					Identifier: ast.Identifier{
+						Identifier: "b",
+						Pos:        ast.Position{Line: 1, Column: 14, Offset: 14},
+					},
+				},
+				Attachment: &ast.InvocationExpression{
+					InvokedExpression: &ast.IdentifierExpression{
+						Identifier: ast.Identifier{
+							Identifier: "A",
+							Pos:        ast.Position{Line: 1, Column: 7, Offset: 7},
+						},
+					},
+					ArgumentsStartPos: ast.Position{Line: 1, Column: 8, Offset: 8},
+					EndPos:            ast.Position{Line: 1, Column: 9, Offset: 9},
+				},
+				StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+			},
+			result,
+		)
+	})
+
+	t.Run("invocation", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseExpression("attach A() to b()")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.AttachExpression{
+				Base: &ast.InvocationExpression{
+					InvokedExpression: &ast.IdentifierExpression{
+						Identifier: ast.Identifier{
+							Identifier: "b",
+							Pos:        ast.Position{Line: 1, Column: 14, Offset: 14},
+						},
+					},
+					ArgumentsStartPos: ast.Position{Line: 1, Column: 15, Offset: 15},
+					EndPos:            ast.Position{Line: 1, Column: 16, Offset: 16},
+				},
+				Attachment: &ast.InvocationExpression{
+					InvokedExpression: &ast.IdentifierExpression{
+						Identifier: ast.Identifier{
+							Identifier: "A",
+							Pos:        ast.Position{Line: 1, Column: 7, Offset: 7},
+						},
+					},
+					ArgumentsStartPos: ast.Position{Line: 1, Column: 8, Offset: 8},
+					EndPos:            ast.Position{Line: 1, Column: 9, Offset: 9},
+				},
+				StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+			},
+			result,
+		)
+	})
+
+	t.Run("missing to", func(t *testing.T) {
+
+		t.Parallel()
+
+		// Without the `to` keyword, `attach` is an identifier
+
+		_, errs := ParseExpression("attach A() b")
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Code:    SyntaxErrorCodeUnexpectedToken,
+					Message: "unexpected token: identifier",
+					Pos:     ast.Position{Line: 1, Column: 7, Offset: 7},
+					Got:     "identifier",
+				},
+			},
+			errs,
+		)
+	})
+
+	t.Run("identifier", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseExpression("attach + 1")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.BinaryExpression{
+				Operation: ast.OperationPlus,
+				Left: &ast.IdentifierExpression{
+					Identifier: ast.Identifier{
+						Identifier: "attach",
+						Pos:        ast.Position{Line: 1, Column: 0, Offset: 0},
+					},
+				},
+				Right: &ast.IntegerExpression{
+					PositiveLiteral: "1",
+					Value:           big.NewInt(1),
+					Base:            10,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 9, Offset: 9},
+						EndPos:   ast.Position{Line: 1, Column: 9, Offset: 9},
+					},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("identifier in statements", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseStatements("let y = attach + 1\nreturn attach")
+		require.Empty(t, errs)
 
-	t.Parallel()
+		require.Len(t, result, 2)
 
-	result, errs := ParseExpression(" nil")
-	require.Empty(t, errs)
+		returnStatement, ok := result[1].(*ast.ReturnStatement)
+		require.True(t, ok)
 
-	utils.AssertEqualWithDiff(t,
-		&ast.NilExpression{
-			Pos: ast.Position{Line: 1, Column: 1, Offset: 1},
-		},
-		result,
-	)
+		utils.AssertEqualWithDiff(t,
+			&ast.IdentifierExpression{
+				Identifier: ast.Identifier{
+					Identifier: "attach",
+					Pos:        ast.Position{Line: 2, Column: 7, Offset: 26},
+				},
+			},
+			returnStatement.Expression,
+		)
+	})
 }
 
-func TestParseDestroy(t *testing.T) {
+func TestParseRemove(t *testing.T) {
 
 	t.Parallel()
 
@@ -2235,22 +2817,59 @@ func TestParseDestroy(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseExpression("destroy t")
+		result, errs := ParseExpression("a.remove<A>()")
 		require.Empty(t, errs)
 
 		utils.AssertEqualWithDiff(t,
-			&ast.DestroyExpression{
-				Expression: &ast.IdentifierExpression{
+			&ast.RemoveExpression{
+				Base: &ast.IdentifierExpression{
 					Identifier: ast.Identifier{
-						Identifier: "t",
-						Pos:        ast.Position{Line: 1, Column: 8, Offset: 8},
+						Identifier: "a",
+						Pos:        ast.Position{Line: 1, Column: 0, Offset: 0},
 					},
 				},
-				StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+				Attachment: &ast.NominalType{
+					Identifier: ast.Identifier{
+						Identifier: "A",
+						Pos:        ast.Position{Line: 1, Column: 9, Offset: 9},
+					},
+				},
+				EndPos: ast.Position{Line: 1, Column: 12, Offset: 12},
 			},
 			result,
 		)
 	})
+
+	t.Run("within attach", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseExpression("attach A() to b.remove<B>()")
+		require.Empty(t, errs)
+
+		require.IsType(t, &ast.AttachExpression{}, result)
+		assert.IsType(t, &ast.RemoveExpression{}, result.(*ast.AttachExpression).Base)
+	})
+
+	t.Run("member invocation with arguments", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseExpression("a.remove(key: 1)")
+		require.Empty(t, errs)
+
+		assert.IsType(t, &ast.InvocationExpression{}, result)
+	})
+
+	t.Run("member invocation with arguments and type argument", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseExpression("a.remove<A>(1)")
+		require.Empty(t, errs)
+
+		assert.IsType(t, &ast.InvocationExpression{}, result)
+	})
 }
 
 func TestParseLineComment(t *testing.T) {
@@ -2382,6 +3001,7 @@ func TestParseIntegerLiterals(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
+					Code:    SyntaxErrorCodeInvalidToken,
 					Message: "missing digits",
 					Pos:     ast.Position{Offset: 1, Line: 1, Column: 1},
 				},
@@ -2549,6 +3169,7 @@ func TestParseIntegerLiterals(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
+					Code:    SyntaxErrorCodeInvalidToken,
 					Message: "missing digits",
 					Pos:     ast.Position{Line: 1, Column: 1, Offset: 1},
 				},
@@ -2773,6 +3394,7 @@ func TestParseIntegerLiterals(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
+					Code:    SyntaxErrorCodeInvalidToken,
 					Message: "missing digits",
 					Pos:     ast.Position{Line: 1, Column: 1, Offset: 1},
 				},
@@ -3005,6 +3627,7 @@ func TestParseIntegerLiterals(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
+					Code:    SyntaxErrorCodeInvalidToken,
 					Message: "invalid number literal prefix: 'z'",
 					Pos:     ast.Position{Line: 1, Column: 1, Offset: 1},
 				},
@@ -3156,6 +3779,7 @@ func TestParseFixedPoint(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
+					Code:    SyntaxErrorCodeInvalidToken,
 					Message: "missing fractional digits",
 					Pos:     ast.Position{Line: 1, Column: 1, Offset: 1},
 				},
@@ -4970,6 +5594,88 @@ func TestParseExpression(t *testing.T) {
 	utils.AssertEqualWithDiff(t, expected, actual)
 }
 
+func TestParseExpressionRemainder(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("trailing trivia", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseExpression(" 1 /* block */ // line\n ")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.IntegerExpression{
+				PositiveLiteral: "1",
+				Value:           big.NewInt(1),
+				Base:            10,
+				Range: ast.Range{
+					StartPos: ast.Position{Offset: 1, Line: 1, Column: 1},
+					EndPos:   ast.Position{Offset: 1, Line: 1, Column: 1},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("trailing tokens", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := ParseExpression("f(1) 2")
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Code:    SyntaxErrorCodeUnexpectedToken,
+					Message: "unexpected token: decimal integer",
+					Pos:     ast.Position{Offset: 5, Line: 1, Column: 5},
+					Got:     "decimal integer",
+				},
+			},
+			errs,
+		)
+	})
+
+	t.Run("incomplete", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseExpression("1 +")
+		assert.Nil(t, result)
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Code:     SyntaxErrorCodeMissingExpression,
+					Message:  "expected expression",
+					Pos:      ast.Position{Offset: 3, Line: 1, Column: 3},
+					Expected: "expression",
+				},
+			},
+			errs,
+		)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseExpression("")
+		assert.Nil(t, result)
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Code:     SyntaxErrorCodeMissingExpression,
+					Message:  "expected expression",
+					Pos:      ast.Position{Offset: 0, Line: 1, Column: 0},
+					Expected: "expression",
+				},
+			},
+			errs,
+		)
+	})
+}
+
 func TestParseStringEscapes(t *testing.T) {
 
 	t.Parallel()
@@ -5026,6 +5732,146 @@ func TestParseStringWithUnicode(t *testing.T) {
 	utils.AssertEqualWithDiff(t, expected, actual)
 }
 
+func TestParseMultilineString(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("valid, indentation stripped", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseExpression("\"\"\"\n    {\n      \"a\": 1\n\n    }\n    \"\"\"")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.StringExpression{
+				Value: "{\n  \"a\": 1\n\n}",
+				Range: ast.Range{
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+					EndPos:   ast.Position{Line: 6, Column: 6, Offset: 36},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("valid, indentation of end quotes", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseExpression("\"\"\"\n    a\n      b\n  \"\"\"")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.StringExpression{
+				Value: "  a\n    b",
+				Range: ast.Range{
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+					EndPos:   ast.Position{Line: 4, Column: 4, Offset: 22},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("valid, end quotes at end of line", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseExpression("\"\"\"\n  a\n  b\"\"\"")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.StringExpression{
+				Value: "a\nb",
+				Range: ast.Range{
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+					EndPos:   ast.Position{Line: 3, Column: 5, Offset: 13},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("valid, escapes", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseExpression(`"""
+          test \0\n\r\t\"\'\\ \u{1F3CE} \"""
+          """`)
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.StringExpression{
+				Value: "test \x00\n\r\t\"'\\ \U0001F3CE \"\"\"",
+				Range: ast.Range{
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+					EndPos:   ast.Position{Line: 3, Column: 12, Offset: 61},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("invalid, content on first line", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseExpression(`"""test"""`)
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Code:     SyntaxErrorCodeInvalidStringLiteral,
+					Message:  "invalid multi-line string literal: expected new line after \"\"\"",
+					Pos:      ast.Position{Line: 1, Column: 10, Offset: 10},
+					Expected: "new line after \"\"\"",
+				},
+			},
+			errs,
+		)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.StringExpression{
+				Value: "test",
+				Range: ast.Range{
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+					EndPos:   ast.Position{Line: 1, Column: 9, Offset: 9},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("invalid, missing end at end of file", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseExpression("\"\"\"\ntest")
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Code:    SyntaxErrorCodeInvalidStringLiteral,
+					Message: "invalid end of multi-line string literal: missing \"\"\"",
+					Pos:     ast.Position{Line: 2, Column: 4, Offset: 8},
+				},
+			},
+			errs,
+		)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.StringExpression{
+				Value: "test",
+				Range: ast.Range{
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+					EndPos:   ast.Position{Line: 2, Column: 3, Offset: 7},
+				},
+			},
+			result,
+		)
+	})
+}
+
 func TestParseNilCoalescing(t *testing.T) {
 
 	t.Parallel()
@@ -5717,3 +6563,70 @@ func TestParseInvalidNegativeIntegerLiteralWithIncorrectPrefix(t *testing.T) {
 
 	require.Error(t, err)
 }
+
+func TestParseNestingDepth(t *testing.T) {
+
+	t.Parallel()
+
+	nested := func(depth int) string {
+		return strings.Repeat("(", depth) + "1" + strings.Repeat(")", depth)
+	}
+
+	t.Run("within limit", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseProgram(
+			fmt.Sprintf("let x = %s", nested(9)),
+			WithMaxNestingDepth(10),
+		)
+		require.NoError(t, err)
+	})
+
+	t.Run("exceeding limit", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := Parse(
+			nested(10),
+			func(p *parser) interface{} {
+				return parseExpression(p, lowestBindingPower)
+			},
+			WithMaxNestingDepth(10),
+		)
+
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&NestingDepthExceededError{
+					MaxDepth: 10,
+					Pos:      ast.Position{Offset: 10, Line: 1, Column: 10},
+				},
+			},
+			errs,
+		)
+	})
+
+	t.Run("unlimited", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseProgram(
+			fmt.Sprintf("let x = %s", nested(2*DefaultMaxNestingDepth)),
+			WithMaxNestingDepth(0),
+		)
+		require.NoError(t, err)
+	})
+
+	t.Run("default limit", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseProgram(fmt.Sprintf("let x = %s", nested(100_000)))
+		require.IsType(t, Error{}, err)
+
+		errs := err.(Error).Errors
+		require.Len(t, errs, 1)
+		require.IsType(t, &NestingDepthExceededError{}, errs[0])
+		assert.Equal(t, DefaultMaxNestingDepth, errs[0].(*NestingDepthExceededError).MaxDepth)
+	})
+}
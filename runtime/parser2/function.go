@@ -19,7 +19,7 @@
 package parser2
 
 import (
-	"fmt"
+	"strconv"
 
 	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/parser2/lexer"
@@ -31,7 +31,10 @@ func parseParameterList(p *parser) (parameterList *ast.ParameterList) {
 	p.skipSpaceAndComments(true)
 
 	if !p.current.Is(lexer.TokenParenOpen) {
-		panic(fmt.Errorf(
+		panic(p.expectedError(
+			SyntaxErrorCodeUnexpectedToken,
+			lexer.TokenParenOpen.String(),
+			p.current.Type.String(),
 			"expected %s as start of parameter list, got %s",
 			lexer.TokenParenOpen,
 			p.current.Type,
@@ -52,7 +55,12 @@ func parseParameterList(p *parser) (parameterList *ast.ParameterList) {
 		switch p.current.Type {
 		case lexer.TokenIdentifier:
 			if !expectParameter {
-				panic("expected comma, got start of parameter")
+				panic(p.expectedError(
+					SyntaxErrorCodeUnexpectedToken,
+					lexer.TokenComma.String(),
+					"start of parameter",
+					"expected comma, got start of parameter",
+				))
 			}
 			parameter := parseParameter(p)
 			parameters = append(parameters, parameter)
@@ -60,7 +68,10 @@ func parseParameterList(p *parser) (parameterList *ast.ParameterList) {
 
 		case lexer.TokenComma:
 			if expectParameter {
-				panic(fmt.Errorf(
+				panic(p.expectedError(
+					SyntaxErrorCodeUnexpectedToken,
+					"parameter or end of parameter list",
+					p.current.Type.String(),
 					"expected parameter or end of parameter list, got %s",
 					p.current.Type,
 				))
@@ -76,19 +87,28 @@ func parseParameterList(p *parser) (parameterList *ast.ParameterList) {
 			atEnd = true
 
 		case lexer.TokenEOF:
-			panic(fmt.Errorf(
+			panic(p.expectedError(
+				SyntaxErrorCodeUnclosedParen,
+				lexer.TokenParenClose.String(),
+				"",
 				"missing %s at end of parameter list",
 				lexer.TokenParenClose,
 			))
 
 		default:
 			if expectParameter {
-				panic(fmt.Errorf(
+				panic(p.expectedError(
+					SyntaxErrorCodeUnexpectedToken,
+					"parameter or end of parameter list",
+					p.current.Type.String(),
 					"expected parameter or end of parameter list, got %s",
 					p.current.Type,
 				))
 			} else {
-				panic(fmt.Errorf(
+				panic(p.expectedError(
+					SyntaxErrorCodeUnexpectedToken,
+					"comma or end of parameter list",
+					p.current.Type.String(),
 					"expected comma or end of parameter list, got %s",
 					p.current.Type,
 				))
@@ -112,7 +132,10 @@ func parseParameter(p *parser) *ast.Parameter {
 	parameterPos := startPos
 
 	if !p.current.Is(lexer.TokenIdentifier) {
-		panic(fmt.Errorf(
+		panic(p.expectedError(
+			SyntaxErrorCodeMissingIdentifier,
+			"argument label or parameter name",
+			p.current.Type.String(),
 			"expected argument label or parameter name, got %s",
 			p.current.Type,
 		))
@@ -120,7 +143,8 @@ func parseParameter(p *parser) *ast.Parameter {
 	argumentLabel := ""
 	parameterName, ok := p.current.Value.(string)
 	if !ok {
-		panic(fmt.Errorf(
+		panic(p.codedSyntaxError(
+			SyntaxErrorCodeUnexpectedToken,
 			"expected parameter %s to be a string",
 			p.current,
 		))
@@ -136,7 +160,8 @@ func parseParameter(p *parser) *ast.Parameter {
 		argumentLabel = parameterName
 		parameterName, ok = p.current.Value.(string)
 		if !ok {
-			panic(fmt.Errorf(
+			panic(p.codedSyntaxError(
+				SyntaxErrorCodeUnexpectedToken,
 				"expected parameter %s to be a string",
 				p.current,
 			))
@@ -148,7 +173,10 @@ func parseParameter(p *parser) *ast.Parameter {
 	}
 
 	if !p.current.Is(lexer.TokenColon) {
-		panic(fmt.Errorf(
+		panic(p.expectedError(
+			SyntaxErrorCodeUnexpectedToken,
+			lexer.TokenColon.String(),
+			p.current.Type.String(),
 			"expected %s after argument label/parameter name, got %s",
 			lexer.TokenColon,
 			p.current.Type,
@@ -195,7 +223,10 @@ func parseFunctionDeclaration(
 
 	p.skipSpaceAndComments(true)
 	if !p.current.Is(lexer.TokenIdentifier) {
-		panic(fmt.Errorf(
+		panic(p.expectedError(
+			SyntaxErrorCodeMissingIdentifier,
+			lexer.TokenIdentifier.String(),
+			p.current.Type.String(),
 			"expected identifier after start of function declaration, got %s",
 			p.current.Type,
 		))
@@ -220,6 +251,54 @@ func parseFunctionDeclaration(
 	}
 }
 
+// parseNativeFunctionDeclaration parses a native function declaration,
+// i.e. a function declaration without a function block,
+// which is implemented by the host environment.
+//
+//     nativeFunctionDeclaration :
+//         'native' 'fun' identifier parameterList ( ':' typeAnnotation )?
+//
+func parseNativeFunctionDeclaration(
+	p *parser,
+	access ast.Access,
+	accessPos *ast.Position,
+	docString string,
+) *ast.FunctionDeclaration {
+
+	startPos := p.current.StartPos
+	if accessPos != nil {
+		startPos = *accessPos
+	}
+
+	// Skip the `native` keyword
+	p.next()
+
+	p.skipSpaceAndComments(true)
+	if !p.current.IsString(lexer.TokenIdentifier, keywordFun) {
+		panic(p.expectedError(
+			SyntaxErrorCodeMissingKeyword,
+			strconv.Quote(keywordFun),
+			p.current.Type.String(),
+			"expected keyword %q, got %s",
+			keywordFun,
+			p.current.Type,
+		))
+	}
+
+	declaration := parseFunctionDeclaration(p, true, access, &startPos, docString)
+	declaration.IsNative = true
+
+	if declaration.FunctionBlock != nil {
+		p.report(NewCodedSyntaxError(
+			SyntaxErrorCodeUnexpectedFunctionBlock,
+			declaration.FunctionBlock.StartPosition(),
+			"native function declarations must not have a function block",
+		))
+	}
+
+	return declaration
+}
+
 func parseFunctionParameterListAndRest(
 	p *parser,
 	functionBlockIsOptional bool,
@@ -0,0 +1,210 @@
+//go:build go1.18
+// +build go1.18
+
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parser2
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/turbolent/prettier"
+
+	"github.com/onflow/cadence/runtime/ast"
+)
+
+// fuzzSeedProgramPatterns are the glob patterns of the programs in the repository
+// which are added to the seed corpus of FuzzParseProgram
+//
+var fuzzSeedProgramPatterns = []string{
+	"../examples/*.cdc",
+	"../examples/*/*.cdc",
+	"../stdlib/contracts/*.cdc",
+}
+
+var fuzzSeedPrograms = []string{
+	``,
+	`let x = 1`,
+	`pub fun test(a: Int, _ b: String): [Int?] { return [a, nil] }`,
+	`
+      pub resource R {
+          pub let x: Int
+
+          init(x: Int) {
+              self.x = x
+          }
+      }
+
+      pub fun test() {
+          let r <- create R(x: 1)
+          let ref = &r as &R
+          destroy r
+      }
+    `,
+	`
+      transaction {
+          prepare(signer: AuthAccount) {}
+          execute {
+              let s = "\(1 + 2) is three"
+          }
+      }
+    `,
+}
+
+// FuzzParseProgram is only built by Go 1.18 and newer toolchains (see the build constraint above),
+// as the module still supports Go 1.16, which has no native fuzzing (testing.F).
+// Older toolchains skip this file, and the rest of the package is tested as usual.
+//
+// Run it with e.g. `go test -run=^$ -fuzz=FuzzParseProgram ./runtime/parser2`
+//
+func FuzzParseProgram(f *testing.F) {
+
+	for _, pattern := range fuzzSeedProgramPatterns {
+		paths, err := filepath.Glob(pattern)
+		require.NoError(f, err)
+
+		for _, path := range paths {
+			code, err := os.ReadFile(path)
+			require.NoError(f, err)
+
+			f.Add(string(code))
+		}
+	}
+
+	for _, code := range fuzzSeedPrograms {
+		f.Add(code)
+	}
+
+	f.Fuzz(func(t *testing.T, code string) {
+
+		if !utf8.ValidString(code) {
+			return
+		}
+
+		program := parseProgramForFuzzing(t, code)
+		if program == nil {
+			return
+		}
+
+		// A successfully parsed program must re-parse to the same AST,
+		// when it is rendered back to source code
+
+		var builder strings.Builder
+		prettier.Prettier(&builder, program.Doc(), 80, "    ")
+		rendered := builder.String()
+
+		reparsedProgram := parseProgramForFuzzing(t, rendered)
+		require.NotNil(t, reparsedProgram, rendered)
+
+		assert.Equal(t,
+			programWithoutPositions(t, program),
+			programWithoutPositions(t, reparsedProgram),
+			rendered,
+		)
+	})
+}
+
+// parseProgramForFuzzing parses the given code,
+// and fails if parsing panics, or reports errors for recovered unexpected panics.
+// It returns nil if the code is not a valid program.
+//
+func parseProgramForFuzzing(t *testing.T, code string) *ast.Program {
+
+	defer func() {
+		if r := recover(); r != nil {
+			t.Fatalf("unexpected panic: %v", r)
+		}
+	}()
+
+	program, err := ParseProgram(code)
+	if err == nil {
+		return program
+	}
+
+	parserError, ok := err.(Error)
+	require.True(t, ok, "unexpected error: %#+v", err)
+
+	// The parser reports panics that are not parse errors,
+	// i.e. which indicate a bug in the parser or lexer, as internal syntax errors
+
+	for _, childError := range parserError.Errors {
+		require.Implements(t, (*ParseError)(nil), childError)
+
+		syntaxError, ok := childError.(*SyntaxError)
+		if ok && syntaxError.Code == SyntaxErrorCodeInternal {
+			t.Fatalf("unexpected panic: %s", syntaxError.Message)
+		}
+	}
+
+	return nil
+}
+
+// programWithoutPositions returns the JSON representation of the given program,
+// with all positions removed
+//
+func programWithoutPositions(t *testing.T, program *ast.Program) interface{} {
+	encoded, err := json.Marshal(program)
+	require.NoError(t, err)
+
+	var decoded interface{}
+	err = json.Unmarshal(encoded, &decoded)
+	require.NoError(t, err)
+
+	return positionsRemoved(decoded)
+}
+
+// positionsRemoved returns the given JSON value,
+// with all positions (objects with offset, line, and column) removed
+//
+func positionsRemoved(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		if len(value) == 3 {
+			_, hasOffset := value["Offset"]
+			_, hasLine := value["Line"]
+			_, hasColumn := value["Column"]
+			if hasOffset && hasLine && hasColumn {
+				return nil
+			}
+		}
+
+		result := make(map[string]interface{}, len(value))
+		for key, element := range value {
+			result[key] = positionsRemoved(element)
+		}
+		return result
+
+	case []interface{}:
+		result := make([]interface{}, len(value))
+		for i, element := range value {
+			result[i] = positionsRemoved(element)
+		}
+		return result
+
+	default:
+		return value
+	}
+}
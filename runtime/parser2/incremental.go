@@ -0,0 +1,398 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parser2
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/parser2/lexer"
+)
+
+// IncrementalParser parses a program and caches the result,
+// so that after an edit of the source code, e.g. in an editor,
+// only the top-level declarations affected by the edit are re-parsed.
+//
+type IncrementalParser struct {
+	options []Option
+	source  string
+	program *ast.Program
+	// hasErrors is true if the cached program was parsed with errors.
+	// The cached program might then not reflect all of the source code,
+	// so it cannot be re-parsed incrementally
+	hasErrors bool
+}
+
+// DeclarationChanges are the top-level declarations changed by a re-parse.
+// Declarations which are not affected by an edit are re-used as-is,
+// i.e. they are neither removed nor added, but their positions are updated.
+//
+type DeclarationChanges struct {
+	// Removed are the declarations of the previous program
+	// which are not part of the new program anymore
+	Removed []ast.Declaration
+	// Added are the declarations of the new program
+	// which were not part of the previous program
+	Added []ast.Declaration
+}
+
+// NewIncrementalParser parses the given source code into a program,
+// which can be re-parsed incrementally after edits using Reparse.
+//
+func NewIncrementalParser(source string, options ...Option) (*IncrementalParser, error) {
+	parser := &IncrementalParser{
+		options: options,
+	}
+	_, err := parser.parseAll(source)
+	return parser, err
+}
+
+// Program returns the program for the current source code.
+// The program might be partial or nil if parsing failed.
+//
+func (p *IncrementalParser) Program() *ast.Program {
+	return p.program
+}
+
+// Source returns the current source code.
+//
+func (p *IncrementalParser) Source() string {
+	return p.source
+}
+
+// Reparse parses the new source code, which is the current source code
+// with the given range replaced.
+//
+// Unlike most ranges, the end position of the changed range is exclusive,
+// so an insertion is an empty range. Only the offsets of the range are used.
+//
+// Only the top-level declarations which overlap the changed range,
+// and their direct neighbours, which might be extended by the edit, are re-parsed.
+// If the re-parsed declarations are malformed, or the changed range does not match the new source code,
+// the whole source code is parsed instead.
+//
+// Warnings are only reported for the re-parsed declarations.
+//
+func (p *IncrementalParser) Reparse(newSource string, changedRange ast.Range) (DeclarationChanges, error) {
+
+	oldSource := p.source
+	start := changedRange.StartPos.Offset
+	oldEnd := changedRange.EndPos.Offset
+	newEnd := oldEnd + len(newSource) - len(oldSource)
+
+	if p.program == nil ||
+		p.hasErrors ||
+		!isEdit(oldSource, newSource, start, oldEnd, newEnd) {
+
+		return p.parseAll(newSource)
+	}
+
+	declarations := p.program.Declarations()
+	count := len(declarations)
+	if count == 0 {
+		return p.parseAll(newSource)
+	}
+
+	// The declarations are ordered by position,
+	// so find the affected declarations using binary search.
+
+	// The first declaration which ends at or after the start of the change,
+	// and the last declaration which starts at or before the end of the change,
+	// might be affected directly.
+	//
+	// Also re-parse the declarations before and after them,
+	// as the edit might e.g. extend the expression at the end of the declaration before,
+	// or add an access modifier to the declaration after.
+
+	firstIndex := sort.Search(count, func(i int) bool {
+		return declarations[i].EndPosition().Offset >= start
+	})
+	lowIndex := firstIndex - 1
+	if lowIndex < 0 {
+		lowIndex = 0
+	}
+
+	// highIndex is the index of the first declaration starting after the end of the change.
+	// It is count if there is no such declaration
+
+	highIndex := sort.Search(count, func(i int) bool {
+		return declarations[i].StartPosition().Offset > oldEnd
+	})
+
+	// Re-parse the source code from the end of the declaration before the affected ones,
+	// so leading doc strings are included, up to the end of the affected declarations
+
+	regionStart := 0
+	if lowIndex > 0 {
+		regionStart = declarations[lowIndex-1].EndPosition().Offset + 1
+	}
+
+	oldRegionEnd := len(oldSource)
+	removedEnd := count
+	if highIndex < count {
+		oldRegionEnd = declarations[highIndex].EndPosition().Offset + 1
+		removedEnd = highIndex + 1
+	}
+	newRegionEnd := oldRegionEnd + newEnd - oldEnd
+
+	tokens := lexer.LexFrom(
+		newSource[:newRegionEnd],
+		sourcePosition(newSource, regionStart),
+	)
+	region, err := ParseProgramFromTokenStream(tokens, p.options...)
+	if err != nil {
+		return p.parseAll(newSource)
+	}
+
+	removed := declarations[lowIndex:removedEnd]
+	added := region.Declarations()
+
+	// Re-use the removed declarations which are outside of the changed range,
+	// if they were re-parsed unchanged
+
+	shift := newPositionShifter(oldSource, newSource, oldEnd, newEnd)
+
+	unchanged := map[int]ast.Declaration{}
+	for _, declaration := range removed {
+		startOffset := declaration.StartPosition().Offset
+		switch {
+		case declaration.EndPosition().Offset < start:
+			unchanged[startOffset] = declaration
+		case startOffset >= oldEnd:
+			unchanged[shift.shiftPosition(declaration.StartPosition()).Offset] = declaration
+		}
+	}
+
+	for i, declaration := range added {
+		existing, ok := unchanged[declaration.StartPosition().Offset]
+		if !ok {
+			continue
+		}
+
+		isAfter := existing.StartPosition().Offset >= oldEnd
+
+		existingEnd := existing.EndPosition()
+		if isAfter {
+			existingEnd = shift.shiftPosition(existingEnd)
+		}
+
+		if existingEnd.Offset != declaration.EndPosition().Offset ||
+			existing.DeclarationKind() != declaration.DeclarationKind() ||
+			existing.DeclarationDocString() != declaration.DeclarationDocString() {
+
+			continue
+		}
+
+		if isAfter {
+			shift.shiftDeclaration(existing)
+		}
+		added[i] = existing
+	}
+
+	// Shift the positions of all declarations after the change
+
+	for _, declaration := range declarations[removedEnd:] {
+		shift.shiftDeclaration(declaration)
+	}
+
+	newDeclarations := make([]ast.Declaration, 0, lowIndex+len(added)+count-removedEnd)
+	newDeclarations = append(newDeclarations, declarations[:lowIndex]...)
+	newDeclarations = append(newDeclarations, added...)
+	newDeclarations = append(newDeclarations, declarations[removedEnd:]...)
+
+	p.source = newSource
+	p.program = ast.NewProgram(newDeclarations)
+
+	return declarationChanges(removed, added), nil
+}
+
+func (p *IncrementalParser) parseAll(source string) (DeclarationChanges, error) {
+	var removed []ast.Declaration
+	if p.program != nil {
+		removed = p.program.Declarations()
+	}
+
+	program, err := ParseProgram(source, p.options...)
+
+	p.source = source
+	p.program = program
+	p.hasErrors = err != nil
+
+	var added []ast.Declaration
+	if program != nil {
+		added = program.Declarations()
+	}
+
+	return declarationChanges(removed, added), err
+}
+
+// declarationChanges returns the changes for the given removed and added declarations,
+// ignoring declarations which are both removed and added, i.e. which were re-used
+//
+func declarationChanges(removed, added []ast.Declaration) (changes DeclarationChanges) {
+	removedSet := make(map[ast.Declaration]struct{}, len(removed))
+	for _, declaration := range removed {
+		removedSet[declaration] = struct{}{}
+	}
+
+	addedSet := make(map[ast.Declaration]struct{}, len(added))
+	for _, declaration := range added {
+		addedSet[declaration] = struct{}{}
+	}
+
+	for _, declaration := range removed {
+		if _, ok := addedSet[declaration]; !ok {
+			changes.Removed = append(changes.Removed, declaration)
+		}
+	}
+
+	for _, declaration := range added {
+		if _, ok := removedSet[declaration]; !ok {
+			changes.Added = append(changes.Added, declaration)
+		}
+	}
+
+	return
+}
+
+// isEdit returns true if the new source is the old source
+// with the range from start to the old end replaced by the range from start to the new end
+//
+func isEdit(oldSource, newSource string, start, oldEnd, newEnd int) bool {
+	return start >= 0 &&
+		start <= oldEnd &&
+		oldEnd <= len(oldSource) &&
+		start <= newEnd &&
+		newEnd <= len(newSource) &&
+		oldSource[:start] == newSource[:start] &&
+		oldSource[oldEnd:] == newSource[newEnd:]
+}
+
+// sourcePosition returns the position of the given offset in the source code
+//
+func sourcePosition(source string, offset int) ast.Position {
+	preceding := source[:offset]
+	lineStart := strings.LastIndexByte(preceding, '\n') + 1
+	return ast.Position{
+		Offset: offset,
+		Line:   strings.Count(preceding, "\n") + 1,
+		Column: utf8.RuneCountInString(preceding[lineStart:]),
+	}
+}
+
+// positionShifter updates the positions in declarations after an edit.
+//
+// Declarations are traversed using reflection, instead of e.g. using ast.Walk,
+// as positions are stored in all kinds of nodes, including types, parameters, and identifiers,
+// and new kinds of nodes should not require updating the shifter.
+//
+type positionShifter struct {
+	oldEnd  ast.Position
+	newEnd  ast.Position
+	visited map[interface{}]struct{}
+}
+
+func newPositionShifter(oldSource, newSource string, oldEnd, newEnd int) *positionShifter {
+	return &positionShifter{
+		oldEnd:  sourcePosition(oldSource, oldEnd),
+		newEnd:  sourcePosition(newSource, newEnd),
+		visited: map[interface{}]struct{}{},
+	}
+}
+
+// shiftPosition returns the new position for the given position after the edit
+//
+func (s *positionShifter) shiftPosition(position ast.Position) ast.Position {
+	if position.Line == s.oldEnd.Line {
+		position.Column += s.newEnd.Column - s.oldEnd.Column
+	}
+	position.Line += s.newEnd.Line - s.oldEnd.Line
+	position.Offset += s.newEnd.Offset - s.oldEnd.Offset
+	return position
+}
+
+func (s *positionShifter) shiftDeclaration(declaration ast.Declaration) {
+	s.shiftValue(reflect.ValueOf(declaration))
+}
+
+var positionType = reflect.TypeOf(ast.Position{})
+var membersType = reflect.TypeOf(&ast.Members{})
+
+func (s *positionShifter) shiftValue(value reflect.Value) {
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() {
+			return
+		}
+
+		// Nodes might be referenced multiple times,
+		// e.g. through back-pointers to parent nodes,
+		// so only shift each node once
+
+		key := value.Interface()
+		if _, ok := s.visited[key]; ok {
+			return
+		}
+		s.visited[key] = struct{}{}
+
+		// The declarations of members are unexported
+
+		if value.Type() == membersType {
+			members := key.(*ast.Members)
+			for _, declaration := range members.Declarations() {
+				s.shiftDeclaration(declaration)
+			}
+			return
+		}
+
+		s.shiftValue(value.Elem())
+
+	case reflect.Interface:
+		if value.IsNil() {
+			return
+		}
+		s.shiftValue(value.Elem())
+
+	case reflect.Struct:
+		if value.Type() == positionType {
+			if value.CanSet() {
+				position := value.Interface().(ast.Position)
+				value.Set(reflect.ValueOf(s.shiftPosition(position)))
+			}
+			return
+		}
+
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Field(i)
+			// Skip unexported fields, e.g. caches
+			if !field.CanSet() {
+				continue
+			}
+			s.shiftValue(field)
+		}
+
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			s.shiftValue(value.Index(i))
+		}
+	}
+}
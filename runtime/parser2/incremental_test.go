@@ -0,0 +1,256 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parser2
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func TestIncrementalParser(t *testing.T) {
+
+	t.Parallel()
+
+	// edit replaces the only occurrence of old in the source with new,
+	// and returns the new source and the replaced range
+	edit := func(t *testing.T, source, old, new string) (string, ast.Range) {
+		require.Equal(t, 1, strings.Count(source, old))
+		start := strings.Index(source, old)
+		return source[:start] + new + source[start+len(old):],
+			ast.Range{
+				StartPos: ast.Position{Offset: start},
+				EndPos:   ast.Position{Offset: start + len(old)},
+			}
+	}
+
+	// reparse applies the edit and asserts that
+	// the incrementally parsed program is equal to the program parsed from scratch
+	reparse := func(t *testing.T, parser *IncrementalParser, old, new string) DeclarationChanges {
+		newSource, changedRange := edit(t, parser.Source(), old, new)
+
+		changes, err := parser.Reparse(newSource, changedRange)
+		require.NoError(t, err)
+
+		expected, err := ParseProgram(newSource)
+		require.NoError(t, err)
+
+		utils.AssertEqualWithDiff(t, expected, parser.Program())
+
+		return changes
+	}
+
+	const code = `
+      fun a() {
+          let x = 1
+      }
+
+      /// b
+      pub fun b(): Int {
+          return 2
+      }
+
+      pub contract C {
+          pub fun c() {}
+      }
+    `
+
+	newParser := func(t *testing.T) (*IncrementalParser, []ast.Declaration) {
+		parser, err := NewIncrementalParser(code)
+		require.NoError(t, err)
+		return parser, parser.Program().Declarations()
+	}
+
+	t.Run("change in declaration", func(t *testing.T) {
+
+		t.Parallel()
+
+		parser, declarations := newParser(t)
+
+		changes := reparse(t, parser, "return 2", "let y = 3\n          return y")
+
+		newDeclarations := parser.Program().Declarations()
+		require.Len(t, newDeclarations, 3)
+
+		assert.Equal(t,
+			DeclarationChanges{
+				Removed: []ast.Declaration{declarations[1]},
+				Added:   []ast.Declaration{newDeclarations[1]},
+			},
+			changes,
+		)
+
+		// The declarations before and after the change are re-used
+
+		assert.Same(t, declarations[0], newDeclarations[0])
+		assert.Same(t, declarations[2], newDeclarations[2])
+	})
+
+	t.Run("insert declaration", func(t *testing.T) {
+
+		t.Parallel()
+
+		parser, declarations := newParser(t)
+
+		changes := reparse(t, parser, "\n\n      /// b", "\n\n      fun z() {}\n\n      /// b")
+
+		newDeclarations := parser.Program().Declarations()
+		require.Len(t, newDeclarations, 4)
+
+		assert.Equal(t,
+			DeclarationChanges{
+				Added: []ast.Declaration{newDeclarations[1]},
+			},
+			changes,
+		)
+
+		assert.Same(t, declarations[0], newDeclarations[0])
+		assert.Same(t, declarations[1], newDeclarations[2])
+		assert.Same(t, declarations[2], newDeclarations[3])
+	})
+
+	t.Run("remove declaration", func(t *testing.T) {
+
+		t.Parallel()
+
+		parser, declarations := newParser(t)
+
+		changes := reparse(t, parser, "/// b\n      pub fun b(): Int {\n          return 2\n      }", "")
+
+		newDeclarations := parser.Program().Declarations()
+		require.Len(t, newDeclarations, 2)
+
+		assert.Equal(t,
+			DeclarationChanges{
+				Removed: []ast.Declaration{declarations[1]},
+			},
+			changes,
+		)
+
+		assert.Same(t, declarations[0], newDeclarations[0])
+		assert.Same(t, declarations[2], newDeclarations[1])
+	})
+
+	t.Run("change doc string", func(t *testing.T) {
+
+		t.Parallel()
+
+		parser, declarations := newParser(t)
+
+		changes := reparse(t, parser, "/// b", "/// the function b")
+
+		newDeclarations := parser.Program().Declarations()
+		require.Len(t, newDeclarations, 3)
+
+		assert.Equal(t,
+			DeclarationChanges{
+				Removed: []ast.Declaration{declarations[1]},
+				Added:   []ast.Declaration{newDeclarations[1]},
+			},
+			changes,
+		)
+	})
+
+	t.Run("extend previous declaration", func(t *testing.T) {
+
+		t.Parallel()
+
+		parser, err := NewIncrementalParser("let x = 1\nlet y = 2")
+		require.NoError(t, err)
+
+		declarations := parser.Program().Declarations()
+
+		changes := reparse(t, parser, "1\n", "1 + 3\n")
+
+		newDeclarations := parser.Program().Declarations()
+		require.Len(t, newDeclarations, 2)
+
+		assert.Equal(t,
+			DeclarationChanges{
+				Removed: []ast.Declaration{declarations[0]},
+				Added:   []ast.Declaration{newDeclarations[0]},
+			},
+			changes,
+		)
+
+		assert.Same(t, declarations[1], newDeclarations[1])
+	})
+
+	t.Run("same line", func(t *testing.T) {
+
+		t.Parallel()
+
+		parser, err := NewIncrementalParser(`let x = "😀"; let y = 2; let z = 3`)
+		require.NoError(t, err)
+
+		declarations := parser.Program().Declarations()
+
+		reparse(t, parser, `"😀"`, `"😀😀"`)
+
+		newDeclarations := parser.Program().Declarations()
+		require.Len(t, newDeclarations, 3)
+
+		assert.Same(t, declarations[2], newDeclarations[2])
+	})
+
+	t.Run("syntax error", func(t *testing.T) {
+
+		t.Parallel()
+
+		parser, _ := newParser(t)
+
+		newSource, changedRange := edit(t, parser.Source(), "return 2", "return (")
+		_, err := parser.Reparse(newSource, changedRange)
+		require.Error(t, err)
+
+		// The source code is parsed from scratch after errors
+
+		changes := reparse(t, parser, "return (", "return 2")
+
+		assert.Len(t, changes.Added, 3)
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+
+		t.Parallel()
+
+		parser, declarations := newParser(t)
+
+		newSource, _ := edit(t, parser.Source(), "return 2", "return 3")
+
+		changes, err := parser.Reparse(
+			newSource,
+			ast.Range{
+				StartPos: ast.Position{Offset: 0},
+				EndPos:   ast.Position{Offset: 1},
+			},
+		)
+		require.NoError(t, err)
+
+		// The source code is parsed from scratch
+
+		assert.Equal(t, declarations, changes.Removed)
+		assert.Equal(t, parser.Program().Declarations(), changes.Added)
+	})
+}
@@ -62,4 +62,10 @@ const (
 	keywordSwitch      = "switch"
 	keywordDefault     = "default"
 	keywordEnum        = "enum"
+	keywordAttachment  = "attachment"
+	keywordAttach      = "attach"
+	keywordTo          = "to"
+	keywordRemove      = "remove"
+	keywordNative      = "native"
+	keywordTypeAlias   = "typealias"
 )
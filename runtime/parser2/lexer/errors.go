@@ -0,0 +1,45 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lexer
+
+import (
+	"github.com/onflow/cadence/runtime/ast"
+)
+
+// LexError is an error which occurred while lexing the input,
+// e.g. an unrecognized character.
+//
+// The position is the position of the offending character.
+//
+type LexError struct {
+	Message  string
+	StartPos ast.Position
+}
+
+func (e *LexError) Error() string {
+	return e.Message
+}
+
+func (e *LexError) StartPosition() ast.Position {
+	return e.StartPos
+}
+
+func (e *LexError) EndPosition() ast.Position {
+	return e.StartPos
+}
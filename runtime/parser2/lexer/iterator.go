@@ -0,0 +1,68 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lexer
+
+// TokenIterator iterates over the tokens of a source string,
+// for use by external tools, such as syntax highlighters or linters.
+//
+// All tokens are produced, including spaces and comments.
+// The last token is always an EOF token.
+// If the source is malformed, error tokens are produced.
+//
+type TokenIterator struct {
+	tokens TokenStream
+	done   bool
+}
+
+// Tokenize returns an iterator over the tokens of the given source.
+//
+func Tokenize(source string) *TokenIterator {
+	return &TokenIterator{
+		tokens: Lex(source),
+	}
+}
+
+// Next returns the next token and true,
+// or false if all tokens, including the EOF token, were returned.
+//
+func (i *TokenIterator) Next() (Token, bool) {
+	if i.done {
+		return Token{}, false
+	}
+
+	token := i.tokens.Next()
+	if token.Is(TokenEOF) {
+		i.done = true
+	}
+
+	return token, true
+}
+
+// All returns all remaining tokens, including the EOF token.
+//
+func (i *TokenIterator) All() []Token {
+	var tokens []Token
+	for {
+		token, ok := i.Next()
+		if !ok {
+			return tokens
+		}
+		tokens = append(tokens, token)
+	}
+}
@@ -20,11 +20,14 @@ package lexer
 
 import (
 	"fmt"
+	"strings"
 	"unicode/utf8"
 
 	"github.com/onflow/cadence/runtime/ast"
 )
 
+const multilineStringQuotes = `"""`
+
 type position struct {
 	line   int
 	column int
@@ -52,6 +55,9 @@ type lexer struct {
 	// the tokens of the stream
 	tokens     []Token
 	tokenCount int
+	// the number of unclosed parentheses in each string interpolation being lexed,
+	// innermost last
+	interpolationParenDepths []int
 }
 
 var _ TokenStream = &lexer{}
@@ -96,11 +102,20 @@ func (l *lexer) Revert(cursor int) {
 }
 
 func Lex(input string) TokenStream {
+	return LexFrom(input, ast.Position{Line: 1})
+}
+
+// LexFrom is like Lex, but starts scanning the input at the given position,
+// instead of at the beginning of the input.
+// The position must be the position of its offset in the input.
+//
+func LexFrom(input string, start ast.Position) TokenStream {
 	l := &lexer{
 		input:         input,
-		startPos:      position{line: 1},
-		endOffset:     0,
-		prevEndOffset: 0,
+		startOffset:   start.Offset,
+		startPos:      position{line: start.Line, column: start.Column},
+		endOffset:     start.Offset,
+		prevEndOffset: start.Offset,
 		current:       EOF,
 		prev:          EOF,
 	}
@@ -123,15 +138,15 @@ func (l *lexer) run(state stateFn) {
 	// closing it
 	defer func() {
 		if r := recover(); r != nil {
-			var err error
+			var message string
 			switch r := r.(type) {
 			case error:
-				err = r
+				message = r.Error()
 			default:
-				err = fmt.Errorf("lexer: %v", r)
+				message = fmt.Sprintf("lexer: %v", r)
 			}
 
-			l.emitError(err)
+			l.emitError("%s", message)
 		}
 	}()
 
@@ -268,13 +283,20 @@ func (l *lexer) emitValue(ty TokenType) {
 	l.emit(ty, l.word(), l.startPosition(), true)
 }
 
-func (l *lexer) emitError(err error) {
+// emitError emits an error token with a LexError
+// at the position of the current character
+//
+func (l *lexer) emitError(message string, params ...interface{}) {
 	endPos := l.endPos()
 	rangeStart := ast.Position{
 		Line:   endPos.line,
 		Column: endPos.column,
 		Offset: l.endOffset - 1,
 	}
+	err := &LexError{
+		Message:  fmt.Sprintf(message, params...),
+		StartPos: rangeStart,
+	}
 	l.emit(TokenError, err, rangeStart, false)
 }
 
@@ -327,25 +349,82 @@ func (l *lexer) acceptWhile(f func(rune) bool) {
 	}
 }
 
-func (l *lexer) scanString(quote rune) {
+// scanString reads the runes of a string literal up to and including the end quote.
+//
+// If the string literal contains an interpolation (`\(`),
+// scanning stops before it, and true is returned.
+//
+func (l *lexer) scanString(quote rune) (interpolation bool) {
 	r := l.next()
 	for r != quote {
 		switch r {
 		case '\n', EOF:
 			// NOTE: invalid end of string handled by parser
 			l.backupOne()
-			return
+			return false
 		case '\\':
+			if strings.HasPrefix(l.input[l.endOffset:], "(") {
+				l.backupOne()
+				return true
+			}
 			r = l.next()
 			switch r {
 			case '\n', EOF:
 				// NOTE: invalid end of string handled by parser
 				l.backupOne()
-				return
+				return false
 			}
 		}
 		r = l.next()
 	}
+	return false
+}
+
+// inInterpolation returns true if the lexer is inside of a string interpolation
+//
+func (l *lexer) inInterpolation() bool {
+	return len(l.interpolationParenDepths) > 0
+}
+
+// acceptMultilineStringStart reads the remaining two quotes
+// of the start of a multi-line string literal (`"""`), if any.
+// The first quote is already lexed.
+//
+func (l *lexer) acceptMultilineStringStart() bool {
+	if !strings.HasPrefix(l.input[l.endOffset:], multilineStringQuotes[1:]) {
+		return false
+	}
+
+	l.next()
+	l.next()
+	return true
+}
+
+func (l *lexer) scanMultilineString() {
+	for {
+		r := l.next()
+		switch r {
+		case EOF:
+			// NOTE: invalid end of string handled by parser
+			l.backupOne()
+			return
+
+		case '\\':
+			r = l.next()
+			if r == EOF {
+				// NOTE: invalid end of string handled by parser
+				l.backupOne()
+				return
+			}
+
+		case '"':
+			if strings.HasPrefix(l.input[l.endOffset:], multilineStringQuotes[1:]) {
+				l.next()
+				l.next()
+				return
+			}
+		}
+	}
 }
 
 func (l *lexer) scanBinaryRemainder() {
@@ -385,7 +464,7 @@ func (l *lexer) scanFixedPointRemainder() {
 	r := l.next()
 	if !isDecimalDigitOrUnderscore(r) {
 		l.backupOne()
-		l.emitError(fmt.Errorf("missing fractional digits"))
+		l.emitError("missing fractional digits")
 		return
 	}
 	l.acceptWhile(isDecimalDigitOrUnderscore)
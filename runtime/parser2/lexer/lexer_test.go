@@ -19,7 +19,6 @@
 package lexer
 
 import (
-	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -902,6 +901,386 @@ func TestLexString(t *testing.T) {
 	})
 }
 
+func TestLexStringInterpolation(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("single interpolation", func(t *testing.T) {
+		testLex(t,
+			`"a \(x) b"`,
+			[]Token{
+				{
+					Type:  TokenStringPart,
+					Value: `"a `,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+						EndPos:   ast.Position{Line: 1, Column: 2, Offset: 2},
+					},
+				},
+				{
+					Type: TokenInterpolationStart,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 3, Offset: 3},
+						EndPos:   ast.Position{Line: 1, Column: 4, Offset: 4},
+					},
+				},
+				{
+					Type:  TokenIdentifier,
+					Value: "x",
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 5, Offset: 5},
+						EndPos:   ast.Position{Line: 1, Column: 5, Offset: 5},
+					},
+				},
+				{
+					Type: TokenInterpolationEnd,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 6, Offset: 6},
+						EndPos:   ast.Position{Line: 1, Column: 6, Offset: 6},
+					},
+				},
+				{
+					Type:  TokenStringPart,
+					Value: ` b"`,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 7, Offset: 7},
+						EndPos:   ast.Position{Line: 1, Column: 9, Offset: 9},
+					},
+				},
+				{
+					Type: TokenEOF,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 10, Offset: 10},
+						EndPos:   ast.Position{Line: 1, Column: 10, Offset: 10},
+					},
+				},
+			},
+		)
+	})
+
+	t.Run("adjacent interpolations", func(t *testing.T) {
+		testLex(t,
+			`"\(a)\(b)"`,
+			[]Token{
+				{
+					Type:  TokenStringPart,
+					Value: `"`,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+						EndPos:   ast.Position{Line: 1, Column: 0, Offset: 0},
+					},
+				},
+				{
+					Type: TokenInterpolationStart,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 1, Offset: 1},
+						EndPos:   ast.Position{Line: 1, Column: 2, Offset: 2},
+					},
+				},
+				{
+					Type:  TokenIdentifier,
+					Value: "a",
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 3, Offset: 3},
+						EndPos:   ast.Position{Line: 1, Column: 3, Offset: 3},
+					},
+				},
+				{
+					Type: TokenInterpolationEnd,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 4, Offset: 4},
+						EndPos:   ast.Position{Line: 1, Column: 4, Offset: 4},
+					},
+				},
+				{
+					Type: TokenInterpolationStart,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 5, Offset: 5},
+						EndPos:   ast.Position{Line: 1, Column: 6, Offset: 6},
+					},
+				},
+				{
+					Type:  TokenIdentifier,
+					Value: "b",
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 7, Offset: 7},
+						EndPos:   ast.Position{Line: 1, Column: 7, Offset: 7},
+					},
+				},
+				{
+					Type: TokenInterpolationEnd,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 8, Offset: 8},
+						EndPos:   ast.Position{Line: 1, Column: 8, Offset: 8},
+					},
+				},
+				{
+					Type:  TokenStringPart,
+					Value: `"`,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 9, Offset: 9},
+						EndPos:   ast.Position{Line: 1, Column: 9, Offset: 9},
+					},
+				},
+				{
+					Type: TokenEOF,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 10, Offset: 10},
+						EndPos:   ast.Position{Line: 1, Column: 10, Offset: 10},
+					},
+				},
+			},
+		)
+	})
+
+	t.Run("parentheses in interpolation", func(t *testing.T) {
+		testLex(t,
+			`"\((a))"`,
+			[]Token{
+				{
+					Type:  TokenStringPart,
+					Value: `"`,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+						EndPos:   ast.Position{Line: 1, Column: 0, Offset: 0},
+					},
+				},
+				{
+					Type: TokenInterpolationStart,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 1, Offset: 1},
+						EndPos:   ast.Position{Line: 1, Column: 2, Offset: 2},
+					},
+				},
+				{
+					Type: TokenParenOpen,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 3, Offset: 3},
+						EndPos:   ast.Position{Line: 1, Column: 3, Offset: 3},
+					},
+				},
+				{
+					Type:  TokenIdentifier,
+					Value: "a",
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 4, Offset: 4},
+						EndPos:   ast.Position{Line: 1, Column: 4, Offset: 4},
+					},
+				},
+				{
+					Type: TokenParenClose,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 5, Offset: 5},
+						EndPos:   ast.Position{Line: 1, Column: 5, Offset: 5},
+					},
+				},
+				{
+					Type: TokenInterpolationEnd,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 6, Offset: 6},
+						EndPos:   ast.Position{Line: 1, Column: 6, Offset: 6},
+					},
+				},
+				{
+					Type:  TokenStringPart,
+					Value: `"`,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 7, Offset: 7},
+						EndPos:   ast.Position{Line: 1, Column: 7, Offset: 7},
+					},
+				},
+				{
+					Type: TokenEOF,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 8, Offset: 8},
+						EndPos:   ast.Position{Line: 1, Column: 8, Offset: 8},
+					},
+				},
+			},
+		)
+	})
+
+	t.Run("nested interpolation", func(t *testing.T) {
+		testLex(t,
+			`"\("\(a)")"`,
+			[]Token{
+				{
+					Type:  TokenStringPart,
+					Value: `"`,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+						EndPos:   ast.Position{Line: 1, Column: 0, Offset: 0},
+					},
+				},
+				{
+					Type: TokenInterpolationStart,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 1, Offset: 1},
+						EndPos:   ast.Position{Line: 1, Column: 2, Offset: 2},
+					},
+				},
+				{
+					Type:  TokenStringPart,
+					Value: `"`,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 3, Offset: 3},
+						EndPos:   ast.Position{Line: 1, Column: 3, Offset: 3},
+					},
+				},
+				{
+					Type: TokenInterpolationStart,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 4, Offset: 4},
+						EndPos:   ast.Position{Line: 1, Column: 5, Offset: 5},
+					},
+				},
+				{
+					Type:  TokenIdentifier,
+					Value: "a",
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 6, Offset: 6},
+						EndPos:   ast.Position{Line: 1, Column: 6, Offset: 6},
+					},
+				},
+				{
+					Type: TokenInterpolationEnd,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 7, Offset: 7},
+						EndPos:   ast.Position{Line: 1, Column: 7, Offset: 7},
+					},
+				},
+				{
+					Type:  TokenStringPart,
+					Value: `"`,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 8, Offset: 8},
+						EndPos:   ast.Position{Line: 1, Column: 8, Offset: 8},
+					},
+				},
+				{
+					Type: TokenInterpolationEnd,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 9, Offset: 9},
+						EndPos:   ast.Position{Line: 1, Column: 9, Offset: 9},
+					},
+				},
+				{
+					Type:  TokenStringPart,
+					Value: `"`,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 10, Offset: 10},
+						EndPos:   ast.Position{Line: 1, Column: 10, Offset: 10},
+					},
+				},
+				{
+					Type: TokenEOF,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 11, Offset: 11},
+						EndPos:   ast.Position{Line: 1, Column: 11, Offset: 11},
+					},
+				},
+			},
+		)
+	})
+
+	t.Run("escaped backslash", func(t *testing.T) {
+		testLex(t,
+			`"a\\(b)"`,
+			[]Token{
+				{
+					Type:  TokenString,
+					Value: `"a\\(b)"`,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+						EndPos:   ast.Position{Line: 1, Column: 7, Offset: 7},
+					},
+				},
+				{
+					Type: TokenEOF,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 8, Offset: 8},
+						EndPos:   ast.Position{Line: 1, Column: 8, Offset: 8},
+					},
+				},
+			},
+		)
+	})
+}
+
+func TestLexMultilineString(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+		testLex(t,
+			"\"\"\"\n  te\"st\n  \"\"\"",
+			[]Token{
+				{
+					Type:  TokenMultilineString,
+					Value: "\"\"\"\n  te\"st\n  \"\"\"",
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+						EndPos:   ast.Position{Line: 3, Column: 4, Offset: 16},
+					},
+				},
+				{
+					Type: TokenEOF,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 3, Column: 5, Offset: 17},
+						EndPos:   ast.Position{Line: 3, Column: 5, Offset: 17},
+					},
+				},
+			},
+		)
+	})
+
+	t.Run("valid, with escaped quotes", func(t *testing.T) {
+		testLex(t,
+			"\"\"\"\n\\\"\"\"\n\"\"\"",
+			[]Token{
+				{
+					Type:  TokenMultilineString,
+					Value: "\"\"\"\n\\\"\"\"\n\"\"\"",
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+						EndPos:   ast.Position{Line: 3, Column: 2, Offset: 11},
+					},
+				},
+				{
+					Type: TokenEOF,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 3, Column: 3, Offset: 12},
+						EndPos:   ast.Position{Line: 3, Column: 3, Offset: 12},
+					},
+				},
+			},
+		)
+	})
+
+	t.Run("invalid, not terminated at end of file", func(t *testing.T) {
+		testLex(t,
+			"\"\"\"\ntest",
+			[]Token{
+				{
+					Type:  TokenMultilineString,
+					Value: "\"\"\"\ntest",
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+						EndPos:   ast.Position{Line: 2, Column: 3, Offset: 7},
+					},
+				},
+				{
+					Type: TokenEOF,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 2, Column: 4, Offset: 8},
+						EndPos:   ast.Position{Line: 2, Column: 4, Offset: 8},
+					},
+				},
+			},
+		)
+	})
+}
+
 func TestLexBlockComment(t *testing.T) {
 
 	t.Parallel()
@@ -1043,8 +1422,11 @@ func TestLexIntegerLiterals(t *testing.T) {
 			`0b`,
 			[]Token{
 				{
-					Type:  TokenError,
-					Value: errors.New("missing digits"),
+					Type: TokenError,
+					Value: &LexError{
+						Message:  "missing digits",
+						StartPos: ast.Position{Line: 1, Column: 1, Offset: 1},
+					},
 					Range: ast.Range{
 						StartPos: ast.Position{Line: 1, Column: 1, Offset: 1},
 						EndPos:   ast.Position{Line: 1, Column: 1, Offset: 1},
@@ -1189,8 +1571,11 @@ func TestLexIntegerLiterals(t *testing.T) {
 			`0o`,
 			[]Token{
 				{
-					Type:  TokenError,
-					Value: errors.New("missing digits"),
+					Type: TokenError,
+					Value: &LexError{
+						Message:  "missing digits",
+						StartPos: ast.Position{Line: 1, Column: 1, Offset: 1},
+					},
 					Range: ast.Range{
 						StartPos: ast.Position{Line: 1, Column: 1, Offset: 1},
 						EndPos:   ast.Position{Line: 1, Column: 1, Offset: 1},
@@ -1381,8 +1766,11 @@ func TestLexIntegerLiterals(t *testing.T) {
 			`0x`,
 			[]Token{
 				{
-					Type:  TokenError,
-					Value: errors.New("missing digits"),
+					Type: TokenError,
+					Value: &LexError{
+						Message:  "missing digits",
+						StartPos: ast.Position{Line: 1, Column: 1, Offset: 1},
+					},
 					Range: ast.Range{
 						StartPos: ast.Position{Line: 1, Column: 1, Offset: 1},
 						EndPos:   ast.Position{Line: 1, Column: 1, Offset: 1},
@@ -1604,8 +1992,11 @@ func TestLexIntegerLiterals(t *testing.T) {
 			"0z123",
 			[]Token{
 				{
-					Type:  TokenError,
-					Value: errors.New("invalid number literal prefix: 'z'"),
+					Type: TokenError,
+					Value: &LexError{
+						Message:  "invalid number literal prefix: 'z'",
+						StartPos: ast.Position{Line: 1, Column: 1, Offset: 1},
+					},
 					Range: ast.Range{
 						StartPos: ast.Position{Line: 1, Column: 1, Offset: 1},
 						EndPos:   ast.Position{Line: 1, Column: 1, Offset: 1},
@@ -1630,6 +2021,41 @@ func TestLexIntegerLiterals(t *testing.T) {
 		)
 	})
 
+	t.Run("invalid prefix at end of input", func(t *testing.T) {
+
+		testLex(t,
+			"0z",
+			[]Token{
+				{
+					Type: TokenError,
+					Value: &LexError{
+						Message:  "invalid number literal prefix: 'z'",
+						StartPos: ast.Position{Line: 1, Column: 1, Offset: 1},
+					},
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 1, Offset: 1},
+						EndPos:   ast.Position{Line: 1, Column: 1, Offset: 1},
+					},
+				},
+				{
+					Type:  TokenUnknownBaseIntegerLiteral,
+					Value: "0z",
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+						EndPos:   ast.Position{Line: 1, Column: 1, Offset: 1},
+					},
+				},
+				{
+					Type: TokenEOF,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 2, Offset: 2},
+						EndPos:   ast.Position{Line: 1, Column: 2, Offset: 2},
+					},
+				},
+			},
+		)
+	})
+
 	t.Run("leading zero and underscore", func(t *testing.T) {
 
 		testLex(t,
@@ -1734,8 +2160,11 @@ func TestLexFixedPoint(t *testing.T) {
 			"0.",
 			[]Token{
 				{
-					Type:  TokenError,
-					Value: errors.New("missing fractional digits"),
+					Type: TokenError,
+					Value: &LexError{
+						Message:  "missing fractional digits",
+						StartPos: ast.Position{Line: 1, Column: 1, Offset: 1},
+					},
 					Range: ast.Range{
 						StartPos: ast.Position{Line: 1, Column: 1, Offset: 1},
 						EndPos:   ast.Position{Line: 1, Column: 1, Offset: 1},
@@ -2092,8 +2521,11 @@ func TestEOFsAfterError(t *testing.T) {
 
 	assert.Equal(t,
 		Token{
-			Type:  TokenError,
-			Value: errors.New(`unrecognized character: U+0027 '''`),
+			Type: TokenError,
+			Value: &LexError{
+				Message:  `unrecognized character: U+0027 '''`,
+				StartPos: ast.Position{Line: 1, Column: 2, Offset: 2},
+			},
 			Range: ast.Range{
 				StartPos: ast.Position{Line: 1, Column: 2, Offset: 2},
 				EndPos:   ast.Position{Line: 1, Column: 2, Offset: 2},
@@ -2143,3 +2575,121 @@ func TestEOFsAfterEmptyInput(t *testing.T) {
 		)
 	}
 }
+
+func TestLexFrom(t *testing.T) {
+
+	t.Parallel()
+
+	withTokens(
+		LexFrom("a\nb c", ast.Position{Offset: 4, Line: 2, Column: 2}),
+		func(tokens []Token) {
+			utils.AssertEqualWithDiff(t,
+				[]Token{
+					{
+						Type:  TokenIdentifier,
+						Value: "c",
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 2, Column: 2, Offset: 4},
+							EndPos:   ast.Position{Line: 2, Column: 2, Offset: 4},
+						},
+					},
+					{
+						Type: TokenEOF,
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 2, Column: 3, Offset: 5},
+							EndPos:   ast.Position{Line: 2, Column: 3, Offset: 5},
+						},
+					},
+				},
+				tokens,
+			)
+		},
+	)
+}
+
+func TestTokenize(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("next", func(t *testing.T) {
+
+		t.Parallel()
+
+		iterator := Tokenize("x")
+
+		token, ok := iterator.Next()
+		require.True(t, ok)
+		assert.Equal(t,
+			Token{
+				Type:  TokenIdentifier,
+				Value: "x",
+				Range: ast.Range{
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+					EndPos:   ast.Position{Line: 1, Column: 0, Offset: 0},
+				},
+			},
+			token,
+		)
+
+		token, ok = iterator.Next()
+		require.True(t, ok)
+		assert.Equal(t, TokenEOF, token.Type)
+
+		_, ok = iterator.Next()
+		require.False(t, ok)
+
+		assert.Empty(t, iterator.All())
+	})
+
+	t.Run("all", func(t *testing.T) {
+
+		t.Parallel()
+
+		tokens := Tokenize("let x = 0x1 // one").All()
+
+		types := make([]TokenType, len(tokens))
+		for i, token := range tokens {
+			types[i] = token.Type
+		}
+
+		assert.Equal(t,
+			[]TokenType{
+				TokenIdentifier,
+				TokenSpace,
+				TokenIdentifier,
+				TokenSpace,
+				TokenEqual,
+				TokenSpace,
+				TokenHexadecimalIntegerLiteral,
+				TokenSpace,
+				TokenLineComment,
+				TokenEOF,
+			},
+			types,
+		)
+
+		assert.Equal(t, "0x1", tokens[6].Value)
+		assert.Equal(t, "// one", tokens[8].Value)
+		assert.Nil(t, tokens[4].Value)
+		assert.Nil(t, tokens[9].Value)
+	})
+
+	t.Run("error", func(t *testing.T) {
+
+		t.Parallel()
+
+		tokens := Tokenize("1.").All()
+		require.Len(t, tokens, 3)
+
+		assert.Equal(t, TokenError, tokens[0].Type)
+		assert.Equal(t,
+			&LexError{
+				Message:  "missing fractional digits",
+				StartPos: ast.Position{Line: 1, Column: 1, Offset: 1},
+			},
+			tokens[0].Value,
+		)
+		assert.Equal(t, TokenFixedPointNumberLiteral, tokens[1].Type)
+		assert.Equal(t, TokenEOF, tokens[2].Type)
+	})
+}
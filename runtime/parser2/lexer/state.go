@@ -18,10 +18,6 @@
 
 package lexer
 
-import (
-	"fmt"
-)
-
 const keywordAs = "as"
 
 // stateFn uses the input lexer to read runes and emit tokens.
@@ -47,8 +43,20 @@ func rootState(l *lexer) stateFn {
 		case '%':
 			l.emitType(TokenPercent)
 		case '(':
+			if l.inInterpolation() {
+				l.interpolationParenDepths[len(l.interpolationParenDepths)-1]++
+			}
 			l.emitType(TokenParenOpen)
 		case ')':
+			if l.inInterpolation() {
+				lastIndex := len(l.interpolationParenDepths) - 1
+				if l.interpolationParenDepths[lastIndex] == 0 {
+					l.interpolationParenDepths = l.interpolationParenDepths[:lastIndex]
+					l.emitType(TokenInterpolationEnd)
+					return stringPartState
+				}
+				l.interpolationParenDepths[lastIndex]--
+			}
 			l.emitType(TokenParenClose)
 		case '{':
 			l.emitType(TokenBraceOpen)
@@ -168,14 +176,14 @@ func rootState(l *lexer) stateFn {
 				return identifierState
 
 			default:
-				return l.error(fmt.Errorf("unrecognized character: %#U", r))
+				return l.error("unrecognized character: %#U", r)
 			}
 		}
 	}
 }
 
-func (l *lexer) error(err error) stateFn {
-	l.emitError(err)
+func (l *lexer) error(message string, params ...interface{}) stateFn {
+	l.emitError(message, params...)
 	return nil
 }
 
@@ -191,21 +199,21 @@ func numberState(l *lexer) stateFn {
 		case 'b':
 			l.scanBinaryRemainder()
 			if l.endOffset-l.startOffset <= 2 {
-				l.emitError(fmt.Errorf("missing digits"))
+				l.emitError("missing digits")
 			}
 			l.emitValue(TokenBinaryIntegerLiteral)
 
 		case 'o':
 			l.scanOctalRemainder()
 			if l.endOffset-l.startOffset <= 2 {
-				l.emitError(fmt.Errorf("missing digits"))
+				l.emitError("missing digits")
 			}
 			l.emitValue(TokenOctalIntegerLiteral)
 
 		case 'x':
 			l.scanHexadecimalRemainder()
 			if l.endOffset-l.startOffset <= 2 {
-				l.emitError(fmt.Errorf("missing digits"))
+				l.emitError("missing digits")
 			}
 			l.emitValue(TokenHexadecimalIntegerLiteral)
 
@@ -225,8 +233,10 @@ func numberState(l *lexer) stateFn {
 			prefixChar := r
 
 			if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
-				l.emitError(fmt.Errorf("invalid number literal prefix: %q", prefixChar))
-				l.next()
+				l.emitError("invalid number literal prefix: %q", prefixChar)
+				if l.next() == EOF {
+					l.backupOne()
+				}
 
 				tokenType := l.scanDecimalOrFixedPointRemainder()
 				if tokenType == TokenDecimalIntegerLiteral {
@@ -289,11 +299,49 @@ func identifierState(l *lexer) stateFn {
 }
 
 func stringState(l *lexer) stateFn {
-	l.scanString('"')
+	if l.acceptMultilineStringStart() {
+		l.scanMultilineString()
+		l.emitValue(TokenMultilineString)
+		return rootState
+	}
+
+	if l.scanString('"') {
+		l.emitValue(TokenStringPart)
+		return interpolationState
+	}
+
 	l.emitValue(TokenString)
 	return rootState
 }
 
+// stringPartState returns a stateFn that scans the remainder of a string literal
+// after a string interpolation, up to the end quote or the next interpolation.
+// An empty part, e.g. between two interpolations, is not emitted.
+//
+func stringPartState(l *lexer) stateFn {
+	interpolation := l.scanString('"')
+	if l.endOffset > l.startOffset {
+		l.emitValue(TokenStringPart)
+	}
+
+	if interpolation {
+		return interpolationState
+	}
+	return rootState
+}
+
+// interpolationState returns a stateFn that scans the start of a string interpolation (`\(`).
+// The tokens of the interpolated expression are scanned by the root state,
+// until the parenthesis which ends the interpolation.
+//
+func interpolationState(l *lexer) stateFn {
+	l.next()
+	l.next()
+	l.emitType(TokenInterpolationStart)
+	l.interpolationParenDepths = append(l.interpolationParenDepths, 0)
+	return rootState
+}
+
 func lineCommentState(l *lexer) stateFn {
 	l.scanLineComment()
 	l.emitValue(TokenLineComment)
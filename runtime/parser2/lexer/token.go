@@ -22,6 +22,17 @@ import (
 	"github.com/onflow/cadence/runtime/ast"
 )
 
+// Token is a token of the source code.
+//
+// The value of the token depends on its type:
+//
+// - Integer and fixed-point literals, identifiers, strings, multi-line strings,
+//   line comments, and block comment contents carry their source text as a string.
+//   Numeric literals are not converted, e.g. the value of `0x1_0` is "0x1_0"
+// - Spaces carry a Space
+// - Errors carry an error
+// - All other tokens, e.g. operators, punctuation, and EOF, have a nil value
+//
 type Token struct {
 	Type  TokenType
 	Value interface{}
@@ -81,6 +81,10 @@ const (
 	TokenAsExclamationMark
 	TokenAsQuestionMark
 	TokenPragma
+	TokenMultilineString
+	TokenStringPart
+	TokenInterpolationStart
+	TokenInterpolationEnd
 	// NOTE: not an actual token, must be last item
 	TokenMax
 )
@@ -202,6 +206,14 @@ func (t TokenType) String() string {
 		return `'as?'`
 	case TokenPragma:
 		return `'#'`
+	case TokenMultilineString:
+		return "multi-line string"
+	case TokenStringPart:
+		return "string part"
+	case TokenInterpolationStart:
+		return "start of string interpolation"
+	case TokenInterpolationEnd:
+		return "end of string interpolation"
 	default:
 		panic(errors.NewUnreachableError())
 	}
@@ -24,6 +24,7 @@ import (
 	"strings"
 
 	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/errors"
 	"github.com/onflow/cadence/runtime/parser2/lexer"
 )
@@ -45,6 +46,71 @@ type parser struct {
 	backtrackingCursorStack []int
 	// bufferedErrorsStack is the stack of parsing errors encountered during buffering
 	bufferedErrorsStack [][]error
+	// errorRecovery is true if parsing should continue after a malformed declaration
+	errorRecovery bool
+	// strictAccess is true if the `pub` access modifier should be reported
+	strictAccess bool
+	// warningHandler is called for each warning encountered during parsing
+	warningHandler func(ParseWarning)
+	// maxNestingDepth is the maximum nesting depth of expressions, or zero if unlimited
+	maxNestingDepth int
+	// expressionDepth is the nesting depth of the expression currently being parsed
+	expressionDepth int
+}
+
+// DefaultMaxNestingDepth is the default maximum nesting depth of expressions.
+// See WithMaxNestingDepth.
+//
+const DefaultMaxNestingDepth = 1024
+
+// Option is a function that configures the parser.
+type Option func(*parser)
+
+// WithErrorRecovery returns a parser option which enables or disables error recovery.
+//
+// When error recovery is enabled, a malformed declaration does not abort parsing:
+// The error is reported, tokens are skipped until the next recovery point,
+// and parsing continues with the next declaration.
+// The result is a partial program, containing all well-formed declarations.
+//
+func WithErrorRecovery(enabled bool) Option {
+	return func(p *parser) {
+		p.errorRecovery = enabled
+	}
+}
+
+// WithStrictAccess returns a parser option which enables or disables strict access mode.
+//
+// In strict access mode, a PubAccessWarning is reported for each use of the `pub` access modifier,
+// suggesting the equivalent `access(all)` instead.
+// Warnings do not cause parsing to fail, they are passed to the warning handler, if any.
+// See WithWarningHandler.
+//
+func WithStrictAccess(enabled bool) Option {
+	return func(p *parser) {
+		p.strictAccess = enabled
+	}
+}
+
+// WithWarningHandler returns a parser option which sets the function
+// that is called for each warning encountered during parsing.
+//
+func WithWarningHandler(handler func(ParseWarning)) Option {
+	return func(p *parser) {
+		p.warningHandler = handler
+	}
+}
+
+// WithMaxNestingDepth returns a parser option which sets the maximum nesting depth of expressions.
+//
+// Parsing an expression which is nested deeper fails with a NestingDepthExceededError,
+// instead of exhausting the stack. A depth of zero disables the limit.
+// The default is DefaultMaxNestingDepth.
+//
+func WithMaxNestingDepth(depth int) Option {
+	return func(p *parser) {
+		p.maxNestingDepth = depth
+	}
 }
 
 // Parse creates a lexer to scan the given input string,
@@ -53,14 +119,35 @@ type parser struct {
 // It can be composed with different parse functions to parse the input string into different results.
 // See "ParseExpression", "ParseStatements" as examples.
 //
-func Parse(input string, parse func(*parser) interface{}) (result interface{}, errors []error) {
+func Parse(
+	input string,
+	parse func(*parser) interface{},
+	options ...Option,
+) (
+	result interface{},
+	errors []error,
+) {
 	// create a lexer, which turns the input string into tokens
 	tokens := lexer.Lex(input)
-	return ParseTokenStream(tokens, parse)
+	return ParseTokenStream(tokens, parse, options...)
 }
 
-func ParseTokenStream(tokens lexer.TokenStream, parse func(*parser) interface{}) (result interface{}, errors []error) {
-	p := &parser{tokens: tokens}
+func ParseTokenStream(
+	tokens lexer.TokenStream,
+	parse func(*parser) interface{},
+	options ...Option,
+) (
+	result interface{},
+	errors []error,
+) {
+	p := &parser{
+		tokens:          tokens,
+		maxNestingDepth: DefaultMaxNestingDepth,
+	}
+
+	for _, option := range options {
+		option(p)
+	}
 
 	defer func() {
 		if r := recover(); r != nil {
@@ -94,7 +181,13 @@ func ParseTokenStream(tokens lexer.TokenStream, parse func(*parser) interface{})
 	result = parse(p)
 
 	if !p.current.Is(lexer.TokenEOF) {
-		p.report(fmt.Errorf("unexpected token: %s", p.current.Type))
+		p.report(p.expectedError(
+			SyntaxErrorCodeUnexpectedToken,
+			"",
+			p.current.Type.String(),
+			"unexpected token: %s",
+			p.current.Type,
+		))
 	}
 
 	return result, p.errors
@@ -104,13 +197,27 @@ func (p *parser) report(errs ...error) {
 	for _, err := range errs {
 
 		// If the reported error is not yet a parse error,
-		// create a `SyntaxError` at the current position
+		// create a `SyntaxError` at the position of the lexer error,
+		// or at the current position
 
 		var parseError ParseError
-		var ok bool
-		parseError, ok = err.(ParseError)
-		if !ok {
+
+		switch err := err.(type) {
+		case ParseError:
+			parseError = err
+
+		case *lexer.LexError:
+			parseError = &SyntaxError{
+				Code:    SyntaxErrorCodeInvalidToken,
+				Pos:     err.StartPos,
+				Message: err.Message,
+			}
+
+		default:
+			// Any other error is not caused by the input,
+			// e.g. an error recovered from a panic
 			parseError = &SyntaxError{
+				Code:    SyntaxErrorCodeInternal,
 				Pos:     p.current.StartPos,
 				Message: err.Error(),
 			}
@@ -132,6 +239,39 @@ func (p *parser) report(errs ...error) {
 	}
 }
 
+// codedSyntaxError returns a syntax error with the given code at the start of the current token.
+//
+// The position is determined when the error is created, not when it is reported,
+// so the error refers to the token which caused it,
+// even if the parser moves on before reporting, e.g. when recovering.
+//
+func (p *parser) codedSyntaxError(code SyntaxErrorCode, message string, params ...interface{}) *SyntaxError {
+	return NewCodedSyntaxError(code, p.current.StartPos, message, params...)
+}
+
+// expectedError returns a syntax error with the given code at the current position,
+// which states what was expected, and what was found instead, if anything
+//
+func (p *parser) expectedError(
+	code SyntaxErrorCode,
+	expected string,
+	got string,
+	message string,
+	params ...interface{},
+) *SyntaxError {
+	err := p.codedSyntaxError(code, message, params...)
+	err.Expected = expected
+	err.Got = got
+	return err
+}
+
+func (p *parser) warn(warning ParseWarning) {
+	if p.warningHandler == nil {
+		return
+	}
+	p.warningHandler(warning)
+}
+
 // next reads the next token and marks it as the "current" token.
 // The next token could either be read from the lexer or from
 // the buffer.
@@ -148,14 +288,7 @@ func (p *parser) next() {
 			if !ok {
 				panic(errors.NewUnreachableError())
 			}
-			parseError, ok := err.(ParseError)
-			if !ok {
-				parseError = &SyntaxError{
-					Pos:     token.StartPos,
-					Message: err.Error(),
-				}
-			}
-			p.report(parseError)
+			p.report(err)
 			continue
 		}
 
@@ -168,7 +301,13 @@ func (p *parser) next() {
 func (p *parser) mustOne(tokenType lexer.TokenType) lexer.Token {
 	t := p.current
 	if !t.Is(tokenType) {
-		panic(fmt.Errorf("expected token %s", tokenType))
+		panic(p.expectedError(
+			SyntaxErrorCodeUnexpectedToken,
+			tokenType.String(),
+			"",
+			"expected token %s",
+			tokenType,
+		))
 	}
 	p.next()
 	return t
@@ -177,7 +316,14 @@ func (p *parser) mustOne(tokenType lexer.TokenType) lexer.Token {
 func (p *parser) mustOneString(tokenType lexer.TokenType, string string) lexer.Token {
 	t := p.current
 	if !t.IsString(tokenType, string) {
-		panic(fmt.Errorf("expected token %s with string value %s", tokenType, string))
+		panic(p.expectedError(
+			SyntaxErrorCodeUnexpectedToken,
+			string,
+			"",
+			"expected token %s with string value %s",
+			tokenType,
+			string,
+		))
 	}
 	p.next()
 	return t
@@ -247,6 +393,15 @@ func (p *parser) replayBuffered() {
 	p.bufferedErrorsStack = p.bufferedErrorsStack[:lastIndex]
 }
 
+// abandonBuffering stops all buffering which was started
+// after the buffering stack had the given depth.
+// The lexer is not reverted, and the buffered errors are kept.
+func (p *parser) abandonBuffering(depth int) {
+	for len(p.backtrackingCursorStack) > depth {
+		p.acceptBuffered()
+	}
+}
+
 type triviaOptions struct {
 	skipNewlines    bool
 	parseDocStrings bool
@@ -294,7 +449,12 @@ func (p *parser) parseTrivia(options triviaOptions) (containsNewline bool, docSt
 			if options.parseDocStrings {
 				inLineDocString = false
 				docStringBuilder.Reset()
-				if strings.HasPrefix(comment, "/**") {
+				// Comments like `/**/` are not documentation comments,
+				// and unterminated comments have no suffix
+				if strings.HasPrefix(comment, "/**") &&
+					strings.HasSuffix(comment, "*/") &&
+					len(comment) >= 5 {
+
 					// Strip prefix and suffix (`*/`)
 					docStringBuilder.WriteString(comment[3 : len(comment)-2])
 				}
@@ -343,6 +503,10 @@ func tokenToIdentifier(identifier lexer.Token) ast.Identifier {
 	}
 }
 
+// ParseExpression parses the given input as a single expression.
+// Leading and trailing whitespace and comments are allowed,
+// but any other tokens after the expression are reported as errors.
+//
 func ParseExpression(input string) (expression ast.Expression, errs []error) {
 	var res interface{}
 	res, errs = Parse(input, func(p *parser) interface{} {
@@ -376,10 +540,17 @@ func ParseStatements(input string) (statements []ast.Statement, errs []error) {
 	return
 }
 
+// ParseType parses the given input as a single type,
+// e.g. a type in an ABI or a configuration file.
+// Leading and trailing whitespace and comments are allowed,
+// but any other tokens after the type are reported as errors.
+//
 func ParseType(input string) (ty ast.Type, errs []error) {
 	var res interface{}
 	res, errs = Parse(input, func(p *parser) interface{} {
-		return parseType(p, lowestBindingPower)
+		ty := parseType(p, lowestBindingPower)
+		p.skipSpaceAndComments(true)
+		return ty
 	})
 	if res == nil {
 		ty = nil
@@ -393,11 +564,40 @@ func ParseType(input string) (ty ast.Type, errs []error) {
 	return
 }
 
-func ParseDeclarations(input string) (declarations []ast.Declaration, errs []error) {
+// ParseTypeAnnotation parses the given input as a single type annotation,
+// i.e. a type which is optionally prefixed with the resource symbol `@`.
+// Leading and trailing whitespace and comments are allowed,
+// but any other tokens after the type annotation are reported as errors.
+//
+func ParseTypeAnnotation(input string) (typeAnnotation *ast.TypeAnnotation, errs []error) {
 	var res interface{}
 	res, errs = Parse(input, func(p *parser) interface{} {
-		return parseDeclarations(p, lexer.TokenEOF)
+		p.skipSpaceAndComments(true)
+		typeAnnotation := parseTypeAnnotation(p)
+		p.skipSpaceAndComments(true)
+		return typeAnnotation
 	})
+	if res == nil {
+		typeAnnotation = nil
+		return
+	}
+
+	typeAnnotation, ok := res.(*ast.TypeAnnotation)
+	if !ok {
+		panic(errors.NewUnreachableError())
+	}
+	return
+}
+
+func ParseDeclarations(input string, options ...Option) (declarations []ast.Declaration, errs []error) {
+	var res interface{}
+	res, errs = Parse(
+		input,
+		func(p *parser) interface{} {
+			return parseDeclarations(p, lexer.TokenEOF)
+		},
+		options...,
+	)
 	if res == nil {
 		declarations = nil
 		return
@@ -431,16 +631,27 @@ func ParseArgumentList(input string) (arguments ast.Arguments, errs []error) {
 	return
 }
 
-func ParseProgram(input string) (program *ast.Program, err error) {
-	return ParseProgramFromTokenStream(lexer.Lex(input))
+// ParseProgram parses the given input string into a program.
+//
+// Parsing never panics on malformed input: If the input contains syntax errors,
+// the returned error is an Error, and all of its child errors are ParseErrors,
+// which provide the position of the problem in the input.
+// The returned program might be nil or incomplete in that case.
+//
+func ParseProgram(input string, options ...Option) (program *ast.Program, err error) {
+	return ParseProgramFromTokenStream(lexer.Lex(input), options...)
 }
 
-func ParseProgramFromTokenStream(input lexer.TokenStream) (program *ast.Program, err error) {
+func ParseProgramFromTokenStream(input lexer.TokenStream, options ...Option) (program *ast.Program, err error) {
 	var res interface{}
 	var errs []error
-	res, errs = ParseTokenStream(input, func(p *parser) interface{} {
-		return parseDeclarations(p, lexer.TokenEOF)
-	})
+	res, errs = ParseTokenStream(
+		input,
+		func(p *parser) interface{} {
+			return parseDeclarations(p, lexer.TokenEOF)
+		},
+		options...,
+	)
 	if len(errs) > 0 {
 		err = Error{
 			Code:   input.Input(),
@@ -462,6 +673,19 @@ func ParseProgramFromTokenStream(input lexer.TokenStream) (program *ast.Program,
 	return
 }
 
+// ParseSourceFile parses the code of the given source file into a program.
+//
+// It behaves like ParseProgram, but syntax errors refer to the URI of the source file.
+//
+func ParseSourceFile(file common.SourceFile, options ...Option) (program *ast.Program, err error) {
+	program, err = ParseProgram(string(file.Code), options...)
+	if parseErr, ok := err.(Error); ok {
+		parseErr.URI = file.URI
+		err = parseErr
+	}
+	return
+}
+
 func ParseProgramFromFile(filename string) (program *ast.Program, code string, err error) {
 	var data []byte
 	data, err = ioutil.ReadFile(filename)
@@ -471,7 +695,12 @@ func ParseProgramFromFile(filename string) (program *ast.Program, code string, e
 
 	code = string(data)
 
-	program, err = ParseProgram(code)
+	program, err = ParseSourceFile(
+		common.SourceFile{
+			URI:  filename,
+			Code: data,
+		},
+	)
 	if err != nil {
 		return nil, code, err
 	}
@@ -28,6 +28,7 @@ import (
 	"go.uber.org/goleak"
 
 	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/parser2/lexer"
 	"github.com/onflow/cadence/runtime/tests/utils"
 )
@@ -61,6 +62,38 @@ func TestParseInvalid(t *testing.T) {
 	}
 }
 
+func TestParseProgramErrors(t *testing.T) {
+
+	t.Parallel()
+
+	for _, code := range []string{
+		"X",
+		"let x = ",
+		"fun f(",
+		"struct S { let x: }",
+		"import 0x1 from",
+		`let s = "`,
+		"let x = 0b",
+		"pub(set",
+	} {
+		t.Run(code, func(t *testing.T) {
+
+			_, err := ParseProgram(code)
+			require.Error(t, err)
+
+			require.IsType(t, Error{}, err)
+			parserError := err.(Error)
+
+			assert.Equal(t, code, parserError.Code)
+			require.NotEmpty(t, parserError.Errors)
+
+			for _, childError := range parserError.Errors {
+				require.Implements(t, (*ParseError)(nil), childError)
+			}
+		})
+	}
+}
+
 func TestParseBuffering(t *testing.T) {
 
 	t.Parallel()
@@ -115,8 +148,10 @@ func TestParseBuffering(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
-					Message: "expected token identifier with string value c",
-					Pos:     ast.Position{Offset: 4, Line: 1, Column: 4},
+					Code:     SyntaxErrorCodeUnexpectedToken,
+					Message:  "expected token identifier with string value c",
+					Pos:      ast.Position{Offset: 4, Line: 1, Column: 4},
+					Expected: "c",
 				},
 			},
 			errs,
@@ -239,8 +274,10 @@ func TestParseBuffering(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
-					Message: "expected token identifier with string value d",
-					Pos:     ast.Position{Offset: 6, Line: 1, Column: 6},
+					Code:     SyntaxErrorCodeUnexpectedToken,
+					Message:  "expected token identifier with string value d",
+					Pos:      ast.Position{Offset: 6, Line: 1, Column: 6},
+					Expected: "d",
 				},
 			},
 			errs,
@@ -266,8 +303,10 @@ func TestParseBuffering(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
-					Message: "expected token identifier",
-					Pos:     ast.Position{Offset: 420, Line: 10, Column: 20},
+					Code:     SyntaxErrorCodeUnexpectedToken,
+					Message:  "expected token identifier",
+					Pos:      ast.Position{Offset: 420, Line: 10, Column: 20},
+					Expected: "identifier",
 				},
 			},
 			err.(Error).Errors,
@@ -290,8 +329,10 @@ func TestParseBuffering(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
-					Message: "expected token '/'",
-					Pos:     ast.Position{Offset: 181, Line: 5, Column: 34},
+					Code:     SyntaxErrorCodeUnexpectedToken,
+					Message:  "expected token '/'",
+					Pos:      ast.Position{Offset: 181, Line: 5, Column: 34},
+					Expected: "'/'",
 				},
 			},
 			err.(Error).Errors,
@@ -445,8 +486,10 @@ func TestParseArgumentList(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
-					Message: "expected token '('",
-					Pos:     ast.Position{Offset: 0, Line: 1, Column: 0},
+					Code:     SyntaxErrorCodeUnexpectedToken,
+					Message:  "expected token '('",
+					Pos:      ast.Position{Offset: 0, Line: 1, Column: 0},
+					Expected: "'('",
 				},
 			},
 			errs,
@@ -561,12 +604,15 @@ func TestParseBufferedErrors(t *testing.T) {
 	utils.AssertEqualWithDiff(t,
 		[]error{
 			&SyntaxError{
+				Code:    SyntaxErrorCodeMissingType,
 				Message: "missing type annotation after comma",
 				Pos:     ast.Position{Offset: 4, Line: 1, Column: 4},
 			},
 			&SyntaxError{
-				Message: "missing ')' at end of invocation argument list",
-				Pos:     ast.Position{Offset: 6, Line: 1, Column: 6},
+				Code:     SyntaxErrorCodeUnclosedParen,
+				Message:  "missing ')' at end of invocation argument list",
+				Pos:      ast.Position{Offset: 6, Line: 1, Column: 6},
+				Expected: "')'",
 			},
 		},
 		errs,
@@ -581,3 +627,127 @@ func TestParseInvalidSingleQuoteImport(t *testing.T) {
 
 	require.EqualError(t, err, "Parsing failed:\nerror: unrecognized character: U+0027 '''\n --> :1:7\n  |\n1 | import 'X'\n  |        ^\n\nerror: unexpected end in import declaration: expected string, address, or identifier\n --> :1:7\n  |\n1 | import 'X'\n  |        ^\n")
 }
+
+func TestParseSourceFile(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+
+		t.Parallel()
+
+		program, err := ParseSourceFile(
+			common.SourceFile{
+				URI:  "test.cdc",
+				Code: []byte(`let x = 1`),
+			},
+		)
+		require.NoError(t, err)
+		require.Len(t, program.Declarations(), 1)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseSourceFile(
+			common.SourceFile{
+				URI:  "test.cdc",
+				Code: []byte(`let x =`),
+			},
+		)
+
+		var parseErr Error
+		require.ErrorAs(t, err, &parseErr)
+		assert.Equal(t, "test.cdc", parseErr.URI)
+
+		require.EqualError(t, err, "Parsing failed:\nerror: expected expression\n --> test.cdc:1:7\n  |\n1 | let x =\n  |        ^\n")
+	})
+}
+
+func TestParseLexerErrorPosition(t *testing.T) {
+
+	t.Parallel()
+
+	_, errs := ParseExpression("1 +\n  2 ~ 3")
+
+	require.NotEmpty(t, errs)
+
+	utils.AssertEqualWithDiff(t,
+		&SyntaxError{
+			Code:    SyntaxErrorCodeInvalidToken,
+			Message: "unrecognized character: U+007E '~'",
+			Pos:     ast.Position{Offset: 8, Line: 2, Column: 4},
+		},
+		errs[0],
+	)
+}
+
+func TestParseInternalError(t *testing.T) {
+
+	t.Parallel()
+
+	_, errs := Parse("x", func(p *parser) interface{} {
+		panic(fmt.Errorf("failure"))
+	})
+
+	utils.AssertEqualWithDiff(t,
+		[]error{
+			&SyntaxError{
+				Code:    SyntaxErrorCodeInternal,
+				Message: "failure",
+				Pos:     ast.Position{Offset: 0, Line: 1, Column: 0},
+			},
+		},
+		errs,
+	)
+}
+
+func TestParseProgramDeclarationsOrder(t *testing.T) {
+
+	t.Parallel()
+
+	program, err := ParseProgram(`
+      import A from 0x1
+
+      pub struct S {}
+
+      pub fun f() {}
+
+      import B from 0x2
+
+      pub event E()
+
+      pub fun g() {}
+
+      pub struct T {}
+    `)
+	require.NoError(t, err)
+
+	declarations := program.Declarations()
+
+	var kinds []common.DeclarationKind
+	for i, declaration := range declarations {
+		kinds = append(kinds, declaration.DeclarationKind())
+
+		if i > 0 {
+			assert.Less(t,
+				declarations[i-1].StartPosition().Offset,
+				declaration.StartPosition().Offset,
+			)
+		}
+	}
+
+	assert.Equal(t,
+		[]common.DeclarationKind{
+			common.DeclarationKindImport,
+			common.DeclarationKindStructure,
+			common.DeclarationKindFunction,
+			common.DeclarationKindImport,
+			common.DeclarationKindEvent,
+			common.DeclarationKindFunction,
+			common.DeclarationKindStructure,
+		},
+		kinds,
+	)
+}
@@ -20,6 +20,7 @@ package parser2
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/errors"
@@ -58,10 +59,11 @@ func parseStatements(p *parser, isEndToken func(token lexer.Token) bool) (statem
 					previousLine := previousStatement.EndPosition().Line
 					currentStartPos := statement.StartPosition()
 					if previousLine == currentStartPos.Line {
-						p.report(&SyntaxError{
-							Message: "statements on the same line must be separated with a semicolon",
-							Pos:     currentStartPos,
-						})
+						p.report(NewCodedSyntaxError(
+							SyntaxErrorCodeMissingSemicolon,
+							currentStartPos,
+							"statements on the same line must be separated with a semicolon",
+						))
 					}
 				}
 			}
@@ -95,6 +97,8 @@ func parseStatement(p *parser) ast.Statement {
 			return parseForStatement(p)
 		case keywordEmit:
 			return parseEmitStatement(p)
+		case keywordDestroy:
+			return parseDestroyStatement(p)
 		case keywordFun:
 			// The `fun` keyword is ambiguous: it either introduces a function expression
 			// or a function declaration, depending on if an identifier follows, or not.
@@ -188,6 +192,11 @@ func parseFunctionDeclarationOrFunctionExpressionStatement(p *parser) ast.Statem
 	}
 }
 
+// parseReturnStatement parses a return statement.
+// The returned value is optional: it must start on the same line as the `return` keyword.
+// The statement has no value if it is followed by a newline, a semicolon,
+// the end of the enclosing block, or the end of the input.
+//
 func parseReturnStatement(p *parser) *ast.ReturnStatement {
 	tokenRange := p.current.Range
 	endPosition := tokenRange.EndPos
@@ -345,7 +354,10 @@ func parseForStatement(p *parser) *ast.ForStatement {
 	p.skipSpaceAndComments(true)
 
 	if p.current.IsString(lexer.TokenIdentifier, keywordIn) {
-		p.report(fmt.Errorf(
+		p.report(p.expectedError(
+			SyntaxErrorCodeMissingIdentifier,
+			lexer.TokenIdentifier.String(),
+			fmt.Sprintf("keyword %q", keywordIn),
 			"expected identifier, got keyword %q",
 			keywordIn,
 		))
@@ -370,7 +382,10 @@ func parseForStatement(p *parser) *ast.ForStatement {
 	}
 
 	if !p.current.IsString(lexer.TokenIdentifier, keywordIn) {
-		p.report(fmt.Errorf(
+		p.report(p.expectedError(
+			SyntaxErrorCodeMissingKeyword,
+			strconv.Quote(keywordIn),
+			p.current.Type.String(),
 			"expected keyword %q, got %s",
 			keywordIn,
 			p.current.Type,
@@ -518,6 +533,24 @@ func parseEmitStatement(p *parser) *ast.EmitStatement {
 	}
 }
 
+// parseDestroyStatement parses a destroy statement.
+//
+// Like the destroyed expression of a destroy expression,
+// the destroyed expression extends as far as possible.
+//
+func parseDestroyStatement(p *parser) *ast.DestroyStatement {
+	startPos := p.current.StartPos
+
+	// Skip the `destroy` keyword
+	p.next()
+
+	expression := parseExpression(p, lowestBindingPower)
+	return &ast.DestroyStatement{
+		Expression: expression,
+		StartPos:   startPos,
+	}
+}
+
 func parseSwitchStatement(p *parser) *ast.SwitchStatement {
 
 	startPos := p.current.StartPos
@@ -550,7 +583,10 @@ func parseSwitchStatement(p *parser) *ast.SwitchStatement {
 func parseSwitchCases(p *parser) (cases []*ast.SwitchCase) {
 
 	reportUnexpected := func() {
-		p.report(fmt.Errorf(
+		p.report(p.expectedError(
+			SyntaxErrorCodeUnexpectedToken,
+			fmt.Sprintf("%q or %q", keywordCase, keywordDefault),
+			p.current.Type.String(),
 			"unexpected token: got %s, expected %q or %q",
 			p.current.Type,
 			keywordCase,
@@ -613,7 +649,10 @@ func parseSwitchCase(p *parser, hasExpression bool) *ast.SwitchCase {
 	colonPos := p.current.StartPos
 
 	if !p.current.Is(lexer.TokenColon) {
-		p.report(fmt.Errorf(
+		p.report(p.expectedError(
+			SyntaxErrorCodeUnexpectedToken,
+			lexer.TokenColon.String(),
+			p.current.Type.String(),
 			"expected %s, got %s",
 			lexer.TokenColon,
 			p.current.Type,
@@ -166,6 +166,189 @@ func TestParseReturnStatement(t *testing.T) {
 			result,
 		)
 	})
+
+	t.Run("expression on same line, comment", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseStatements("return 1 // one\n")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Statement{
+				&ast.ReturnStatement{
+					Expression: &ast.IntegerExpression{
+						PositiveLiteral: "1",
+						Value:           big.NewInt(1),
+						Base:            10,
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 7, Offset: 7},
+							EndPos:   ast.Position{Line: 1, Column: 7, Offset: 7},
+						},
+					},
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+						EndPos:   ast.Position{Line: 1, Column: 7, Offset: 7},
+					},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("expression on next line, comment", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseStatements("return // none\n1")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Statement{
+				&ast.ReturnStatement{
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+						EndPos:   ast.Position{Line: 1, Column: 5, Offset: 5},
+					},
+				},
+				&ast.ExpressionStatement{
+					Expression: &ast.IntegerExpression{
+						PositiveLiteral: "1",
+						Value:           big.NewInt(1),
+						Base:            10,
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 2, Column: 0, Offset: 15},
+							EndPos:   ast.Position{Line: 2, Column: 0, Offset: 15},
+						},
+					},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("last statement in block, no expression", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseStatements("if true { return }")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Statement{
+				&ast.IfStatement{
+					Test: &ast.BoolExpression{
+						Value: true,
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 3, Offset: 3},
+							EndPos:   ast.Position{Line: 1, Column: 6, Offset: 6},
+						},
+					},
+					Then: &ast.Block{
+						Statements: []ast.Statement{
+							&ast.ReturnStatement{
+								Range: ast.Range{
+									StartPos: ast.Position{Line: 1, Column: 10, Offset: 10},
+									EndPos:   ast.Position{Line: 1, Column: 15, Offset: 15},
+								},
+							},
+						},
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 8, Offset: 8},
+							EndPos:   ast.Position{Line: 1, Column: 17, Offset: 17},
+						},
+					},
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("last statement in block, expression", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseStatements("if true { return 1 }")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Statement{
+				&ast.IfStatement{
+					Test: &ast.BoolExpression{
+						Value: true,
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 3, Offset: 3},
+							EndPos:   ast.Position{Line: 1, Column: 6, Offset: 6},
+						},
+					},
+					Then: &ast.Block{
+						Statements: []ast.Statement{
+							&ast.ReturnStatement{
+								Expression: &ast.IntegerExpression{
+									PositiveLiteral: "1",
+									Value:           big.NewInt(1),
+									Base:            10,
+									Range: ast.Range{
+										StartPos: ast.Position{Line: 1, Column: 17, Offset: 17},
+										EndPos:   ast.Position{Line: 1, Column: 17, Offset: 17},
+									},
+								},
+								Range: ast.Range{
+									StartPos: ast.Position{Line: 1, Column: 10, Offset: 10},
+									EndPos:   ast.Position{Line: 1, Column: 17, Offset: 17},
+								},
+							},
+						},
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 8, Offset: 8},
+							EndPos:   ast.Position{Line: 1, Column: 19, Offset: 19},
+						},
+					},
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("last statement in block, newline", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseStatements("if true {\n return\n}")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Statement{
+				&ast.IfStatement{
+					Test: &ast.BoolExpression{
+						Value: true,
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 3, Offset: 3},
+							EndPos:   ast.Position{Line: 1, Column: 6, Offset: 6},
+						},
+					},
+					Then: &ast.Block{
+						Statements: []ast.Statement{
+							&ast.ReturnStatement{
+								Range: ast.Range{
+									StartPos: ast.Position{Line: 2, Column: 1, Offset: 11},
+									EndPos:   ast.Position{Line: 2, Column: 6, Offset: 16},
+								},
+							},
+						},
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 8, Offset: 8},
+							EndPos:   ast.Position{Line: 3, Column: 0, Offset: 18},
+						},
+					},
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+				},
+			},
+			result,
+		)
+	})
 }
 
 func TestParseIfStatement(t *testing.T) {
@@ -502,10 +685,191 @@ func TestParseIfStatement(t *testing.T) {
 				StartPos: ast.Position{Line: 1, Column: 3, Offset: 3},
 			},
 			Then: &ast.Block{
-				Statements: nil,
+				Statements: nil,
+				Range: ast.Range{
+					StartPos: ast.Position{Line: 1, Column: 13, Offset: 13},
+					EndPos:   ast.Position{Line: 1, Column: 15, Offset: 15},
+				},
+			},
+			StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+		}
+
+		expected.Test.(*ast.VariableDeclaration).ParentIfStatement = expected
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Statement{
+				expected,
+			},
+			result,
+		)
+	})
+
+	t.Run("if-let", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseStatements("if let x = 1 { }")
+		require.Empty(t, errs)
+
+		expected := &ast.IfStatement{
+			Test: &ast.VariableDeclaration{
+				IsConstant: true,
+				Identifier: ast.Identifier{
+					Identifier: "x",
+					Pos:        ast.Position{Line: 1, Column: 7, Offset: 7},
+				},
+				Value: &ast.IntegerExpression{
+					PositiveLiteral: "1",
+					Value:           big.NewInt(1),
+					Base:            10,
+					Range: ast.Range{
+						StartPos: ast.Position{Line: 1, Column: 11, Offset: 11},
+						EndPos:   ast.Position{Line: 1, Column: 11, Offset: 11},
+					},
+				},
+				Transfer: &ast.Transfer{
+					Operation: ast.TransferOperationCopy,
+					Pos:       ast.Position{Line: 1, Column: 9, Offset: 9},
+				},
+				StartPos: ast.Position{Line: 1, Column: 3, Offset: 3},
+			},
+			Then: &ast.Block{
+				Statements: nil,
+				Range: ast.Range{
+					StartPos: ast.Position{Line: 1, Column: 13, Offset: 13},
+					EndPos:   ast.Position{Line: 1, Column: 15, Offset: 15},
+				},
+			},
+			StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+		}
+
+		expected.Test.(*ast.VariableDeclaration).ParentIfStatement = expected
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Statement{
+				expected,
+			},
+			result,
+		)
+	})
+
+	t.Run("if-let, else", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseStatements("if let x = y { } else { }")
+		require.Empty(t, errs)
+
+		expected := &ast.IfStatement{
+			Test: &ast.VariableDeclaration{
+				IsConstant: true,
+				Identifier: ast.Identifier{
+					Identifier: "x",
+					Pos:        ast.Position{Line: 1, Column: 7, Offset: 7},
+				},
+				Value: &ast.IdentifierExpression{
+					Identifier: ast.Identifier{
+						Identifier: "y",
+						Pos:        ast.Position{Line: 1, Column: 11, Offset: 11},
+					},
+				},
+				Transfer: &ast.Transfer{
+					Operation: ast.TransferOperationCopy,
+					Pos:       ast.Position{Line: 1, Column: 9, Offset: 9},
+				},
+				StartPos: ast.Position{Line: 1, Column: 3, Offset: 3},
+			},
+			Then: &ast.Block{
+				Statements: nil,
+				Range: ast.Range{
+					StartPos: ast.Position{Line: 1, Column: 13, Offset: 13},
+					EndPos:   ast.Position{Line: 1, Column: 15, Offset: 15},
+				},
+			},
+			Else: &ast.Block{
+				Statements: nil,
+				Range: ast.Range{
+					StartPos: ast.Position{Line: 1, Column: 22, Offset: 22},
+					EndPos:   ast.Position{Line: 1, Column: 24, Offset: 24},
+				},
+			},
+			StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+		}
+
+		expected.Test.(*ast.VariableDeclaration).ParentIfStatement = expected
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Statement{
+				expected,
+			},
+			result,
+		)
+	})
+
+	t.Run("if-let, nested", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseStatements("if let x = y { if let z = x { } }")
+		require.Empty(t, errs)
+
+		inner := &ast.IfStatement{
+			Test: &ast.VariableDeclaration{
+				IsConstant: true,
+				Identifier: ast.Identifier{
+					Identifier: "z",
+					Pos:        ast.Position{Line: 1, Column: 22, Offset: 22},
+				},
+				Value: &ast.IdentifierExpression{
+					Identifier: ast.Identifier{
+						Identifier: "x",
+						Pos:        ast.Position{Line: 1, Column: 26, Offset: 26},
+					},
+				},
+				Transfer: &ast.Transfer{
+					Operation: ast.TransferOperationCopy,
+					Pos:       ast.Position{Line: 1, Column: 24, Offset: 24},
+				},
+				StartPos: ast.Position{Line: 1, Column: 18, Offset: 18},
+			},
+			Then: &ast.Block{
+				Statements: nil,
+				Range: ast.Range{
+					StartPos: ast.Position{Line: 1, Column: 28, Offset: 28},
+					EndPos:   ast.Position{Line: 1, Column: 30, Offset: 30},
+				},
+			},
+			StartPos: ast.Position{Line: 1, Column: 15, Offset: 15},
+		}
+
+		inner.Test.(*ast.VariableDeclaration).ParentIfStatement = inner
+
+		expected := &ast.IfStatement{
+			Test: &ast.VariableDeclaration{
+				IsConstant: true,
+				Identifier: ast.Identifier{
+					Identifier: "x",
+					Pos:        ast.Position{Line: 1, Column: 7, Offset: 7},
+				},
+				Value: &ast.IdentifierExpression{
+					Identifier: ast.Identifier{
+						Identifier: "y",
+						Pos:        ast.Position{Line: 1, Column: 11, Offset: 11},
+					},
+				},
+				Transfer: &ast.Transfer{
+					Operation: ast.TransferOperationCopy,
+					Pos:       ast.Position{Line: 1, Column: 9, Offset: 9},
+				},
+				StartPos: ast.Position{Line: 1, Column: 3, Offset: 3},
+			},
+			Then: &ast.Block{
+				Statements: []ast.Statement{
+					inner,
+				},
 				Range: ast.Range{
 					StartPos: ast.Position{Line: 1, Column: 13, Offset: 13},
-					EndPos:   ast.Position{Line: 1, Column: 15, Offset: 15},
+					EndPos:   ast.Position{Line: 1, Column: 32, Offset: 32},
 				},
 			},
 			StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
@@ -521,11 +885,14 @@ func TestParseIfStatement(t *testing.T) {
 		)
 	})
 
-	t.Run("if-let", func(t *testing.T) {
+	t.Run("if-let, shadowed in else", func(t *testing.T) {
 
 		t.Parallel()
 
-		result, errs := ParseStatements("if let x = 1 { }")
+		// The binding is only in scope in the then-block,
+		// so the else-block may declare a variable with the same name
+
+		result, errs := ParseStatements("if let x = y { } else { let x = 1 }")
 		require.Empty(t, errs)
 
 		expected := &ast.IfStatement{
@@ -535,13 +902,10 @@ func TestParseIfStatement(t *testing.T) {
 					Identifier: "x",
 					Pos:        ast.Position{Line: 1, Column: 7, Offset: 7},
 				},
-				Value: &ast.IntegerExpression{
-					PositiveLiteral: "1",
-					Value:           big.NewInt(1),
-					Base:            10,
-					Range: ast.Range{
-						StartPos: ast.Position{Line: 1, Column: 11, Offset: 11},
-						EndPos:   ast.Position{Line: 1, Column: 11, Offset: 11},
+				Value: &ast.IdentifierExpression{
+					Identifier: ast.Identifier{
+						Identifier: "y",
+						Pos:        ast.Position{Line: 1, Column: 11, Offset: 11},
 					},
 				},
 				Transfer: &ast.Transfer{
@@ -557,6 +921,35 @@ func TestParseIfStatement(t *testing.T) {
 					EndPos:   ast.Position{Line: 1, Column: 15, Offset: 15},
 				},
 			},
+			Else: &ast.Block{
+				Statements: []ast.Statement{
+					&ast.VariableDeclaration{
+						IsConstant: true,
+						Identifier: ast.Identifier{
+							Identifier: "x",
+							Pos:        ast.Position{Line: 1, Column: 28, Offset: 28},
+						},
+						Value: &ast.IntegerExpression{
+							PositiveLiteral: "1",
+							Value:           big.NewInt(1),
+							Base:            10,
+							Range: ast.Range{
+								StartPos: ast.Position{Line: 1, Column: 32, Offset: 32},
+								EndPos:   ast.Position{Line: 1, Column: 32, Offset: 32},
+							},
+						},
+						Transfer: &ast.Transfer{
+							Operation: ast.TransferOperationCopy,
+							Pos:       ast.Position{Line: 1, Column: 30, Offset: 30},
+						},
+						StartPos: ast.Position{Line: 1, Column: 24, Offset: 24},
+					},
+				},
+				Range: ast.Range{
+					StartPos: ast.Position{Line: 1, Column: 22, Offset: 22},
+					EndPos:   ast.Position{Line: 1, Column: 34, Offset: 34},
+				},
+			},
 			StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
 		}
 
@@ -569,7 +962,6 @@ func TestParseIfStatement(t *testing.T) {
 			result,
 		)
 	})
-
 }
 
 func TestParseWhileStatement(t *testing.T) {
@@ -606,6 +998,110 @@ func TestParseWhileStatement(t *testing.T) {
 			result,
 		)
 	})
+
+	t.Run("complex condition", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseStatements("while i < 10 && !done { i = i + 1 }")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Statement{
+				&ast.WhileStatement{
+					Test: &ast.BinaryExpression{
+						Operation: ast.OperationAnd,
+						Left: &ast.BinaryExpression{
+							Operation: ast.OperationLess,
+							Left: &ast.IdentifierExpression{
+								Identifier: ast.Identifier{
+									Identifier: "i",
+									Pos:        ast.Position{Line: 1, Column: 6, Offset: 6},
+								},
+							},
+							Right: &ast.IntegerExpression{
+								PositiveLiteral: "10",
+								Value:           big.NewInt(10),
+								Base:            10,
+								Range: ast.Range{
+									StartPos: ast.Position{Line: 1, Column: 10, Offset: 10},
+									EndPos:   ast.Position{Line: 1, Column: 11, Offset: 11},
+								},
+							},
+						},
+						Right: &ast.UnaryExpression{
+							Operation: ast.OperationNegate,
+							Expression: &ast.IdentifierExpression{
+								Identifier: ast.Identifier{
+									Identifier: "done",
+									Pos:        ast.Position{Line: 1, Column: 17, Offset: 17},
+								},
+							},
+							StartPos: ast.Position{Line: 1, Column: 16, Offset: 16},
+						},
+					},
+					Block: &ast.Block{
+						Statements: []ast.Statement{
+							&ast.AssignmentStatement{
+								Target: &ast.IdentifierExpression{
+									Identifier: ast.Identifier{
+										Identifier: "i",
+										Pos:        ast.Position{Line: 1, Column: 24, Offset: 24},
+									},
+								},
+								Transfer: &ast.Transfer{
+									Operation: ast.TransferOperationCopy,
+									Pos:       ast.Position{Line: 1, Column: 26, Offset: 26},
+								},
+								Value: &ast.BinaryExpression{
+									Operation: ast.OperationPlus,
+									Left: &ast.IdentifierExpression{
+										Identifier: ast.Identifier{
+											Identifier: "i",
+											Pos:        ast.Position{Line: 1, Column: 28, Offset: 28},
+										},
+									},
+									Right: &ast.IntegerExpression{
+										PositiveLiteral: "1",
+										Value:           big.NewInt(1),
+										Base:            10,
+										Range: ast.Range{
+											StartPos: ast.Position{Line: 1, Column: 32, Offset: 32},
+											EndPos:   ast.Position{Line: 1, Column: 32, Offset: 32},
+										},
+									},
+								},
+							},
+						},
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 22, Offset: 22},
+							EndPos:   ast.Position{Line: 1, Column: 34, Offset: 34},
+						},
+					},
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("missing block", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := ParseStatements("while true")
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Code:     SyntaxErrorCodeUnexpectedToken,
+					Message:  "expected token '{'",
+					Pos:      ast.Position{Offset: 10, Line: 1, Column: 10},
+					Expected: "'{'",
+				},
+			},
+			errs,
+		)
+	})
 }
 
 func TestParseAssignmentStatement(t *testing.T) {
@@ -823,6 +1319,114 @@ func TestParseForStatement(t *testing.T) {
 			result,
 		)
 	})
+
+	t.Run("array", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseStatements("for x in [1] { x }")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Statement{
+				&ast.ForStatement{
+					Identifier: ast.Identifier{
+						Identifier: "x",
+						Pos:        ast.Position{Line: 1, Column: 4, Offset: 4},
+					},
+					Value: &ast.ArrayExpression{
+						Values: []ast.Expression{
+							&ast.IntegerExpression{
+								PositiveLiteral: "1",
+								Value:           big.NewInt(1),
+								Base:            10,
+								Range: ast.Range{
+									StartPos: ast.Position{Line: 1, Column: 10, Offset: 10},
+									EndPos:   ast.Position{Line: 1, Column: 10, Offset: 10},
+								},
+							},
+						},
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 9, Offset: 9},
+							EndPos:   ast.Position{Line: 1, Column: 11, Offset: 11},
+						},
+					},
+					Block: &ast.Block{
+						Statements: []ast.Statement{
+							&ast.ExpressionStatement{
+								Expression: &ast.IdentifierExpression{
+									Identifier: ast.Identifier{
+										Identifier: "x",
+										Pos:        ast.Position{Line: 1, Column: 15, Offset: 15},
+									},
+								},
+							},
+						},
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 13, Offset: 13},
+							EndPos:   ast.Position{Line: 1, Column: 17, Offset: 17},
+						},
+					},
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("dictionary", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseStatements(`for k in {"a": 1} { }`)
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Statement{
+				&ast.ForStatement{
+					Identifier: ast.Identifier{
+						Identifier: "k",
+						Pos:        ast.Position{Line: 1, Column: 4, Offset: 4},
+					},
+					Value: &ast.DictionaryExpression{
+						Entries: []ast.DictionaryEntry{
+							{
+								Key: &ast.StringExpression{
+									Value: "a",
+									Range: ast.Range{
+										StartPos: ast.Position{Line: 1, Column: 10, Offset: 10},
+										EndPos:   ast.Position{Line: 1, Column: 12, Offset: 12},
+									},
+								},
+								Value: &ast.IntegerExpression{
+									PositiveLiteral: "1",
+									Value:           big.NewInt(1),
+									Base:            10,
+									Range: ast.Range{
+										StartPos: ast.Position{Line: 1, Column: 15, Offset: 15},
+										EndPos:   ast.Position{Line: 1, Column: 15, Offset: 15},
+									},
+								},
+							},
+						},
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 9, Offset: 9},
+							EndPos:   ast.Position{Line: 1, Column: 16, Offset: 16},
+						},
+					},
+					Block: &ast.Block{
+						Statements: nil,
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 18, Offset: 18},
+							EndPos:   ast.Position{Line: 1, Column: 20, Offset: 20},
+						},
+					},
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+				},
+			},
+			result,
+		)
+	})
 }
 
 func TestParseForStatementIndexBinding(t *testing.T) {
@@ -875,12 +1479,17 @@ func TestParseForStatementIndexBinding(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
-					Message: "expected keyword \"in\", got identifier",
-					Pos:     ast.Position{Offset: 6, Line: 1, Column: 6},
+					Code:     SyntaxErrorCodeMissingKeyword,
+					Message:  "expected keyword \"in\", got identifier",
+					Pos:      ast.Position{Offset: 6, Line: 1, Column: 6},
+					Expected: "\"in\"",
+					Got:      "identifier",
 				},
 				&SyntaxError{
-					Message: "expected token '{'",
-					Pos:     ast.Position{Offset: 11, Line: 1, Column: 11},
+					Code:     SyntaxErrorCodeUnexpectedToken,
+					Message:  "expected token '{'",
+					Pos:      ast.Position{Offset: 11, Line: 1, Column: 11},
+					Expected: "'{'",
 				},
 			},
 			errs,
@@ -895,12 +1504,17 @@ func TestParseForStatementIndexBinding(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
-					Message: "expected identifier, got keyword \"in\"",
-					Pos:     ast.Position{Offset: 4, Line: 1, Column: 4},
+					Code:     SyntaxErrorCodeMissingIdentifier,
+					Message:  "expected identifier, got keyword \"in\"",
+					Pos:      ast.Position{Offset: 4, Line: 1, Column: 4},
+					Expected: "identifier",
+					Got:      "keyword \"in\"",
 				},
 				&SyntaxError{
-					Message: "expected token identifier",
-					Pos:     ast.Position{Offset: 6, Line: 1, Column: 6},
+					Code:     SyntaxErrorCodeUnexpectedToken,
+					Message:  "expected token identifier",
+					Pos:      ast.Position{Offset: 6, Line: 1, Column: 6},
+					Expected: "identifier",
 				},
 			},
 			errs,
@@ -908,6 +1522,38 @@ func TestParseForStatementIndexBinding(t *testing.T) {
 	})
 }
 
+func TestParseDestroyStatement(t *testing.T) {
+
+	t.Parallel()
+
+	result, errs := ParseStatements("destroy a\ndestroy b")
+	require.Empty(t, errs)
+
+	utils.AssertEqualWithDiff(t,
+		[]ast.Statement{
+			&ast.DestroyStatement{
+				Expression: &ast.IdentifierExpression{
+					Identifier: ast.Identifier{
+						Identifier: "a",
+						Pos:        ast.Position{Line: 1, Column: 8, Offset: 8},
+					},
+				},
+				StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+			},
+			&ast.DestroyStatement{
+				Expression: &ast.IdentifierExpression{
+					Identifier: ast.Identifier{
+						Identifier: "b",
+						Pos:        ast.Position{Line: 2, Column: 8, Offset: 18},
+					},
+				},
+				StartPos: ast.Position{Line: 2, Column: 0, Offset: 10},
+			},
+		},
+		result,
+	)
+}
+
 func TestParseEmit(t *testing.T) {
 
 	t.Parallel()
@@ -938,6 +1584,81 @@ func TestParseEmit(t *testing.T) {
 			result,
 		)
 	})
+
+	t.Run("with arguments", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseStatements("emit T(a: 1, 2)")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			[]ast.Statement{
+				&ast.EmitStatement{
+					InvocationExpression: &ast.InvocationExpression{
+						InvokedExpression: &ast.IdentifierExpression{
+							Identifier: ast.Identifier{
+								Identifier: "T",
+								Pos:        ast.Position{Line: 1, Column: 5, Offset: 5},
+							},
+						},
+						Arguments: []*ast.Argument{
+							{
+								Label:         "a",
+								LabelStartPos: &ast.Position{Line: 1, Column: 7, Offset: 7},
+								LabelEndPos:   &ast.Position{Line: 1, Column: 7, Offset: 7},
+								Expression: &ast.IntegerExpression{
+									PositiveLiteral: "1",
+									Value:           big.NewInt(1),
+									Base:            10,
+									Range: ast.Range{
+										StartPos: ast.Position{Line: 1, Column: 10, Offset: 10},
+										EndPos:   ast.Position{Line: 1, Column: 10, Offset: 10},
+									},
+								},
+								TrailingSeparatorPos: ast.Position{Line: 1, Column: 11, Offset: 11},
+							},
+							{
+								Expression: &ast.IntegerExpression{
+									PositiveLiteral: "2",
+									Value:           big.NewInt(2),
+									Base:            10,
+									Range: ast.Range{
+										StartPos: ast.Position{Line: 1, Column: 13, Offset: 13},
+										EndPos:   ast.Position{Line: 1, Column: 13, Offset: 13},
+									},
+								},
+								TrailingSeparatorPos: ast.Position{Line: 1, Column: 14, Offset: 14},
+							},
+						},
+						ArgumentsStartPos: ast.Position{Line: 1, Column: 6, Offset: 6},
+						EndPos:            ast.Position{Line: 1, Column: 14, Offset: 14},
+					},
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("missing invocation", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := ParseStatements("emit T")
+
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Code:     SyntaxErrorCodeUnexpectedToken,
+					Message:  "expected token '('",
+					Pos:      ast.Position{Offset: 6, Line: 1, Column: 6},
+					Expected: "'('",
+				},
+			},
+			errs,
+		)
+	})
 }
 
 func TestParseFunctionStatementOrExpression(t *testing.T) {
@@ -1094,6 +1815,7 @@ func TestParseStatements(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
+					Code:    SyntaxErrorCodeMissingSemicolon,
 					Message: "statements on the same line must be separated with a semicolon",
 					Pos:     ast.Position{Offset: 7, Line: 1, Column: 7},
 				},
@@ -2292,16 +3014,14 @@ func TestParseExpressionStatementAfterReturnStatement(t *testing.T) {
 									EndPos:   ast.Position{Offset: 35, Line: 3, Column: 15},
 								},
 							},
-							&ast.ExpressionStatement{
-								Expression: &ast.DestroyExpression{
-									Expression: &ast.IdentifierExpression{
-										Identifier: ast.Identifier{
-											Identifier: "x",
-											Pos:        ast.Position{Offset: 55, Line: 4, Column: 18},
-										},
+							&ast.DestroyStatement{
+								Expression: &ast.IdentifierExpression{
+									Identifier: ast.Identifier{
+										Identifier: "x",
+										Pos:        ast.Position{Offset: 55, Line: 4, Column: 18},
 									},
-									StartPos: ast.Position{Offset: 47, Line: 4, Column: 10},
 								},
+								StartPos: ast.Position{Offset: 47, Line: 4, Column: 10},
 							},
 						},
 						Range: ast.Range{
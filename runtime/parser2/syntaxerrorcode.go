@@ -0,0 +1,55 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parser2
+
+//go:generate go run golang.org/x/tools/cmd/stringer -type=SyntaxErrorCode -trimprefix=SyntaxErrorCode
+
+// SyntaxErrorCode identifies the kind of a syntax error,
+// so syntax errors can be matched without inspecting their message
+//
+type SyntaxErrorCode uint
+
+const (
+	SyntaxErrorCodeUnknown SyntaxErrorCode = iota
+	SyntaxErrorCodeUnexpectedToken
+	SyntaxErrorCodeUnexpectedEnd
+	SyntaxErrorCodeMissingIdentifier
+	SyntaxErrorCodeMissingKeyword
+	SyntaxErrorCodeMissingExpression
+	SyntaxErrorCodeMissingDeclaration
+	SyntaxErrorCodeMissingType
+	SyntaxErrorCodeMissingTransfer
+	SyntaxErrorCodeMissingCompositeKind
+	SyntaxErrorCodeMissingConformance
+	SyntaxErrorCodeUnexpectedConformances
+	SyntaxErrorCodeUnclosedParen
+	SyntaxErrorCodeInvalidAccessModifier
+	SyntaxErrorCodeInvalidAnnotation
+	SyntaxErrorCodeInvalidAddress
+	SyntaxErrorCodeInvalidStringLiteral
+	SyntaxErrorCodeInvalidEscapeSequence
+	SyntaxErrorCodeInvalidType
+	SyntaxErrorCodeUnterminatedComment
+	SyntaxErrorCodeDuplicateBlock
+	SyntaxErrorCodeMissingSemicolon
+	SyntaxErrorCodeInvalidWhitespace
+	SyntaxErrorCodeInvalidToken
+	SyntaxErrorCodeUnexpectedFunctionBlock
+	SyntaxErrorCodeInternal
+)
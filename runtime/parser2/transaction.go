@@ -82,11 +82,20 @@ func parseTransactionDeclaration(p *parser, docString string) *ast.TransactionDe
 		case keywordExecute:
 			execute = parseTransactionExecute(p)
 
+		case keywordPre, keywordPost:
+			// Pre-conditions and post-conditions are parsed below,
+			// the prepare block is optional
+
 		default:
-			panic(fmt.Errorf(
-				"unexpected identifier, expected keyword %q or %q, got %q",
+			panic(p.expectedError(
+				SyntaxErrorCodeUnexpectedToken,
+				fmt.Sprintf("keyword %q, %q, %q, or %q", keywordPrepare, keywordPre, keywordExecute, keywordPost),
+				fmt.Sprintf("%q", p.current.Value),
+				"unexpected identifier, expected keyword %q, %q, %q, or %q, got %q",
 				keywordPrepare,
+				keywordPre,
 				keywordExecute,
+				keywordPost,
 				p.current.Value,
 			))
 		}
@@ -122,14 +131,20 @@ func parseTransactionDeclaration(p *parser, docString string) *ast.TransactionDe
 			switch p.current.Value {
 			case keywordExecute:
 				if execute != nil {
-					panic(fmt.Errorf("unexpected second %q block", keywordExecute))
+					panic(p.codedSyntaxError(
+						SyntaxErrorCodeDuplicateBlock,
+						"unexpected second %q block", keywordExecute,
+					))
 				}
 
 				execute = parseTransactionExecute(p)
 
 			case keywordPost:
 				if sawPost {
-					panic(fmt.Errorf("unexpected second post-conditions"))
+					panic(p.codedSyntaxError(
+						SyntaxErrorCodeDuplicateBlock,
+						"unexpected second post-conditions",
+					))
 				}
 				// Skip the `post` keyword
 				p.next()
@@ -138,7 +153,10 @@ func parseTransactionDeclaration(p *parser, docString string) *ast.TransactionDe
 				sawPost = true
 
 			default:
-				panic(fmt.Errorf(
+				panic(p.expectedError(
+					SyntaxErrorCodeUnexpectedToken,
+					fmt.Sprintf("keyword %q or %q", keywordExecute, keywordPost),
+					fmt.Sprintf("%q", p.current.Value),
 					"unexpected identifier, expected keyword %q or %q, got %q",
 					keywordExecute,
 					keywordPost,
@@ -153,7 +171,13 @@ func parseTransactionDeclaration(p *parser, docString string) *ast.TransactionDe
 			atEnd = true
 
 		default:
-			panic(fmt.Errorf("unexpected token: %s", p.current.Type))
+			panic(p.expectedError(
+				SyntaxErrorCodeUnexpectedToken,
+				"",
+				p.current.Type.String(),
+				"unexpected token: %s",
+				p.current.Type,
+			))
 		}
 	}
 
@@ -20,6 +20,7 @@ package parser2
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/errors"
@@ -180,7 +181,10 @@ func parseNominalTypeRemainder(p *parser, token lexer.Token) *ast.NominalType {
 		nestedToken := p.current
 
 		if !nestedToken.Is(lexer.TokenIdentifier) {
-			panic(fmt.Errorf(
+			panic(p.expectedError(
+				SyntaxErrorCodeMissingIdentifier,
+				lexer.TokenIdentifier.String(),
+				nestedToken.Type.String(),
 				"expected identifier after %s, got %s",
 				lexer.TokenDot,
 				nestedToken.Type,
@@ -226,7 +230,10 @@ func defineArrayType() {
 
 				integerExpression, ok := numberExpression.(*ast.IntegerExpression)
 				if !ok {
-					p.report(fmt.Errorf(
+					p.report(p.expectedError(
+						SyntaxErrorCodeInvalidType,
+						"integer size for constant sized type",
+						fmt.Sprint(numberExpression),
 						"expected integer size for constant sized type, got %s",
 						numberExpression,
 					))
@@ -330,15 +337,24 @@ func defineRestrictedOrDictionaryType() {
 				switch p.current.Type {
 				case lexer.TokenComma:
 					if dictionaryType != nil {
-						panic(fmt.Errorf("unexpected comma in dictionary type"))
+						panic(p.codedSyntaxError(
+							SyntaxErrorCodeUnexpectedToken,
+							"unexpected comma in dictionary type",
+						))
 					}
 					if expectType {
-						panic(fmt.Errorf("unexpected comma in restricted type"))
+						panic(p.codedSyntaxError(
+							SyntaxErrorCodeUnexpectedToken,
+							"unexpected comma in restricted type",
+						))
 					}
 					if restrictedType == nil {
 						firstNominalType, ok := firstType.(*ast.NominalType)
 						if !ok {
-							panic(fmt.Errorf("non-nominal type in restriction list: %s", firstType))
+							panic(p.codedSyntaxError(
+								SyntaxErrorCodeInvalidType,
+								"non-nominal type in restriction list: %s", firstType,
+							))
 						}
 						restrictedType = &ast.RestrictedType{
 							Restrictions: []*ast.NominalType{
@@ -355,14 +371,23 @@ func defineRestrictedOrDictionaryType() {
 
 				case lexer.TokenColon:
 					if restrictedType != nil {
-						panic(fmt.Errorf("unexpected colon in restricted type"))
+						panic(p.codedSyntaxError(
+							SyntaxErrorCodeUnexpectedToken,
+							"unexpected colon in restricted type",
+						))
 					}
 					if expectType {
-						panic(fmt.Errorf("unexpected colon in dictionary type"))
+						panic(p.codedSyntaxError(
+							SyntaxErrorCodeUnexpectedToken,
+							"unexpected colon in dictionary type",
+						))
 					}
 					if dictionaryType == nil {
 						if firstType == nil {
-							panic(fmt.Errorf("unexpected colon after missing dictionary key type"))
+							panic(p.codedSyntaxError(
+								SyntaxErrorCodeUnexpectedToken,
+								"unexpected colon after missing dictionary key type",
+							))
 						}
 						dictionaryType = &ast.DictionaryType{
 							KeyType: firstType,
@@ -371,7 +396,10 @@ func defineRestrictedOrDictionaryType() {
 							},
 						}
 					} else {
-						panic(fmt.Errorf("unexpected colon in dictionary type"))
+						panic(p.codedSyntaxError(
+							SyntaxErrorCodeUnexpectedToken,
+							"unexpected colon in dictionary type",
+						))
 					}
 					// Skip the colon
 					p.next()
@@ -381,9 +409,15 @@ func defineRestrictedOrDictionaryType() {
 					if expectType {
 						switch {
 						case dictionaryType != nil:
-							p.report(fmt.Errorf("missing dictionary value type"))
+							p.report(p.codedSyntaxError(
+								SyntaxErrorCodeMissingType,
+								"missing dictionary value type",
+							))
 						case restrictedType != nil:
-							p.report(fmt.Errorf("missing type after comma"))
+							p.report(p.codedSyntaxError(
+								SyntaxErrorCodeMissingType,
+								"missing type after comma",
+							))
 						}
 					}
 					endPos = p.current.EndPos
@@ -393,14 +427,28 @@ func defineRestrictedOrDictionaryType() {
 
 				case lexer.TokenEOF:
 					if expectType {
-						panic(fmt.Errorf("invalid end of input, expected type"))
+						panic(p.expectedError(
+							SyntaxErrorCodeUnexpectedEnd,
+							"type",
+							"",
+							"invalid end of input, expected type",
+						))
 					} else {
-						panic(fmt.Errorf("invalid end of input, expected %s", lexer.TokenBraceClose))
+						panic(p.expectedError(
+							SyntaxErrorCodeUnexpectedEnd,
+							lexer.TokenBraceClose.String(),
+							"",
+							"invalid end of input, expected %s",
+							lexer.TokenBraceClose,
+						))
 					}
 
 				default:
 					if !expectType {
-						panic(fmt.Errorf("unexpected type"))
+						panic(p.codedSyntaxError(
+							SyntaxErrorCodeInvalidType,
+							"unexpected type",
+						))
 					}
 
 					ty := parseType(p, lowestBindingPower)
@@ -414,7 +462,10 @@ func defineRestrictedOrDictionaryType() {
 					case restrictedType != nil:
 						nominalType, ok := ty.(*ast.NominalType)
 						if !ok {
-							panic(fmt.Errorf("non-nominal type in restriction list: %s", ty))
+							panic(p.codedSyntaxError(
+								SyntaxErrorCodeInvalidType,
+								"non-nominal type in restriction list: %s", ty,
+							))
 						}
 						restrictedType.Restrictions = append(restrictedType.Restrictions, nominalType)
 
@@ -441,7 +492,10 @@ func defineRestrictedOrDictionaryType() {
 				if firstType != nil {
 					firstNominalType, ok := firstType.(*ast.NominalType)
 					if !ok {
-						panic(fmt.Errorf("non-nominal type in restriction list: %s", firstType))
+						panic(p.codedSyntaxError(
+							SyntaxErrorCodeInvalidType,
+							"non-nominal type in restriction list: %s", firstType,
+						))
 					}
 					restrictedType.Restrictions = append(restrictedType.Restrictions, firstNominalType)
 				}
@@ -524,7 +578,10 @@ func parseNominalTypes(
 		switch p.current.Type {
 		case lexer.TokenComma:
 			if expectType {
-				panic(fmt.Errorf("unexpected comma"))
+				panic(p.codedSyntaxError(
+					SyntaxErrorCodeUnexpectedToken,
+					"unexpected comma",
+				))
 			}
 			// Skip the comma
 			p.next()
@@ -532,21 +589,38 @@ func parseNominalTypes(
 
 		case endTokenType:
 			if expectType && len(nominalTypes) > 0 {
-				p.report(fmt.Errorf("missing type after comma"))
+				p.report(p.codedSyntaxError(
+					SyntaxErrorCodeMissingType,
+					"missing type after comma",
+				))
 			}
 			endPos = p.current.EndPos
 			atEnd = true
 
 		case lexer.TokenEOF:
 			if expectType {
-				panic(fmt.Errorf("invalid end of input, expected type"))
+				panic(p.expectedError(
+					SyntaxErrorCodeUnexpectedEnd,
+					"type",
+					"",
+					"invalid end of input, expected type",
+				))
 			} else {
-				panic(fmt.Errorf("invalid end of input, expected %s", endTokenType))
+				panic(p.expectedError(
+					SyntaxErrorCodeUnexpectedEnd,
+					endTokenType.String(),
+					"",
+					"invalid end of input, expected %s",
+					endTokenType,
+				))
 			}
 
 		default:
 			if !expectType {
-				panic(fmt.Errorf(
+				panic(p.expectedError(
+					SyntaxErrorCodeUnexpectedToken,
+					fmt.Sprintf("%s or %s", lexer.TokenComma, endTokenType),
+					p.current.Type.String(),
 					"unexpected token: got %s, expected %s or %s",
 					p.current.Type,
 					lexer.TokenComma,
@@ -560,7 +634,10 @@ func parseNominalTypes(
 
 			nominalType, ok := ty.(*ast.NominalType)
 			if !ok {
-				panic(fmt.Errorf("unexpected non-nominal type: %s", ty))
+				panic(p.codedSyntaxError(
+					SyntaxErrorCodeInvalidType,
+					"unexpected non-nominal type: %s", ty,
+				))
 			}
 			nominalTypes = append(nominalTypes, nominalType)
 		}
@@ -610,7 +687,10 @@ func parseParameterTypeAnnotations(p *parser) (typeAnnotations []*ast.TypeAnnota
 		switch p.current.Type {
 		case lexer.TokenComma:
 			if expectTypeAnnotation {
-				panic(fmt.Errorf(
+				panic(p.expectedError(
+					SyntaxErrorCodeUnexpectedToken,
+					"type annotation or end of list",
+					strconv.Quote(p.current.Type.String()),
 					"expected type annotation or end of list, got %q",
 					p.current.Type,
 				))
@@ -625,14 +705,20 @@ func parseParameterTypeAnnotations(p *parser) (typeAnnotations []*ast.TypeAnnota
 			atEnd = true
 
 		case lexer.TokenEOF:
-			panic(fmt.Errorf(
+			panic(p.expectedError(
+				SyntaxErrorCodeUnclosedParen,
+				strconv.Quote(lexer.TokenParenClose.String()),
+				"",
 				"missing %q at end of list",
 				lexer.TokenParenClose,
 			))
 
 		default:
 			if !expectTypeAnnotation {
-				panic(fmt.Errorf(
+				panic(p.expectedError(
+					SyntaxErrorCodeUnexpectedToken,
+					"comma or end of list",
+					strconv.Quote(p.current.Type.String()),
 					"expected comma or end of list, got %q",
 					p.current.Type,
 				))
@@ -736,7 +822,13 @@ func applyTypeNullDenotation(p *parser, token lexer.Token) ast.Type {
 	tokenType := token.Type
 	nullDenotation := typeNullDenotations[tokenType]
 	if nullDenotation == nil {
-		panic(fmt.Errorf("unexpected token in type: %s", tokenType))
+		panic(p.expectedError(
+			SyntaxErrorCodeUnexpectedToken,
+			"",
+			tokenType.String(),
+			"unexpected token in type: %s",
+			tokenType,
+		))
 	}
 	return nullDenotation(p, token)
 }
@@ -744,7 +836,13 @@ func applyTypeNullDenotation(p *parser, token lexer.Token) ast.Type {
 func applyTypeLeftDenotation(p *parser, token lexer.Token, left ast.Type) ast.Type {
 	leftDenotation := typeLeftDenotations[token.Type]
 	if leftDenotation == nil {
-		panic(fmt.Errorf("unexpected token in type: %s", token.Type))
+		panic(p.expectedError(
+			SyntaxErrorCodeUnexpectedToken,
+			"",
+			token.Type.String(),
+			"unexpected token in type: %s",
+			token.Type,
+		))
 	}
 	return leftDenotation(p, token, left)
 }
@@ -794,7 +892,10 @@ func parseCommaSeparatedTypeAnnotations(
 		switch p.current.Type {
 		case lexer.TokenComma:
 			if expectTypeAnnotation {
-				panic(fmt.Errorf("unexpected comma"))
+				panic(p.codedSyntaxError(
+					SyntaxErrorCodeUnexpectedToken,
+					"unexpected comma",
+				))
 			}
 			// Skip the comma
 			p.next()
@@ -802,20 +903,37 @@ func parseCommaSeparatedTypeAnnotations(
 
 		case endTokenType:
 			if expectTypeAnnotation && len(typeAnnotations) > 0 {
-				p.report(fmt.Errorf("missing type annotation after comma"))
+				p.report(p.codedSyntaxError(
+					SyntaxErrorCodeMissingType,
+					"missing type annotation after comma",
+				))
 			}
 			atEnd = true
 
 		case lexer.TokenEOF:
 			if expectTypeAnnotation {
-				panic(fmt.Errorf("invalid end of input, expected type"))
+				panic(p.expectedError(
+					SyntaxErrorCodeUnexpectedEnd,
+					"type",
+					"",
+					"invalid end of input, expected type",
+				))
 			} else {
-				panic(fmt.Errorf("invalid end of input, expected %s", endTokenType))
+				panic(p.expectedError(
+					SyntaxErrorCodeUnexpectedEnd,
+					endTokenType.String(),
+					"",
+					"invalid end of input, expected %s",
+					endTokenType,
+				))
 			}
 
 		default:
 			if !expectTypeAnnotation {
-				panic(fmt.Errorf(
+				panic(p.expectedError(
+					SyntaxErrorCodeUnexpectedToken,
+					fmt.Sprintf("%s or %s", lexer.TokenComma, endTokenType),
+					p.current.Type.String(),
 					"unexpected token: got %s, expected %s or %s",
 					p.current.Type,
 					lexer.TokenComma,
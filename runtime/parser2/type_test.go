@@ -19,6 +19,7 @@
 package parser2
 
 import (
+	"fmt"
 	"math/big"
 	"testing"
 
@@ -438,6 +439,114 @@ func TestParseRestrictedType(t *testing.T) {
 		)
 	})
 
+	t.Run("without restricted type, two restrictions", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseType("{ T , U }")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.RestrictedType{
+				Restrictions: []*ast.NominalType{
+					{
+						Identifier: ast.Identifier{
+							Identifier: "T",
+							Pos:        ast.Position{Line: 1, Column: 2, Offset: 2},
+						},
+					},
+					{
+						Identifier: ast.Identifier{
+							Identifier: "U",
+							Pos:        ast.Position{Line: 1, Column: 6, Offset: 6},
+						},
+					},
+				},
+				Range: ast.Range{
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+					EndPos:   ast.Position{Line: 1, Column: 8, Offset: 8},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("AnyResource, qualified restrictions", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseType("AnyResource{A.I, B}")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.RestrictedType{
+				Type: &ast.NominalType{
+					Identifier: ast.Identifier{
+						Identifier: "AnyResource",
+						Pos:        ast.Position{Line: 1, Column: 0, Offset: 0},
+					},
+				},
+				Restrictions: []*ast.NominalType{
+					{
+						Identifier: ast.Identifier{
+							Identifier: "A",
+							Pos:        ast.Position{Line: 1, Column: 12, Offset: 12},
+						},
+						NestedIdentifiers: []ast.Identifier{
+							{
+								Identifier: "I",
+								Pos:        ast.Position{Line: 1, Column: 14, Offset: 14},
+							},
+						},
+					},
+					{
+						Identifier: ast.Identifier{
+							Identifier: "B",
+							Pos:        ast.Position{Line: 1, Column: 17, Offset: 17},
+						},
+					},
+				},
+				Range: ast.Range{
+					StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+					EndPos:   ast.Position{Line: 1, Column: 18, Offset: 18},
+				},
+			},
+			result,
+		)
+	})
+
+	t.Run("without restricted type, nested optional", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseType("{T}??")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.OptionalType{
+				Type: &ast.OptionalType{
+					Type: &ast.RestrictedType{
+						Restrictions: []*ast.NominalType{
+							{
+								Identifier: ast.Identifier{
+									Identifier: "T",
+									Pos:        ast.Position{Line: 1, Column: 1, Offset: 1},
+								},
+							},
+						},
+						Range: ast.Range{
+							StartPos: ast.Position{Line: 1, Column: 0, Offset: 0},
+							EndPos:   ast.Position{Line: 1, Column: 2, Offset: 2},
+						},
+					},
+					EndPos: ast.Position{Line: 1, Column: 3, Offset: 3},
+				},
+				EndPos: ast.Position{Line: 1, Column: 4, Offset: 4},
+			},
+			result,
+		)
+	})
+
 	t.Run("invalid: without restricted type, missing type after comma", func(t *testing.T) {
 
 		t.Parallel()
@@ -446,6 +555,7 @@ func TestParseRestrictedType(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
+					Code:    SyntaxErrorCodeMissingType,
 					Message: "missing type after comma",
 					Pos:     ast.Position{Offset: 6, Line: 1, Column: 6},
 				},
@@ -480,6 +590,7 @@ func TestParseRestrictedType(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
+					Code:    SyntaxErrorCodeInvalidType,
 					Message: "unexpected type",
 					Pos:     ast.Position{Offset: 4, Line: 1, Column: 4},
 				},
@@ -499,6 +610,7 @@ func TestParseRestrictedType(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
+					Code:    SyntaxErrorCodeUnexpectedToken,
 					Message: "unexpected colon in restricted type",
 					Pos:     ast.Position{Offset: 8, Line: 1, Column: 8},
 				},
@@ -518,8 +630,11 @@ func TestParseRestrictedType(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
-					Message: `unexpected token: got ':', expected ',' or '}'`,
-					Pos:     ast.Position{Offset: 8, Line: 1, Column: 8},
+					Code:     SyntaxErrorCodeUnexpectedToken,
+					Message:  `unexpected token: got ':', expected ',' or '}'`,
+					Pos:      ast.Position{Offset: 8, Line: 1, Column: 8},
+					Expected: "',' or '}'",
+					Got:      "':'",
 				},
 			},
 			errs,
@@ -537,6 +652,7 @@ func TestParseRestrictedType(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
+					Code:    SyntaxErrorCodeInvalidType,
 					Message: "non-nominal type in restriction list: [T]",
 					Pos:     ast.Position{Offset: 5, Line: 1, Column: 5},
 				},
@@ -556,6 +672,7 @@ func TestParseRestrictedType(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
+					Code:    SyntaxErrorCodeInvalidType,
 					Message: "unexpected non-nominal type: [U]",
 					Pos:     ast.Position{Offset: 5, Line: 1, Column: 5},
 				},
@@ -575,6 +692,7 @@ func TestParseRestrictedType(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
+					Code:    SyntaxErrorCodeInvalidType,
 					Message: "non-nominal type in restriction list: [U]",
 					Pos:     ast.Position{Offset: 7, Line: 1, Column: 7},
 				},
@@ -594,6 +712,7 @@ func TestParseRestrictedType(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
+					Code:    SyntaxErrorCodeInvalidType,
 					Message: "unexpected non-nominal type: [V]",
 					Pos:     ast.Position{Offset: 8, Line: 1, Column: 8},
 				},
@@ -613,8 +732,10 @@ func TestParseRestrictedType(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
-					Message: "invalid end of input, expected type",
-					Pos:     ast.Position{Offset: 1, Line: 1, Column: 1},
+					Code:     SyntaxErrorCodeUnexpectedEnd,
+					Message:  "invalid end of input, expected type",
+					Pos:      ast.Position{Offset: 1, Line: 1, Column: 1},
+					Expected: "type",
 				},
 			},
 			errs,
@@ -631,8 +752,10 @@ func TestParseRestrictedType(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
-					Message: "invalid end of input, expected type",
-					Pos:     ast.Position{Offset: 2, Line: 1, Column: 2},
+					Code:     SyntaxErrorCodeUnexpectedEnd,
+					Message:  "invalid end of input, expected type",
+					Pos:      ast.Position{Offset: 2, Line: 1, Column: 2},
+					Expected: "type",
 				},
 			},
 			errs,
@@ -649,8 +772,10 @@ func TestParseRestrictedType(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
-					Message: "invalid end of input, expected '}'",
-					Pos:     ast.Position{Offset: 2, Line: 1, Column: 2},
+					Code:     SyntaxErrorCodeUnexpectedEnd,
+					Message:  "invalid end of input, expected '}'",
+					Pos:      ast.Position{Offset: 2, Line: 1, Column: 2},
+					Expected: "'}'",
 				},
 			},
 			errs,
@@ -667,8 +792,10 @@ func TestParseRestrictedType(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
-					Message: "invalid end of input, expected '}'",
-					Pos:     ast.Position{Offset: 3, Line: 1, Column: 3},
+					Code:     SyntaxErrorCodeUnexpectedEnd,
+					Message:  "invalid end of input, expected '}'",
+					Pos:      ast.Position{Offset: 3, Line: 1, Column: 3},
+					Expected: "'}'",
 				},
 			},
 			errs,
@@ -685,8 +812,10 @@ func TestParseRestrictedType(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
-					Message: "invalid end of input, expected type",
-					Pos:     ast.Position{Offset: 3, Line: 1, Column: 3},
+					Code:     SyntaxErrorCodeUnexpectedEnd,
+					Message:  "invalid end of input, expected type",
+					Pos:      ast.Position{Offset: 3, Line: 1, Column: 3},
+					Expected: "type",
 				},
 			},
 			errs,
@@ -703,8 +832,10 @@ func TestParseRestrictedType(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
-					Message: "invalid end of input, expected type",
-					Pos:     ast.Position{Offset: 4, Line: 1, Column: 4},
+					Code:     SyntaxErrorCodeUnexpectedEnd,
+					Message:  "invalid end of input, expected type",
+					Pos:      ast.Position{Offset: 4, Line: 1, Column: 4},
+					Expected: "type",
 				},
 			},
 			errs,
@@ -721,6 +852,7 @@ func TestParseRestrictedType(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
+					Code:    SyntaxErrorCodeUnexpectedToken,
 					Message: "unexpected comma in restricted type",
 					Pos:     ast.Position{Offset: 1, Line: 1, Column: 1},
 				},
@@ -739,6 +871,7 @@ func TestParseRestrictedType(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
+					Code:    SyntaxErrorCodeUnexpectedToken,
 					Message: "unexpected comma",
 					Pos:     ast.Position{Offset: 2, Line: 1, Column: 2},
 				},
@@ -792,6 +925,7 @@ func TestParseDictionaryType(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
+					Code:    SyntaxErrorCodeMissingType,
 					Message: "missing dictionary value type",
 					Pos:     ast.Position{Offset: 3, Line: 1, Column: 3},
 				},
@@ -825,6 +959,7 @@ func TestParseDictionaryType(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
+					Code:    SyntaxErrorCodeUnexpectedToken,
 					Message: "unexpected colon in dictionary type",
 					Pos:     ast.Position{Offset: 1, Line: 1, Column: 1},
 				},
@@ -843,6 +978,7 @@ func TestParseDictionaryType(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
+					Code:    SyntaxErrorCodeUnexpectedToken,
 					Message: "unexpected colon in dictionary type",
 					Pos:     ast.Position{Offset: 1, Line: 1, Column: 1},
 				},
@@ -862,6 +998,7 @@ func TestParseDictionaryType(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
+					Code:    SyntaxErrorCodeUnexpectedToken,
 					Message: "unexpected comma in dictionary type",
 					Pos:     ast.Position{Offset: 4, Line: 1, Column: 4},
 				},
@@ -881,6 +1018,7 @@ func TestParseDictionaryType(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
+					Code:    SyntaxErrorCodeUnexpectedToken,
 					Message: "unexpected colon in dictionary type",
 					Pos:     ast.Position{Offset: 4, Line: 1, Column: 4},
 				},
@@ -900,6 +1038,7 @@ func TestParseDictionaryType(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
+					Code:    SyntaxErrorCodeUnexpectedToken,
 					Message: "unexpected colon in dictionary type",
 					Pos:     ast.Position{Offset: 3, Line: 1, Column: 3},
 				},
@@ -919,8 +1058,10 @@ func TestParseDictionaryType(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
-					Message: "invalid end of input, expected type",
-					Pos:     ast.Position{Offset: 3, Line: 1, Column: 3},
+					Code:     SyntaxErrorCodeUnexpectedEnd,
+					Message:  "invalid end of input, expected type",
+					Pos:      ast.Position{Offset: 3, Line: 1, Column: 3},
+					Expected: "type",
 				},
 			},
 			errs,
@@ -937,8 +1078,10 @@ func TestParseDictionaryType(t *testing.T) {
 		utils.AssertEqualWithDiff(t,
 			[]error{
 				&SyntaxError{
-					Message: "invalid end of input, expected '}'",
-					Pos:     ast.Position{Offset: 4, Line: 1, Column: 4},
+					Code:     SyntaxErrorCodeUnexpectedEnd,
+					Message:  "invalid end of input, expected '}'",
+					Pos:      ast.Position{Offset: 4, Line: 1, Column: 4},
+					Expected: "'}'",
 				},
 			},
 			errs,
@@ -2779,3 +2922,162 @@ func TestParseConstantSizedSizedArrayWithTrailingUnderscoreSize(t *testing.T) {
 		errs,
 	)
 }
+
+func TestParseTypeStandalone(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("categories", func(t *testing.T) {
+
+		t.Parallel()
+
+		tests := []struct {
+			code     string
+			expected ast.Type
+			string   string
+		}{
+			{"S.T", &ast.NominalType{}, "S.T"},
+			{"Int?", &ast.OptionalType{}, "Int?"},
+			{"[Int]", &ast.VariableSizedType{}, "[Int]"},
+			{"[Int; 2]", &ast.ConstantSizedType{}, "[Int; 2]"},
+			{"{String: Int}", &ast.DictionaryType{}, "{String: Int}"},
+			{"R{I, J}", &ast.RestrictedType{}, "R{I, J}"},
+			{"((Int, String): Bool)", &ast.FunctionType{}, "((Int, String): Bool)"},
+			{"auth &R", &ast.ReferenceType{}, "auth &R"},
+			{"Capability<&R>", &ast.InstantiationType{}, "Capability<&R>"},
+		}
+
+		for _, test := range tests {
+
+			test := test
+
+			t.Run(test.code, func(t *testing.T) {
+
+				t.Parallel()
+
+				// Leading and trailing whitespace and comments are allowed
+
+				for _, code := range []string{
+					test.code,
+					fmt.Sprintf(" /* leading */ %s // trailing\n ", test.code),
+				} {
+					result, errs := ParseType(code)
+					require.Empty(t, errs)
+
+					assert.IsType(t, test.expected, result)
+					assert.Equal(t, test.string, result.String())
+				}
+			})
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+
+		t.Parallel()
+
+		for _, code := range []string{
+			"",
+			"[Int",
+			"{Int: }",
+			"@R",
+			"1",
+		} {
+			_, errs := ParseType(code)
+			assert.NotEmpty(t, errs, code)
+		}
+	})
+
+	t.Run("trailing tokens", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseType("Int String")
+
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Code:    SyntaxErrorCodeUnexpectedToken,
+					Message: "unexpected token: identifier",
+					Pos:     ast.Position{Offset: 4, Line: 1, Column: 4},
+					Got:     "identifier",
+				},
+			},
+			errs,
+		)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.NominalType{
+				Identifier: ast.Identifier{
+					Identifier: "Int",
+					Pos:        ast.Position{Offset: 0, Line: 1, Column: 0},
+				},
+			},
+			result,
+		)
+	})
+}
+
+func TestParseTypeAnnotationStandalone(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("resource", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseTypeAnnotation(" @R ")
+		require.Empty(t, errs)
+
+		utils.AssertEqualWithDiff(t,
+			&ast.TypeAnnotation{
+				IsResource: true,
+				Type: &ast.NominalType{
+					Identifier: ast.Identifier{
+						Identifier: "R",
+						Pos:        ast.Position{Offset: 2, Line: 1, Column: 2},
+					},
+				},
+				StartPos: ast.Position{Offset: 1, Line: 1, Column: 1},
+			},
+			result,
+		)
+	})
+
+	t.Run("non-resource", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, errs := ParseTypeAnnotation("[Int]")
+		require.Empty(t, errs)
+
+		assert.False(t, result.IsResource)
+		assert.Equal(t, "[Int]", result.String())
+	})
+
+	t.Run("missing type", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := ParseTypeAnnotation("@")
+		assert.NotEmpty(t, errs)
+	})
+
+	t.Run("trailing tokens", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, errs := ParseTypeAnnotation("@R R")
+
+		utils.AssertEqualWithDiff(t,
+			[]error{
+				&SyntaxError{
+					Code:    SyntaxErrorCodeUnexpectedToken,
+					Message: "unexpected token: identifier",
+					Pos:     ast.Position{Offset: 3, Line: 1, Column: 3},
+					Got:     "identifier",
+				},
+			},
+			errs,
+		)
+	})
+}
@@ -0,0 +1,44 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parser2
+
+import (
+	"github.com/onflow/cadence/runtime/ast"
+)
+
+// ParseWarning is a problem in the input which does not cause parsing to fail
+//
+type ParseWarning interface {
+	ast.HasPosition
+	Warning() string
+	isParseWarning()
+}
+
+// PubAccessWarning is reported in strict access mode
+// when the `pub` access modifier is used instead of the equivalent `access(all)`
+//
+type PubAccessWarning struct {
+	ast.Range
+}
+
+func (*PubAccessWarning) isParseWarning() {}
+
+func (*PubAccessWarning) Warning() string {
+	return "`pub` is equivalent to `access(all)`: prefer `access(all)` in strict access mode"
+}
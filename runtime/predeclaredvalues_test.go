@@ -16,7 +16,7 @@
  * limitations under the License.
  */
 
-package runtime
+package runtime_test
 
 import (
 	"fmt"
@@ -24,7 +24,9 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/onflow/cadence/runtime"
 	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/runtimetest"
 	"github.com/onflow/cadence/runtime/sema"
 	"github.com/onflow/cadence/runtime/tests/checker"
 )
@@ -43,7 +45,7 @@ func TestRuntimePredeclaredValues(t *testing.T) {
 	address3 := common.MustBytesToAddress([]byte{0x3})
 	address4 := common.MustBytesToAddress([]byte{0x4})
 
-	valueDeclaration1 := ValueDeclaration{
+	valueDeclaration1 := runtime.ValueDeclaration{
 		Name: "foo",
 		Type: &sema.FunctionType{
 			ReturnTypeAnnotation: &sema.TypeAnnotation{
@@ -60,7 +62,7 @@ func TestRuntimePredeclaredValues(t *testing.T) {
 		Value: nil,
 	}
 
-	valueDeclaration2 := ValueDeclaration{
+	valueDeclaration2 := runtime.ValueDeclaration{
 		Name: "foo",
 		Type: &sema.FunctionType{
 			Parameters: []*sema.Parameter{
@@ -98,31 +100,30 @@ func TestRuntimePredeclaredValues(t *testing.T) {
 	  }
 	`)
 
-	runtime := newTestInterpreterRuntime()
-
-	runtimeInterface := &testRuntimeInterface{
-		getAccountContractCode: func(address Address, name string) (bytes []byte, err error) {
-			switch address {
-			case address2:
-				return program2, nil
-			case address3:
-				return program3, nil
-			case address4:
-				return program4, nil
-			default:
-				return nil, fmt.Errorf("unknown address: %s", address.ShortHexWithPrefix())
-			}
-		},
+	rt, runtimeInterface := runtimetest.NewTestRuntime()
+	rt.SetAtreeValidationEnabled(true)
+
+	runtimeInterface.OnGetAccountContractCode = func(address runtime.Address, name string) (bytes []byte, err error) {
+		switch address {
+		case address2:
+			return program2, nil
+		case address3:
+			return program3, nil
+		case address4:
+			return program4, nil
+		default:
+			return nil, fmt.Errorf("unknown address: %s", address.ShortHexWithPrefix())
+		}
 	}
 
-	_, err := runtime.ExecuteScript(
-		Script{
+	_, err := rt.ExecuteScript(
+		runtime.Script{
 			Source: program1,
 		},
-		Context{
+		runtime.Context{
 			Interface: runtimeInterface,
 			Location:  common.ScriptLocation{},
-			PredeclaredValues: []ValueDeclaration{
+			PredeclaredValues: []runtime.ValueDeclaration{
 				valueDeclaration1,
 				valueDeclaration2,
 			},
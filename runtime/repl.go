@@ -33,11 +33,13 @@ import (
 )
 
 type REPL struct {
-	checker  *sema.Checker
-	inter    *interpreter.Interpreter
-	onError  func(err error, location common.Location, codes map[common.LocationID]string)
-	onResult func(interpreter.Value)
-	codes    map[common.LocationID]string
+	checker            *sema.Checker
+	inter              *interpreter.Interpreter
+	onError            func(err error, location common.Location, codes map[common.LocationID]string)
+	onResult           func(interpreter.Value)
+	codes              map[common.LocationID]string
+	checkerOptions     []sema.Option
+	interpreterOptions []interpreter.Option
 }
 
 func NewREPL(
@@ -47,6 +49,26 @@ func NewREPL(
 	interpreterOptions []interpreter.Option,
 ) (*REPL, error) {
 
+	repl := &REPL{
+		onError:            onError,
+		onResult:           onResult,
+		checkerOptions:     checkerOptions,
+		interpreterOptions: interpreterOptions,
+	}
+
+	err := repl.Reset()
+	if err != nil {
+		return nil, err
+	}
+
+	return repl, nil
+}
+
+// Reset starts a new session,
+// i.e. it discards all previously accepted declarations and statements
+//
+func (r *REPL) Reset() error {
+
 	valueDeclarations := append(
 		stdlib.FlowBuiltInFunctions(stdlib.DefaultFlowBuiltinImpls()),
 		stdlib.BuiltinFunctions...,
@@ -57,7 +79,7 @@ func NewREPL(
 
 	var newChecker func(program *ast.Program, location common.Location) (*sema.Checker, error)
 
-	checkerOptions = append(
+	checkerOptions := append(
 		[]sema.Option{
 			sema.WithPredeclaredValues(valueDeclarations.ToSemaValueDeclarations()),
 			sema.WithPredeclaredTypes(typeDeclarations),
@@ -89,7 +111,7 @@ func NewREPL(
 				},
 			),
 		},
-		checkerOptions...,
+		r.checkerOptions...,
 	)
 
 	newChecker = func(program *ast.Program, location common.Location) (*sema.Checker, error) {
@@ -102,7 +124,7 @@ func NewREPL(
 
 	checker, err := newChecker(nil, common.REPLLocation{})
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	values := valueDeclarations.ToInterpreterValueDeclarations()
@@ -111,7 +133,7 @@ func NewREPL(
 
 	storage := interpreter.NewInMemoryStorage()
 
-	interpreterOptions = append(
+	interpreterOptions := append(
 		[]interpreter.Option{
 			interpreter.WithStorage(storage),
 			interpreter.WithPredeclaredValues(values),
@@ -120,7 +142,7 @@ func NewREPL(
 				return uuid, nil
 			}),
 		},
-		interpreterOptions...,
+		r.interpreterOptions...,
 	)
 
 	inter, err := interpreter.NewInterpreter(
@@ -129,17 +151,14 @@ func NewREPL(
 		interpreterOptions...,
 	)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	repl := &REPL{
-		checker:  checker,
-		inter:    inter,
-		onError:  onError,
-		onResult: onResult,
-		codes:    codes,
-	}
-	return repl, nil
+	r.checker = checker
+	r.inter = inter
+	r.codes = codes
+
+	return nil
 }
 
 func (r *REPL) handleCheckerError() bool {
@@ -226,6 +245,42 @@ func (r *REPL) Accept(code string) (inputIsComplete bool) {
 	return
 }
 
+// TypeOf returns the static type of the given expression, without evaluating it.
+// If the expression is invalid, the errors are reported and nil is returned.
+//
+func (r *REPL) TypeOf(code string) sema.Type {
+
+	expression, errs := parser2.ParseExpression(code)
+	if len(errs) > 0 {
+		if r.onError != nil {
+			r.onError(
+				parser2.Error{
+					Code:   code,
+					Errors: errs,
+				},
+				r.checker.Location,
+				r.codes,
+			)
+		}
+		return nil
+	}
+
+	r.checker.ResetErrors()
+	r.checker.ResetHints()
+	r.checker.Program = nil
+
+	// Check the expression without affecting the session,
+	// e.g. without invalidating a resource which is moved in the expression
+
+	ty := r.checker.VisitExpressionWithoutEffects(expression, nil)
+	r.codes[r.checker.Location.ID()] = code
+	if !r.handleCheckerError() {
+		return nil
+	}
+
+	return ty
+}
+
 type REPLSuggestion struct {
 	Name, Description string
 }
@@ -0,0 +1,146 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+func TestREPL(t *testing.T) {
+
+	t.Parallel()
+
+	newREPL := func(t *testing.T) (*REPL, *[]interpreter.Value, *[]error) {
+		var results []interpreter.Value
+		var errs []error
+
+		repl, err := NewREPL(
+			func(err error, _ common.Location, _ map[common.LocationID]string) {
+				errs = append(errs, err)
+			},
+			func(value interpreter.Value) {
+				results = append(results, value)
+			},
+			nil,
+			nil,
+		)
+		require.NoError(t, err)
+
+		return repl, &results, &errs
+	}
+
+	t.Run("declarations are kept across inputs", func(t *testing.T) {
+
+		t.Parallel()
+
+		repl, results, errs := newREPL(t)
+
+		assert.True(t, repl.Accept("let x = 1\n"))
+		assert.True(t, repl.Accept("x + 2\n"))
+
+		require.Empty(t, *errs)
+		assert.Equal(t,
+			[]interpreter.Value{
+				interpreter.NewIntValueFromInt64(3),
+			},
+			*results,
+		)
+	})
+
+	t.Run("reset", func(t *testing.T) {
+
+		t.Parallel()
+
+		repl, results, errs := newREPL(t)
+
+		repl.Accept("let x = 1\n")
+		require.Empty(t, *errs)
+
+		err := repl.Reset()
+		require.NoError(t, err)
+
+		repl.Accept("x\n")
+		require.Len(t, *errs, 1)
+		require.IsType(t, &sema.CheckerError{}, (*errs)[0])
+		assert.Empty(t, *results)
+
+		// The name can be declared again
+
+		repl.Accept("let x = true\n")
+		repl.Accept("x\n")
+		require.Len(t, *errs, 1)
+		assert.Equal(t,
+			[]interpreter.Value{
+				interpreter.BoolValue(true),
+			},
+			*results,
+		)
+	})
+
+	t.Run("type of expression", func(t *testing.T) {
+
+		t.Parallel()
+
+		repl, results, errs := newREPL(t)
+
+		repl.Accept("fun f(): [Int] { panic(\"evaluated\") }\n")
+		require.Empty(t, *errs)
+
+		ty := repl.TypeOf("f()")
+		require.Empty(t, *errs)
+		assert.Equal(t,
+			&sema.VariableSizedType{
+				Type: sema.IntType,
+			},
+			ty,
+		)
+		assert.Empty(t, *results)
+
+		assert.Nil(t, repl.TypeOf("g()"))
+		require.Len(t, *errs, 1)
+		require.IsType(t, &sema.CheckerError{}, (*errs)[0])
+	})
+	t.Run("type of expression does not affect session", func(t *testing.T) {
+
+		t.Parallel()
+
+		repl, _, errs := newREPL(t)
+
+		repl.Accept("resource R {}\n")
+		repl.Accept("let r <- create R()\n")
+		require.Empty(t, *errs)
+
+		// Checking the move must not invalidate the resource
+
+		ty := repl.TypeOf("<-r")
+		require.Empty(t, *errs)
+		require.IsType(t, &sema.CompositeType{}, ty)
+		assert.Equal(t, "R", ty.(*sema.CompositeType).Identifier)
+
+		assert.True(t, repl.Accept("destroy r\n"))
+		require.Empty(t, *errs)
+	})
+}
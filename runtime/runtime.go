@@ -42,7 +42,29 @@ type Script struct {
 	Arguments [][]byte
 }
 
-type importResolutionResults map[common.LocationID]bool
+// importResolutionResults is the stack of locations of the imports which are currently resolved,
+// from the outermost to the innermost import.
+//
+type importResolutionResults []common.Location
+
+// importCycle returns the import cycle which is caused by importing the given location,
+// starting and ending with the given location, or nil if the import does not cause a cycle.
+//
+func (results importResolutionResults) importCycle(location common.Location) []common.Location {
+	locationID := location.ID()
+
+	for i, importedLocation := range results {
+		if importedLocation.ID() != locationID {
+			continue
+		}
+
+		cycle := make([]common.Location, 0, len(results)-i+1)
+		cycle = append(cycle, results[i:]...)
+		return append(cycle, location)
+	}
+
+	return nil
+}
 
 // Runtime is a runtime capable of executing Cadence.
 type Runtime interface {
@@ -76,6 +98,18 @@ type Runtime interface {
 	// This function returns an error if the program contains any syntax or semantic errors.
 	ParseAndCheckProgram(source []byte, context Context) (*interpreter.Program, error)
 
+	// UpgradeContract updates the code of the deployed contract or contract interface
+	// at the given location to the given code.
+	//
+	// This function returns an error if the code contains any syntax or semantic errors,
+	// or if the upgrade is invalid, e.g. if an exported declaration was removed or changed incompatibly.
+	// The existing contract value is kept, i.e. the contract is not re-initialized.
+	//
+	// Like an update in a transaction, the account must be a signing account,
+	// the code is metered, and an `AccountContractUpdated` event is emitted.
+	//
+	UpgradeContract(context Context, location common.Location, code []byte) error
+
 	// SetCoverageReport activates reporting coverage in the given report.
 	// Passing nil disables coverage reporting (default).
 	//
@@ -470,6 +504,7 @@ func (r *interpreterRuntime) interpret(
 }
 
 func (r *interpreterRuntime) newAuthAccountValue(
+	inter *interpreter.Interpreter,
 	addressValue interpreter.AddressValue,
 	context Context,
 	storage *Storage,
@@ -477,6 +512,7 @@ func (r *interpreterRuntime) newAuthAccountValue(
 	checkerOptions []sema.Option,
 ) interpreter.Value {
 	return interpreter.NewAuthAccountValue(
+		inter,
 		addressValue,
 		accountBalanceGetFunction(addressValue, context.Interface),
 		accountAvailableBalanceGetFunction(addressValue, context.Interface),
@@ -550,6 +586,7 @@ func (r *interpreterRuntime) InvokeContractFunction(
 
 	for i, argumentType := range argumentTypes {
 		arguments[i] = r.convertArgument(
+			inter,
 			arguments[i],
 			argumentType,
 			context,
@@ -610,6 +647,7 @@ func (r *interpreterRuntime) InvokeContractFunction(
 }
 
 func (r *interpreterRuntime) convertArgument(
+	inter *interpreter.Interpreter,
 	argument interpreter.Value,
 	argumentType sema.Type,
 	context Context,
@@ -622,7 +660,8 @@ func (r *interpreterRuntime) convertArgument(
 		// convert addresses to auth accounts so there is no need to construct an auth account value for the caller
 		if addressValue, ok := argument.(interpreter.AddressValue); ok {
 			return r.newAuthAccountValue(
-				interpreter.NewAddressValue(addressValue.ToAddress()),
+				inter,
+				interpreter.NewAddressValue(inter, addressValue.ToAddress()),
 				context,
 				storage,
 				interpreterOptions,
@@ -633,7 +672,8 @@ func (r *interpreterRuntime) convertArgument(
 		// convert addresses to public accounts so there is no need to construct a public account value for the caller
 		if addressValue, ok := argument.(interpreter.AddressValue); ok {
 			return r.getPublicAccount(
-				interpreter.NewAddressValue(addressValue.ToAddress()),
+				inter,
+				interpreter.NewAddressValue(inter, addressValue.ToAddress()),
 				context.Interface,
 				storage,
 			)
@@ -726,7 +766,8 @@ func (r *interpreterRuntime) ExecuteTransaction(script Script, context Context)
 
 		for i, address := range authorizers {
 			authorizerValues[i] = r.newAuthAccountValue(
-				interpreter.NewAddressValue(address),
+				inter,
+				interpreter.NewAddressValue(inter, address),
 				context,
 				storage,
 				interpreterOptions,
@@ -1025,6 +1066,116 @@ func (r *interpreterRuntime) ParseAndCheckProgram(
 	return program, nil
 }
 
+func (r *interpreterRuntime) UpgradeContract(
+	context Context,
+	location common.Location,
+	code []byte,
+) (
+	err error,
+) {
+	defer r.Recover(
+		func(internalErr error) {
+			err = internalErr
+		},
+		context,
+	)
+
+	context = context.WithLocation(location)
+	context.InitializeCodesAndPrograms()
+
+	addressLocation, ok := location.(common.AddressLocation)
+	if !ok {
+		return newError(
+			fmt.Errorf("cannot upgrade contract at non-account location %s", location),
+			context,
+		)
+	}
+
+	address := addressLocation.Address
+	name := addressLocation.Name
+
+	// Like an update in a transaction,
+	// an upgrade must be authorized by the account
+
+	var signingAccounts []Address
+	wrapPanic(func() {
+		signingAccounts, err = context.Interface.GetSigningAccounts()
+	})
+	if err != nil {
+		return newError(err, context)
+	}
+
+	authorized := false
+	for _, signingAccount := range signingAccounts {
+		if signingAccount == address {
+			authorized = true
+			break
+		}
+	}
+
+	if !authorized {
+		return newError(
+			fmt.Errorf(
+				"cannot upgrade contract with name %q in account %s: account did not authorize the upgrade",
+				name,
+				address.ShortHexWithPrefix(),
+			),
+			context,
+		)
+	}
+
+	storage := NewStorage(context.Interface)
+
+	var interpreterOptions []interpreter.Option
+	var checkerOptions []sema.Option
+
+	functions := r.standardLibraryFunctions(
+		context,
+		storage,
+		interpreterOptions,
+		checkerOptions,
+	)
+
+	// The interpreter has no program, it only performs the update
+
+	inter, err := r.newInterpreter(
+		nil,
+		context,
+		functions,
+		stdlib.BuiltinValues,
+		storage,
+		interpreterOptions,
+		checkerOptions,
+	)
+	if err != nil {
+		return newError(err, context)
+	}
+
+	// Update the contract like an update in a transaction:
+	// The code is checked, validated, metered, and updated,
+	// and the update event is emitted
+
+	r.changeAccountContract(
+		inter,
+		context,
+		storage,
+		interpreter.NewAddressValue(inter, address),
+		interpreter.NewStringValue(name),
+		code,
+		nil,
+		nil,
+		interpreterOptions,
+		checkerOptions,
+		interpreter.ReturnEmptyLocationRange,
+		changeAccountContractOptions{
+			isUpdate:  true,
+			isUpgrade: true,
+		},
+	)
+
+	return nil
+}
+
 func (r *interpreterRuntime) parseAndCheckProgram(
 	code []byte,
 	context Context,
@@ -1141,17 +1292,22 @@ func (r *interpreterRuntime) check(
 							context := startContext.WithLocation(importedLocation)
 
 							// Check for cyclic imports
-							if checkedImports[importedLocation.ID()] {
+							cycle := checkedImports.importCycle(importedLocation)
+							if cycle != nil {
 								return nil, &sema.CyclicImportsError{
 									Location: importedLocation,
+									Cycle:    cycle,
 									Range:    importRange,
 								}
-							} else {
-								checkedImports[importedLocation.ID()] = true
-								defer delete(checkedImports, importedLocation.ID())
 							}
 
-							program, err := r.getProgram(context, functions, values, checkerOptions, checkedImports)
+							program, err := r.getProgram(
+								context,
+								functions,
+								values,
+								checkerOptions,
+								append(checkedImports, importedLocation),
+							)
 							if err != nil {
 								return nil, err
 							}
@@ -1164,6 +1320,17 @@ func (r *interpreterRuntime) check(
 						}, nil
 					},
 				),
+				sema.WithOnMeterMemoryFuncHandler(
+					func(usage common.MemoryUsage) {
+						var err error
+						wrapPanic(func() {
+							err = startContext.Interface.MeterMemory(usage)
+						})
+						if err != nil {
+							panic(err)
+						}
+					},
+				),
 				sema.WithCheckHandler(func(location common.Location, check func()) {
 					reportMetric(
 						check,
@@ -1270,11 +1437,12 @@ func (r *interpreterRuntime) newInterpreter(
 			r.importLocationHandler(context, functions, values, checkerOptions),
 		),
 		interpreter.WithOnStatementHandler(
-			r.onStatementHandler(),
+			r.onStatementHandler(context),
 		),
 		interpreter.WithPublicAccountHandler(
-			func(_ *interpreter.Interpreter, address interpreter.AddressValue) interpreter.Value {
+			func(inter *interpreter.Interpreter, address interpreter.AddressValue) interpreter.Value {
 				return r.getPublicAccount(
+					inter,
 					address,
 					context.Interface,
 					storage,
@@ -1378,7 +1546,7 @@ func (r *interpreterRuntime) newInterpreter(
 	}
 
 	defaultOptions = append(defaultOptions,
-		r.meteringInterpreterOptions(context.Interface)...,
+		r.meteringInterpreterOptions(context.Interface, context.GasTable)...,
 	)
 
 	return interpreter.NewInterpreter(
@@ -1506,10 +1674,11 @@ func (r *interpreterRuntime) injectedCompositeFieldsHandler(
 					panic(runtimeErrors.NewUnreachableError())
 				}
 
-				addressValue := interpreter.NewAddressValue(address)
+				addressValue := interpreter.NewAddressValue(inter, address)
 
 				return map[string]interpreter.Value{
 					"account": r.newAuthAccountValue(
+						inter,
 						addressValue,
 						context,
 						storage,
@@ -1524,7 +1693,10 @@ func (r *interpreterRuntime) injectedCompositeFieldsHandler(
 	}
 }
 
-func (r *interpreterRuntime) meteringInterpreterOptions(runtimeInterface Interface) []interpreter.Option {
+func (r *interpreterRuntime) meteringInterpreterOptions(
+	runtimeInterface Interface,
+	gasTable *GasTable,
+) []interpreter.Option {
 	callStackDepth := 0
 	// TODO: make runtime interface function
 	const callStackDepthLimit = 2000
@@ -1540,6 +1712,8 @@ func (r *interpreterRuntime) meteringInterpreterOptions(runtimeInterface Interfa
 		})
 	}
 
+	gasMeter := newGasMeter(gasTable)
+
 	return []interpreter.Option{
 		interpreter.WithOnFunctionInvocationHandler(
 			func(_ *interpreter.Interpreter, _ int) {
@@ -1561,6 +1735,21 @@ func (r *interpreterRuntime) meteringInterpreterOptions(runtimeInterface Interfa
 				if err != nil {
 					panic(err)
 				}
+
+				if gasMeter != nil {
+					gasMeter.consume(compKind, intensity)
+				}
+			},
+		),
+		interpreter.WithOnMeterMemoryFuncHandler(
+			func(usage common.MemoryUsage) {
+				var err error
+				wrapPanic(func() {
+					err = runtimeInterface.MeterMemory(usage)
+				})
+				if err != nil {
+					panic(err)
+				}
 			},
 		),
 	}
@@ -1740,7 +1929,7 @@ func (r *interpreterRuntime) newCreateAccountFunction(
 			panic(err)
 		}
 
-		addressValue := interpreter.NewAddressValue(address)
+		addressValue := interpreter.NewAddressValue(inter, address)
 
 		r.emitAccountEvent(
 			stdlib.AccountCreatedEventType,
@@ -1751,6 +1940,7 @@ func (r *interpreterRuntime) newCreateAccountFunction(
 		)
 
 		return r.newAuthAccountValue(
+			inter,
 			addressValue,
 			context,
 			storage,
@@ -1888,7 +2078,7 @@ func (r *interpreterRuntime) newAddPublicKeyFunction(
 				},
 			)
 
-			return interpreter.VoidValue{}
+			return interpreter.NewVoidValue(invocation.Interpreter)
 		},
 		sema.AuthAccountTypeAddPublicKeyFunctionType,
 	)
@@ -1934,7 +2124,7 @@ func (r *interpreterRuntime) newRemovePublicKeyFunction(
 				},
 			)
 
-			return interpreter.VoidValue{}
+			return interpreter.NewVoidValue(invocation.Interpreter)
 		},
 		sema.AuthAccountTypeRemovePublicKeyFunctionType,
 	)
@@ -2153,6 +2343,7 @@ func (r *interpreterRuntime) newGetAuthAccountFunction(
 		}
 
 		return r.newAuthAccountValue(
+			invocation.Interpreter,
 			accountAddress,
 			context,
 			storage,
@@ -2170,6 +2361,7 @@ func (r *interpreterRuntime) newGetAccountFunction(runtimeInterface Interface, s
 		}
 
 		return r.getPublicAccount(
+			invocation.Interpreter,
 			accountAddress,
 			runtimeInterface,
 			storage,
@@ -2178,12 +2370,14 @@ func (r *interpreterRuntime) newGetAccountFunction(runtimeInterface Interface, s
 }
 
 func (r *interpreterRuntime) getPublicAccount(
+	inter *interpreter.Interpreter,
 	accountAddress interpreter.AddressValue,
 	runtimeInterface Interface,
 	storage *Storage,
 ) interpreter.Value {
 
 	return interpreter.NewPublicAccountValue(
+		inter,
 		accountAddress,
 		accountBalanceGetFunction(accountAddress, runtimeInterface),
 		accountAvailableBalanceGetFunction(accountAddress, runtimeInterface),
@@ -2209,7 +2403,7 @@ func (r *interpreterRuntime) newLogFunction(runtimeInterface Interface) interpre
 		if err != nil {
 			panic(err)
 		}
-		return interpreter.VoidValue{}
+		return interpreter.NewVoidValue(invocation.Interpreter)
 	}
 }
 
@@ -2287,7 +2481,7 @@ func (r *interpreterRuntime) newGetBlockFunction(runtimeInterface Interface) int
 		}
 
 		if block == nil {
-			return interpreter.NilValue{}
+			return interpreter.NewNilValue(invocation.Interpreter)
 		}
 
 		return interpreter.NewSomeValueNonCopying(block)
@@ -2405,271 +2599,398 @@ func (r *interpreterRuntime) newAuthAccountContractsChangeFunction(
 				panic("add requires the second argument to be an array")
 			}
 
-			// Get the existing code
-
-			nameArgument := nameValue.Str
-
-			if nameArgument == "" {
+			if nameValue.Str == "" {
 				panic(errors.New(
 					"contract name argument cannot be empty." +
 						"it must match the name of the deployed contract declaration or contract interface declaration",
 				))
 			}
 
-			address := addressValue.ToAddress()
-			existingCode, err := startContext.Interface.GetAccountContractCode(address, nameArgument)
-			if err != nil {
-				panic(err)
-			}
+			r.changeAccountContract(
+				invocation.Interpreter,
+				startContext,
+				storage,
+				addressValue,
+				nameValue,
+				code,
+				constructorArguments,
+				constructorArgumentTypes,
+				interpreterOptions,
+				checkerOptions,
+				invocation.GetLocationRange,
+				changeAccountContractOptions{
+					isUpdate: isUpdate,
+				},
+			)
 
-			if isUpdate {
-				// We are updating an existing contract.
-				// Ensure that there's a contract/contract-interface with the given name exists already
+			return interpreter.NewDeployedContractValue(
+				addressValue,
+				nameValue,
+				newCodeValue,
+			)
+		},
+		sema.AuthAccountContractsTypeAddFunctionType,
+	)
+}
 
-				if len(existingCode) == 0 {
-					panic(fmt.Errorf(
-						"cannot update non-existing contract with name %q in account %s",
-						nameArgument,
-						address.ShortHexWithPrefix(),
-					))
-				}
+type changeAccountContractOptions struct {
+	// isUpdate is true if an existing contract is changed,
+	// and false if a new contract is added
+	isUpdate bool
+	// isUpgrade is true if the change is an upgrade (see UpgradeContract),
+	// which must also preserve the exported declarations of the existing contract.
+	// An upgrade is always an update, and is always validated
+	isUpgrade bool
+}
 
-			} else {
-				// We are adding a new contract.
-				// Ensure that no contract/contract interface with the given name exists already
-
-				if len(existingCode) > 0 {
-					panic(fmt.Errorf(
-						"cannot overwrite existing contract with name %q in account %s",
-						nameArgument,
-						address.ShortHexWithPrefix(),
-					))
-				}
-			}
+// changeAccountContract adds or updates the account contract with the given name,
+// like the `add` and `update__experimental` functions of `AuthAccount.contracts`:
+// The code is checked and validated, the account contract code is updated,
+// and the corresponding account event is emitted.
+//
+// It panics if the change is invalid.
+//
+func (r *interpreterRuntime) changeAccountContract(
+	inter *interpreter.Interpreter,
+	startContext Context,
+	storage *Storage,
+	addressValue interpreter.AddressValue,
+	nameValue *interpreter.StringValue,
+	code []byte,
+	constructorArguments []interpreter.Value,
+	constructorArgumentTypes []sema.Type,
+	interpreterOptions []interpreter.Option,
+	checkerOptions []sema.Option,
+	getLocationRange func() interpreter.LocationRange,
+	options changeAccountContractOptions,
+) {
+	// Get the existing code
+
+	nameArgument := nameValue.Str
+
+	address := addressValue.ToAddress()
 
-			// Check the code
+	var existingCode []byte
+	var err error
+	wrapPanic(func() {
+		existingCode, err = startContext.Interface.GetAccountContractCode(address, nameArgument)
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	if options.isUpdate {
+		// We are updating an existing contract.
+		// Ensure that there's a contract/contract-interface with the given name exists already
 
-			location := common.AddressLocation{
-				Address: address,
-				Name:    nameArgument,
+		if len(existingCode) == 0 {
+			operation := "update"
+			if options.isUpgrade {
+				operation = "upgrade"
 			}
 
-			context := startContext.WithLocation(location)
+			panic(fmt.Errorf(
+				"cannot %s non-existing contract with name %q in account %s",
+				operation,
+				nameArgument,
+				address.ShortHexWithPrefix(),
+			))
+		}
 
-			functions := r.standardLibraryFunctions(
-				context,
-				storage,
-				interpreterOptions,
-				checkerOptions,
-			)
+	} else {
+		// We are adding a new contract.
+		// Ensure that no contract/contract interface with the given name exists already
+
+		if len(existingCode) > 0 {
+			panic(fmt.Errorf(
+				"cannot overwrite existing contract with name %q in account %s",
+				nameArgument,
+				address.ShortHexWithPrefix(),
+			))
+		}
+	}
 
-			handleContractUpdateError := func(err error) {
-				if err == nil {
-					return
-				}
+	// Check the code
 
-				// Update the code for the error pretty printing
-				// NOTE: only do this when an error occurs
+	location := common.AddressLocation{
+		Address: address,
+		Name:    nameArgument,
+	}
 
-				context.SetCode(context.Location, string(code))
+	context := startContext.WithLocation(location)
 
-				panic(&InvalidContractDeploymentError{
-					Err:           err,
-					LocationRange: invocation.GetLocationRange(),
-				})
-			}
+	functions := r.standardLibraryFunctions(
+		context,
+		storage,
+		interpreterOptions,
+		checkerOptions,
+	)
 
-			var cachedProgram *interpreter.Program
-			if isUpdate {
-				// Get the old program from host environment, if available. This is an optimization
-				// so that old program doesn't need to be re-parsed for update validation.
-				wrapPanic(func() {
-					cachedProgram, err = context.Interface.GetProgram(context.Location)
-				})
-				handleContractUpdateError(err)
-			}
+	handleContractUpdateError := func(err error) {
+		if err == nil {
+			return
+		}
 
-			// NOTE: do NOT use the program obtained from the host environment, as the current program.
-			// Always re-parse and re-check the new program.
+		// Update the code for the error pretty printing
+		// NOTE: only do this when an error occurs
 
-			// NOTE: *DO NOT* store the program – the new or updated program
-			// should not be effective during the execution
+		context.SetCode(context.Location, string(code))
 
-			const storeProgram = false
+		panic(&InvalidContractDeploymentError{
+			Err:           err,
+			LocationRange: getLocationRange(),
+		})
+	}
 
-			program, err := r.parseAndCheckProgram(
-				code,
-				context,
-				functions,
-				stdlib.BuiltinValues,
-				checkerOptions,
-				storeProgram,
-				importResolutionResults{},
-			)
-			if err != nil {
-				// Update the code for the error pretty printing
-				// NOTE: only do this when an error occurs
+	var cachedProgram *interpreter.Program
+	if options.isUpdate {
+		// Get the old program from host environment, if available. This is an optimization
+		// so that old program doesn't need to be re-parsed for update validation.
+		wrapPanic(func() {
+			cachedProgram, err = context.Interface.GetProgram(context.Location)
+		})
+		handleContractUpdateError(err)
+	}
 
-				context.SetCode(context.Location, string(code))
+	// NOTE: do NOT use the program obtained from the host environment, as the current program.
+	// Always re-parse and re-check the new program.
 
-				panic(&InvalidContractDeploymentError{
-					Err:           err,
-					LocationRange: invocation.GetLocationRange(),
-				})
-			}
+	// NOTE: *DO NOT* store the program – the new or updated program
+	// should not be effective during the execution
 
-			// The code may declare exactly one contract or one contract interface.
+	const storeProgram = false
 
-			var contractTypes []*sema.CompositeType
-			var contractInterfaceTypes []*sema.InterfaceType
+	program, err := r.parseAndCheckProgram(
+		code,
+		context,
+		functions,
+		stdlib.BuiltinValues,
+		checkerOptions,
+		storeProgram,
+		importResolutionResults{},
+	)
+	handleContractUpdateError(err)
 
-			program.Elaboration.GlobalTypes.Foreach(func(_ string, variable *sema.Variable) {
-				switch ty := variable.Type.(type) {
-				case *sema.CompositeType:
-					if ty.Kind == common.CompositeKindContract {
-						contractTypes = append(contractTypes, ty)
-					}
+	// The code may declare exactly one contract or one contract interface.
 
-				case *sema.InterfaceType:
-					if ty.CompositeKind == common.CompositeKindContract {
-						contractInterfaceTypes = append(contractInterfaceTypes, ty)
-					}
-				}
-			})
+	var contractTypes []*sema.CompositeType
+	var contractInterfaceTypes []*sema.InterfaceType
 
-			var deployedType sema.Type
-			var contractType *sema.CompositeType
-			var contractInterfaceType *sema.InterfaceType
-			var declaredName string
-			var declarationKind common.DeclarationKind
-
-			switch {
-			case len(contractTypes) == 1 && len(contractInterfaceTypes) == 0:
-				contractType = contractTypes[0]
-				declaredName = contractType.Identifier
-				deployedType = contractType
-				declarationKind = common.DeclarationKindContract
-			case len(contractInterfaceTypes) == 1 && len(contractTypes) == 0:
-				contractInterfaceType = contractInterfaceTypes[0]
-				declaredName = contractInterfaceType.Identifier
-				deployedType = contractInterfaceType
-				declarationKind = common.DeclarationKindContractInterface
+	program.Elaboration.GlobalTypes.Foreach(func(_ string, variable *sema.Variable) {
+		switch ty := variable.Type.(type) {
+		case *sema.CompositeType:
+			if ty.Kind == common.CompositeKindContract {
+				contractTypes = append(contractTypes, ty)
 			}
 
-			if deployedType == nil {
-				// Update the code for the error pretty printing
-				// NOTE: only do this when an error occurs
+		case *sema.InterfaceType:
+			if ty.CompositeKind == common.CompositeKindContract {
+				contractInterfaceTypes = append(contractInterfaceTypes, ty)
+			}
+		}
+	})
 
-				context.SetCode(context.Location, string(code))
+	var deployedType sema.Type
+	var contractType *sema.CompositeType
+	var contractInterfaceType *sema.InterfaceType
+	var declaredName string
+	var declarationKind common.DeclarationKind
+
+	switch {
+	case len(contractTypes) == 1 && len(contractInterfaceTypes) == 0:
+		contractType = contractTypes[0]
+		declaredName = contractType.Identifier
+		deployedType = contractType
+		declarationKind = common.DeclarationKindContract
+	case len(contractInterfaceTypes) == 1 && len(contractTypes) == 0:
+		contractInterfaceType = contractInterfaceTypes[0]
+		declaredName = contractInterfaceType.Identifier
+		deployedType = contractInterfaceType
+		declarationKind = common.DeclarationKindContractInterface
+	}
+
+	if deployedType == nil {
+		// Update the code for the error pretty printing
+		// NOTE: only do this when an error occurs
 
-				panic(fmt.Errorf(
-					"invalid %s: the code must declare exactly one contract or contract interface",
-					declarationKind.Name(),
-				))
-			}
+		context.SetCode(context.Location, string(code))
 
-			// The declared contract or contract interface must have the name
-			// passed to the constructor as the first argument
+		panic(fmt.Errorf(
+			"invalid %s: the code must declare exactly one contract or contract interface",
+			declarationKind.Name(),
+		))
+	}
 
-			if declaredName != nameArgument {
-				// Update the code for the error pretty printing
-				// NOTE: only do this when an error occurs
+	// The declared contract or contract interface must have the name
+	// passed to the constructor as the first argument,
+	// or for an upgrade, the name of the existing contract
 
-				context.SetCode(context.Location, string(code))
+	if declaredName != nameArgument {
+		// Update the code for the error pretty printing
+		// NOTE: only do this when an error occurs
 
-				panic(fmt.Errorf(
-					"invalid %s: the name argument must match the name of the declaration: got %q, expected %q",
-					declarationKind.Name(),
-					nameArgument,
-					declaredName,
-				))
-			}
+		context.SetCode(context.Location, string(code))
 
-			// Validate the contract update (if enabled)
+		if options.isUpgrade {
+			panic(fmt.Errorf(
+				"invalid %s: the name of the declaration must match the name of the contract: got %q, expected %q",
+				declarationKind.Name(),
+				declaredName,
+				nameArgument,
+			))
+		}
 
-			if r.contractUpdateValidationEnabled && isUpdate {
-				var oldProgram *ast.Program
-				if cachedProgram != nil {
-					oldProgram = cachedProgram.Program
-				} else {
-					oldProgram, err = parser2.ParseProgram(string(existingCode))
-					handleContractUpdateError(err)
-				}
+		panic(fmt.Errorf(
+			"invalid %s: the name argument must match the name of the declaration: got %q, expected %q",
+			declarationKind.Name(),
+			nameArgument,
+			declaredName,
+		))
+	}
 
-				validator := NewContractUpdateValidator(
-					context.Location,
-					nameArgument,
-					oldProgram,
-					program.Program,
-				)
-				err = validator.Validate()
-				handleContractUpdateError(err)
-			}
+	// Validate the contract update (if enabled).
+	// An upgrade is always validated
 
-			inter := invocation.Interpreter
+	if options.isUpgrade || (r.contractUpdateValidationEnabled && options.isUpdate) {
+		var oldProgram *ast.Program
+		if cachedProgram != nil {
+			oldProgram = cachedProgram.Program
+		} else {
+			oldProgram, err = parser2.ParseProgram(string(existingCode))
+			handleContractUpdateError(err)
+		}
 
-			err = r.updateAccountContractCode(
-				inter,
-				program,
-				context,
-				storage,
-				declaredName,
-				code,
-				addressValue,
-				contractType,
-				constructorArguments,
-				constructorArgumentTypes,
-				interpreterOptions,
-				checkerOptions,
-				updateAccountContractCodeOptions{
-					createContract: !isUpdate,
-				},
+		if options.isUpgrade {
+			validator := NewContractUpgradeValidator(
+				context.Location,
+				nameArgument,
+				oldProgram,
+				program.Program,
 			)
-			if err != nil {
-				// Update the code for the error pretty printing
-				// NOTE: only do this when an error occurs
+			handleContractUpdateError(validator.Validate())
+		} else {
+			r.validateContractUpdate(
+				context.Location,
+				nameArgument,
+				oldProgram,
+				program.Program,
+				handleContractUpdateError,
+			)
+		}
+	}
 
-				context.SetCode(context.Location, string(code))
+	err = r.updateAccountContractCode(
+		inter,
+		program,
+		context,
+		storage,
+		declaredName,
+		code,
+		addressValue,
+		contractType,
+		constructorArguments,
+		constructorArgumentTypes,
+		interpreterOptions,
+		checkerOptions,
+		updateAccountContractCodeOptions{
+			createContract: !options.isUpdate,
+		},
+	)
+	if err != nil {
+		// Update the code for the error pretty printing
+		// NOTE: only do this when an error occurs
 
-				panic(err)
-			}
+		context.SetCode(context.Location, string(code))
 
-			codeHashValue := CodeToHashValue(inter, code)
+		panic(err)
+	}
 
-			eventArguments := []exportableValue{
-				newExportableValue(addressValue, inter),
-				newExportableValue(codeHashValue, inter),
-				newExportableValue(nameValue, inter),
-			}
+	codeHashValue := CodeToHashValue(inter, code)
 
-			if isUpdate {
-				r.emitAccountEvent(
-					stdlib.AccountContractUpdatedEventType,
-					startContext.Interface,
-					eventArguments,
-				)
-			} else {
-				r.emitAccountEvent(
-					stdlib.AccountContractAddedEventType,
-					startContext.Interface,
-					eventArguments,
-				)
-			}
+	eventArguments := []exportableValue{
+		newExportableValue(addressValue, inter),
+		newExportableValue(codeHashValue, inter),
+		newExportableValue(nameValue, inter),
+	}
 
-			return interpreter.NewDeployedContractValue(
-				addressValue,
-				nameValue,
-				newCodeValue,
-			)
-		},
-		sema.AuthAccountContractsTypeAddFunctionType,
+	if options.isUpdate {
+		r.emitAccountEvent(
+			stdlib.AccountContractUpdatedEventType,
+			startContext.Interface,
+			eventArguments,
+		)
+	} else {
+		r.emitAccountEvent(
+			stdlib.AccountContractAddedEventType,
+			startContext.Interface,
+			eventArguments,
+		)
+	}
+}
+
+// validateContractUpdate reports the violations of the storage rules
+// and of the update rules of the given contract update together
+//
+func (r *interpreterRuntime) validateContractUpdate(
+	location Location,
+	contractName string,
+	oldProgram *ast.Program,
+	newProgram *ast.Program,
+	handleContractUpdateError func(err error),
+) {
+	var errs []error
+
+	validator := NewContractUpdateValidator(
+		location,
+		contractName,
+		oldProgram,
+		newProgram,
 	)
+	err := validator.Validate()
+	if err != nil {
+		contractUpdateErr, ok := err.(*ContractUpdateError)
+		if !ok {
+			handleContractUpdateError(err)
+		}
+		errs = append(errs, contractUpdateErr.Errors...)
+	}
+
+	for _, updateErr := range ValidateContractUpdate(oldProgram, newProgram) {
+
+		// Changed field types are already reported by the ContractUpdateValidator
+
+		if exportedDeclarationErr, ok := updateErr.(*ExportedDeclarationUpdateError); ok &&
+			exportedDeclarationErr.Kind == UpdateErrorKindChangedFieldType {
+
+			continue
+		}
+
+		errs = append(errs, updateErr)
+	}
+
+	if len(errs) > 0 {
+		handleContractUpdateError(&ContractUpdateError{
+			ContractName: contractName,
+			Errors:       errs,
+			Location:     location,
+		})
+	}
 }
 
 type updateAccountContractCodeOptions struct {
 	createContract bool
 }
 
+// newDeployedContractMemoryUsage returns the memory usage of a deployed contract,
+// which is proportional to the length of its code
+//
+func newDeployedContractMemoryUsage(code []byte) common.MemoryUsage {
+	return common.MemoryUsage{
+		Kind:   common.MemoryKindDeployedContract,
+		Amount: uint64(len(code)),
+	}
+}
+
 // updateAccountContractCode updates an account contract's code.
 // This function is only used for the new account code/contract API.
 //
@@ -2732,6 +3053,8 @@ func (r *interpreterRuntime) updateAccountContractCode(
 		}
 	}
 
+	inter.UseMemory(newDeployedContractMemoryUsage(code))
+
 	// NOTE: only update account code if contract instantiation succeeded
 	wrapPanic(func() {
 		err = context.Interface.UpdateAccountContractCode(address, name, code)
@@ -2792,7 +3115,7 @@ func (r *interpreterRuntime) newAccountContractsGetFunction(
 					),
 				)
 			} else {
-				return interpreter.NilValue{}
+				return interpreter.NewNilValue(invocation.Interpreter)
 			}
 		},
 		sema.AuthAccountContractsTypeGetFunctionType,
@@ -2893,7 +3216,7 @@ func (r *interpreterRuntime) newAuthAccountContractsRemoveFunction(
 					),
 				)
 			} else {
-				return interpreter.NilValue{}
+				return interpreter.NewNilValue(invocation.Interpreter)
 			}
 		},
 		sema.AuthAccountContractsTypeRemoveFunctionType,
@@ -2934,15 +3257,30 @@ func (r *interpreterRuntime) newAccountContractsGetNamesFunction(
 	}
 }
 
-func (r *interpreterRuntime) onStatementHandler() interpreter.OnStatementFunc {
-	if r.coverageReport == nil {
+func (r *interpreterRuntime) onStatementHandler(context Context) interpreter.OnStatementFunc {
+	coverageReport := r.coverageReport
+	contextCoverageReport := context.CoverageReport
+	sourceMap := context.SourceMap
+
+	if coverageReport == nil && contextCoverageReport == nil && sourceMap == nil {
 		return nil
 	}
 
 	return func(inter *interpreter.Interpreter, statement ast.Statement) {
 		location := inter.Location
 		line := statement.StartPosition().Line
-		r.coverageReport.AddLineHit(location, line)
+
+		if coverageReport != nil {
+			coverageReport.AddLineHit(location, line)
+		}
+
+		if contextCoverageReport != nil {
+			contextCoverageReport.AddLineHit(location, line)
+		}
+
+		if sourceMap != nil {
+			sourceMap.addStatement(location, statement)
+		}
 	}
 }
 
@@ -3179,7 +3517,7 @@ func (r *interpreterRuntime) newAccountKeysGetFunction(
 			// This is done because, if the host function returns an error when a key is not found, then
 			// currently there's no way to distinguish between a 'key not found error' vs other internal errors.
 			if accountKey == nil {
-				return interpreter.NilValue{}
+				return interpreter.NewNilValue(invocation.Interpreter)
 			}
 
 			inter := invocation.Interpreter
@@ -3226,7 +3564,7 @@ func (r *interpreterRuntime) newAccountKeysRevokeFunction(
 			// This is done because, if the host function returns an error when a key is not found, then
 			// currently there's no way to distinguish between a 'key not found error' vs other internal errors.
 			if accountKey == nil {
-				return interpreter.NilValue{}
+				return interpreter.NewNilValue(invocation.Interpreter)
 			}
 
 			inter := invocation.Interpreter
@@ -3499,7 +3837,7 @@ func blsAggregateSignatures(
 
 	// If the crypto layer produces an error, we have invalid input, return nil
 	if err != nil {
-		return interpreter.NilValue{}
+		return interpreter.NewNilValue(inter)
 	}
 
 	aggregatedSignatureValue := interpreter.ByteSliceToByteArrayValue(inter, aggregatedSignature)
@@ -3543,7 +3881,7 @@ func blsAggregatePublicKeys(
 
 	// If the crypto layer produces an error, we have invalid input, return nil
 	if err != nil {
-		return interpreter.NilValue{}
+		return interpreter.NewNilValue(inter)
 	}
 
 	aggregatedPublicKeyValue := NewPublicKeyValue(
@@ -0,0 +1,1309 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+type testMemoryGauge struct {
+	meter  map[common.MemoryKind]uint64
+	usages map[common.MemoryKind]int
+}
+
+func newTestMemoryGauge() *testMemoryGauge {
+	return &testMemoryGauge{
+		meter:  make(map[common.MemoryKind]uint64),
+		usages: make(map[common.MemoryKind]int),
+	}
+}
+
+func (g *testMemoryGauge) MeterMemory(usage common.MemoryUsage) error {
+	g.meter[usage.Kind] += usage.Amount
+	g.usages[usage.Kind]++
+	return nil
+}
+
+func (g *testMemoryGauge) getMemory(kind common.MemoryKind) uint64 {
+	return g.meter[kind]
+}
+
+// getUsageCount returns how often memory of the given kind was metered,
+// which is useful for kinds of memory which have no amount
+//
+func (g *testMemoryGauge) getUsageCount(kind common.MemoryKind) int {
+	return g.usages[kind]
+}
+
+// executeScriptWithMeter executes the given script,
+// and returns the memory gauge which metered the execution
+//
+func executeScriptWithMeter(t *testing.T, script string) *testMemoryGauge {
+	return executeScriptWithInterfaceAndMeter(t, script, &testRuntimeInterface{})
+}
+
+// executeScriptWithInterfaceAndMeter is like executeScriptWithMeter,
+// but executes the script with the given runtime interface
+//
+func executeScriptWithInterfaceAndMeter(
+	t *testing.T,
+	script string,
+	runtimeInterface *testRuntimeInterface,
+) *testMemoryGauge {
+
+	meter := newTestMemoryGauge()
+	runtimeInterface.meterMemory = meter.MeterMemory
+
+	runtime := newTestInterpreterRuntime()
+
+	_, err := runtime.ExecuteScript(
+		Script{
+			Source: []byte(script),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  utils.TestLocation,
+		},
+	)
+	require.NoError(t, err)
+
+	return meter
+}
+
+// executeTransactionWithMeter executes the given transaction, signed by account 0x1,
+// and returns the memory gauge which metered the execution.
+// The transaction may deploy contracts to the signer account
+//
+func executeTransactionWithMeter(t *testing.T, transaction string) *testMemoryGauge {
+
+	var accountCode []byte
+
+	runtimeInterface := &testRuntimeInterface{
+		storage: newTestLedger(nil, nil),
+		getSigningAccounts: func() ([]Address, error) {
+			return []Address{{1}}, nil
+		},
+		getAccountContractCode: func(_ Address, _ string) (code []byte, err error) {
+			return accountCode, nil
+		},
+		updateAccountContractCode: func(_ Address, _ string, code []byte) error {
+			accountCode = code
+			return nil
+		},
+		emitEvent: func(_ cadence.Event) error {
+			return nil
+		},
+	}
+
+	return executeTransactionWithInterfaceAndMeter(t, transaction, runtimeInterface)
+}
+
+// executeTransactionWithInterfaceAndMeter is like executeTransactionWithMeter,
+// but executes the transaction with the given runtime interface
+//
+func executeTransactionWithInterfaceAndMeter(
+	t *testing.T,
+	transaction string,
+	runtimeInterface *testRuntimeInterface,
+) *testMemoryGauge {
+
+	meter := newTestMemoryGauge()
+	runtimeInterface.meterMemory = meter.MeterMemory
+
+	runtime := newTestInterpreterRuntime()
+
+	err := runtime.ExecuteTransaction(
+		Script{
+			Source: []byte(transaction),
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  common.TransactionLocation{},
+		},
+	)
+	require.NoError(t, err)
+
+	return meter
+}
+
+func TestRuntimeFunctionMetering(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("global function", func(t *testing.T) {
+
+		t.Parallel()
+
+		script := `
+          pub fun main() {}
+        `
+
+		meter := executeScriptWithMeter(t, script)
+
+		// No captured variables
+		assert.Equal(t, uint64(1), meter.getMemory(common.MemoryKindFunction))
+	})
+
+	t.Run("nested closures", func(t *testing.T) {
+
+		t.Parallel()
+
+		script := `
+          pub fun main() {
+              let a = 1
+              let f = fun (): Int {
+                  let b = 2
+                  let g = fun (): Int {
+                      return a + b
+                  }
+                  return g()
+              }
+              f()
+          }
+        `
+
+		meter := executeScriptWithMeter(t, script)
+
+		assert.Equal(t, uint64(3), meter.getMemory(common.MemoryKindFunction))
+
+		// main: no captured variables.
+		// f: captures a: 1.
+		// g: captures b: 1.
+		// g also captures a and f, but their environment was already captured when f was created.
+		assert.Equal(t, uint64(1+1), meter.getMemory(common.MemoryKindClosure))
+	})
+
+	t.Run("closure in loop", func(t *testing.T) {
+
+		t.Parallel()
+
+		script := `
+          pub fun main() {
+              let x = 1
+              var i = 0
+              while i < 3 {
+                  let f = fun (): Int {
+                      return x
+                  }
+                  i = i + 1
+              }
+          }
+        `
+
+		meter := executeScriptWithMeter(t, script)
+
+		assert.Equal(t, uint64(1+3), meter.getMemory(common.MemoryKindFunction))
+
+		// The three closures share the environment of x and i.
+		assert.Equal(t, uint64(2), meter.getMemory(common.MemoryKindClosure))
+	})
+}
+
+func TestRuntimeClosureMetering(t *testing.T) {
+
+	t.Parallel()
+
+	script := `
+      pub fun makeCounter(): [((): Int); 2] {
+          var count = 0
+          let increment = fun (): Int {
+              count = count + 1
+              return count
+          }
+          let get = fun (): Int {
+              return count
+          }
+          return [increment, get]
+      }
+
+      pub fun main() {
+          let first = makeCounter()
+          let second = makeCounter()
+          first[0]()
+          second[0]()
+          assert(first[1]() + second[1]() == 2)
+      }
+    `
+
+	meter := executeScriptWithMeter(t, script)
+
+	// makeCounter, main, and two closures for each of the two invocations of makeCounter
+	assert.Equal(t, uint64(2+2*2), meter.getMemory(common.MemoryKindFunction))
+
+	// The closures of each invocation share one environment, which captures count,
+	// but each invocation has an independent environment
+	assert.Equal(t, uint64(2*1), meter.getMemory(common.MemoryKindClosure))
+}
+
+func TestRuntimeOptionalMetering(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("nested optional", func(t *testing.T) {
+
+		t.Parallel()
+
+		script := `
+          pub fun main() {
+              let x: Int?? = 1
+          }
+        `
+
+		meter := executeScriptWithMeter(t, script)
+
+		// The integer is boxed twice
+		assert.Equal(t, uint64(2), meter.getMemory(common.MemoryKindOptional))
+	})
+
+	t.Run("optional chaining", func(t *testing.T) {
+
+		t.Parallel()
+
+		script := `
+          pub struct S {
+              pub let x: Int
+
+              init() {
+                  self.x = 1
+              }
+          }
+
+          pub fun main() {
+              let s: S? = S()
+              let x = s?.x
+          }
+        `
+
+		meter := executeScriptWithMeter(t, script)
+
+		// The structure is boxed, and the result of the optional chaining is wrapped
+		assert.Equal(t, uint64(2), meter.getMemory(common.MemoryKindOptional))
+	})
+}
+
+func TestRuntimeNumberMetering(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("small literal", func(t *testing.T) {
+
+		t.Parallel()
+
+		meter := executeScriptWithMeter(t, `
+          pub fun main() {
+              let x = 1
+          }
+        `)
+
+		assert.Equal(t, uint64(1), meter.getMemory(common.MemoryKindIntValue))
+	})
+
+	t.Run("large literal", func(t *testing.T) {
+
+		t.Parallel()
+
+		meter := executeScriptWithMeter(t, `
+          pub fun main() {
+              let x = 0x1_0000_0000_0000_0000_0000_0000_0000_0000
+          }
+        `)
+
+		// 2^128 needs 129 bits, i.e. 17 bytes
+		assert.Equal(t, uint64(17), meter.getMemory(common.MemoryKindIntValue))
+	})
+
+	t.Run("fixed-size integers", func(t *testing.T) {
+
+		t.Parallel()
+
+		meter := executeScriptWithMeter(t, `
+          pub fun main() {
+              let x: UInt256 = 0xff_ffff
+              let y: UInt64 = 0xff_ffff
+          }
+        `)
+
+		// Fixed-size integers are metered with the byte width of their type,
+		// independent of their magnitude
+		assert.Equal(t, uint64(32), meter.getMemory(common.MemoryKindUInt256Value))
+		assert.Equal(t, uint64(8), meter.getMemory(common.MemoryKindUInt64Value))
+	})
+
+	t.Run("all types", func(t *testing.T) {
+
+		t.Parallel()
+
+		meter := executeScriptWithMeter(t, `
+          pub fun main() {
+              let int: Int = 0x1_0000
+              let uint: UInt = 0x1_0000
+              let int8: Int8 = 1
+              let int16: Int16 = 1
+              let int32: Int32 = 1
+              let int64: Int64 = 1
+              let int128: Int128 = 1
+              let int256: Int256 = 1
+              let uint8: UInt8 = 1
+              let uint16: UInt16 = 1
+              let uint32: UInt32 = 1
+              let uint64: UInt64 = 1
+              let uint128: UInt128 = 1
+              let uint256: UInt256 = 1
+              let word8: Word8 = 1
+              let word16: Word16 = 1
+              let word32: Word32 = 1
+              let word64: Word64 = 1
+              let fix64: Fix64 = -1.5
+              let ufix64: UFix64 = 1.5
+          }
+        `)
+
+		for kind, expected := range map[common.MemoryKind]uint64{
+			common.MemoryKindIntValue:     3,
+			common.MemoryKindUIntValue:    3,
+			common.MemoryKindInt8Value:    1,
+			common.MemoryKindInt16Value:   2,
+			common.MemoryKindInt32Value:   4,
+			common.MemoryKindInt64Value:   8,
+			common.MemoryKindInt128Value:  16,
+			common.MemoryKindInt256Value:  32,
+			common.MemoryKindUInt8Value:   1,
+			common.MemoryKindUInt16Value:  2,
+			common.MemoryKindUInt32Value:  4,
+			common.MemoryKindUInt64Value:  8,
+			common.MemoryKindUInt128Value: 16,
+			common.MemoryKindUInt256Value: 32,
+			common.MemoryKindWord8Value:   1,
+			common.MemoryKindWord16Value:  2,
+			common.MemoryKindWord32Value:  4,
+			common.MemoryKindWord64Value:  8,
+			common.MemoryKindFix64Value:   8,
+			common.MemoryKindUFix64Value:  8,
+		} {
+			assert.Equal(t, expected, meter.getMemory(kind), kind.String())
+		}
+	})
+
+	t.Run("word conversions", func(t *testing.T) {
+
+		t.Parallel()
+
+		for _, test := range []struct {
+			typeName string
+			kind     common.MemoryKind
+			width    uint64
+		}{
+			{"Word8", common.MemoryKindWord8Value, 1},
+			{"Word16", common.MemoryKindWord16Value, 2},
+			{"Word32", common.MemoryKindWord32Value, 4},
+			{"Word64", common.MemoryKindWord64Value, 8},
+		} {
+			test := test
+
+			t.Run(test.typeName, func(t *testing.T) {
+
+				t.Parallel()
+
+				meter := executeScriptWithMeter(t, fmt.Sprintf(
+					`
+                      pub fun main() {
+                          let x = %s(1)
+                      }
+                    `,
+					test.typeName,
+				))
+
+				assert.Equal(t, test.width, meter.getMemory(test.kind))
+			})
+		}
+	})
+}
+
+func TestRuntimeEventMetering(t *testing.T) {
+
+	t.Parallel()
+
+	script := `
+      pub event Foo(a: Int, b: String)
+      pub event Bar()
+
+      pub fun main() {
+          emit Foo(a: 1, b: "one")
+          emit Foo(a: 2, b: "two")
+          emit Bar()
+      }
+    `
+
+	var events []cadence.Event
+
+	runtimeInterface := &testRuntimeInterface{
+		emitEvent: func(event cadence.Event) error {
+			events = append(events, event)
+			return nil
+		},
+	}
+
+	meter := executeScriptWithInterfaceAndMeter(t, script, runtimeInterface)
+
+	require.Len(t, events, 3)
+
+	// Each event has a base cost of 1, and each field adds 1
+	assert.Equal(t, uint64(3+3+1), meter.getMemory(common.MemoryKindEvent))
+
+	// Emitting events does not meter other kinds of memory
+	assert.Equal(t, uint64(0), meter.getMemory(common.MemoryKindOptional))
+	assert.Equal(t, uint64(0), meter.getMemory(common.MemoryKindUnknown))
+}
+
+func TestRuntimeCapabilityMetering(t *testing.T) {
+
+	t.Parallel()
+
+	transaction := `
+      transaction {
+          prepare(signer: AuthAccount) {
+              signer.link<&Int>(/public/x, target: /storage/x)
+              signer.getCapability(/public/x)
+              getAccount(0x2).getCapability(/public/y)
+              getAccount(0x3).getCapability<&Int>(/public/z)
+          }
+      }
+    `
+
+	t.Run("metered", func(t *testing.T) {
+
+		t.Parallel()
+
+		meter := executeTransactionWithMeter(t, transaction)
+
+		assert.Equal(t, uint64(4), meter.getMemory(common.MemoryKindCapability))
+	})
+
+	t.Run("limit exceeded", func(t *testing.T) {
+
+		t.Parallel()
+
+		const limit = 2
+
+		limitErr := errors.New("memory limit exceeded")
+
+		meter := newTestMemoryGauge()
+
+		// Unlike the other tests, the execution fails,
+		// so it is not performed using executeTransactionWithMeter
+
+		runtimeInterface := &testRuntimeInterface{
+			storage: newTestLedger(nil, nil),
+			getSigningAccounts: func() ([]Address, error) {
+				return []Address{{1}}, nil
+			},
+			meterMemory: func(usage common.MemoryUsage) error {
+				err := meter.MeterMemory(usage)
+				if err != nil {
+					return err
+				}
+				if meter.getMemory(common.MemoryKindCapability) > limit {
+					return limitErr
+				}
+				return nil
+			},
+		}
+
+		runtime := newTestInterpreterRuntime()
+
+		err := runtime.ExecuteTransaction(
+			Script{
+				Source: []byte(transaction),
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  common.TransactionLocation{},
+			},
+		)
+		require.ErrorIs(t, err, limitErr)
+
+		// Execution is aborted as soon as the limit is exceeded
+		assert.Equal(t, uint64(limit+1), meter.getMemory(common.MemoryKindCapability))
+	})
+}
+
+func TestRuntimeLinkMetering(t *testing.T) {
+
+	t.Parallel()
+
+	transaction := `
+      transaction {
+          prepare(signer: AuthAccount) {
+              signer.link<&Int>(/public/x, target: /storage/x)
+              signer.link<&Int>(/private/y, target: /storage/yy)
+          }
+      }
+    `
+
+	meter := executeTransactionWithMeter(t, transaction)
+
+	// The links are proportional to the length of the target paths,
+	// `/storage/x` and `/storage/yy`
+	assert.Equal(t, uint64(10+11), meter.getMemory(common.MemoryKindLink))
+
+	// The paths `/public/x`, `/storage/x`, `/private/y`, and `/storage/yy`
+	assert.Equal(t, uint64(8), meter.getMemory(common.MemoryKindPublicPath))
+	assert.Equal(t, uint64(9), meter.getMemory(common.MemoryKindPrivatePath))
+	assert.Equal(t, uint64(9+10), meter.getMemory(common.MemoryKindStoragePath))
+	assert.Equal(t, uint64(8+9+9+10), meter.getMemory(common.MemoryKindPath))
+}
+
+func TestRuntimeStoragePathMetering(t *testing.T) {
+
+	t.Parallel()
+
+	saveMeter := executeTransactionWithMeter(t, `
+      transaction {
+          prepare(signer: AuthAccount) {
+              signer.save(1, to: /storage/xyz)
+          }
+      }
+    `)
+
+	linkMeter := executeTransactionWithMeter(t, `
+      transaction {
+          prepare(signer: AuthAccount) {
+              signer.link<&Int>(/public/xyz, target: /storage/xyz)
+          }
+      }
+    `)
+
+	// The storage path `/storage/xyz` is persisted,
+	// so it is metered at twice its length
+
+	assert.Equal(t, uint64(2*11), saveMeter.getMemory(common.MemoryKindStorageCapabilityPath))
+	assert.Equal(t, uint64(0), saveMeter.getMemory(common.MemoryKindTransientPath))
+
+	// The capability path `/public/xyz` is metered at its length,
+	// the target path is not used in a storage operation
+
+	assert.Equal(t, uint64(0), linkMeter.getMemory(common.MemoryKindStorageCapabilityPath))
+	assert.Equal(t, uint64(10), linkMeter.getMemory(common.MemoryKindTransientPath))
+
+	assert.Greater(
+		t,
+		saveMeter.getMemory(common.MemoryKindStorageCapabilityPath),
+		linkMeter.getMemory(common.MemoryKindTransientPath),
+	)
+
+	// The path values themselves are metered independently of the storage operation
+
+	assert.Equal(t, uint64(11), saveMeter.getMemory(common.MemoryKindPath))
+	assert.Equal(t, uint64(10+11), linkMeter.getMemory(common.MemoryKindPath))
+}
+
+func TestRuntimePathMetering(t *testing.T) {
+
+	t.Parallel()
+
+	paths := []struct {
+		domain     common.PathDomain
+		identifier string
+	}{
+		{common.PathDomainStorage, "a"},
+		{common.PathDomainStorage, "bb"},
+		{common.PathDomainStorage, "flowTokenVault"},
+		{common.PathDomainPublic, "ccc"},
+		{common.PathDomainPublic, "flowTokenReceiver"},
+		{common.PathDomainPublic, "x"},
+		{common.PathDomainPrivate, "dddd"},
+		{common.PathDomainPrivate, "eeeee"},
+		{common.PathDomainPrivate, "flowTokenProvider"},
+		{common.PathDomainPrivate, "y_1"},
+	}
+
+	var literals []string
+	var expectedTotal uint64
+	expected := map[common.MemoryKind]uint64{}
+
+	for _, path := range paths {
+		literals = append(
+			literals,
+			fmt.Sprintf("/%s/%s", path.domain.Identifier(), path.identifier),
+		)
+
+		// The domain, the separator, and the identifier
+		amount := uint64(len(path.domain.Identifier()) + 1 + len(path.identifier))
+
+		expectedTotal += amount
+
+		switch path.domain {
+		case common.PathDomainStorage:
+			expected[common.MemoryKindStoragePath] += amount
+		case common.PathDomainPublic:
+			expected[common.MemoryKindPublicPath] += amount
+		case common.PathDomainPrivate:
+			expected[common.MemoryKindPrivatePath] += amount
+		}
+	}
+
+	script := fmt.Sprintf(
+		`
+          pub fun main() {
+              let paths = [%s]
+          }
+        `,
+		strings.Join(literals, ", "),
+	)
+
+	meter := executeScriptWithMeter(t, script)
+
+	// Each domain is metered independently
+
+	for kind, amount := range expected {
+		assert.Equal(t, amount, meter.getMemory(kind), kind.String())
+	}
+
+	// All paths are also metered together,
+	// so the total is the sum of the domains
+
+	assert.Equal(t, expectedTotal, meter.getMemory(common.MemoryKindPath))
+	assert.Equal(
+		t,
+		meter.getMemory(common.MemoryKindPath),
+		meter.getMemory(common.MemoryKindStoragePath)+
+			meter.getMemory(common.MemoryKindPublicPath)+
+			meter.getMemory(common.MemoryKindPrivatePath),
+	)
+}
+
+func TestRuntimeTypeValueMetering(t *testing.T) {
+
+	t.Parallel()
+
+	script := `
+      pub struct S {}
+
+      pub fun main() {
+          let staticType = Type<Int>()
+          let compositeType = CompositeType("S.test.S")!
+          let dynamicType = S().getType()
+      }
+    `
+
+	meter := executeScriptWithMeter(t, script)
+
+	assert.Equal(t, uint64(3), meter.getMemory(common.MemoryKindTypeValue))
+}
+
+func TestRuntimeNilValueMetering(t *testing.T) {
+
+	t.Parallel()
+
+	script := `
+      pub fun none(): Int? {
+          return nil
+      }
+
+      pub fun main() {
+          var i = 0
+          while i < 3 {
+              none()
+              i = i + 1
+          }
+          let dict: {String: Int} = {}
+          dict["missing"]
+      }
+    `
+
+	meter := executeScriptWithMeter(t, script)
+
+	// Three nil literals, and one absent dictionary value
+	assert.Equal(t, uint64(3+1), meter.getMemory(common.MemoryKindNilValue))
+}
+
+func TestRuntimeVoidValueMetering(t *testing.T) {
+
+	t.Parallel()
+
+	script := `
+      pub fun nothing() {}
+
+      pub fun early() {
+          return
+      }
+
+      pub fun main() {
+          var i = 0
+          while i < 3 {
+              nothing()
+              i = i + 1
+          }
+          early()
+      }
+    `
+
+	meter := executeScriptWithMeter(t, script)
+
+	// Void values currently do not use any memory,
+	// so count the usages instead
+
+	assert.Equal(t, uint64(0), meter.getMemory(common.MemoryKindVoid))
+
+	// Three invocations of `nothing`, one of `early`, and one of `main`
+	assert.Equal(t, 3+1+1, meter.getUsageCount(common.MemoryKindVoid))
+}
+
+func TestRuntimeStorageIndexMetering(t *testing.T) {
+
+	t.Parallel()
+
+	transaction := `
+      transaction {
+          prepare(signer: AuthAccount) {
+              signer.save(1, to: /storage/a)
+              signer.save(2, to: /storage/b)
+              let a = signer.load<Int>(from: /storage/a)!
+              signer.save(a, to: /storage/a)
+          }
+      }
+    `
+
+	meter := executeTransactionWithMeter(t, transaction)
+
+	// Each save creates a new entry, as the load removed the entry for `/storage/a`
+	assert.Equal(t, uint64(3), meter.getMemory(common.MemoryKindStorageIndex))
+}
+
+func TestRuntimeReferenceMetering(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("reference expressions", func(t *testing.T) {
+
+		t.Parallel()
+
+		script := `
+          pub resource Inner {}
+
+          pub resource Outer {
+              pub let inner: @Inner
+
+              init() {
+                  self.inner <- create Inner()
+              }
+
+              destroy() {
+                  destroy self.inner
+              }
+          }
+
+          pub fun main() {
+              let outer <- create Outer()
+              let ref1 = &outer.inner as &Inner
+              let ref2 = &outer.inner as auth &Inner
+              destroy outer
+          }
+        `
+
+		meter := executeScriptWithMeter(t, script)
+
+		assert.Equal(t, uint64(2), meter.getMemory(common.MemoryKindEphemeralReference))
+		assert.Equal(t, uint64(0), meter.getMemory(common.MemoryKindReference))
+	})
+
+	t.Run("local resource", func(t *testing.T) {
+
+		t.Parallel()
+
+		script := `
+          pub resource R {}
+
+          pub fun main() {
+              let r <- create R()
+              let ref = &r as &R
+              destroy r
+          }
+        `
+
+		meter := executeScriptWithMeter(t, script)
+
+		assert.Equal(t, uint64(1), meter.getMemory(common.MemoryKindEphemeralReference))
+		assert.Equal(t, uint64(0), meter.getMemory(common.MemoryKindReference))
+	})
+
+	t.Run("borrow", func(t *testing.T) {
+
+		t.Parallel()
+
+		transaction := `
+          transaction {
+              prepare(signer: AuthAccount) {
+                  signer.save([1, 2], to: /storage/a)
+                  let ref1 = signer.borrow<&[Int]>(from: /storage/a)!
+                  let ref2 = signer.borrow<auth &[Int]>(from: /storage/a)!
+              }
+          }
+        `
+
+		meter := executeTransactionWithMeter(t, transaction)
+
+		assert.Equal(t, uint64(2), meter.getMemory(common.MemoryKindReference))
+		assert.Equal(t, uint64(0), meter.getMemory(common.MemoryKindEphemeralReference))
+	})
+}
+
+func TestRuntimeAccountMetering(t *testing.T) {
+
+	t.Parallel()
+
+	transaction := `
+      transaction {
+          prepare(signer1: AuthAccount, signer2: AuthAccount) {
+              let account1 = getAccount(signer1.address)
+              let account2 = getAccount(signer2.address)
+              let account3 = getAccount(0x3)
+          }
+      }
+    `
+
+	runtimeInterface := &testRuntimeInterface{
+		storage: newTestLedger(nil, nil),
+		getSigningAccounts: func() ([]Address, error) {
+			return []Address{{1}, {2}}, nil
+		},
+	}
+
+	meter := executeTransactionWithInterfaceAndMeter(t, transaction, runtimeInterface)
+
+	assert.Equal(t, uint64(2), meter.getMemory(common.MemoryKindAuthAccountValue))
+	assert.Equal(t, uint64(3), meter.getMemory(common.MemoryKindPublicAccountValue))
+}
+
+func TestRuntimeBoundMethodMetering(t *testing.T) {
+
+	t.Parallel()
+
+	script := `
+      pub struct S {
+          pub fun foo() {}
+
+          pub fun bar(): Int {
+              return 1
+          }
+      }
+
+      pub fun main() {
+          let s = S()
+          s.foo()
+          s.bar()
+          let f = s.foo
+          f()
+          f()
+      }
+    `
+
+	meter := executeScriptWithMeter(t, script)
+
+	// One for each of the three member expressions `s.foo`, `s.bar`, and `s.foo`.
+	// Invoking the bound function `f` does not create another bound function
+	assert.Equal(t, uint64(3), meter.getMemory(common.MemoryKindBoundMethod))
+}
+
+func TestRuntimeCharacterMetering(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("literal", func(t *testing.T) {
+
+		t.Parallel()
+
+		meter := executeScriptWithMeter(t, `
+          pub fun main() {
+              let c: Character = "x"
+          }
+        `)
+
+		assert.Equal(t, uint64(1), meter.getMemory(common.MemoryKindCharacter))
+	})
+
+	t.Run("string to characters", func(t *testing.T) {
+
+		t.Parallel()
+
+		meter := executeScriptWithMeter(t, `
+          pub fun main() {
+              let s = "hello"
+              let characters: [Character] = []
+              var i = 0
+              while i < s.length {
+                  characters.append(s[i])
+                  i = i + 1
+              }
+          }
+        `)
+
+		assert.Equal(t, uint64(5), meter.getMemory(common.MemoryKindCharacter))
+	})
+}
+
+func TestRuntimeAccountStorageMetering(t *testing.T) {
+
+	t.Parallel()
+
+	deployContract := func(t *testing.T, initializer string) *testMemoryGauge {
+
+		contract := fmt.Sprintf(
+			`
+              pub contract Test {
+
+                  pub resource R {
+                      pub let name: String
+
+                      init(name: String) {
+                          self.name = name
+                      }
+                  }
+
+                  init() {
+                      %s
+                  }
+              }
+            `,
+			initializer,
+		)
+
+		return executeTransactionWithMeter(t, newContractAddTransaction("Test", contract))
+	}
+
+	t.Run("save and load", func(t *testing.T) {
+
+		t.Parallel()
+
+		meter := deployContract(t, `
+          self.account.save(<-create R(name: "test"), to: /storage/r)
+
+          let r <- self.account.load<@R>(from: /storage/r)!
+          destroy r
+        `)
+
+		writeMemory := meter.getMemory(common.MemoryKindAccountStorageWrite)
+		readMemory := meter.getMemory(common.MemoryKindAccountStorageRead)
+
+		// The resource is encoded the same way when it is written and read
+		assert.NotZero(t, writeMemory)
+		assert.Equal(t, writeMemory, readMemory)
+	})
+
+	t.Run("borrow", func(t *testing.T) {
+
+		t.Parallel()
+
+		meter := deployContract(t, `
+          self.account.save(<-create R(name: "test"), to: /storage/r)
+
+          let r = self.account.borrow<&R>(from: /storage/r)!
+          r.name
+        `)
+
+		// Borrowing does not read the stored value
+		assert.NotZero(t, meter.getMemory(common.MemoryKindAccountStorageWrite))
+		assert.Zero(t, meter.getMemory(common.MemoryKindAccountStorageRead))
+	})
+}
+
+func TestRuntimePublicKeyMetering(t *testing.T) {
+
+	t.Parallel()
+
+	execute := func(t *testing.T, script string) *testMemoryGauge {
+		return executeScriptWithInterfaceAndMeter(
+			t,
+			script,
+			&testRuntimeInterface{
+				validatePublicKey: func(_ *PublicKey) error {
+					return nil
+				},
+			},
+		)
+	}
+
+	newKeyScript := func(length int, signatureAlgorithm string) string {
+		return fmt.Sprintf(
+			`
+              pub fun main() {
+                  let bytes: [UInt8] = []
+                  var i = 0
+                  while i < %d {
+                      bytes.append(UInt8(i))
+                      i = i + 1
+                  }
+                  PublicKey(
+                      publicKey: bytes,
+                      signatureAlgorithm: SignatureAlgorithm.%s
+                  )
+              }
+            `,
+			length,
+			signatureAlgorithm,
+		)
+	}
+
+	tests := []struct {
+		signatureAlgorithm string
+		length             int
+	}{
+		{"ECDSA_P256", 64},
+		{"ECDSA_secp256k1", 64},
+		{"BLS_BLS12_381", 96},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.signatureAlgorithm, func(t *testing.T) {
+
+			t.Parallel()
+
+			meter := execute(t, newKeyScript(test.length, test.signatureAlgorithm))
+
+			assert.Equal(t,
+				uint64(test.length),
+				meter.getMemory(common.MemoryKindPublicKey),
+			)
+		})
+	}
+
+	t.Run("multiple keys", func(t *testing.T) {
+
+		t.Parallel()
+
+		meter := execute(t, `
+          pub fun main() {
+              PublicKey(
+                  publicKey: [1, 2],
+                  signatureAlgorithm: SignatureAlgorithm.ECDSA_P256
+              )
+              PublicKey(
+                  publicKey: [1, 2, 3, 4],
+                  signatureAlgorithm: SignatureAlgorithm.BLS_BLS12_381
+              )
+          }
+        `)
+
+		assert.Equal(t, uint64(2+4), meter.getMemory(common.MemoryKindPublicKey))
+	})
+}
+
+func TestRuntimeAddressMetering(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("literal", func(t *testing.T) {
+
+		t.Parallel()
+
+		meter := executeScriptWithMeter(t, `
+          pub fun main() {
+              let a: Address = 0x1
+              let b: Address = 0x02cf1A7D7B8E
+          }
+        `)
+
+		assert.Equal(t, uint64(2*8), meter.getMemory(common.MemoryKindAddressValue))
+	})
+
+	t.Run("conversion", func(t *testing.T) {
+
+		t.Parallel()
+
+		meter := executeScriptWithMeter(t, `
+          pub fun main() {
+              let a = Address(0x1)
+          }
+        `)
+
+		// The argument is an integer, only the conversion creates an address
+
+		assert.Equal(t, uint64(8), meter.getMemory(common.MemoryKindAddressValue))
+	})
+
+	t.Run("account field", func(t *testing.T) {
+
+		t.Parallel()
+
+		transaction := `
+          transaction {
+              prepare(signer: AuthAccount) {
+                  let address = signer.address
+              }
+          }
+        `
+
+		meter := executeTransactionWithMeter(t, transaction)
+
+		// The address of the signer account is created once,
+		// accessing the field does not create a new address
+
+		assert.Equal(t, uint64(8), meter.getMemory(common.MemoryKindAddressValue))
+	})
+}
+
+func TestRuntimeComputedFieldMetering(t *testing.T) {
+
+	t.Parallel()
+
+	runScript := func(t *testing.T, script string) *testMemoryGauge {
+		return executeScriptWithInterfaceAndMeter(
+			t,
+			script,
+			&testRuntimeInterface{
+				storage: newTestLedger(nil, nil),
+				getAccountBalance: func(_ Address) (uint64, error) {
+					return 1, nil
+				},
+			},
+		)
+	}
+
+	t.Run("computed field in loop", func(t *testing.T) {
+
+		t.Parallel()
+
+		meter := runScript(t, `
+          pub fun main() {
+              let account = getAccount(0x1)
+              var i = 0
+              while i < 3 {
+                  let balance = account.balance
+                  i = i + 1
+              }
+          }
+        `)
+
+		// The getter of the computed field is invoked on each access
+
+		assert.Equal(t, uint64(3), meter.getMemory(common.MemoryKindComputedField))
+	})
+
+	t.Run("stored field", func(t *testing.T) {
+
+		t.Parallel()
+
+		meter := runScript(t, `
+          pub fun main() {
+              let address = getAccount(0x1).address
+          }
+        `)
+
+		assert.Equal(t, uint64(0), meter.getMemory(common.MemoryKindComputedField))
+	})
+}
+
+func TestRuntimeDeployedContractMetering(t *testing.T) {
+
+	t.Parallel()
+
+	deployContract := func(t *testing.T, code string) *testMemoryGauge {
+		return executeTransactionWithMeter(t, newContractAddTransaction("Test", code))
+	}
+
+	t.Run("small", func(t *testing.T) {
+
+		t.Parallel()
+
+		const code = `pub contract Test {}`
+
+		meter := deployContract(t, code)
+
+		assert.Equal(t, uint64(len(code)), meter.getMemory(common.MemoryKindDeployedContract))
+	})
+
+	t.Run("large", func(t *testing.T) {
+
+		t.Parallel()
+
+		const code = `
+          pub contract Test {
+
+              pub let name: String
+
+              init() {
+                  self.name = "test"
+              }
+
+              pub fun hello(): String {
+                  return "Hello, ".concat(self.name)
+              }
+          }
+        `
+
+		meter := deployContract(t, code)
+
+		assert.Equal(t, uint64(len(code)), meter.getMemory(common.MemoryKindDeployedContract))
+	})
+}
+
+func TestRuntimeStaticTypeMetering(t *testing.T) {
+
+	t.Parallel()
+
+	// The implicit `Void` return type of `main` is converted, too
+	const mainFunctionStaticTypes = 1
+
+	t.Run("no type annotations", func(t *testing.T) {
+
+		t.Parallel()
+
+		meter := executeScriptWithMeter(t, `
+          pub fun main() {
+              let x = 1
+          }
+        `)
+
+		assert.Equal(t,
+			uint64(mainFunctionStaticTypes),
+			meter.getMemory(common.MemoryKindStaticType),
+		)
+	})
+
+	t.Run("nested type annotation", func(t *testing.T) {
+
+		t.Parallel()
+
+		meter := executeScriptWithMeter(t, `
+          pub fun main() {
+              let x: [[{String: [Int?]}?]?] = []
+          }
+        `)
+
+		// [_], _?, [_], _?, {String: _}, String, [_], _?, Int
+		const nestedStaticTypes = 9
+
+		assert.Equal(t,
+			uint64(mainFunctionStaticTypes+nestedStaticTypes),
+			meter.getMemory(common.MemoryKindStaticType),
+		)
+	})
+}
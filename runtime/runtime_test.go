@@ -157,6 +157,7 @@ type testRuntimeInterface struct {
 	)
 	generateUUID       func() (uint64, error)
 	meterComputation   func(compKind common.ComputationKind, intensity uint) error
+	meterMemory        func(usage common.MemoryUsage) error
 	decodeArgument     func(b []byte, t cadence.Type) (cadence.Value, error)
 	programParsed      func(location common.Location, duration time.Duration)
 	programChecked     func(location common.Location, duration time.Duration)
@@ -368,6 +369,13 @@ func (i *testRuntimeInterface) MeterComputation(compKind common.ComputationKind,
 	return i.meterComputation(compKind, intensity)
 }
 
+func (i *testRuntimeInterface) MeterMemory(usage common.MemoryUsage) error {
+	if i.meterMemory == nil {
+		return nil
+	}
+	return i.meterMemory(usage)
+}
+
 func (i *testRuntimeInterface) DecodeArgument(b []byte, t cadence.Type) (cadence.Value, error) {
 	return i.decodeArgument(b, t)
 }
@@ -7246,3 +7254,172 @@ func TestRuntimeComputationMetring(t *testing.T) {
 		})
 	}
 }
+
+func TestRuntimeComputationMetering(t *testing.T) {
+
+	t.Parallel()
+
+	script := []byte(`
+      pub fun inc(_ x: Int): Int {
+          return x + 1
+      }
+
+      pub fun main() {
+          var i = 0
+          while i < 3 {
+              i = inc(i)
+          }
+          for x in [1, 2] {}
+      }
+    `)
+
+	computation := map[common.ComputationKind]uint{}
+
+	runtimeInterface := &testRuntimeInterface{
+		meterComputation: func(kind common.ComputationKind, intensity uint) error {
+			computation[kind] += intensity
+			return nil
+		},
+	}
+
+	runtime := newTestInterpreterRuntime()
+
+	_, err := runtime.ExecuteScript(
+		Script{
+			Source: script,
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  utils.TestLocation,
+		},
+	)
+	require.NoError(t, err)
+
+	// Three iterations of the while-loop, and two iterations of the for-loop
+	assert.Equal(t, uint(3+2), computation[common.ComputationKindLoop])
+
+	// Three invocations of inc
+	assert.Equal(t, uint(3), computation[common.ComputationKindFunctionInvocation])
+
+	// main: three statements, three assignments in the while-loop.
+	// inc: one return statement per invocation
+	assert.Equal(t, uint(3+3+3), computation[common.ComputationKindStatement])
+}
+
+func TestRuntimeGasMetering(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("exhausted mid-loop", func(t *testing.T) {
+
+		t.Parallel()
+
+		script := []byte(`
+          pub fun main() {
+              var i = 0
+              while i < 100 {
+                  log(i)
+                  i = i + 1
+              }
+          }
+        `)
+
+		var loggedMessages []string
+
+		runtimeInterface := &testRuntimeInterface{
+			log: func(message string) {
+				loggedMessages = append(loggedMessages, message)
+			},
+		}
+
+		runtime := newTestInterpreterRuntime()
+
+		_, err := runtime.ExecuteScript(
+			Script{
+				Source: script,
+			},
+			Context{
+				Interface: runtimeInterface,
+				Location:  utils.TestLocation,
+				GasTable: &GasTable{
+					StatementCost:     1,
+					LoopIterationCost: 2,
+					FunctionCallCost:  3,
+					GasLimit:          50,
+				},
+			},
+		)
+		require.Error(t, err)
+
+		var gasExhaustedErr GasExhaustedError
+		require.ErrorAs(t, err, &gasExhaustedErr)
+		assert.Equal(t, uint64(50), gasExhaustedErr.Limit)
+
+		// The two statements of main cost 2.
+		// Each iteration costs 2 for the loop iteration, 2 for the two statements,
+		// and 3 for the invocation of log, i.e. 7.
+		// The limit is exhausted in the seventh iteration, after the invocation of log
+		assert.Equal(t,
+			[]string{"0", "1", "2", "3", "4", "5", "6"},
+			loggedMessages,
+		)
+	})
+
+	t.Run("storage", func(t *testing.T) {
+
+		t.Parallel()
+
+		runtime := newTestInterpreterRuntime()
+
+		tx := []byte(`
+          transaction {
+              prepare(signer: AuthAccount) {
+                  signer.save(1, to: /storage/one)
+                  signer.save(2, to: /storage/two)
+                  signer.load<Int>(from: /storage/one)
+              }
+          }
+        `)
+
+		execute := func(gasTable *GasTable) error {
+
+			runtimeInterface := &testRuntimeInterface{
+				storage: newTestLedger(nil, nil),
+				getSigningAccounts: func() ([]Address, error) {
+					return []Address{{42}}, nil
+				},
+			}
+
+			return runtime.ExecuteTransaction(
+				Script{
+					Source: tx,
+				},
+				Context{
+					Interface: runtimeInterface,
+					Location:  newTransactionLocationGenerator()(),
+					GasTable:  gasTable,
+				},
+			)
+		}
+
+		// The two saves write, and the load reads and then removes,
+		// i.e. three writes and one read cost 3*10 + 1*5 = 35
+
+		err := execute(&GasTable{
+			StorageReadCost:  5,
+			StorageWriteCost: 10,
+			GasLimit:         35,
+		})
+		require.NoError(t, err)
+
+		err = execute(&GasTable{
+			StorageReadCost:  5,
+			StorageWriteCost: 10,
+			GasLimit:         34,
+		})
+		require.Error(t, err)
+
+		var gasExhaustedErr GasExhaustedError
+		require.ErrorAs(t, err, &gasExhaustedErr)
+	})
+}
@@ -0,0 +1,517 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package runtimetest provides utilities for testing code which uses the runtime,
+// similar to how package net/http/httptest provides utilities for HTTP testing.
+//
+package runtimetest
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"time"
+
+	"github.com/onflow/atree"
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// NewTestRuntime returns a new runtime,
+// and a new runtime interface which can be used to execute programs with it.
+//
+func NewTestRuntime(options ...runtime.Option) (runtime.Runtime, *TestRuntimeInterface) {
+	return runtime.NewInterpreterRuntime(options...), NewTestRuntimeInterface()
+}
+
+// TestRuntimeInterface is an implementation of runtime.Interface for tests.
+//
+// By default, all functions are safe no-ops, or use an in-memory implementation:
+// Storage, programs, and account contract code are kept in memory,
+// and logs and emitted events are recorded.
+//
+// Each function can be overridden by setting the corresponding `On` field.
+//
+type TestRuntimeInterface struct {
+	OnResolveLocation           func(identifiers []runtime.Identifier, location runtime.Location) ([]runtime.ResolvedLocation, error)
+	OnGetCode                   func(location runtime.Location) ([]byte, error)
+	OnGetProgram                func(location runtime.Location) (*interpreter.Program, error)
+	OnSetProgram                func(location runtime.Location, program *interpreter.Program) error
+	OnGetValue                  func(owner, key []byte) (value []byte, err error)
+	OnSetValue                  func(owner, key, value []byte) (err error)
+	OnValueExists               func(owner, key []byte) (exists bool, err error)
+	OnAllocateStorageIndex      func(owner []byte) (atree.StorageIndex, error)
+	OnCreateAccount             func(payer runtime.Address) (address runtime.Address, err error)
+	OnAddEncodedAccountKey      func(address runtime.Address, publicKey []byte) error
+	OnRevokeEncodedAccountKey   func(address runtime.Address, index int) (publicKey []byte, err error)
+	OnAddAccountKey             func(address runtime.Address, publicKey *runtime.PublicKey, hashAlgo runtime.HashAlgorithm, weight int) (*runtime.AccountKey, error)
+	OnGetAccountKey             func(address runtime.Address, index int) (*runtime.AccountKey, error)
+	OnRevokeAccountKey          func(address runtime.Address, index int) (*runtime.AccountKey, error)
+	OnUpdateAccountContractCode func(address runtime.Address, name string, code []byte) error
+	OnGetAccountContractCode    func(address runtime.Address, name string) (code []byte, err error)
+	OnRemoveAccountContractCode func(address runtime.Address, name string) error
+	OnGetSigningAccounts        func() ([]runtime.Address, error)
+	OnProgramLog                func(message string) error
+	OnEmitEvent                 func(event cadence.Event) error
+	OnGenerateUUID              func() (uint64, error)
+	OnMeterComputation          func(compKind common.ComputationKind, intensity uint) error
+	OnMeterMemory               func(usage common.MemoryUsage) error
+	OnDecodeArgument            func(argument []byte, argumentType cadence.Type) (cadence.Value, error)
+	OnGetCurrentBlockHeight     func() (uint64, error)
+	OnGetBlockAtHeight          func(height uint64) (block runtime.Block, exists bool, err error)
+	OnUnsafeRandom              func() (uint64, error)
+	OnVerifySignature           func(
+		signature []byte,
+		tag string,
+		signedData []byte,
+		publicKey []byte,
+		signatureAlgorithm runtime.SignatureAlgorithm,
+		hashAlgorithm runtime.HashAlgorithm,
+	) (bool, error)
+	OnHash                       func(data []byte, tag string, hashAlgorithm runtime.HashAlgorithm) ([]byte, error)
+	OnGetAccountBalance          func(address common.Address) (value uint64, err error)
+	OnGetAccountAvailableBalance func(address common.Address) (value uint64, err error)
+	OnGetStorageUsed             func(address runtime.Address) (value uint64, err error)
+	OnGetStorageCapacity         func(address runtime.Address) (value uint64, err error)
+	OnImplementationDebugLog     func(message string) error
+	OnValidatePublicKey          func(key *runtime.PublicKey) error
+	OnGetAccountContractNames    func(address runtime.Address) ([]string, error)
+	OnRecordTrace                func(operation string, location common.Location, duration time.Duration, logs []opentracing.LogRecord)
+	OnBLSVerifyPOP               func(pk *runtime.PublicKey, s []byte) (bool, error)
+	OnBLSAggregateSignatures     func(sigs [][]byte) ([]byte, error)
+	OnBLSAggregatePublicKeys     func(keys []*runtime.PublicKey) (*runtime.PublicKey, error)
+	OnResourceOwnerChanged       func(
+		interpreter *interpreter.Interpreter,
+		resource *interpreter.CompositeValue,
+		oldOwner common.Address,
+		newOwner common.Address,
+	)
+
+	// Logs are the messages logged by programs, in order
+	Logs []string
+	// Events are the events emitted by programs, in order
+	Events []cadence.Event
+
+	programs       map[common.LocationID]*interpreter.Program
+	storedValues   map[string][]byte
+	storageIndices map[string]uint64
+	contractCodes  map[string][]byte
+	nextAddress    uint64
+	uuid           uint64
+}
+
+var _ runtime.Interface = &TestRuntimeInterface{}
+
+// NewTestRuntimeInterface returns a new runtime interface for tests,
+// which has all functions set to their defaults.
+//
+func NewTestRuntimeInterface() *TestRuntimeInterface {
+	return &TestRuntimeInterface{
+		programs:       map[common.LocationID]*interpreter.Program{},
+		storedValues:   map[string][]byte{},
+		storageIndices: map[string]uint64{},
+		contractCodes:  map[string][]byte{},
+	}
+}
+
+func storageKey(owner, key string) string {
+	return strings.Join([]string{owner, key}, "|")
+}
+
+func (i *TestRuntimeInterface) ResolveLocation(
+	identifiers []runtime.Identifier,
+	location runtime.Location,
+) ([]runtime.ResolvedLocation, error) {
+	if i.OnResolveLocation != nil {
+		return i.OnResolveLocation(identifiers, location)
+	}
+
+	// Resolve each imported identifier of an address location
+	// to the contract with the same name in the account
+
+	addressLocation, ok := location.(common.AddressLocation)
+	if ok && len(identifiers) > 0 {
+		resolvedLocations := make([]runtime.ResolvedLocation, len(identifiers))
+		for index, identifier := range identifiers {
+			resolvedLocations[index] = runtime.ResolvedLocation{
+				Location: common.AddressLocation{
+					Address: addressLocation.Address,
+					Name:    identifier.Identifier,
+				},
+				Identifiers: []runtime.Identifier{identifier},
+			}
+		}
+		return resolvedLocations, nil
+	}
+
+	return []runtime.ResolvedLocation{
+		{
+			Location:    location,
+			Identifiers: identifiers,
+		},
+	}, nil
+}
+
+func (i *TestRuntimeInterface) GetCode(location runtime.Location) ([]byte, error) {
+	if i.OnGetCode != nil {
+		return i.OnGetCode(location)
+	}
+	if addressLocation, ok := location.(common.AddressLocation); ok {
+		return i.contractCodes[storageKey(string(addressLocation.Address[:]), addressLocation.Name)], nil
+	}
+	return nil, nil
+}
+
+func (i *TestRuntimeInterface) GetProgram(location runtime.Location) (*interpreter.Program, error) {
+	if i.OnGetProgram != nil {
+		return i.OnGetProgram(location)
+	}
+	return i.programs[location.ID()], nil
+}
+
+func (i *TestRuntimeInterface) SetProgram(location runtime.Location, program *interpreter.Program) error {
+	if i.OnSetProgram != nil {
+		return i.OnSetProgram(location, program)
+	}
+	i.programs[location.ID()] = program
+	return nil
+}
+
+func (i *TestRuntimeInterface) GetValue(owner, key []byte) (value []byte, err error) {
+	if i.OnGetValue != nil {
+		return i.OnGetValue(owner, key)
+	}
+	return i.storedValues[storageKey(string(owner), string(key))], nil
+}
+
+func (i *TestRuntimeInterface) SetValue(owner, key, value []byte) (err error) {
+	if i.OnSetValue != nil {
+		return i.OnSetValue(owner, key, value)
+	}
+	i.storedValues[storageKey(string(owner), string(key))] = value
+	return nil
+}
+
+func (i *TestRuntimeInterface) ValueExists(owner, key []byte) (exists bool, err error) {
+	if i.OnValueExists != nil {
+		return i.OnValueExists(owner, key)
+	}
+	return len(i.storedValues[storageKey(string(owner), string(key))]) > 0, nil
+}
+
+func (i *TestRuntimeInterface) AllocateStorageIndex(owner []byte) (result atree.StorageIndex, err error) {
+	if i.OnAllocateStorageIndex != nil {
+		return i.OnAllocateStorageIndex(owner)
+	}
+	index := i.storageIndices[string(owner)] + 1
+	i.storageIndices[string(owner)] = index
+	binary.BigEndian.PutUint64(result[:], index)
+	return
+}
+
+func (i *TestRuntimeInterface) CreateAccount(payer runtime.Address) (address runtime.Address, err error) {
+	if i.OnCreateAccount != nil {
+		return i.OnCreateAccount(payer)
+	}
+	i.nextAddress++
+	binary.BigEndian.PutUint64(address[:], i.nextAddress)
+	return
+}
+
+func (i *TestRuntimeInterface) AddEncodedAccountKey(address runtime.Address, publicKey []byte) error {
+	if i.OnAddEncodedAccountKey != nil {
+		return i.OnAddEncodedAccountKey(address, publicKey)
+	}
+	return nil
+}
+
+func (i *TestRuntimeInterface) RevokeEncodedAccountKey(address runtime.Address, index int) ([]byte, error) {
+	if i.OnRevokeEncodedAccountKey != nil {
+		return i.OnRevokeEncodedAccountKey(address, index)
+	}
+	return nil, nil
+}
+
+func (i *TestRuntimeInterface) AddAccountKey(
+	address runtime.Address,
+	publicKey *runtime.PublicKey,
+	hashAlgo runtime.HashAlgorithm,
+	weight int,
+) (*runtime.AccountKey, error) {
+	if i.OnAddAccountKey != nil {
+		return i.OnAddAccountKey(address, publicKey, hashAlgo, weight)
+	}
+	return &runtime.AccountKey{
+		PublicKey: publicKey,
+		HashAlgo:  hashAlgo,
+		Weight:    weight,
+	}, nil
+}
+
+func (i *TestRuntimeInterface) GetAccountKey(address runtime.Address, index int) (*runtime.AccountKey, error) {
+	if i.OnGetAccountKey != nil {
+		return i.OnGetAccountKey(address, index)
+	}
+	return nil, nil
+}
+
+func (i *TestRuntimeInterface) RevokeAccountKey(address runtime.Address, index int) (*runtime.AccountKey, error) {
+	if i.OnRevokeAccountKey != nil {
+		return i.OnRevokeAccountKey(address, index)
+	}
+	return nil, nil
+}
+
+func (i *TestRuntimeInterface) UpdateAccountContractCode(address runtime.Address, name string, code []byte) error {
+	if i.OnUpdateAccountContractCode != nil {
+		return i.OnUpdateAccountContractCode(address, name, code)
+	}
+	i.contractCodes[storageKey(string(address[:]), name)] = code
+	return nil
+}
+
+func (i *TestRuntimeInterface) GetAccountContractCode(address runtime.Address, name string) ([]byte, error) {
+	if i.OnGetAccountContractCode != nil {
+		return i.OnGetAccountContractCode(address, name)
+	}
+	return i.contractCodes[storageKey(string(address[:]), name)], nil
+}
+
+func (i *TestRuntimeInterface) RemoveAccountContractCode(address runtime.Address, name string) error {
+	if i.OnRemoveAccountContractCode != nil {
+		return i.OnRemoveAccountContractCode(address, name)
+	}
+	delete(i.contractCodes, storageKey(string(address[:]), name))
+	return nil
+}
+
+func (i *TestRuntimeInterface) GetSigningAccounts() ([]runtime.Address, error) {
+	if i.OnGetSigningAccounts != nil {
+		return i.OnGetSigningAccounts()
+	}
+	return nil, nil
+}
+
+func (i *TestRuntimeInterface) ProgramLog(message string) error {
+	i.Logs = append(i.Logs, message)
+	if i.OnProgramLog != nil {
+		return i.OnProgramLog(message)
+	}
+	return nil
+}
+
+func (i *TestRuntimeInterface) EmitEvent(event cadence.Event) error {
+	i.Events = append(i.Events, event)
+	if i.OnEmitEvent != nil {
+		return i.OnEmitEvent(event)
+	}
+	return nil
+}
+
+func (i *TestRuntimeInterface) GenerateUUID() (uint64, error) {
+	if i.OnGenerateUUID != nil {
+		return i.OnGenerateUUID()
+	}
+	i.uuid++
+	return i.uuid, nil
+}
+
+func (i *TestRuntimeInterface) MeterComputation(compKind common.ComputationKind, intensity uint) error {
+	if i.OnMeterComputation != nil {
+		return i.OnMeterComputation(compKind, intensity)
+	}
+	return nil
+}
+
+func (i *TestRuntimeInterface) MeterMemory(usage common.MemoryUsage) error {
+	if i.OnMeterMemory != nil {
+		return i.OnMeterMemory(usage)
+	}
+	return nil
+}
+
+func (i *TestRuntimeInterface) DecodeArgument(argument []byte, argumentType cadence.Type) (cadence.Value, error) {
+	if i.OnDecodeArgument != nil {
+		return i.OnDecodeArgument(argument, argumentType)
+	}
+	return jsoncdc.Decode(argument)
+}
+
+func (i *TestRuntimeInterface) GetCurrentBlockHeight() (uint64, error) {
+	if i.OnGetCurrentBlockHeight != nil {
+		return i.OnGetCurrentBlockHeight()
+	}
+	return 1, nil
+}
+
+func (i *TestRuntimeInterface) GetBlockAtHeight(height uint64) (block runtime.Block, exists bool, err error) {
+	if i.OnGetBlockAtHeight != nil {
+		return i.OnGetBlockAtHeight(height)
+	}
+
+	buf := new(bytes.Buffer)
+	err = binary.Write(buf, binary.BigEndian, height)
+	if err != nil {
+		return
+	}
+
+	encoded := buf.Bytes()
+	var hash runtime.BlockHash
+	copy(hash[sema.BlockIDSize-len(encoded):], encoded)
+
+	block = runtime.Block{
+		Height:    height,
+		View:      height,
+		Hash:      hash,
+		Timestamp: time.Unix(int64(height), 0).UnixNano(),
+	}
+	return block, true, nil
+}
+
+func (i *TestRuntimeInterface) UnsafeRandom() (uint64, error) {
+	if i.OnUnsafeRandom != nil {
+		return i.OnUnsafeRandom()
+	}
+	return 0, nil
+}
+
+func (i *TestRuntimeInterface) VerifySignature(
+	signature []byte,
+	tag string,
+	signedData []byte,
+	publicKey []byte,
+	signatureAlgorithm runtime.SignatureAlgorithm,
+	hashAlgorithm runtime.HashAlgorithm,
+) (bool, error) {
+	if i.OnVerifySignature != nil {
+		return i.OnVerifySignature(
+			signature,
+			tag,
+			signedData,
+			publicKey,
+			signatureAlgorithm,
+			hashAlgorithm,
+		)
+	}
+	return false, nil
+}
+
+func (i *TestRuntimeInterface) Hash(data []byte, tag string, hashAlgorithm runtime.HashAlgorithm) ([]byte, error) {
+	if i.OnHash != nil {
+		return i.OnHash(data, tag, hashAlgorithm)
+	}
+	return nil, nil
+}
+
+func (i *TestRuntimeInterface) GetAccountBalance(address common.Address) (uint64, error) {
+	if i.OnGetAccountBalance != nil {
+		return i.OnGetAccountBalance(address)
+	}
+	return 0, nil
+}
+
+func (i *TestRuntimeInterface) GetAccountAvailableBalance(address common.Address) (uint64, error) {
+	if i.OnGetAccountAvailableBalance != nil {
+		return i.OnGetAccountAvailableBalance(address)
+	}
+	return 0, nil
+}
+
+func (i *TestRuntimeInterface) GetStorageUsed(address runtime.Address) (uint64, error) {
+	if i.OnGetStorageUsed != nil {
+		return i.OnGetStorageUsed(address)
+	}
+	return 0, nil
+}
+
+func (i *TestRuntimeInterface) GetStorageCapacity(address runtime.Address) (uint64, error) {
+	if i.OnGetStorageCapacity != nil {
+		return i.OnGetStorageCapacity(address)
+	}
+	return 0, nil
+}
+
+func (i *TestRuntimeInterface) ImplementationDebugLog(message string) error {
+	if i.OnImplementationDebugLog != nil {
+		return i.OnImplementationDebugLog(message)
+	}
+	return nil
+}
+
+func (i *TestRuntimeInterface) ValidatePublicKey(key *runtime.PublicKey) error {
+	if i.OnValidatePublicKey != nil {
+		return i.OnValidatePublicKey(key)
+	}
+	return nil
+}
+
+func (i *TestRuntimeInterface) GetAccountContractNames(address runtime.Address) ([]string, error) {
+	if i.OnGetAccountContractNames != nil {
+		return i.OnGetAccountContractNames(address)
+	}
+	return []string{}, nil
+}
+
+func (i *TestRuntimeInterface) RecordTrace(
+	operation string,
+	location common.Location,
+	duration time.Duration,
+	logs []opentracing.LogRecord,
+) {
+	if i.OnRecordTrace != nil {
+		i.OnRecordTrace(operation, location, duration, logs)
+	}
+}
+
+func (i *TestRuntimeInterface) BLSVerifyPOP(pk *runtime.PublicKey, s []byte) (bool, error) {
+	if i.OnBLSVerifyPOP != nil {
+		return i.OnBLSVerifyPOP(pk, s)
+	}
+	return false, nil
+}
+
+func (i *TestRuntimeInterface) BLSAggregateSignatures(sigs [][]byte) ([]byte, error) {
+	if i.OnBLSAggregateSignatures != nil {
+		return i.OnBLSAggregateSignatures(sigs)
+	}
+	return []byte{}, nil
+}
+
+func (i *TestRuntimeInterface) BLSAggregatePublicKeys(keys []*runtime.PublicKey) (*runtime.PublicKey, error) {
+	if i.OnBLSAggregatePublicKeys != nil {
+		return i.OnBLSAggregatePublicKeys(keys)
+	}
+	return nil, nil
+}
+
+func (i *TestRuntimeInterface) ResourceOwnerChanged(
+	interpreter *interpreter.Interpreter,
+	resource *interpreter.CompositeValue,
+	oldOwner common.Address,
+	newOwner common.Address,
+) {
+	if i.OnResourceOwnerChanged != nil {
+		i.OnResourceOwnerChanged(interpreter, resource, oldOwner, newOwner)
+	}
+}
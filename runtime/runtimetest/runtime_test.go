@@ -0,0 +1,166 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtimetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func TestNewTestRuntime(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("script", func(t *testing.T) {
+
+		t.Parallel()
+
+		rt, runtimeInterface := NewTestRuntime()
+
+		result, err := rt.ExecuteScript(
+			runtime.Script{
+				Source: []byte(`
+                  pub fun main(): Int {
+                      log("hello")
+                      return 42
+                  }
+                `),
+			},
+			runtime.Context{
+				Interface: runtimeInterface,
+				Location:  utils.TestLocation,
+			},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, cadence.NewInt(42), result)
+		assert.Equal(t, []string{`"hello"`}, runtimeInterface.Logs)
+	})
+
+	t.Run("transaction", func(t *testing.T) {
+
+		t.Parallel()
+
+		rt, runtimeInterface := NewTestRuntime()
+
+		address := common.MustBytesToAddress([]byte{0x1})
+
+		runtimeInterface.OnGetSigningAccounts = func() ([]runtime.Address, error) {
+			return []runtime.Address{address}, nil
+		}
+
+		err := rt.ExecuteTransaction(
+			runtime.Script{
+				Source: []byte(`
+                  transaction {
+                      prepare(signer: AuthAccount) {
+                          signer.save(42, to: /storage/answer)
+                      }
+                  }
+                `),
+			},
+			runtime.Context{
+				Interface: runtimeInterface,
+				Location:  common.TransactionLocation{0x1},
+			},
+		)
+		require.NoError(t, err)
+
+		err = rt.ExecuteTransaction(
+			runtime.Script{
+				Source: []byte(`
+                  transaction {
+                      prepare(signer: AuthAccount) {
+                          log(signer.load<Int>(from: /storage/answer))
+                      }
+                  }
+                `),
+			},
+			runtime.Context{
+				Interface: runtimeInterface,
+				Location:  common.TransactionLocation{0x2},
+			},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"42"}, runtimeInterface.Logs)
+	})
+
+	t.Run("contract", func(t *testing.T) {
+
+		t.Parallel()
+
+		rt, runtimeInterface := NewTestRuntime()
+
+		address := common.MustBytesToAddress([]byte{0x1})
+
+		runtimeInterface.OnGetSigningAccounts = func() ([]runtime.Address, error) {
+			return []runtime.Address{address}, nil
+		}
+
+		err := rt.ExecuteTransaction(
+			runtime.Script{
+				Source: utils.DeploymentTransaction(
+					"Test",
+					[]byte(`
+                      pub contract Test {
+                          pub fun answer(): Int {
+                              return 42
+                          }
+                      }
+                    `),
+				),
+			},
+			runtime.Context{
+				Interface: runtimeInterface,
+				Location:  common.TransactionLocation{0x1},
+			},
+		)
+		require.NoError(t, err)
+
+		require.Len(t, runtimeInterface.Events, 1)
+		assert.Equal(t, "flow.AccountContractAdded", runtimeInterface.Events[0].EventType.ID())
+
+		result, err := rt.ExecuteScript(
+			runtime.Script{
+				Source: []byte(`
+                  import Test from 0x1
+
+                  pub fun main(): Int {
+                      return Test.answer()
+                  }
+                `),
+			},
+			runtime.Context{
+				Interface: runtimeInterface,
+				Location:  utils.TestLocation,
+			},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, cadence.NewInt(42), result)
+	})
+}
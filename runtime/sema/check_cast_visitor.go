@@ -169,6 +169,10 @@ func (d *CheckCastVisitor) VisitStringExpression(_ *ast.StringExpression) ast.Re
 	return d.isTypeRedundant(StringType, d.targetType)
 }
 
+func (d *CheckCastVisitor) VisitStringInterpolationExpression(_ *ast.StringInterpolationExpression) ast.Repr {
+	return d.isTypeRedundant(StringType, d.targetType)
+}
+
 func (d *CheckCastVisitor) VisitCastingExpression(_ *ast.CastingExpression) ast.Repr {
 	// This is already covered under Case-I: where expected type is same as casted type.
 	// So skip checking it here to avid duplicate errors.
@@ -183,6 +187,14 @@ func (d *CheckCastVisitor) VisitDestroyExpression(_ *ast.DestroyExpression) ast.
 	return d.isTypeRedundant(d.exprInferredType, d.targetType)
 }
 
+func (d *CheckCastVisitor) VisitAttachExpression(_ *ast.AttachExpression) ast.Repr {
+	return d.isTypeRedundant(d.exprInferredType, d.targetType)
+}
+
+func (d *CheckCastVisitor) VisitRemoveExpression(_ *ast.RemoveExpression) ast.Repr {
+	return d.isTypeRedundant(d.exprInferredType, d.targetType)
+}
+
 func (d *CheckCastVisitor) VisitReferenceExpression(_ *ast.ReferenceExpression) ast.Repr {
 	return d.isTypeRedundant(d.exprInferredType, d.targetType)
 }
@@ -1812,7 +1812,7 @@ func (checker *Checker) checkCompositeFunctions(
 			)
 		}()
 
-		if function.FunctionBlock == nil {
+		if function.FunctionBlock == nil && !function.IsNative {
 			checker.report(
 				&MissingFunctionBodyError{
 					Pos: function.EndPosition(),
@@ -1921,6 +1921,19 @@ func (checker *Checker) VisitEnumCaseDeclaration(_ *ast.EnumCaseDeclaration) ast
 	panic(errors.NewUnreachableError())
 }
 
+func (checker *Checker) VisitAttachmentDeclaration(declaration *ast.AttachmentDeclaration) ast.Repr {
+	// TODO: support attachments
+
+	checker.report(
+		&UnsupportedDeclarationError{
+			DeclarationKind: declaration.DeclarationKind(),
+			Range:           ast.NewRangeFromPositioned(declaration),
+		},
+	)
+
+	return nil
+}
+
 // checkUnknownSpecialFunctions checks that the special function declarations
 // are supported, i.e., they are either initializers or destructors
 //
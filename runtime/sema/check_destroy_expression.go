@@ -22,13 +22,26 @@ import (
 	"github.com/onflow/cadence/runtime/ast"
 )
 
-func (checker *Checker) VisitDestroyExpression(expression *ast.DestroyExpression) (resultType ast.Repr) {
-	resultType = VoidType
+func (checker *Checker) VisitDestroyExpression(expression *ast.DestroyExpression) ast.Repr {
+	checker.checkDestroy(expression.Expression)
 
-	valueType := checker.VisitExpression(expression.Expression, nil)
+	return VoidType
+}
+
+func (checker *Checker) VisitDestroyStatement(statement *ast.DestroyStatement) ast.Repr {
+	checker.checkDestroy(statement.Expression)
+
+	return nil
+}
+
+// checkDestroy checks that the destroyed expression has a resource type,
+// and invalidates the destroyed resource
+//
+func (checker *Checker) checkDestroy(expression ast.Expression) {
+	valueType := checker.VisitExpression(expression, nil)
 
 	checker.recordResourceInvalidation(
-		expression.Expression,
+		expression,
 		valueType,
 		ResourceInvalidationKindDestroy,
 	)
@@ -43,12 +56,8 @@ func (checker *Checker) VisitDestroyExpression(expression *ast.DestroyExpression
 
 		checker.report(
 			&InvalidDestructionError{
-				Range: ast.NewRangeFromPositioned(expression.Expression),
+				Range: ast.NewRangeFromPositioned(expression),
 			},
 		)
-
-		return
 	}
-
-	return
 }
@@ -30,6 +30,8 @@ func (checker *Checker) VisitIdentifierExpression(expression *ast.IdentifierExpr
 		return InvalidType
 	}
 
+	checker.recordVariableUse(variable)
+
 	valueType := variable.Type
 
 	if valueType.IsResourceType() {
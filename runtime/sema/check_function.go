@@ -61,6 +61,10 @@ func (checker *Checker) visitFunctionDeclaration(
 		true,
 	)
 
+	if declaration.IsNative {
+		checker.checkNativeFunctionDeclaration(declaration)
+	}
+
 	// global functions were previously declared, see `declareFunctionDeclaration`
 
 	functionType := checker.Elaboration.FunctionDeclarationFunctionTypes[declaration]
@@ -87,6 +91,28 @@ func (checker *Checker) visitFunctionDeclaration(
 	return nil
 }
 
+// checkNativeFunctionDeclaration checks that the given native function declaration
+// is declared in the standard library, i.e. in an identifier location.
+//
+// Native functions are implemented by the host environment,
+// so user programs must not declare them.
+//
+func (checker *Checker) checkNativeFunctionDeclaration(declaration *ast.FunctionDeclaration) {
+	if _, ok := checker.Location.(common.IdentifierLocation); ok {
+		return
+	}
+
+	checker.report(
+		&InvalidNativeFunctionDeclarationError{
+			Location: checker.Location,
+			Range: ast.Range{
+				StartPos: declaration.StartPos,
+				EndPos:   declaration.Identifier.EndPosition(),
+			},
+		},
+	)
+}
+
 func (checker *Checker) declareFunctionDeclaration(
 	declaration *ast.FunctionDeclaration,
 	functionType *FunctionType,
@@ -129,6 +155,23 @@ func (checker *Checker) checkFunction(
 		checker.checkTypeAnnotation(functionType.ReturnTypeAnnotation, returnTypeAnnotation)
 	}
 
+	// Skip checking the function body if it was already checked without errors,
+	// see CheckerSession
+
+	functionBodyKey, cacheable := checker.functionBodyCache.key(functionBlock)
+	if cacheable {
+		if checker.functionBodyCache.isChecked(functionBodyKey) {
+			return
+		}
+
+		errorCount := len(checker.errors)
+		defer func() {
+			if len(checker.errors) == errorCount {
+				checker.functionBodyCache.markChecked(functionBodyKey)
+			}
+		}()
+	}
+
 	// Reset the returning state and restore it when leaving
 
 	jumpedOrReturned := checker.resources.JumpsOrReturns
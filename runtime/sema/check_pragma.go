@@ -18,15 +18,29 @@
 
 package sema
 
-import "github.com/onflow/cadence/runtime/ast"
+import (
+	"fmt"
+
+	"github.com/onflow/cadence/runtime/ast"
+)
+
+const (
+	VersionPragmaName  = "version"
+	LanguagePragmaName = "language"
+)
+
+// knownPragmaNames are the names of the pragmas which are known to the checker.
+// Each of them requires exactly one string argument, e.g. `#version("1.0")`
+//
+var knownPragmaNames = map[string]struct{}{
+	VersionPragmaName:  {},
+	LanguagePragmaName: {},
+}
 
 func (checker *Checker) VisitPragmaDeclaration(p *ast.PragmaDeclaration) ast.Repr {
 
 	invocPragma, isInvocPragma := p.Expression.(*ast.InvocationExpression)
-	var isIdentPragma bool
-	if !isInvocPragma {
-		_, isIdentPragma = p.Expression.(*ast.IdentifierExpression)
-	}
+	identPragma, isIdentPragma := p.Expression.(*ast.IdentifierExpression)
 
 	// Pragma can be either an invocation expression or an identfier expression
 	if !(isInvocPragma || isIdentPragma) {
@@ -54,7 +68,42 @@ func (checker *Checker) VisitPragmaDeclaration(p *ast.PragmaDeclaration) ast.Rep
 				})
 			}
 		}
+
+		identPragma, isIdentPragma = invocPragma.InvokedExpression.(*ast.IdentifierExpression)
+	}
+
+	if isIdentPragma {
+		checker.checkPragmaName(p, identPragma.Identifier, invocPragma)
 	}
 
 	return nil
 }
+
+// checkPragmaName checks that the pragma with the given name is known,
+// and that the arguments of known pragmas are valid.
+// The invocation is nil if the pragma is just an identifier.
+//
+func (checker *Checker) checkPragmaName(
+	p *ast.PragmaDeclaration,
+	identifier ast.Identifier,
+	invocation *ast.InvocationExpression,
+) {
+	name := identifier.Identifier
+
+	if _, ok := knownPragmaNames[name]; !ok {
+		checker.hint(
+			&UnknownPragmaHint{
+				Name:  name,
+				Range: ast.NewRangeFromPositioned(identifier),
+			},
+		)
+		return
+	}
+
+	if invocation == nil || len(invocation.Arguments) != 1 {
+		checker.report(&InvalidPragmaError{
+			Message: fmt.Sprintf("`%s` requires exactly one string argument", name),
+			Range:   ast.NewRangeFromPositioned(p.Expression),
+		})
+	}
+}
@@ -0,0 +1,86 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+func (checker *Checker) VisitStringInterpolationExpression(expression *ast.StringInterpolationExpression) ast.Repr {
+
+	for _, segment := range expression.Segments {
+
+		// String segments are part of the literal, they are not checked
+
+		if _, ok := segment.(*ast.StringExpression); ok {
+			continue
+		}
+
+		valueType := checker.VisitExpression(segment, nil)
+
+		if valueType.IsInvalidType() ||
+			isStringInterpolatableType(valueType) {
+
+			continue
+		}
+
+		checker.report(
+			&InvalidStringInterpolationTypeError{
+				Type:  valueType,
+				Range: ast.NewRangeFromPositioned(segment),
+			},
+		)
+	}
+
+	return StringType
+}
+
+// isStringInterpolatableType returns true if values of the given type can be interpolated into a string,
+// i.e. if the type is `String`, or if it has a function `toString(): String`.
+//
+// Resources cannot be interpolated, as that would require moving them.
+//
+func isStringInterpolatableType(ty Type) bool {
+	if ty.IsResourceType() {
+		return false
+	}
+
+	if IsSubType(ty, StringType) {
+		return true
+	}
+
+	resolver, ok := ty.GetMembers()[ToStringFunctionName]
+	if !ok || resolver.Kind != common.DeclarationKindFunction {
+		return false
+	}
+
+	member := resolver.Resolve(ToStringFunctionName, ast.Range{}, func(error) {})
+	if member == nil {
+		return false
+	}
+
+	functionType, ok := member.TypeAnnotation.Type.(*FunctionType)
+	if !ok {
+		return false
+	}
+
+	return len(functionType.Parameters) == 0 &&
+		IsSubType(functionType.ReturnTypeAnnotation.Type, StringType)
+}
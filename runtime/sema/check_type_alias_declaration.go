@@ -0,0 +1,70 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"github.com/onflow/cadence/runtime/ast"
+)
+
+// VisitTypeAliasDeclaration checks a type alias declaration.
+//
+// NOTE: The alias itself was previously declared using `declareTypeAliasDeclaration`,
+// as type aliases are declared before all other declarations are checked.
+//
+func (checker *Checker) VisitTypeAliasDeclaration(declaration *ast.TypeAliasDeclaration) ast.Repr {
+
+	checker.checkDeclarationAccessModifier(
+		declaration.Access,
+		declaration.DeclarationKind(),
+		declaration.StartPos,
+		true,
+	)
+
+	return nil
+}
+
+// declareTypeAliasDeclaration declares the given type alias,
+// i.e. it declares a type with the alias' name, which refers to the target type.
+//
+// Aliases may only refer to types declared before them, e.g. to composite types,
+// interface types, and to previously declared aliases.
+//
+func (checker *Checker) declareTypeAliasDeclaration(declaration *ast.TypeAliasDeclaration) {
+
+	targetType := checker.ConvertType(declaration.TargetType)
+
+	checker.Elaboration.TypeAliasDeclarationTypes[declaration] = targetType
+
+	variable, err := checker.typeActivations.DeclareType(typeDeclaration{
+		identifier:               declaration.Identifier,
+		ty:                       targetType,
+		declarationKind:          declaration.DeclarationKind(),
+		access:                   declaration.Access,
+		docString:                declaration.DocString,
+		allowOuterScopeShadowing: false,
+	})
+	checker.report(err)
+
+	if checker.positionInfoEnabled {
+		checker.recordVariableDeclarationOccurrence(
+			declaration.Identifier.Identifier,
+			variable,
+		)
+	}
+}
@@ -0,0 +1,69 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"github.com/onflow/cadence/runtime/ast"
+)
+
+// recordVariableUse records that the given variable is used,
+// if unused variable hints are enabled
+//
+func (checker *Checker) recordVariableUse(variable *Variable) {
+	if !checker.unusedVariableHintsEnabled {
+		return
+	}
+
+	checker.usedVariables[variable] = struct{}{}
+}
+
+// checkUnusedVariable reports an UnusedVariableHint for the given local variable
+// when its scope is left, if the variable was never used.
+//
+// Variables named `_` are intentionally discarded and not reported.
+// Resource variables are not reported either,
+// as they must be moved or destroyed, and a ResourceLossError is reported otherwise.
+//
+func (checker *Checker) checkUnusedVariable(identifier ast.Identifier, variable *Variable) {
+	if !checker.unusedVariableHintsEnabled ||
+		variable == nil ||
+		identifier.Identifier == "_" ||
+		!checker.functionActivations.IsLocal() ||
+		variable.Type.IsResourceType() {
+
+		return
+	}
+
+	activation := checker.valueActivations.Current()
+	activation.LeaveCallbacks = append(
+		activation.LeaveCallbacks,
+		func(_ func() ast.Position) {
+			if _, ok := checker.usedVariables[variable]; ok {
+				return
+			}
+
+			checker.hint(
+				&UnusedVariableHint{
+					Name:  identifier.Identifier,
+					Range: ast.NewRangeFromPositioned(identifier),
+				},
+			)
+		},
+	)
+}
@@ -173,8 +173,15 @@ func (checker *Checker) visitVariableDeclaration(declaration *ast.VariableDeclar
 
 	// Finally, declare the variable in the current value activation
 
+	if declaration.Pattern != nil {
+		checker.declareTuplePatternVariables(declaration)
+		return
+	}
+
 	identifier := declaration.Identifier.Identifier
 
+	checker.checkShadowedVariable(declaration.Identifier)
+
 	variable, err := checker.valueActivations.Declare(variableDeclaration{
 		identifier:               identifier,
 		ty:                       declarationType,
@@ -188,6 +195,8 @@ func (checker *Checker) visitVariableDeclaration(declaration *ast.VariableDeclar
 	})
 	checker.report(err)
 
+	checker.checkUnusedVariable(declaration.Identifier, variable)
+
 	if checker.positionInfoEnabled {
 		checker.recordVariableDeclarationOccurrence(identifier, variable)
 		checker.recordVariableDeclarationRange(declaration, identifier, declarationType)
@@ -237,3 +246,32 @@ func (checker *Checker) elaborateNestedResourceMoveExpression(expression ast.Exp
 		checker.Elaboration.IsNestedResourceMoveExpression[expression] = struct{}{}
 	}
 }
+
+// declareTuplePatternVariables reports that destructuring declarations are not supported yet,
+// as there are no tuple types the value could be destructured into.
+//
+// The variables of the pattern are still declared, with the invalid type,
+// to avoid follow-up errors for their uses
+//
+func (checker *Checker) declareTuplePatternVariables(declaration *ast.VariableDeclaration) {
+
+	checker.report(
+		&UnsupportedDestructuringError{
+			Range: declaration.Pattern.Range,
+		},
+	)
+
+	for _, identifier := range declaration.Pattern.Identifiers() {
+		_, err := checker.valueActivations.Declare(variableDeclaration{
+			identifier:               identifier.Identifier,
+			ty:                       InvalidType,
+			access:                   declaration.Access,
+			kind:                     declaration.DeclarationKind(),
+			pos:                      identifier.Pos,
+			isConstant:               declaration.IsConstant,
+			argumentLabels:           nil,
+			allowOuterScopeShadowing: true,
+		})
+		checker.report(err)
+	}
+}
@@ -78,6 +78,9 @@ type ImportHandlerFunc func(checker *Checker, importedLocation common.Location,
 
 type MemberAccountAccessHandlerFunc func(checker *Checker, memberLocation common.Location) bool
 
+// OnMeterMemoryFunc is a function that is called when some memory is about to be used.
+type OnMeterMemoryFunc func(usage common.MemoryUsage)
+
 // Checker
 
 type Checker struct {
@@ -116,8 +119,20 @@ type Checker struct {
 	expectedType                       Type
 	memberAccountAccessHandler         MemberAccountAccessHandlerFunc
 	lintEnabled                        bool
+	maxNestingDepth                    int
+	expressionDepth                    int
+	leftOperand                        ast.Expression
+	unusedVariableHintsEnabled         bool
+	usedVariables                      map[*Variable]struct{}
+	functionBodyCache                  *functionBodyCache
+	onMeterMemory                      OnMeterMemoryFunc
 }
 
+// DefaultMaxNestingDepth is the default maximum nesting depth of expressions.
+// See WithMaxNestingDepth.
+//
+const DefaultMaxNestingDepth = 1024
+
 type Option func(*Checker) error
 
 func WithPredeclaredValues(predeclaredValues []ValueDeclaration) Option {
@@ -246,6 +261,42 @@ func WithLintingEnabled(enabled bool) Option {
 	}
 }
 
+// WithUnusedVariableHintsEnabled returns a checker option which enables/disables
+// hints for local variables which are declared, but never used.
+//
+func WithUnusedVariableHintsEnabled(enabled bool) Option {
+	return func(checker *Checker) error {
+		checker.unusedVariableHintsEnabled = enabled
+		if enabled {
+			checker.usedVariables = map[*Variable]struct{}{}
+		}
+		return nil
+	}
+}
+
+// WithMaxNestingDepth returns a checker option which sets the maximum nesting depth of expressions.
+//
+// Expressions which are nested deeper are not checked, and a NestingDepthExceededError is reported,
+// instead of exhausting the stack. A depth of zero disables the limit.
+// The default is DefaultMaxNestingDepth.
+//
+func WithMaxNestingDepth(depth int) Option {
+	return func(checker *Checker) error {
+		checker.maxNestingDepth = depth
+		return nil
+	}
+}
+
+// WithOnMeterMemoryFuncHandler returns a checker option which sets
+// the given function as the meter memory handler.
+//
+func WithOnMeterMemoryFuncHandler(handler OnMeterMemoryFunc) Option {
+	return func(checker *Checker) error {
+		checker.onMeterMemory = handler
+		return nil
+	}
+}
+
 func NewChecker(program *ast.Program, location common.Location, options ...Option) (*Checker, error) {
 
 	if location == nil {
@@ -269,6 +320,7 @@ func NewChecker(program *ast.Program, location common.Location, options ...Optio
 		functionActivations: functionActivations,
 		containerTypes:      map[Type]bool{},
 		Elaboration:         NewElaboration(),
+		maxNestingDepth:     DefaultMaxNestingDepth,
 	}
 
 	checker.beforeExtractor = NewBeforeExtractor(checker.report)
@@ -299,6 +351,7 @@ func (checker *Checker) SubChecker(program *ast.Program, location common.Locatio
 		WithCheckHandler(checker.checkHandler),
 		WithImportHandler(checker.importHandler),
 		WithLocationHandler(checker.locationHandler),
+		WithMaxNestingDepth(checker.maxNestingDepth),
 	)
 }
 
@@ -411,6 +464,12 @@ func (checker *Checker) hint(hint Hint) {
 	checker.hints = append(checker.hints, hint)
 }
 
+func (checker *Checker) UseMemory(usage common.MemoryUsage) {
+	if checker.onMeterMemory != nil {
+		checker.onMeterMemory(usage)
+	}
+}
+
 func (checker *Checker) UserDefinedValues() map[string]*Variable {
 	variables := map[string]*Variable{}
 
@@ -476,6 +535,14 @@ func (checker *Checker) VisitProgram(program *ast.Program) ast.Repr {
 		VisitThisAndNested(compositeType, registerInElaboration)
 	}
 
+	// Declare type aliases.
+	// NOTE: after interface and composite types, so aliases may refer to them,
+	// but before their members, so members may refer to aliases
+
+	for _, declaration := range program.TypeAliasDeclarations() {
+		checker.declareTypeAliasDeclaration(declaration)
+	}
+
 	// Declare interfaces' and composites' members
 
 	for _, declaration := range program.InterfaceDeclarations() {
@@ -899,8 +966,18 @@ func (checker *Checker) findAndCheckValueVariable(identifierExpression *ast.Iden
 	return variable
 }
 
+// staticTypeMemoryUsage is the memory usage of a single converted type
+var staticTypeMemoryUsage = common.MemoryUsage{
+	Kind:   common.MemoryKindStaticType,
+	Amount: 1,
+}
+
 // ConvertType converts an AST type representation to a sema type
 func (checker *Checker) ConvertType(t ast.Type) Type {
+	if t != nil {
+		checker.UseMemory(staticTypeMemoryUsage)
+	}
+
 	switch t := t.(type) {
 	case *ast.NominalType:
 		return checker.convertNominalType(t)
@@ -2371,6 +2448,23 @@ func (checker *Checker) VisitExpression(expr ast.Expression, expectedType Type)
 	return actualType
 }
 
+// VisitExpressionWithoutEffects checks the given expression like VisitExpression,
+// but without affecting later checks:
+// The expression is checked in a new value scope and with a copy of the resources,
+// so e.g. a move of a resource in the expression does not invalidate the resource.
+//
+func (checker *Checker) VisitExpressionWithoutEffects(expr ast.Expression, expectedType Type) Type {
+	checker.enterValueScope()
+	defer checker.leaveValueScope(expr.EndPosition, false)
+
+	return checker.checkWithResources(
+		func() Type {
+			return checker.VisitExpression(expr, expectedType)
+		},
+		checker.resources.Clone(),
+	)
+}
+
 func (checker *Checker) visitExpression(expr ast.Expression, expectedType Type) (visibleType Type, actualType Type) {
 	return checker.visitExpressionWithForceType(expr, expectedType, true)
 }
@@ -2393,12 +2487,64 @@ func (checker *Checker) VisitExpressionWithForceType(expr ast.Expression, expect
 //               used as the type of the expression to avoid the type errors being delegated up.
 // actualType  - The actual type of the expression.
 //
+// leftOperand returns the left operand of the given expression, if any,
+// i.e. the sub-expression the parser parses before the expression's operator
+//
+func leftOperand(expr ast.Expression) ast.Expression {
+	switch expr := expr.(type) {
+	case *ast.BinaryExpression:
+		return expr.Left
+	case *ast.CastingExpression:
+		return expr.Expression
+	case *ast.InvocationExpression:
+		return expr.InvokedExpression
+	case *ast.IndexExpression:
+		return expr.TargetExpression
+	case *ast.MemberExpression:
+		return expr.Expression
+	case *ast.ForceExpression:
+		return expr.Expression
+	case *ast.ConditionalExpression:
+		return expr.Test
+	default:
+		return nil
+	}
+}
+
 func (checker *Checker) visitExpressionWithForceType(
 	expr ast.Expression,
 	expectedType Type,
 	forceType bool,
 ) (visibleType Type, actualType Type) {
 
+	// Count the nesting depth like the parser does:
+	// The left operand of an expression, e.g. of a binary expression,
+	// is parsed iteratively, so it has the same depth as the expression itself.
+	// This allows long flat chains, like `a + a + ... + a`.
+
+	if expr != checker.leftOperand {
+		checker.expressionDepth++
+		defer func() {
+			checker.expressionDepth--
+		}()
+
+		if checker.maxNestingDepth > 0 && checker.expressionDepth > checker.maxNestingDepth {
+			checker.report(
+				&NestingDepthExceededError{
+					MaxDepth: checker.maxNestingDepth,
+					Range:    ast.NewRangeFromPositioned(expr),
+				},
+			)
+			return InvalidType, InvalidType
+		}
+	}
+
+	prevLeftOperand := checker.leftOperand
+	checker.leftOperand = leftOperand(expr)
+	defer func() {
+		checker.leftOperand = prevLeftOperand
+	}()
+
 	// Cache the current contextually expected type, and set the `expectedType`
 	// as the new contextually expected type.
 	prevExpectedType := checker.expectedType
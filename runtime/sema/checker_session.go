@@ -0,0 +1,227 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"crypto/sha256"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+type functionBodyKey [sha256.Size]byte
+
+// CheckerSession checks successive versions of a program, e.g. as it is edited,
+// and only re-checks the bodies of function declarations which changed.
+//
+// The bodies of function declarations which were checked without errors
+// are cached by the hash of the source code of the declaration,
+// the path of its enclosing declarations, and the rest of the program.
+// A cached function body is not checked again, as long as the rest of the program,
+// i.e. the source code of the program excluding the bodies of function declarations, is unchanged.
+// Any other change invalidates the cache.
+//
+// Only the errors of the checked program are reported.
+// As skipped function bodies are not elaborated, the checker should not be used for interpretation.
+// Imported programs are assumed to be unchanged.
+//
+type CheckerSession struct {
+	location common.Location
+	options  []Option
+	// skeletonKey is the hash of the source code of the last checked program,
+	// excluding the bodies of function declarations
+	skeletonKey functionBodyKey
+	// checked are the keys of the function declarations
+	// which were checked without errors
+	checked map[functionBodyKey]struct{}
+}
+
+// NewCheckerSession returns a new session for checking programs
+// with the given location and checker options.
+//
+func NewCheckerSession(location common.Location, options ...Option) *CheckerSession {
+	return &CheckerSession{
+		location: location,
+		options:  options,
+		checked:  map[functionBodyKey]struct{}{},
+	}
+}
+
+// Check checks the given program, which was parsed from the given code.
+// It returns the same error a checker would return for the program.
+//
+func (s *CheckerSession) Check(program *ast.Program, code string) error {
+
+	checker, err := NewChecker(program, s.location, s.options...)
+	if err != nil {
+		return err
+	}
+
+	cache := newFunctionBodyCache(program, code, s.checked)
+
+	if cache.skeletonKey != s.skeletonKey {
+		s.skeletonKey = cache.skeletonKey
+		s.checked = map[functionBodyKey]struct{}{}
+		cache.checked = s.checked
+	}
+
+	checker.functionBodyCache = cache
+
+	return checker.Check()
+}
+
+// functionBodyCache allows the checker to skip the bodies of function declarations,
+// which were already checked without errors.
+//
+type functionBodyCache struct {
+	keys        map[*ast.FunctionBlock]functionBodyKey
+	checked     map[functionBodyKey]struct{}
+	skeletonKey functionBodyKey
+}
+
+func newFunctionBodyCache(
+	program *ast.Program,
+	code string,
+	checked map[functionBodyKey]struct{},
+) *functionBodyCache {
+
+	cache := &functionBodyCache{
+		keys:    map[*ast.FunctionBlock]functionBodyKey{},
+		checked: checked,
+	}
+
+	// The skeleton of the program is the source code
+	// with the bodies of all function declarations removed
+
+	var skeleton []byte
+	skeletonStart := 0
+
+	// The source code of a function declaration alone is not sufficient as a key:
+	// The same declaration may occur in different composites, e.g. using `self`,
+	// so the function source code is recorded together with the path
+	// of the enclosing declarations, and hashed once the skeleton is known
+
+	type functionSource struct {
+		path string
+		code string
+	}
+
+	sources := map[*ast.FunctionBlock]functionSource{}
+
+	addFunction := func(path string, declaration *ast.FunctionDeclaration) {
+		block := declaration.FunctionBlock
+		if block == nil {
+			return
+		}
+
+		start := declaration.StartPosition().Offset
+		end := declaration.EndPosition().Offset + 1
+		blockStart := block.StartPosition().Offset
+		if start < skeletonStart ||
+			blockStart < start ||
+			end > len(code) {
+
+			return
+		}
+
+		sources[block] = functionSource{
+			path: path,
+			code: code[start:end],
+		}
+
+		skeleton = append(skeleton, code[skeletonStart:blockStart]...)
+		skeletonStart = end
+	}
+
+	var addMembers func(path string, members *ast.Members)
+	addMembers = func(path string, members *ast.Members) {
+		for _, declaration := range members.Declarations() {
+			switch declaration := declaration.(type) {
+			case *ast.FunctionDeclaration:
+				addFunction(path, declaration)
+
+			case *ast.SpecialFunctionDeclaration:
+				addFunction(path, declaration.FunctionDeclaration)
+
+			case *ast.CompositeDeclaration:
+				addMembers(path+"."+declaration.Identifier.Identifier, declaration.Members)
+
+			case *ast.InterfaceDeclaration:
+				addMembers(path+"."+declaration.Identifier.Identifier, declaration.Members)
+
+			case *ast.AttachmentDeclaration:
+				addMembers(path+"."+declaration.Identifier.Identifier, declaration.Members)
+			}
+		}
+	}
+
+	for _, declaration := range program.Declarations() {
+		switch declaration := declaration.(type) {
+		case *ast.FunctionDeclaration:
+			addFunction("", declaration)
+
+		case *ast.CompositeDeclaration:
+			addMembers(declaration.Identifier.Identifier, declaration.Members)
+
+		case *ast.InterfaceDeclaration:
+			addMembers(declaration.Identifier.Identifier, declaration.Members)
+
+		case *ast.AttachmentDeclaration:
+			addMembers(declaration.Identifier.Identifier, declaration.Members)
+		}
+	}
+
+	skeleton = append(skeleton, code[skeletonStart:]...)
+	cache.skeletonKey = sha256.Sum256(skeleton)
+
+	for block, source := range sources {
+		hash := sha256.New()
+		hash.Write(cache.skeletonKey[:])
+		hash.Write([]byte(source.path))
+		// Separate the path from the code
+		hash.Write([]byte{0})
+		hash.Write([]byte(source.code))
+
+		var key functionBodyKey
+		hash.Sum(key[:0])
+		cache.keys[block] = key
+	}
+
+	return cache
+}
+
+// key returns the key of the given function block,
+// and false if the function block cannot be cached.
+//
+func (c *functionBodyCache) key(block *ast.FunctionBlock) (functionBodyKey, bool) {
+	if c == nil || block == nil {
+		return functionBodyKey{}, false
+	}
+	key, ok := c.keys[block]
+	return key, ok
+}
+
+func (c *functionBodyCache) isChecked(key functionBodyKey) bool {
+	_, ok := c.checked[key]
+	return ok
+}
+
+func (c *functionBodyCache) markChecked(key functionBodyKey) {
+	c.checked[key] = struct{}{}
+}
@@ -1171,6 +1171,86 @@ func (e *UnsupportedOverloadingError) Error() string {
 
 func (*UnsupportedOverloadingError) isSemanticError() {}
 
+// UnsupportedDeclarationError
+
+type UnsupportedDeclarationError struct {
+	DeclarationKind common.DeclarationKind
+	ast.Range
+}
+
+func (e *UnsupportedDeclarationError) Error() string {
+	return fmt.Sprintf(
+		"%s declarations are not supported yet",
+		e.DeclarationKind.Name(),
+	)
+}
+
+func (*UnsupportedDeclarationError) isSemanticError() {}
+
+// UnsupportedExpressionError
+
+type UnsupportedExpressionError struct {
+	Keyword string
+	ast.Range
+}
+
+func (e *UnsupportedExpressionError) Error() string {
+	return fmt.Sprintf(
+		"`%s` expressions are not supported yet",
+		e.Keyword,
+	)
+}
+
+func (*UnsupportedExpressionError) isSemanticError() {}
+
+// UnsupportedDestructuringError
+
+type UnsupportedDestructuringError struct {
+	ast.Range
+}
+
+func (e *UnsupportedDestructuringError) Error() string {
+	return "destructuring declarations are not supported yet"
+}
+
+func (*UnsupportedDestructuringError) isSemanticError() {}
+
+// NestingDepthExceededError
+
+type NestingDepthExceededError struct {
+	MaxDepth int
+	ast.Range
+}
+
+func (e *NestingDepthExceededError) Error() string {
+	return fmt.Sprintf(
+		"expression is nested too deeply: maximum nesting depth is %d",
+		e.MaxDepth,
+	)
+}
+
+func (*NestingDepthExceededError) isSemanticError() {}
+
+// InvalidStringInterpolationTypeError
+
+type InvalidStringInterpolationTypeError struct {
+	Type Type
+	ast.Range
+}
+
+func (e *InvalidStringInterpolationTypeError) Error() string {
+	return fmt.Sprintf(
+		"cannot interpolate value of type `%s` into string",
+		e.Type.QualifiedString(),
+	)
+}
+
+func (e *InvalidStringInterpolationTypeError) SecondaryError() string {
+	return "expected `String`, or a type with a function `toString(): String`"
+}
+
+func (*InvalidStringInterpolationTypeError) isSemanticError() {}
+
 // CompositeKindMismatchError
 
 type CompositeKindMismatchError struct {
@@ -2116,6 +2196,22 @@ func (e *MissingFunctionBodyError) EndPosition() ast.Position {
 	return e.Pos
 }
 
+// InvalidNativeFunctionDeclarationError
+
+type InvalidNativeFunctionDeclarationError struct {
+	Location common.Location
+	ast.Range
+}
+
+func (e *InvalidNativeFunctionDeclarationError) Error() string {
+	return fmt.Sprintf(
+		"native functions can only be declared in the standard library, not in `%s`",
+		e.Location,
+	)
+}
+
+func (*InvalidNativeFunctionDeclarationError) isSemanticError() {}
+
 // InvalidOptionalChainingError
 
 type InvalidOptionalChainingError struct {
@@ -2897,11 +2993,27 @@ func (e *TypeAnnotationRequiredError) EndPosition() ast.Position {
 
 type CyclicImportsError struct {
 	Location common.Location
+	// Cycle is the path of imports which forms the cycle,
+	// starting and ending with the location, if known
+	Cycle []common.Location
 	ast.Range
 }
 
 func (e *CyclicImportsError) Error() string {
-	return fmt.Sprintf("cyclic import of `%s`", e.Location)
+	if len(e.Cycle) == 0 {
+		return fmt.Sprintf("cyclic import of `%s`", e.Location)
+	}
+
+	locations := make([]string, len(e.Cycle))
+	for i, location := range e.Cycle {
+		locations[i] = fmt.Sprintf("`%s`", location)
+	}
+
+	return fmt.Sprintf(
+		"cyclic import of `%s`: %s",
+		e.Location,
+		strings.Join(locations, " -> "),
+	)
 }
 
 func (*CyclicImportsError) isSemanticError() {}
@@ -116,3 +116,52 @@ func (h *UnnecessaryCastHint) Hint() string {
 }
 
 func (*UnnecessaryCastHint) isHint() {}
+
+// UnusedVariableHint
+
+type UnusedVariableHint struct {
+	Name string
+	ast.Range
+}
+
+func (h *UnusedVariableHint) Hint() string {
+	return fmt.Sprintf(
+		"variable `%s` is never used",
+		h.Name,
+	)
+}
+
+func (*UnusedVariableHint) isHint() {}
+
+// ShadowedVariableHint
+
+type ShadowedVariableHint struct {
+	Name        string
+	PreviousPos *ast.Position
+	ast.Range
+}
+
+func (h *ShadowedVariableHint) Hint() string {
+	return fmt.Sprintf(
+		"variable `%s` shadows a variable declared in an outer scope",
+		h.Name,
+	)
+}
+
+func (*ShadowedVariableHint) isHint() {}
+
+// UnknownPragmaHint
+
+type UnknownPragmaHint struct {
+	Name string
+	ast.Range
+}
+
+func (h *UnknownPragmaHint) Hint() string {
+	return fmt.Sprintf(
+		"unknown pragma `%s`",
+		h.Name,
+	)
+}
+
+func (*UnknownPragmaHint) isHint() {}
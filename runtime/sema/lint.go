@@ -0,0 +1,84 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sema
+
+import (
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// Lint checks the given program with linting enabled,
+// and returns the hints and the errors reported by the checker separately.
+//
+// In addition to the given options, advanced linting and unused variable hints are enabled.
+// Errors do not prevent hints from being returned,
+// so tools may show hints even for programs which fail to check.
+// If the checker cannot be created, the error is returned as the only error.
+//
+func Lint(program *ast.Program, location common.Location, options ...Option) (hints []Hint, errs []error) {
+
+	options = append(
+		options,
+		WithLintingEnabled(true),
+		WithUnusedVariableHintsEnabled(true),
+	)
+
+	checker, err := NewChecker(program, location, options...)
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	checkerErr := checker.Check()
+	if checkerErr, ok := checkerErr.(*CheckerError); ok {
+		errs = checkerErr.Errors
+	}
+
+	return checker.Hints(), errs
+}
+
+// checkShadowedVariable reports a ShadowedVariableHint if linting is enabled
+// and a local variable with the given identifier shadows a variable declared in an outer scope.
+//
+// Shadowing variables of the current scope and built-in values is reported as an error,
+// so only variables declared by the program in outer scopes are reported.
+//
+func (checker *Checker) checkShadowedVariable(identifier ast.Identifier) {
+	if !checker.lintEnabled ||
+		identifier.Identifier == "_" {
+
+		return
+	}
+
+	existingVariable := checker.valueActivations.Find(identifier.Identifier)
+	if existingVariable == nil ||
+		existingVariable.IsBaseValue ||
+		existingVariable.ActivationDepth == 0 ||
+		existingVariable.ActivationDepth == checker.valueActivations.Depth() {
+
+		return
+	}
+
+	checker.hint(
+		&ShadowedVariableHint{
+			Name:        identifier.Identifier,
+			PreviousPos: existingVariable.Pos,
+			Range:       ast.NewRangeFromPositioned(identifier),
+		},
+	)
+}
@@ -0,0 +1,107 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// SourceMapEntry is a source position in a program
+//
+type SourceMapEntry struct {
+	Location common.Location
+	Position ast.Position
+}
+
+type sourceMapKey struct {
+	locationID common.LocationID
+	position   ast.Position
+}
+
+// SourceMap maps program counters back to positions in the source code.
+//
+// The interpreter executes the AST directly,
+// so a program counter identifies an executed statement:
+// When a source map is set in the context, the first distinct executed statement has program counter 0,
+// the second distinct executed statement has program counter 1, and so on.
+//
+// Statements are identified by their location and start position,
+// so statements of imported programs do not collide with the statements of the executed program,
+// and statements which are executed multiple times, e.g. in loops, are only recorded once.
+//
+type SourceMap struct {
+	entries         map[int]SourceMapEntry
+	programCounters map[sourceMapKey]int
+}
+
+func NewSourceMap() *SourceMap {
+	return &SourceMap{
+		entries:         map[int]SourceMapEntry{},
+		programCounters: map[sourceMapKey]int{},
+	}
+}
+
+// AddEntry maps the given program counter to the given source position
+//
+func (m *SourceMap) AddEntry(programCounter int, location common.Location, pos ast.Position) {
+	m.entries[programCounter] = SourceMapEntry{
+		Location: location,
+		Position: pos,
+	}
+	m.programCounters[sourceMapKey{
+		locationID: locationID(location),
+		position:   pos,
+	}] = programCounter
+}
+
+// Lookup returns the source position for the given program counter, if any
+//
+func (m *SourceMap) Lookup(programCounter int) (SourceMapEntry, bool) {
+	entry, ok := m.entries[programCounter]
+	return entry, ok
+}
+
+// ProgramCounter returns the program counter for the given source position, if any
+//
+func (m *SourceMap) ProgramCounter(location common.Location, pos ast.Position) (int, bool) {
+	programCounter, ok := m.programCounters[sourceMapKey{
+		locationID: locationID(location),
+		position:   pos,
+	}]
+	return programCounter, ok
+}
+
+// addStatement adds an entry for the statement which is about to be executed,
+// unless the statement was already recorded
+//
+func (m *SourceMap) addStatement(location common.Location, statement ast.Statement) {
+	pos := statement.StartPosition()
+	if _, ok := m.ProgramCounter(location, pos); ok {
+		return
+	}
+	m.AddEntry(len(m.entries), location, pos)
+}
+
+func locationID(location common.Location) common.LocationID {
+	if location == nil {
+		return ""
+	}
+	return location.ID()
+}
@@ -0,0 +1,219 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runtime
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func TestRuntimeSourceMap(t *testing.T) {
+
+	t.Parallel()
+
+	script := []byte(`
+      pub fun double(_ x: Int): Int {
+          return x * 2
+      }
+
+      pub fun main(): Int {
+          let x = 1
+          return double(x)
+      }
+    `)
+
+	runtime := newTestInterpreterRuntime()
+
+	sourceMap := NewSourceMap()
+
+	value, err := runtime.ExecuteScript(
+		Script{
+			Source: script,
+		},
+		Context{
+			Interface: &testRuntimeInterface{},
+			Location:  utils.TestLocation,
+			SourceMap: sourceMap,
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, cadence.NewInt(2), value)
+
+	// The statements are recorded in the order in which they are executed
+
+	expectedEntries := []SourceMapEntry{
+		{
+			Location: utils.TestLocation,
+			Position: ast.Position{Offset: 109, Line: 7, Column: 10},
+		},
+		{
+			Location: utils.TestLocation,
+			Position: ast.Position{Offset: 129, Line: 8, Column: 10},
+		},
+		{
+			Location: utils.TestLocation,
+			Position: ast.Position{Offset: 49, Line: 3, Column: 10},
+		},
+	}
+
+	for programCounter, expectedEntry := range expectedEntries {
+		entry, ok := sourceMap.Lookup(programCounter)
+		require.True(t, ok)
+		assert.Equal(t, expectedEntry, entry)
+	}
+
+	_, ok := sourceMap.Lookup(len(expectedEntries))
+	assert.False(t, ok)
+}
+
+func TestRuntimeSourceMapImportsAndLoops(t *testing.T) {
+
+	t.Parallel()
+
+	// The statement of the imported function and the first statement of the script
+	// start at the same position, but in different locations
+
+	importedScript := []byte(`
+      // Answer is 42
+
+      pub fun answer(): Int {
+          return 42
+      }
+    `)
+
+	script := []byte(`
+      import "imported"
+
+      pub fun main(): Int {
+          var i = 0
+          while i < 3 {
+              i = i + 1
+          }
+          return answer()
+      }
+    `)
+
+	runtime := newTestInterpreterRuntime()
+
+	sourceMap := NewSourceMap()
+
+	importedLocation := common.StringLocation("imported")
+
+	runtimeInterface := &testRuntimeInterface{
+		getCode: func(location Location) ([]byte, error) {
+			switch location {
+			case importedLocation:
+				return importedScript, nil
+			default:
+				return nil, fmt.Errorf("unknown import location: %s", location)
+			}
+		},
+	}
+
+	value, err := runtime.ExecuteScript(
+		Script{
+			Source: script,
+		},
+		Context{
+			Interface: runtimeInterface,
+			Location:  utils.TestLocation,
+			SourceMap: sourceMap,
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, cadence.NewInt(42), value)
+
+	// The loop body is executed three times, but only recorded once
+
+	expectedEntries := []SourceMapEntry{
+		{
+			Location: utils.TestLocation,
+			Position: ast.Position{Offset: 64, Line: 5, Column: 10},
+		},
+		{
+			Location: utils.TestLocation,
+			Position: ast.Position{Offset: 84, Line: 6, Column: 10},
+		},
+		{
+			Location: utils.TestLocation,
+			Position: ast.Position{Offset: 112, Line: 7, Column: 14},
+		},
+		{
+			Location: utils.TestLocation,
+			Position: ast.Position{Offset: 144, Line: 9, Column: 10},
+		},
+		{
+			Location: importedLocation,
+			Position: ast.Position{Offset: 64, Line: 5, Column: 10},
+		},
+	}
+
+	for programCounter, expectedEntry := range expectedEntries {
+		entry, ok := sourceMap.Lookup(programCounter)
+		require.True(t, ok)
+		assert.Equal(t, expectedEntry, entry)
+
+		actualProgramCounter, ok := sourceMap.ProgramCounter(entry.Location, entry.Position)
+		require.True(t, ok)
+		assert.Equal(t, programCounter, actualProgramCounter)
+	}
+
+	_, ok := sourceMap.Lookup(len(expectedEntries))
+	assert.False(t, ok)
+}
+
+func TestSourceMap(t *testing.T) {
+
+	t.Parallel()
+
+	sourceMap := NewSourceMap()
+
+	pos := ast.Position{Offset: 1, Line: 2, Column: 3}
+	sourceMap.AddEntry(42, utils.TestLocation, pos)
+
+	actual, ok := sourceMap.Lookup(42)
+	require.True(t, ok)
+	assert.Equal(t,
+		SourceMapEntry{
+			Location: utils.TestLocation,
+			Position: pos,
+		},
+		actual,
+	)
+
+	programCounter, ok := sourceMap.ProgramCounter(utils.TestLocation, pos)
+	require.True(t, ok)
+	assert.Equal(t, 42, programCounter)
+
+	_, ok = sourceMap.ProgramCounter(common.StringLocation("other"), pos)
+	assert.False(t, ok)
+
+	_, ok = sourceMap.Lookup(0)
+	assert.False(t, ok)
+}
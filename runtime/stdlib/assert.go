@@ -75,6 +75,6 @@ var AssertFunction = NewStandardLibraryFunction(
 				LocationRange: invocation.GetLocationRange(),
 			})
 		}
-		return interpreter.VoidValue{}
+		return interpreter.NewVoidValue(invocation.Interpreter)
 	},
 )
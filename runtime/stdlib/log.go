@@ -34,6 +34,6 @@ var LogFunction = NewStandardLibraryFunction(
 	logFunctionDocString,
 	func(invocation interpreter.Invocation) interpreter.Value {
 		fmt.Println(invocation.Arguments[0].String())
-		return interpreter.VoidValue{}
+		return interpreter.NewVoidValue(invocation.Interpreter)
 	},
 )
@@ -0,0 +1,154 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+func TestCheckInvalidAttachmentDeclaration(t *testing.T) {
+
+	t.Parallel()
+
+	_, err := ParseAndCheck(t, `
+      pub struct S {}
+
+      pub attachment A for S {}
+    `)
+
+	errs := ExpectCheckerErrors(t, err, 1)
+
+	assert.IsType(t, &sema.UnsupportedDeclarationError{}, errs[0])
+}
+
+func TestCheckInvalidAttachExpression(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("unsupported", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          pub struct S {}
+
+          pub fun test() {
+              let s = S()
+              attach A() to s
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.UnsupportedExpressionError{}, errs[0])
+	})
+
+	t.Run("undeclared base", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          pub fun test() {
+              attach A() to s
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 2)
+
+		assert.IsType(t, &sema.NotDeclaredError{}, errs[0])
+		assert.IsType(t, &sema.UnsupportedExpressionError{}, errs[1])
+	})
+
+	t.Run("undeclared argument", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          pub struct S {}
+
+          pub fun test() {
+              let s = S()
+              attach A(x) to s
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 2)
+
+		assert.IsType(t, &sema.NotDeclaredError{}, errs[0])
+		assert.IsType(t, &sema.UnsupportedExpressionError{}, errs[1])
+	})
+}
+
+func TestCheckInvalidRemoveExpression(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("unsupported", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          pub struct S {}
+
+          pub fun test() {
+              let s = S()
+              s.remove<A>()
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.UnsupportedExpressionError{}, errs[0])
+	})
+
+	t.Run("undeclared base", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          pub fun test() {
+              s.remove<A>()
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 2)
+
+		assert.IsType(t, &sema.NotDeclaredError{}, errs[0])
+		assert.IsType(t, &sema.UnsupportedExpressionError{}, errs[1])
+	})
+
+	t.Run("dictionary remove", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          pub fun test() {
+              let xs: {String: Int} = {}
+              xs.remove(key: "a")
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,281 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checker
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/parser2"
+	"github.com/onflow/cadence/runtime/sema"
+	. "github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func TestCheckCheckerSession(t *testing.T) {
+
+	t.Parallel()
+
+	check := func(t *testing.T, session *sema.CheckerSession, code string) error {
+		program, err := parser2.ParseProgram(code)
+		require.NoError(t, err)
+
+		return session.Check(program, code)
+	}
+
+	const code = `
+      pub fun f(): Int {
+          return 1
+      }
+
+      pub contract C {
+
+          pub fun g(): Int {
+              return f()
+          }
+
+          init() {}
+      }
+    `
+
+	t.Run("unchanged", func(t *testing.T) {
+
+		t.Parallel()
+
+		session := sema.NewCheckerSession(TestLocation)
+
+		require.NoError(t, check(t, session, code))
+		require.NoError(t, check(t, session, code))
+	})
+
+	t.Run("changed body", func(t *testing.T) {
+
+		t.Parallel()
+
+		session := sema.NewCheckerSession(TestLocation)
+
+		require.NoError(t, check(t, session, code))
+
+		newCode := strings.Replace(code, "return f()", `return "g"`, 1)
+
+		errs := ExpectCheckerErrors(t, check(t, session, newCode), 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+
+		require.NoError(t, check(t, session, code))
+	})
+
+	t.Run("changed signature", func(t *testing.T) {
+
+		t.Parallel()
+
+		session := sema.NewCheckerSession(TestLocation)
+
+		require.NoError(t, check(t, session, code))
+
+		// The body of g is unchanged, but the return type of f changed
+
+		newCode := strings.Replace(
+			code,
+			"pub fun f(): Int {\n          return 1",
+			"pub fun f(): String {\n          return \"f\"",
+			1,
+		)
+
+		errs := ExpectCheckerErrors(t, check(t, session, newCode), 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("moved function", func(t *testing.T) {
+
+		t.Parallel()
+
+		session := sema.NewCheckerSession(TestLocation)
+
+		require.NoError(t, check(t, session, code))
+
+		newCode := strings.Replace(code, "return 1", "return 1 + 1", 1)
+
+		require.NoError(t, check(t, session, newCode))
+	})
+
+	t.Run("errors are reported again", func(t *testing.T) {
+
+		t.Parallel()
+
+		session := sema.NewCheckerSession(TestLocation)
+
+		invalidCode := strings.Replace(code, "return 1", "return x", 1)
+
+		errs := ExpectCheckerErrors(t, check(t, session, invalidCode), 1)
+		assert.IsType(t, &sema.NotDeclaredError{}, errs[0])
+
+		// Change another function
+
+		newCode := strings.Replace(invalidCode, "return f()", "return f() + 1", 1)
+
+		errs = ExpectCheckerErrors(t, check(t, session, newCode), 1)
+		assert.IsType(t, &sema.NotDeclaredError{}, errs[0])
+	})
+
+	t.Run("same function in different composites", func(t *testing.T) {
+
+		t.Parallel()
+
+		session := sema.NewCheckerSession(TestLocation)
+
+		const code = `
+          pub struct A {
+              pub let x: Int
+              init() { self.x = 1 }
+              pub fun get(): Int { return self.x }
+          }
+
+          pub struct B {
+              pub let x: String
+              init() { self.x = "1" }
+              pub fun get(): Int { return self.x }
+          }
+        `
+
+		errs := ExpectCheckerErrors(t, check(t, session, code), 1)
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+
+		errs = ExpectCheckerErrors(t, check(t, session, code), 1)
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+}
+
+// checkerSessionBenchmarkCode returns the code of a contract with 50 functions,
+// about 1000 lines long, where the function with the given index uses the given value
+//
+func checkerSessionBenchmarkCode(index int, value int) string {
+	var builder strings.Builder
+
+	builder.WriteString("pub contract C {\n")
+
+	for i := 0; i < 50; i++ {
+		factor := i
+		if i == index {
+			factor = value
+		}
+
+		_, _ = fmt.Fprintf(&builder, `
+    pub fun f%d(a: Int, b: [Int], c: {String: Int}): Int {
+        var total = a
+        for element in b {
+            total = total + element * %d
+        }
+        let keys = c.keys
+        var i = 0
+        while i < keys.length {
+            let value = c[keys[i]] ?? 0
+            if value > total {
+                total = value
+            } else {
+                total = total - value
+            }
+            i = i + 1
+        }
+        let values: [Int] = [total, a, b.length]
+        if values.contains(100) {
+            return total - 1
+        }
+        return total
+    }
+`,
+			i,
+			factor,
+		)
+	}
+
+	builder.WriteString("\n    init() {}\n}\n")
+
+	return builder.String()
+}
+
+// BenchmarkCheckerSession re-checks a contract using a checker session,
+// after changing a single function
+//
+func BenchmarkCheckerSession(b *testing.B) {
+
+	session := sema.NewCheckerSession(TestLocation)
+
+	code := checkerSessionBenchmarkCode(-1, 0)
+	program, err := parser2.ParseProgram(code)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	err = session.Check(program, code)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		code := checkerSessionBenchmarkCode(50, 1000+i)
+		program, err := parser2.ParseProgram(code)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.StartTimer()
+
+		err = session.Check(program, code)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkCheckerSessionBaseline re-checks a contract using a new checker,
+// after changing a single function, for comparison with BenchmarkCheckerSession
+//
+func BenchmarkCheckerSessionBaseline(b *testing.B) {
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		code := checkerSessionBenchmarkCode(50, 1000+i)
+		program, err := parser2.ParseProgram(code)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.StartTimer()
+
+		checker, err := sema.NewChecker(program, TestLocation)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		err = checker.Check()
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
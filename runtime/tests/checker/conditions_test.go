@@ -522,3 +522,70 @@ func TestCheckConditionCreateBefore(t *testing.T) {
 	require.ErrorAs(t, errs[0], &notCallableErr)
 	require.Equal(t, sema.IntType, notCallableErr.Type)
 }
+
+func TestCheckInterfaceFunctionConditions(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          pub struct interface I {
+              pub fun test(n: Int): Int {
+                  pre {
+                      n > 0
+                  }
+                  post {
+                      result < n: "result must be less than n"
+                  }
+              }
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("non-bool conditions", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          pub struct interface I {
+              pub fun test(n: Int): Int {
+                  pre {
+                      n
+                  }
+                  post {
+                      "result"
+                  }
+              }
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 2)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[1])
+	})
+
+	t.Run("non-string message", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          pub struct interface I {
+              pub fun test(n: Int) {
+                  pre {
+                      n > 0: n
+                  }
+              }
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+}
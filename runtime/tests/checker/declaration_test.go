@@ -711,3 +711,56 @@ func TestCheckSetToDictWithType(t *testing.T) {
 	_, err := ParseAndCheck(t, "var j={0.0:Type}")
 	assert.Nil(t, err)
 }
+
+func TestCheckTupleDestructuringDeclaration(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("unsupported", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let pair = [1, 2]
+          let (a, b) = pair
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.UnsupportedDestructuringError{}, errs[0])
+	})
+
+	t.Run("declared variables", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test() {
+              let (a, (b, _)) = [1, [2, 3]]
+              let c = a
+              let d = b
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.UnsupportedDestructuringError{}, errs[0])
+	})
+
+	t.Run("wildcard is not declared", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          fun test() {
+              let (a, _) = [1, 2]
+              let b = _
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 2)
+
+		assert.IsType(t, &sema.UnsupportedDestructuringError{}, errs[0])
+		assert.IsType(t, &sema.NotDeclaredError{}, errs[1])
+	})
+}
@@ -205,6 +205,58 @@ func TestCheckEmitEvent(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("NoArguments", func(t *testing.T) {
+		_, err := ParseAndCheck(t, `
+            event Started()
+
+            fun test() {
+                emit Started()
+            }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("SingleArgument", func(t *testing.T) {
+		_, err := ParseAndCheck(t, `
+            event Deposit(amount: UFix64)
+
+            fun test() {
+                emit Deposit(amount: 1.0)
+            }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("WrongArgumentType", func(t *testing.T) {
+		_, err := ParseAndCheck(t, `
+            event Transfer(to: Int, from: Int)
+
+            fun test() {
+                emit Transfer(to: 1, from: "2")
+            }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("WrongArgumentCount", func(t *testing.T) {
+		_, err := ParseAndCheck(t, `
+            event Transfer(to: Int, from: Int)
+
+            fun test() {
+                emit Transfer(to: 1)
+            }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.ArgumentCountError{}, errs[0])
+	})
+
 	t.Run("MissingEmitStatement", func(t *testing.T) {
 		_, err := ParseAndCheck(t, `
             event Transfer(to: Int, from: Int)
@@ -24,6 +24,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/sema"
 )
 
@@ -415,3 +416,98 @@ func TestCheckFunctionNonExistingField(t *testing.T) {
 
 	assert.IsType(t, &sema.NotDeclaredMemberError{}, errs[0])
 }
+
+func TestCheckNativeFunctionDeclaration(t *testing.T) {
+
+	t.Parallel()
+
+	// Native functions may only be declared in the standard library
+
+	parseAndCheckStandardLibrary := func(t *testing.T, code string) (*sema.Checker, error) {
+		return ParseAndCheckWithOptions(t,
+			code,
+			ParseAndCheckOptions{
+				Location: common.IdentifierLocation("Test"),
+			},
+		)
+	}
+
+	t.Run("top-level", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := parseAndCheckStandardLibrary(t, `
+          pub native fun foo(_ x: Int): Int
+
+          let y: Int = foo(1)
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("member", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := parseAndCheckStandardLibrary(t, `
+          struct S {
+              pub native fun foo(): String
+          }
+
+          let s: String = S().foo()
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid argument", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := parseAndCheckStandardLibrary(t, `
+          native fun foo(_ x: Int)
+
+          let y = foo(true)
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+	})
+
+	t.Run("top-level, user program", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          pub native fun foo(_ x: Int): Int
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidNativeFunctionDeclarationError{}, errs[0])
+	})
+
+	t.Run("member, user program", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheckWithOptions(t,
+			`
+              pub contract C {
+                  pub native fun foo(): String
+              }
+            `,
+			ParseAndCheckOptions{
+				Location: common.AddressLocation{
+					Address: common.MustBytesToAddress([]byte{0x1}),
+					Name:    "C",
+				},
+			},
+		)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidNativeFunctionDeclarationError{}, errs[0])
+	})
+}
@@ -187,6 +187,32 @@ func TestCheckInvalidInterfaceWithFunctionImplementationNoConditions(t *testing.
 	}
 }
 
+func TestCheckInvalidInterfaceFunctionRedeclaration(t *testing.T) {
+
+	t.Parallel()
+
+	for _, kind := range common.CompositeKindsWithFieldsAndFunctions {
+		t.Run(kind.Keyword(), func(t *testing.T) {
+
+			_, err := ParseAndCheck(t,
+				fmt.Sprintf(
+					`
+                      %s interface Test {
+                          fun test(): Int
+                          fun test(): Int
+                      }
+                    `,
+					kind.Keyword(),
+				),
+			)
+
+			errs := ExpectCheckerErrors(t, err, 1)
+
+			assert.IsType(t, &sema.RedeclarationError{}, errs[0])
+		})
+	}
+}
+
 func TestCheckInterfaceWithInitializer(t *testing.T) {
 
 	t.Parallel()
@@ -0,0 +1,152 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/parser2"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/cadence/runtime/tests/utils"
+)
+
+func lint(t *testing.T, code string) ([]sema.Hint, []error) {
+	program, err := parser2.ParseProgram(code)
+	require.NoError(t, err)
+
+	return sema.Lint(program, utils.TestLocation)
+}
+
+func TestCheckLint(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("no hints", func(t *testing.T) {
+
+		t.Parallel()
+
+		hints, errs := lint(t, `
+          pub fun test(): Int {
+              let x = 1
+              return x
+          }
+        `)
+
+		assert.Empty(t, hints)
+		assert.Empty(t, errs)
+	})
+
+	t.Run("unused variable", func(t *testing.T) {
+
+		t.Parallel()
+
+		hints, errs := lint(t, `
+          pub fun test() {
+              let x = 1
+          }
+        `)
+
+		require.Empty(t, errs)
+		require.Len(t, hints, 1)
+		assert.IsType(t, &sema.UnusedVariableHint{}, hints[0])
+	})
+
+	t.Run("shadowed variable", func(t *testing.T) {
+
+		t.Parallel()
+
+		hints, errs := lint(t, `
+          pub let x = 1
+
+          pub fun test(): Int {
+              let x = 2
+              if true {
+                  let x = 3
+                  return x
+              }
+              return x
+          }
+        `)
+
+		require.Empty(t, errs)
+		require.Len(t, hints, 2)
+
+		assert.Equal(t,
+			&sema.ShadowedVariableHint{
+				Name:        "x",
+				PreviousPos: &ast.Position{Offset: 19, Line: 2, Column: 18},
+				Range: ast.Range{
+					StartPos: ast.Position{Offset: 76, Line: 5, Column: 18},
+					EndPos:   ast.Position{Offset: 76, Line: 5, Column: 18},
+				},
+			},
+			hints[0],
+		)
+
+		assert.Equal(t,
+			&sema.ShadowedVariableHint{
+				Name:        "x",
+				PreviousPos: &ast.Position{Offset: 76, Line: 5, Column: 18},
+				Range: ast.Range{
+					StartPos: ast.Position{Offset: 128, Line: 7, Column: 22},
+					EndPos:   ast.Position{Offset: 128, Line: 7, Column: 22},
+				},
+			},
+			hints[1],
+		)
+	})
+
+	t.Run("errors are not fatal", func(t *testing.T) {
+
+		t.Parallel()
+
+		hints, errs := lint(t, `
+          pub fun test() {
+              let x: Int = true
+          }
+        `)
+
+		require.Len(t, errs, 1)
+		assert.IsType(t, &sema.TypeMismatchError{}, errs[0])
+
+		require.Len(t, hints, 1)
+		assert.IsType(t, &sema.UnusedVariableHint{}, hints[0])
+	})
+
+	t.Run("without linting", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          pub let x = 1
+
+          pub fun test(): Int {
+              let x = 2
+              return x
+          }
+        `)
+
+		require.NoError(t, err)
+		assert.Empty(t, checker.Hints())
+	})
+}
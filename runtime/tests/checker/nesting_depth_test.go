@@ -0,0 +1,157 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checker
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+func TestCheckNestingDepth(t *testing.T) {
+
+	t.Parallel()
+
+	// The declaration's value and each array literal's element are nested expressions
+
+	nestedArray := func(depth int) string {
+		return fmt.Sprintf(
+			"let x: %s = %s",
+			strings.Repeat("[", depth-1)+"Int"+strings.Repeat("]", depth-1),
+			strings.Repeat("[", depth-1)+"1"+strings.Repeat("]", depth-1),
+		)
+	}
+
+	t.Run("within limit", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheckWithOptions(t,
+			nestedArray(10),
+			ParseAndCheckOptions{
+				Options: []sema.Option{
+					sema.WithMaxNestingDepth(10),
+				},
+			},
+		)
+		require.NoError(t, err)
+	})
+
+	t.Run("exceeding limit", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheckWithOptions(t,
+			nestedArray(11),
+			ParseAndCheckOptions{
+				Options: []sema.Option{
+					sema.WithMaxNestingDepth(10),
+				},
+			},
+		)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.NestingDepthExceededError{}, errs[0])
+
+		nestingDepthErr := errs[0].(*sema.NestingDepthExceededError)
+		assert.Equal(t, 10, nestingDepthErr.MaxDepth)
+		assert.Equal(t, 43, nestingDepthErr.StartPos.Offset)
+	})
+	t.Run("long flat binary chain", func(t *testing.T) {
+
+		t.Parallel()
+
+		// The parser parses left-associative chains iteratively,
+		// so the chain does not exceed the limit, even though it is longer
+
+		terms := make([]string, 100)
+		for i := range terms {
+			terms[i] = "a"
+		}
+
+		_, err := ParseAndCheckWithOptions(t,
+			fmt.Sprintf(
+				`
+                  let a = 1
+                  let b = %s
+                  let c = a.toString().concat("x").length
+                `,
+				strings.Join(terms, " + "),
+			),
+			ParseAndCheckOptions{
+				Options: []sema.Option{
+					sema.WithMaxNestingDepth(10),
+				},
+			},
+		)
+		require.NoError(t, err)
+	})
+
+	t.Run("long flat binary chain, default limit", func(t *testing.T) {
+
+		t.Parallel()
+
+		terms := make([]string, 2*sema.DefaultMaxNestingDepth)
+		for i := range terms {
+			terms[i] = "a"
+		}
+
+		_, err := ParseAndCheck(t,
+			fmt.Sprintf(
+				`
+                  let a = 1
+                  let b = %s
+                `,
+				strings.Join(terms, " + "),
+			),
+		)
+		require.NoError(t, err)
+	})
+
+	t.Run("nested right operands", func(t *testing.T) {
+
+		t.Parallel()
+
+		// The right operand of a binary expression is parsed recursively,
+		// so each parenthesized right operand is nested one level deeper
+
+		_, err := ParseAndCheckWithOptions(t,
+			fmt.Sprintf(
+				"let a = 1\nlet b = %s%s",
+				strings.Repeat("a + (", 10),
+				"a"+strings.Repeat(")", 10),
+			),
+			ParseAndCheckOptions{
+				Options: []sema.Option{
+					sema.WithMaxNestingDepth(10),
+				},
+			},
+		)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.NestingDepthExceededError{}, errs[0])
+	})
+}
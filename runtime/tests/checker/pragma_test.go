@@ -24,6 +24,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/sema"
 )
 
@@ -91,9 +92,99 @@ func TestCheckPragmaInvalidInvocationExprTypeArgs(t *testing.T) {
 	t.Parallel()
 
 	_, err := ParseAndCheck(t, `
-		#version<X>()
+		#version<X>("1.0")
 	`)
 
 	errs := ExpectCheckerErrors(t, err, 1)
 	assert.IsType(t, &sema.InvalidPragmaError{Message: "type arguments not supported"}, errs[0])
 }
+
+func TestCheckPragmaVersion(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          #version("1.0.0")
+        `)
+
+		require.NoError(t, err)
+		assert.Empty(t, checker.Hints())
+	})
+
+	t.Run("missing argument", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          #version
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+		assert.IsType(t, &sema.InvalidPragmaError{}, errs[0])
+	})
+
+	t.Run("too many arguments", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          #version("1.0.0", "2.0.0")
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+		assert.IsType(t, &sema.InvalidPragmaError{}, errs[0])
+	})
+
+	t.Run("non-string argument", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          #version(1)
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+		assert.IsType(t, &sema.InvalidPragmaError{}, errs[0])
+	})
+}
+
+func TestCheckPragmaLanguage(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := ParseAndCheck(t, `
+      #language("cadence")
+    `)
+
+	require.NoError(t, err)
+	assert.Empty(t, checker.Hints())
+}
+
+func TestCheckPragmaUnknown(t *testing.T) {
+
+	t.Parallel()
+
+	checker, err := ParseAndCheck(t, `
+      #foo("bar")
+    `)
+
+	require.NoError(t, err)
+
+	hints := checker.Hints()
+	require.Len(t, hints, 1)
+
+	assert.Equal(t,
+		&sema.UnknownPragmaHint{
+			Name: "foo",
+			Range: ast.Range{
+				StartPos: ast.Position{Offset: 8, Line: 2, Column: 7},
+				EndPos:   ast.Position{Offset: 10, Line: 2, Column: 9},
+			},
+		},
+		hints[0],
+	)
+}
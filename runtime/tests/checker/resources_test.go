@@ -1331,6 +1331,148 @@ func TestCheckIndirectDestroy(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestCheckDestroyStatement(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("end of scope", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource X {}
+
+          fun test() {
+              let x <- create X()
+              destroy x
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("use after destroy", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource X {}
+
+          fun test() {
+              let x <- create X()
+              destroy x
+              destroy x
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.ResourceUseAfterInvalidationError{}, errs[0])
+	})
+
+	t.Run("conditional", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource X {}
+
+          fun test(cond: Bool) {
+              let x <- create X()
+              if cond {
+                  destroy x
+              } else {
+                  destroy x
+              }
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("use after conditional destroy", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource X {}
+
+          fun test(cond: Bool) {
+              let x <- create X()
+              if cond {
+                  destroy x
+              }
+              destroy x
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.ResourceUseAfterInvalidationError{}, errs[0])
+	})
+
+	t.Run("loop", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource X {}
+
+          fun test() {
+              var i = 0
+              while i < 10 {
+                  let x <- create X()
+                  destroy x
+                  i = i + 1
+              }
+          }
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("destroy outer resource in loop", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource X {}
+
+          fun test() {
+              let x <- create X()
+              var i = 0
+              while i < 10 {
+                  destroy x
+                  i = i + 1
+              }
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 2)
+
+		assert.IsType(t, &sema.ResourceUseAfterInvalidationError{}, errs[0])
+		assert.IsType(t, &sema.ResourceLossError{}, errs[1])
+	})
+
+	t.Run("non-resource", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct X {}
+
+          fun test() {
+              let x = X()
+              destroy x
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.InvalidDestructionError{}, errs[0])
+	})
+}
+
 func TestCheckInvalidResourceCreationWithoutCreate(t *testing.T) {
 
 	t.Parallel()
@@ -25,6 +25,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/sema"
 	"github.com/onflow/cadence/runtime/stdlib"
 )
@@ -485,3 +486,99 @@ func TestCheckNestedFunctionExits(t *testing.T) {
 		},
 	)
 }
+
+func TestCheckUnreachableStatements(t *testing.T) {
+
+	t.Parallel()
+
+	test := func(t *testing.T, code string, expectedRange ast.Range) {
+
+		_, err := ParseAndCheckWithPanic(t, code)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		var unreachableErr *sema.UnreachableStatementError
+		require.ErrorAs(t, errs[0], &unreachableErr)
+
+		// All unreachable statements are reported once
+
+		assert.Equal(t, expectedRange, unreachableErr.Range)
+	}
+
+	t.Run("return", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t,
+			`
+              fun test() {
+                  return
+                  let x = 1
+                  let y = 2
+              }
+            `,
+			ast.Range{
+				StartPos: ast.Position{Offset: 71, Line: 4, Column: 18},
+				EndPos:   ast.Position{Offset: 107, Line: 5, Column: 26},
+			},
+		)
+	})
+
+	t.Run("panic", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t,
+			`
+              fun test() {
+                  panic("")
+                  let x = 1
+              }
+            `,
+			ast.Range{
+				StartPos: ast.Position{Offset: 74, Line: 4, Column: 18},
+				EndPos:   ast.Position{Offset: 82, Line: 4, Column: 26},
+			},
+		)
+	})
+
+	t.Run("break", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t,
+			`
+              fun test() {
+                  while true {
+                      break
+                      let x = 1
+                  }
+              }
+            `,
+			ast.Range{
+				StartPos: ast.Position{Offset: 109, Line: 5, Column: 22},
+				EndPos:   ast.Position{Offset: 117, Line: 5, Column: 30},
+			},
+		)
+	})
+
+	t.Run("continue", func(t *testing.T) {
+
+		t.Parallel()
+
+		test(t,
+			`
+              fun test() {
+                  while true {
+                      continue
+                      let x = 1
+                  }
+              }
+            `,
+			ast.Range{
+				StartPos: ast.Position{Offset: 112, Line: 5, Column: 22},
+				EndPos:   ast.Position{Offset: 120, Line: 5, Column: 30},
+			},
+		)
+	})
+}
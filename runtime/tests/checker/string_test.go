@@ -324,3 +324,149 @@ func TestCheckStringToLower(t *testing.T) {
 		RequireGlobalValue(t, checker.Elaboration, "x"),
 	)
 }
+
+func TestCheckStringInterpolation(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("string", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          let name = "Cadence"
+          let x = "Hello, \(name)!"
+        `)
+
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			sema.StringType,
+			RequireGlobalValue(t, checker.Elaboration, "x"),
+		)
+	})
+
+	t.Run("built-in toString", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let x = "\(1 + 2), \(0x1 as Address), \(/storage/foo), \(1.5)"
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("declared toString", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {
+              fun toString(): String {
+                  return "S"
+              }
+          }
+
+          let x = "\(S())"
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("nested", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let x = "a \("b \(1)")"
+        `)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid, no toString", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {}
+
+          let x = "\(S())"
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.InvalidStringInterpolationTypeError{}, errs[0])
+	})
+
+	t.Run("invalid, toString with wrong type", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          struct S {
+              fun toString(): Int {
+                  return 1
+              }
+          }
+
+          let x = "\(S())"
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.InvalidStringInterpolationTypeError{}, errs[0])
+	})
+
+	t.Run("invalid, optional", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let y: String? = "y"
+          let x = "\(y)"
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.InvalidStringInterpolationTypeError{}, errs[0])
+	})
+
+	t.Run("invalid, resource", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          resource R {
+              fun toString(): String {
+                  return "R"
+              }
+          }
+
+          fun test(): String {
+              let r <- create R()
+              let s = "\(r)"
+              destroy r
+              return s
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.InvalidStringInterpolationTypeError{}, errs[0])
+	})
+
+	t.Run("invalid, undeclared", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := ParseAndCheck(t, `
+          let x = "\(y)"
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		require.IsType(t, &sema.NotDeclaredError{}, errs[0])
+	})
+}
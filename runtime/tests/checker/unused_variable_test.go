@@ -0,0 +1,221 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+func parseAndCheckWithUnusedVariableHints(t *testing.T, code string) (*sema.Checker, error) {
+	return ParseAndCheckWithOptions(t,
+		code,
+		ParseAndCheckOptions{
+			Options: []sema.Option{
+				sema.WithUnusedVariableHintsEnabled(true),
+			},
+		},
+	)
+}
+
+func TestCheckUnusedVariable(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("unused", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := parseAndCheckWithUnusedVariableHints(t, `
+          fun test() {
+              let x = 1
+              var y = 2
+          }
+        `)
+		require.NoError(t, err)
+
+		hints := checker.Hints()
+		require.Len(t, hints, 2)
+
+		assert.Equal(t,
+			&sema.UnusedVariableHint{
+				Name: "x",
+				Range: ast.Range{
+					StartPos: ast.Position{Offset: 42, Line: 3, Column: 18},
+					EndPos:   ast.Position{Offset: 42, Line: 3, Column: 18},
+				},
+			},
+			hints[0],
+		)
+
+		require.IsType(t, &sema.UnusedVariableHint{}, hints[1])
+		assert.Equal(t, "y", hints[1].(*sema.UnusedVariableHint).Name)
+	})
+
+	t.Run("used", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := parseAndCheckWithUnusedVariableHints(t, `
+          fun test(): Int {
+              let x = 1
+              let y = 2
+              let f = fun (): Int {
+                  return y
+              }
+              return x + f()
+          }
+        `)
+		require.NoError(t, err)
+
+		assert.Empty(t, checker.Hints())
+	})
+
+	t.Run("only assigned", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := parseAndCheckWithUnusedVariableHints(t, `
+          fun test() {
+              var x = 1
+              x = 2
+          }
+        `)
+		require.NoError(t, err)
+
+		hints := checker.Hints()
+		require.Len(t, hints, 1)
+		require.IsType(t, &sema.UnusedVariableHint{}, hints[0])
+	})
+
+	t.Run("shadowed", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := parseAndCheckWithUnusedVariableHints(t, `
+          fun test(): Int {
+              let x = 1
+              if true {
+                  let x = 2
+                  return x
+              }
+              return 3
+          }
+        `)
+		require.NoError(t, err)
+
+		hints := checker.Hints()
+		require.Len(t, hints, 1)
+		require.IsType(t, &sema.UnusedVariableHint{}, hints[0])
+		assert.Equal(t, 3, hints[0].StartPosition().Line)
+	})
+
+	t.Run("optional binding", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := parseAndCheckWithUnusedVariableHints(t, `
+          fun test(x: Int?) {
+              if let y = x {}
+          }
+        `)
+		require.NoError(t, err)
+
+		hints := checker.Hints()
+		require.Len(t, hints, 1)
+		require.IsType(t, &sema.UnusedVariableHint{}, hints[0])
+		assert.Equal(t, "y", hints[0].(*sema.UnusedVariableHint).Name)
+	})
+
+	t.Run("discard", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := parseAndCheckWithUnusedVariableHints(t, `
+          fun test() {
+              let _ = 1
+          }
+        `)
+		require.NoError(t, err)
+
+		assert.Empty(t, checker.Hints())
+	})
+
+	t.Run("parameter", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := parseAndCheckWithUnusedVariableHints(t, `
+          fun test(x: Int) {}
+        `)
+		require.NoError(t, err)
+
+		assert.Empty(t, checker.Hints())
+	})
+
+	t.Run("global", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := parseAndCheckWithUnusedVariableHints(t, `
+          let x = 1
+        `)
+		require.NoError(t, err)
+
+		assert.Empty(t, checker.Hints())
+	})
+
+	t.Run("resource", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := parseAndCheckWithUnusedVariableHints(t, `
+          resource R {}
+
+          fun test() {
+              let r <- create R()
+          }
+        `)
+
+		errs := ExpectCheckerErrors(t, err, 1)
+
+		assert.IsType(t, &sema.ResourceLossError{}, errs[0])
+
+		assert.Empty(t, checker.Hints())
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+
+		t.Parallel()
+
+		checker, err := ParseAndCheck(t, `
+          fun test() {
+              let x = 1
+          }
+        `)
+		require.NoError(t, err)
+
+		assert.Empty(t, checker.Hints())
+	})
+}
@@ -45,9 +45,9 @@ func TestInterpretEquality(t *testing.T) {
 		capabilityValueDeclaration := stdlib.StandardLibraryValue{
 			Name: "cap",
 			Type: &sema.CapabilityType{},
-			ValueFactory: func(_ *interpreter.Interpreter) interpreter.Value {
+			ValueFactory: func(inter *interpreter.Interpreter) interpreter.Value {
 				return &interpreter.CapabilityValue{
-					Address: interpreter.NewAddressValue(common.MustBytesToAddress([]byte{0x1})),
+					Address: interpreter.NewAddressValue(inter, common.MustBytesToAddress([]byte{0x1})),
 					Path: interpreter.PathValue{
 						Domain:     common.PathDomainStorage,
 						Identifier: "something",
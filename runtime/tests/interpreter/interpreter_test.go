@@ -717,19 +717,19 @@ func TestInterpretStringIndexing(t *testing.T) {
 	AssertValuesEqual(
 		t,
 		inter,
-		interpreter.NewCharacterValue("a"),
+		interpreter.CharacterValue("a"),
 		inter.Globals["x"].GetValue(),
 	)
 	AssertValuesEqual(
 		t,
 		inter,
-		interpreter.NewCharacterValue("b"),
+		interpreter.CharacterValue("b"),
 		inter.Globals["y"].GetValue(),
 	)
 	AssertValuesEqual(
 		t,
 		inter,
-		interpreter.NewCharacterValue("c"),
+		interpreter.CharacterValue("c"),
 		inter.Globals["z"].GetValue(),
 	)
 }
@@ -798,7 +798,7 @@ func TestInterpretStringIndexingUnicode(t *testing.T) {
 	AssertValuesEqual(
 		t,
 		inter,
-		interpreter.NewCharacterValue("\u00e9"),
+		interpreter.CharacterValue("\u00e9"),
 		value,
 	)
 
@@ -808,7 +808,7 @@ func TestInterpretStringIndexingUnicode(t *testing.T) {
 	AssertValuesEqual(
 		t,
 		inter,
-		interpreter.NewCharacterValue("e\u0301"),
+		interpreter.CharacterValue("e\u0301"),
 		value,
 	)
 }
@@ -6632,7 +6632,7 @@ func TestInterpretEmitEventParameterTypes(t *testing.T) {
 			ty:    sema.StringType,
 		},
 		"Character": {
-			value: interpreter.NewCharacterValue("X"),
+			value: interpreter.CharacterValue("X"),
 			ty:    sema.CharacterType,
 		},
 		"Bool": {
@@ -7914,7 +7914,7 @@ func TestInterpretContractAccountFieldUse(t *testing.T) {
 						_ common.CompositeKind,
 					) map[string]interpreter.Value {
 						return map[string]interpreter.Value{
-							"account": newTestAuthAccountValue(addressValue),
+							"account": newTestAuthAccountValue(inter, addressValue),
 						}
 					},
 				),
@@ -8658,7 +8658,7 @@ func TestInterpretResourceOwnerFieldUse(t *testing.T) {
 		Name: "account",
 		Type: sema.AuthAccountType,
 		ValueFactory: func(inter *interpreter.Interpreter) interpreter.Value {
-			return newTestAuthAccountValue(interpreter.AddressValue(address))
+			return newTestAuthAccountValue(inter, interpreter.AddressValue(address))
 		},
 		Kind: common.DeclarationKindConstant,
 	}
@@ -8676,8 +8676,8 @@ func TestInterpretResourceOwnerFieldUse(t *testing.T) {
 					valueDeclaration,
 				}),
 				interpreter.WithPublicAccountHandler(
-					func(_ *interpreter.Interpreter, address interpreter.AddressValue) interpreter.Value {
-						return newTestPublicAccountValue(address)
+					func(inter *interpreter.Interpreter, address interpreter.AddressValue) interpreter.Value {
+						return newTestPublicAccountValue(inter, address)
 					},
 				),
 			},
@@ -8700,6 +8700,7 @@ func TestInterpretResourceOwnerFieldUse(t *testing.T) {
 }
 
 func newTestAuthAccountValue(
+	inter *interpreter.Interpreter,
 	addressValue interpreter.AddressValue,
 ) interpreter.Value {
 
@@ -8711,6 +8712,7 @@ func newTestAuthAccountValue(
 	)
 
 	return interpreter.NewAuthAccountValue(
+		inter,
 		addressValue,
 		returnZeroUFix64,
 		returnZeroUFix64,
@@ -8750,6 +8752,7 @@ func newTestAuthAccountValue(
 }
 
 func newTestPublicAccountValue(
+	inter *interpreter.Interpreter,
 	addressValue interpreter.AddressValue,
 ) interpreter.Value {
 
@@ -8761,6 +8764,7 @@ func newTestPublicAccountValue(
 	)
 
 	return interpreter.NewPublicAccountValue(
+		inter,
 		addressValue,
 		returnZeroUFix64,
 		returnZeroUFix64,
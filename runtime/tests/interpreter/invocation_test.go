@@ -23,7 +23,9 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/tests/checker"
 )
 
 func TestInterpretFunctionInvocationCheckArgumentTypes(t *testing.T) {
@@ -41,3 +43,50 @@ func TestInterpretFunctionInvocationCheckArgumentTypes(t *testing.T) {
 
 	require.ErrorAs(t, err, &interpreter.ValueTransferTypeError{})
 }
+
+func TestInterpretNativeFunctionInvocation(t *testing.T) {
+
+	t.Parallel()
+
+	// Native functions may only be declared in the standard library
+
+	checker, err := checker.ParseAndCheckWithOptions(t,
+		`
+          native fun foo(): Int
+
+          struct S {
+              native fun bar()
+          }
+
+          fun test() {
+              S().bar()
+          }
+        `,
+		checker.ParseAndCheckOptions{
+			Location: common.IdentifierLocation("Test"),
+		},
+	)
+	require.NoError(t, err)
+
+	inter, err := interpreter.NewInterpreter(
+		interpreter.ProgramFromChecker(checker),
+		checker.Location,
+		interpreter.WithStorage(interpreter.NewInMemoryStorage()),
+	)
+	require.NoError(t, err)
+
+	err = inter.Interpret()
+	require.NoError(t, err)
+
+	_, err = inter.Invoke("foo")
+	require.Error(t, err)
+
+	require.ErrorAs(t, err, &interpreter.NativeFunctionNotImplementedError{})
+
+	_, err = inter.Invoke("test")
+	require.Error(t, err)
+
+	var nativeErr interpreter.NativeFunctionNotImplementedError
+	require.ErrorAs(t, err, &nativeErr)
+	require.Equal(t, "bar", nativeErr.Name)
+}
@@ -713,8 +713,8 @@ func TestInterpretReferenceUseAfterShiftStatementMove(t *testing.T) {
 			ParseCheckAndInterpretOptions{
 				Options: []interpreter.Option{
 					interpreter.WithPublicAccountHandler(
-						func(_ *interpreter.Interpreter, address interpreter.AddressValue) interpreter.Value {
-							return newTestPublicAccountValue(address)
+						func(inter *interpreter.Interpreter, address interpreter.AddressValue) interpreter.Value {
+							return newTestPublicAccountValue(inter, address)
 						},
 					),
 				},
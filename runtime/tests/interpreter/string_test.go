@@ -341,3 +341,31 @@ func TestInterpretCompareCharacters(t *testing.T) {
 		inter.Globals["z"].GetValue(),
 	)
 }
+
+func TestInterpretStringInterpolation(t *testing.T) {
+
+	t.Parallel()
+
+	inter := parseCheckAndInterpret(t, `
+      struct S {
+          fun toString(): String {
+              return "S"
+          }
+      }
+
+      fun test(): String {
+          let name = "Cadence"
+          return "Hello, \(name)! \(1 + 2) \(S()) \("[\(0x1 as Address)]")\\(x)"
+      }
+    `)
+
+	result, err := inter.Invoke("test")
+	require.NoError(t, err)
+
+	RequireValuesEqual(
+		t,
+		inter,
+		interpreter.NewStringValue(`Hello, Cadence! 3 S [0x0000000000000001]\(x)`),
+		result,
+	)
+}
@@ -222,9 +222,11 @@ func TestInterpretTransactions(t *testing.T) {
         `)
 
 		signer1 := newTestAuthAccountValue(
+			inter,
 			interpreter.AddressValue{0, 0, 0, 0, 0, 0, 0, 1},
 		)
 		signer2 := newTestAuthAccountValue(
+			inter,
 			interpreter.AddressValue{0, 0, 0, 0, 0, 0, 0, 2},
 		)
 
@@ -259,6 +261,7 @@ func TestInterpretTransactions(t *testing.T) {
 
 		prepareArguments := []interpreter.Value{
 			newTestAuthAccountValue(
+				inter,
 				interpreter.AddressValue{},
 			),
 		}
@@ -0,0 +1,86 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package edit provides text edits of source code,
+// e.g. for refactorings in language servers and editors.
+//
+package edit
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/onflow/cadence/runtime/ast"
+)
+
+// TextEdit replaces the source code in the given range with the new text.
+// As in the AST, the end position of the range is inclusive.
+//
+type TextEdit struct {
+	ast.Range
+	NewText string
+}
+
+// OverlappingEditsError is returned when two edits change the same source code.
+//
+type OverlappingEditsError struct {
+	Pos ast.Position
+}
+
+func (e *OverlappingEditsError) Error() string {
+	return fmt.Sprintf("overlapping edits at %s", e.Pos)
+}
+
+// Apply applies the given edits to the given source code.
+// Only the offsets of the ranges of the edits are used,
+// and all ranges refer to the given code, i.e. the code before any edit is applied.
+//
+func Apply(code string, edits []TextEdit) (string, error) {
+	sorted := make([]TextEdit, len(edits))
+	copy(sorted, edits)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].StartPos.Offset < sorted[j].StartPos.Offset
+	})
+
+	var result []byte
+	offset := 0
+
+	for _, edit := range sorted {
+		startOffset := edit.StartPos.Offset
+		endOffset := edit.EndPos.Offset + 1
+
+		if startOffset < offset {
+			return "", &OverlappingEditsError{
+				Pos: edit.StartPos,
+			}
+		}
+
+		if endOffset > len(code) || endOffset < startOffset {
+			return "", fmt.Errorf("invalid edit range: %s-%s", edit.StartPos, edit.EndPos)
+		}
+
+		result = append(result, code[offset:startOffset]...)
+		result = append(result, edit.NewText...)
+		offset = endOffset
+	}
+
+	result = append(result, code[offset:]...)
+
+	return string(result), nil
+}
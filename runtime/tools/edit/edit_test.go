@@ -0,0 +1,96 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/ast"
+)
+
+func newTextEdit(startOffset, endOffset int, newText string) TextEdit {
+	return TextEdit{
+		Range: ast.Range{
+			StartPos: ast.Position{Offset: startOffset},
+			EndPos:   ast.Position{Offset: endOffset},
+		},
+		NewText: newText,
+	}
+}
+
+func TestApply(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("unordered", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, err := Apply(
+			"let x = y + x",
+			[]TextEdit{
+				newTextEdit(12, 12, "value"),
+				newTextEdit(4, 4, "value"),
+			},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, "let value = y + value", result)
+	})
+
+	t.Run("no edits", func(t *testing.T) {
+
+		t.Parallel()
+
+		result, err := Apply("let x = 1", nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, "let x = 1", result)
+	})
+
+	t.Run("overlapping", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := Apply(
+			"let xyz = 1",
+			[]TextEdit{
+				newTextEdit(4, 6, "a"),
+				newTextEdit(5, 5, "b"),
+			},
+		)
+		require.IsType(t, &OverlappingEditsError{}, err)
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+
+		t.Parallel()
+
+		_, err := Apply(
+			"let x = 1",
+			[]TextEdit{
+				newTextEdit(8, 9, "2"),
+			},
+		)
+		require.Error(t, err)
+	})
+}
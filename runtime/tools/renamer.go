@@ -0,0 +1,244 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tools
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/parser2"
+	"github.com/onflow/cadence/runtime/tools/edit"
+)
+
+// UnresolvedIdentifierError is returned when there is no symbol to rename at a position.
+//
+type UnresolvedIdentifierError struct {
+	Pos ast.Position
+}
+
+func (e *UnresolvedIdentifierError) Error() string {
+	return fmt.Sprintf("no renamable identifier at %s", e.Pos)
+}
+
+// UnsupportedRenameError is returned when a symbol cannot be renamed,
+// e.g. `self` or an imported declaration.
+//
+type UnsupportedRenameError struct {
+	Name string
+	Kind common.DeclarationKind
+}
+
+func (e *UnsupportedRenameError) Error() string {
+	return fmt.Sprintf("cannot rename %s `%s`", e.Kind.Name(), e.Name)
+}
+
+// InvalidIdentifierError is returned when the new name is not a valid identifier.
+//
+type InvalidIdentifierError struct {
+	Name string
+}
+
+func (e *InvalidIdentifierError) Error() string {
+	return fmt.Sprintf("invalid identifier: `%s`", e.Name)
+}
+
+// NameCollisionError is returned when the new name collides with another declaration,
+// i.e. when the rename would redeclare a name in a scope,
+// or would change which declaration an identifier refers to.
+//
+type NameCollisionError struct {
+	Name string
+	Pos  ast.Position
+}
+
+func (e *NameCollisionError) Error() string {
+	return fmt.Sprintf(
+		"cannot rename to `%s`: collides with another declaration at %s",
+		e.Name,
+		e.Pos,
+	)
+}
+
+// Renamer renames symbols, i.e. a declaration and all references to it.
+//
+// References are resolved using the symbol table,
+// so only references which the symbol table resolves are renamed.
+// For example, fields are only renamed where they are accessed through `self`.
+//
+type Renamer struct{}
+
+// Rename renames the symbol at the given position to the given new name.
+// Only the offset of the position is used.
+//
+// The given program is updated in place and returned,
+// together with the edits which apply the rename to the source code of the program.
+// If the rename fails, the program is left unchanged.
+//
+func (r *Renamer) Rename(program *ast.Program, pos ast.Position, newName string) (*ast.Program, []edit.TextEdit, error) {
+	symbolTable, err := BuildSymbolTable(program)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	symbol, ok := symbolTable.LookupAt(pos)
+	if !ok {
+		return nil, nil, &UnresolvedIdentifierError{
+			Pos: pos,
+		}
+	}
+
+	switch symbol.Kind {
+	case common.DeclarationKindSelf,
+		common.DeclarationKindImport:
+
+		return nil, nil, &UnsupportedRenameError{
+			Name: symbol.Identifier.Identifier,
+			Kind: symbol.Kind,
+		}
+	}
+
+	if !isIdentifier(newName) {
+		return nil, nil, &InvalidIdentifierError{
+			Name: newName,
+		}
+	}
+
+	oldName := symbol.Identifier.Identifier
+	if newName == oldName {
+		return program, nil, nil
+	}
+
+	before := symbolTable.resolutions()
+
+	// Determine the edits before renaming,
+	// as the end position of an identifier depends on its name
+
+	var identifiers []*ast.Identifier
+	var edits []edit.TextEdit
+
+	for _, occurrence := range symbolTable.occurrences {
+		if occurrence.symbol != symbol {
+			continue
+		}
+
+		identifiers = append(identifiers, occurrence.identifier)
+		edits = append(
+			edits,
+			edit.TextEdit{
+				Range:   ast.NewRangeFromPositioned(occurrence.identifier),
+				NewText: newName,
+			},
+		)
+	}
+
+	setName := func(name string) {
+		for _, identifier := range identifiers {
+			identifier.Identifier = name
+		}
+	}
+
+	setName(newName)
+
+	// Resolve the renamed program and ensure that no name collides:
+	// No name may be redeclared, and all identifiers must refer to the same declarations as before
+
+	err = checkResolutions(program, newName, before)
+	if err != nil {
+		setName(oldName)
+		return nil, nil, err
+	}
+
+	return program, edits, nil
+}
+
+// isIdentifier returns true if the given name is a valid identifier,
+// and not e.g. a literal
+//
+func isIdentifier(name string) bool {
+	expression, errs := parser2.ParseExpression(name)
+	if len(errs) > 0 {
+		return false
+	}
+
+	identifierExpression, ok := expression.(*ast.IdentifierExpression)
+	return ok && identifierExpression.Identifier.Identifier == name
+}
+
+// symbolKey identifies a symbol across symbol tables of the same program
+//
+type symbolKey struct {
+	offset int
+	kind   common.DeclarationKind
+}
+
+func newSymbolKey(symbol *Symbol) symbolKey {
+	return symbolKey{
+		offset: symbol.Identifier.Pos.Offset,
+		kind:   symbol.Kind,
+	}
+}
+
+// resolutions returns the symbols which the identifiers refer to, by their start offsets
+//
+func (t *SymbolTable) resolutions() map[int]symbolKey {
+	result := make(map[int]symbolKey, len(t.occurrences))
+	for _, occurrence := range t.occurrences {
+		result[occurrence.startOffset] = newSymbolKey(occurrence.symbol)
+	}
+	return result
+}
+
+func checkResolutions(program *ast.Program, name string, expected map[int]symbolKey) error {
+	symbolTable, err := BuildSymbolTable(program)
+	if err != nil {
+		if redeclarationError, ok := err.(*RedeclarationError); ok {
+			return &NameCollisionError{
+				Name: name,
+				Pos:  redeclarationError.PreviousPos,
+			}
+		}
+		return err
+	}
+
+	for _, occurrence := range symbolTable.occurrences {
+		key, ok := expected[occurrence.startOffset]
+		if !ok || key != newSymbolKey(occurrence.symbol) {
+			return &NameCollisionError{
+				Name: name,
+				Pos:  occurrence.identifier.Pos,
+			}
+		}
+	}
+
+	// All identifiers which were resolved before must still be resolved
+
+	if len(symbolTable.occurrences) != len(expected) {
+		for offset := range expected {
+			if _, ok := symbolTable.LookupAt(ast.Position{Offset: offset}); !ok {
+				return &NameCollisionError{
+					Name: name,
+					Pos:  ast.Position{Offset: offset},
+				}
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,263 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/parser2"
+	"github.com/onflow/cadence/runtime/tools/edit"
+)
+
+// rename renames the given identifier in the n-th occurrence of the given context,
+// and returns the renamed program and the edited code
+//
+func rename(
+	t *testing.T,
+	code string,
+	context string,
+	identifier string,
+	n int,
+	newName string,
+) (*ast.Program, string, error) {
+	program, err := parser2.ParseProgram(code)
+	require.NoError(t, err)
+
+	offset := offsetOf(t, code, context, identifier, n)
+
+	renamer := &Renamer{}
+	renamedProgram, edits, err := renamer.Rename(program, ast.Position{Offset: offset}, newName)
+	if err != nil {
+		return nil, "", err
+	}
+
+	newCode, err := edit.Apply(code, edits)
+	require.NoError(t, err)
+
+	return renamedProgram, newCode, nil
+}
+
+func TestRenamer(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("local variable", func(t *testing.T) {
+
+		t.Parallel()
+
+		const code = `
+          fun test(): Int {
+              let x = 1
+              let y = x + 2
+              if true {
+                  let x = 3
+                  return x
+              }
+              return x + y
+          }
+        `
+
+		program, newCode, err := rename(t, code, "let x = 1", "x", 0, "value")
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			`
+          fun test(): Int {
+              let value = 1
+              let y = value + 2
+              if true {
+                  let x = 3
+                  return x
+              }
+              return value + y
+          }
+        `,
+			newCode,
+		)
+
+		// The AST is renamed
+
+		statements := program.FunctionDeclarations()[0].FunctionBlock.Block.Statements
+		assert.Equal(t,
+			"value",
+			statements[0].(*ast.VariableDeclaration).Identifier.Identifier,
+		)
+	})
+
+	t.Run("parameter", func(t *testing.T) {
+
+		t.Parallel()
+
+		const code = `
+          fun add(a: Int, b: Int): Int {
+              return a + b
+          }
+        `
+
+		program, newCode, err := rename(t, code, "a + b", "a", 0, "first")
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			`
+          fun add(first: Int, b: Int): Int {
+              return first + b
+          }
+        `,
+			newCode,
+		)
+
+		parameters := program.FunctionDeclarations()[0].ParameterList.Parameters
+		assert.Equal(t, "first", parameters[0].Identifier.Identifier)
+	})
+
+	t.Run("struct field", func(t *testing.T) {
+
+		t.Parallel()
+
+		const code = `
+          struct S {
+              let x: Int
+
+              init() {
+                  self.x = 1
+              }
+
+              fun get(): Int {
+                  return self.x
+              }
+          }
+        `
+
+		program, newCode, err := rename(t, code, "let x: Int", "x", 0, "count")
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			`
+          struct S {
+              let count: Int
+
+              init() {
+                  self.count = 1
+              }
+
+              fun get(): Int {
+                  return self.count
+              }
+          }
+        `,
+			newCode,
+		)
+
+		fields := program.CompositeDeclarations()[0].Members.Fields()
+		assert.Equal(t, "count", fields[0].Identifier.Identifier)
+	})
+
+	t.Run("redeclaration", func(t *testing.T) {
+
+		t.Parallel()
+
+		const code = `
+          fun test() {
+              let x = 1
+              let y = 2
+          }
+        `
+
+		program, err := parser2.ParseProgram(code)
+		require.NoError(t, err)
+
+		offset := offsetOf(t, code, "let x", "x", 0)
+
+		_, _, err = (&Renamer{}).Rename(program, ast.Position{Offset: offset}, "y")
+		require.IsType(t, &NameCollisionError{}, err)
+
+		// The program is unchanged
+
+		statements := program.FunctionDeclarations()[0].FunctionBlock.Block.Statements
+		assert.Equal(t,
+			"x",
+			statements[0].(*ast.VariableDeclaration).Identifier.Identifier,
+		)
+	})
+
+	t.Run("shadowing", func(t *testing.T) {
+
+		t.Parallel()
+
+		const code = `
+          let x = 1
+
+          fun test(): Int {
+              let y = 2
+              return x + y
+          }
+        `
+
+		_, _, err := rename(t, code, "let x", "x", 0, "y")
+		require.IsType(t, &NameCollisionError{}, err)
+	})
+
+	t.Run("invalid identifier", func(t *testing.T) {
+
+		t.Parallel()
+
+		const code = `
+          let x = 1
+        `
+
+		for _, name := range []string{"", "1x", "true", "nil", "x.y", "x y"} {
+			_, _, err := rename(t, code, "let x", "x", 0, name)
+			require.IsType(t, &InvalidIdentifierError{}, err, name)
+		}
+	})
+
+	t.Run("self", func(t *testing.T) {
+
+		t.Parallel()
+
+		const code = `
+          struct S {
+              let x: Int
+
+              init() {
+                  self.x = 1
+              }
+          }
+        `
+
+		_, _, err := rename(t, code, "self", "self", 0, "this")
+		require.IsType(t, &UnsupportedRenameError{}, err)
+	})
+
+	t.Run("unresolved", func(t *testing.T) {
+
+		t.Parallel()
+
+		const code = `
+          let x = panic("")
+        `
+
+		_, _, err := rename(t, code, "panic", "panic", 0, "fail")
+		require.IsType(t, &UnresolvedIdentifierError{}, err)
+	})
+}
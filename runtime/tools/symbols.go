@@ -0,0 +1,781 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package tools provides functionality for developer tools,
+// e.g. language servers and editors.
+//
+package tools
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// Symbol is a named entity declared in a program,
+// e.g. a function, a composite, a variable, or a parameter.
+//
+type Symbol struct {
+	Identifier ast.Identifier
+	Kind       common.DeclarationKind
+	// Declaration is the declaration of the symbol.
+	// It is nil for parameters and for-loop variables.
+	// For `self`, it is the enclosing composite or transaction declaration
+	Declaration ast.Declaration
+	// Parameter is the parameter which declares the symbol, if any
+	Parameter *ast.Parameter
+	// members are the members of composites, interfaces, and transactions,
+	// which are accessed through member expressions, e.g. `self.x`
+	members map[string]*Symbol
+	// declaredIdentifier is the identifier in the AST which declares the symbol
+	declaredIdentifier *ast.Identifier
+}
+
+// RedeclarationError is returned when a name is declared more than once in the same scope.
+//
+type RedeclarationError struct {
+	Name        string
+	Pos         ast.Position
+	PreviousPos ast.Position
+}
+
+func (e *RedeclarationError) Error() string {
+	return fmt.Sprintf(
+		"cannot redeclare `%s` at %s: already declared at %s",
+		e.Name,
+		e.Pos,
+		e.PreviousPos,
+	)
+}
+
+const selfIdentifier = "self"
+
+type occurrence struct {
+	// identifier is the identifier in the AST
+	identifier  *ast.Identifier
+	startOffset int
+	endOffset   int
+	symbol      *Symbol
+}
+
+// SymbolTable maps the occurrences of identifiers in a program to the symbols they refer to.
+//
+type SymbolTable struct {
+	// occurrences are sorted by start offset
+	occurrences []occurrence
+}
+
+// LookupAt returns the symbol which the identifier at the given position refers to.
+// Only the offset of the position is used.
+//
+// Identifiers which refer to symbols which are not declared in the program,
+// e.g. built-in types and functions, or members of values of unknown type, are not resolved.
+//
+func (t *SymbolTable) LookupAt(pos ast.Position) (*Symbol, bool) {
+	offset := pos.Offset
+
+	// Find the last occurrence which starts at or before the offset
+
+	index := sort.Search(len(t.occurrences), func(i int) bool {
+		return t.occurrences[i].startOffset > offset
+	}) - 1
+
+	if index < 0 {
+		return nil, false
+	}
+
+	occurrence := t.occurrences[index]
+	if offset > occurrence.endOffset {
+		return nil, false
+	}
+
+	return occurrence.symbol, true
+}
+
+// BuildSymbolTable resolves all identifiers in the given program
+// and returns a symbol table for them.
+//
+// Names are resolved lexically, without type information:
+// Top-level declarations are visible in the whole program,
+// members of composites and transactions are only resolved when accessed through `self`,
+// or through the name of the composite, e.g. for nested types and enum cases,
+// and local declarations are visible after they are declared.
+//
+func BuildSymbolTable(program *ast.Program) (*SymbolTable, error) {
+	r := &resolver{}
+	r.resolveProgram(program)
+
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	sort.SliceStable(r.occurrences, func(i, j int) bool {
+		return r.occurrences[i].startOffset < r.occurrences[j].startOffset
+	})
+
+	return &SymbolTable{
+		occurrences: r.occurrences,
+	}, nil
+}
+
+type scope struct {
+	parent *scope
+	values map[string]*Symbol
+	types  map[string]*Symbol
+}
+
+func newScope(parent *scope) *scope {
+	return &scope{
+		parent: parent,
+		values: map[string]*Symbol{},
+		types:  map[string]*Symbol{},
+	}
+}
+
+func (s *scope) lookupValue(name string) *Symbol {
+	for current := s; current != nil; current = current.parent {
+		if symbol, ok := current.values[name]; ok {
+			return symbol
+		}
+	}
+	return nil
+}
+
+func (s *scope) lookupType(name string) *Symbol {
+	for current := s; current != nil; current = current.parent {
+		if symbol, ok := current.types[name]; ok {
+			return symbol
+		}
+	}
+	return nil
+}
+
+type resolver struct {
+	scope       *scope
+	occurrences []occurrence
+	// err is the first error which occurred
+	err error
+}
+
+func (r *resolver) enterScope() {
+	r.scope = newScope(r.scope)
+}
+
+func (r *resolver) leaveScope() {
+	r.scope = r.scope.parent
+}
+
+func (r *resolver) record(identifier *ast.Identifier, symbol *Symbol) {
+	if symbol == nil || identifier == nil || identifier.Identifier == "" {
+		return
+	}
+
+	r.occurrences = append(
+		r.occurrences,
+		occurrence{
+			identifier:  identifier,
+			startOffset: identifier.StartPosition().Offset,
+			endOffset:   identifier.EndPosition().Offset,
+			symbol:      symbol,
+		},
+	)
+}
+
+func (r *resolver) declare(symbols map[string]*Symbol, symbol *Symbol) {
+	name := symbol.Identifier.Identifier
+
+	if previous, ok := symbols[name]; ok {
+		if r.err == nil {
+			r.err = &RedeclarationError{
+				Name:        name,
+				Pos:         symbol.Identifier.Pos,
+				PreviousPos: previous.Identifier.Pos,
+			}
+		}
+		return
+	}
+
+	symbols[name] = symbol
+	r.record(symbol.declaredIdentifier, symbol)
+}
+
+func (r *resolver) declareValue(symbol *Symbol) {
+	r.declare(r.scope.values, symbol)
+}
+
+func (r *resolver) declareType(symbol *Symbol) {
+	r.declare(r.scope.types, symbol)
+}
+
+// declareValueAndType declares a symbol which is both a type and a value,
+// e.g. a composite, which can be used as a type and as a constructor.
+// The occurrence of the identifier is only recorded once.
+//
+func (r *resolver) declareValueAndType(symbol *Symbol) {
+	r.declare(r.scope.types, symbol)
+
+	name := symbol.Identifier.Identifier
+	if _, ok := r.scope.values[name]; !ok {
+		r.scope.values[name] = symbol
+	}
+}
+
+func declarationSymbol(declaration ast.Declaration) *Symbol {
+	identifier := declaration.DeclarationIdentifier()
+	return &Symbol{
+		Identifier:         *identifier,
+		Kind:               declaration.DeclarationKind(),
+		Declaration:        declaration,
+		declaredIdentifier: identifier,
+	}
+}
+
+// patternSymbols returns a symbol for each identifier bound by the tuple pattern of the given declaration,
+// including the identifiers of nested patterns. Wildcards are not declared
+//
+func patternSymbols(declaration *ast.VariableDeclaration) []*Symbol {
+	var symbols []*Symbol
+
+	var declarePattern func(pattern *ast.TuplePattern)
+	declarePattern = func(pattern *ast.TuplePattern) {
+		for _, element := range pattern.Elements {
+			switch {
+			case element.Pattern != nil:
+				declarePattern(element.Pattern)
+
+			case element.Identifier != nil && element.Identifier.Identifier != "_":
+				symbols = append(symbols, &Symbol{
+					Identifier:         *element.Identifier,
+					Kind:               declaration.DeclarationKind(),
+					Declaration:        declaration,
+					declaredIdentifier: element.Identifier,
+				})
+			}
+		}
+	}
+
+	declarePattern(declaration.Pattern)
+
+	return symbols
+}
+
+// declareVariableDeclaration declares the values of the given variable declaration in the current scope,
+// i.e. the variable, or the identifiers of the tuple pattern of a destructuring declaration
+//
+func (r *resolver) declareVariableDeclaration(declaration *ast.VariableDeclaration) {
+	if declaration.Pattern == nil {
+		r.declareValue(declarationSymbol(declaration))
+		return
+	}
+
+	for _, symbol := range patternSymbols(declaration) {
+		r.declareValue(symbol)
+	}
+}
+
+func (r *resolver) resolveProgram(program *ast.Program) {
+	r.enterScope()
+	defer r.leaveScope()
+
+	declarations := program.Declarations()
+
+	// Top-level declarations are visible in the whole program,
+	// so declare them all before resolving any of them
+
+	symbols := make([]*Symbol, len(declarations))
+
+	for i, declaration := range declarations {
+		symbols[i] = r.declareTopLevelDeclaration(declaration)
+	}
+
+	for i, declaration := range declarations {
+		switch declaration := declaration.(type) {
+		case *ast.VariableDeclaration:
+			r.resolveVariableDeclarationValue(declaration)
+
+		case *ast.TransactionDeclaration:
+			r.resolveTransactionDeclaration(declaration)
+
+		default:
+			r.resolveMemberDeclaration(declaration, symbols[i])
+		}
+	}
+}
+
+func (r *resolver) declareTopLevelDeclaration(declaration ast.Declaration) *Symbol {
+	switch declaration := declaration.(type) {
+	case *ast.ImportDeclaration:
+		for i := range declaration.Identifiers {
+			identifier := &declaration.Identifiers[i]
+			r.declareValueAndType(&Symbol{
+				Identifier:         *identifier,
+				Kind:               common.DeclarationKindImport,
+				Declaration:        declaration,
+				declaredIdentifier: identifier,
+			})
+		}
+		return nil
+
+	case *ast.VariableDeclaration:
+		if declaration.Pattern != nil {
+			r.declareVariableDeclaration(declaration)
+			return nil
+		}
+
+		return r.declareMember(r.scope, declaration)
+
+	case *ast.TransactionDeclaration,
+		*ast.PragmaDeclaration:
+
+		return nil
+
+	default:
+		return r.declareMember(r.scope, declaration)
+	}
+}
+
+// declareMember declares the given composite member or top-level declaration in the given scope.
+// The members of composites and interfaces are declared recursively.
+//
+func (r *resolver) declareMember(scope *scope, declaration ast.Declaration) *Symbol {
+	symbol := declarationSymbol(declaration)
+
+	switch declaration := declaration.(type) {
+	case *ast.CompositeDeclaration:
+		symbol.members = r.declareMembers(declaration.Members)
+		r.declare(scope.types, symbol)
+		if _, ok := scope.values[symbol.Identifier.Identifier]; !ok {
+			scope.values[symbol.Identifier.Identifier] = symbol
+		}
+
+	case *ast.InterfaceDeclaration:
+		symbol.members = r.declareMembers(declaration.Members)
+		r.declare(scope.types, symbol)
+
+	case *ast.TypeAliasDeclaration:
+		r.declare(scope.types, symbol)
+
+	default:
+		r.declare(scope.values, symbol)
+	}
+
+	return symbol
+}
+
+// declareMembers declares the fields, functions, enum cases, and nested types
+// of a composite or interface. Special functions are not members.
+//
+func (r *resolver) declareMembers(members *ast.Members) map[string]*Symbol {
+	memberScope := newScope(nil)
+
+	for _, declaration := range members.Declarations() {
+		if _, ok := declaration.(*ast.SpecialFunctionDeclaration); ok {
+			continue
+		}
+
+		r.declareMember(memberScope, declaration)
+	}
+
+	// Nested types are both types and members,
+	// so merge both namespaces
+
+	symbols := memberScope.values
+	for name, symbol := range memberScope.types {
+		symbols[name] = symbol
+	}
+
+	return symbols
+}
+
+// resolveMemberDeclaration resolves the given composite member or top-level declaration,
+// which was declared by declareMember as the given symbol.
+//
+func (r *resolver) resolveMemberDeclaration(declaration ast.Declaration, symbol *Symbol) {
+	switch declaration := declaration.(type) {
+	case *ast.CompositeDeclaration:
+		r.resolveNominalTypes(declaration.Conformances)
+		r.resolveComposite(declaration.Members, symbol)
+
+	case *ast.InterfaceDeclaration:
+		r.resolveComposite(declaration.Members, symbol)
+
+	case *ast.FunctionDeclaration:
+		r.resolveFunction(
+			declaration.ParameterList,
+			declaration.ReturnTypeAnnotation,
+			declaration.FunctionBlock,
+		)
+
+	case *ast.SpecialFunctionDeclaration:
+		function := declaration.FunctionDeclaration
+		r.resolveFunction(
+			function.ParameterList,
+			function.ReturnTypeAnnotation,
+			function.FunctionBlock,
+		)
+
+	case *ast.FieldDeclaration:
+		r.resolveTypeAnnotation(declaration.TypeAnnotation)
+	}
+}
+
+func (r *resolver) resolveComposite(members *ast.Members, symbol *Symbol) {
+	r.enterScope()
+	defer r.leaveScope()
+
+	// Nested types are in scope,
+	// and nested composites can also be used as values, e.g. to construct them
+
+	for name, member := range symbol.members {
+		switch member.Declaration.(type) {
+		case *ast.CompositeDeclaration:
+			r.scope.types[name] = member
+			r.scope.values[name] = member
+
+		case *ast.InterfaceDeclaration:
+			r.scope.types[name] = member
+		}
+	}
+
+	r.scope.values[selfIdentifier] = &Symbol{
+		Identifier:  symbol.Identifier,
+		Kind:        common.DeclarationKindSelf,
+		Declaration: symbol.Declaration,
+		members:     symbol.members,
+	}
+
+	for _, declaration := range members.Declarations() {
+		var memberSymbol *Symbol
+		if identifier := declaration.DeclarationIdentifier(); identifier != nil {
+			memberSymbol = symbol.members[identifier.Identifier]
+		}
+
+		r.resolveMemberDeclaration(declaration, memberSymbol)
+	}
+}
+
+func (r *resolver) resolveTransactionDeclaration(declaration *ast.TransactionDeclaration) {
+	r.enterScope()
+	defer r.leaveScope()
+
+	r.declareParameters(declaration.ParameterList)
+
+	fieldScope := newScope(nil)
+	for _, field := range declaration.Fields {
+		r.resolveTypeAnnotation(field.TypeAnnotation)
+		r.declare(fieldScope.values, declarationSymbol(field))
+	}
+
+	r.scope.values[selfIdentifier] = &Symbol{
+		Kind:        common.DeclarationKindSelf,
+		Declaration: declaration,
+		members:     fieldScope.values,
+	}
+
+	if declaration.Prepare != nil {
+		r.resolveMemberDeclaration(declaration.Prepare, nil)
+	}
+
+	r.resolveConditions(declaration.PreConditions)
+
+	if declaration.Execute != nil {
+		r.resolveMemberDeclaration(declaration.Execute, nil)
+	}
+
+	r.resolveConditions(declaration.PostConditions)
+}
+
+func (r *resolver) declareParameters(parameterList *ast.ParameterList) {
+	if parameterList == nil {
+		return
+	}
+
+	for _, parameter := range parameterList.Parameters {
+		r.resolveTypeAnnotation(parameter.TypeAnnotation)
+
+		r.declareValue(&Symbol{
+			Identifier:         parameter.Identifier,
+			Kind:               common.DeclarationKindParameter,
+			Parameter:          parameter,
+			declaredIdentifier: &parameter.Identifier,
+		})
+	}
+}
+
+func (r *resolver) resolveFunction(
+	parameterList *ast.ParameterList,
+	returnTypeAnnotation *ast.TypeAnnotation,
+	functionBlock *ast.FunctionBlock,
+) {
+	r.enterScope()
+	defer r.leaveScope()
+
+	r.declareParameters(parameterList)
+	r.resolveTypeAnnotation(returnTypeAnnotation)
+
+	if functionBlock == nil {
+		return
+	}
+
+	r.resolveConditions(functionBlock.PreConditions)
+	r.resolveBlock(functionBlock.Block)
+	r.resolveConditions(functionBlock.PostConditions)
+}
+
+func (r *resolver) resolveConditions(conditions *ast.Conditions) {
+	if conditions == nil {
+		return
+	}
+
+	for _, condition := range *conditions {
+		r.resolveExpression(condition.Test)
+		if condition.Message != nil {
+			r.resolveExpression(condition.Message)
+		}
+	}
+}
+
+func (r *resolver) resolveBlock(block *ast.Block) {
+	if block == nil {
+		return
+	}
+
+	r.resolveStatements(block.Statements)
+}
+
+// resolveStatements resolves the given statements in a new scope
+//
+func (r *resolver) resolveStatements(statements []ast.Statement) {
+	r.enterScope()
+	defer r.leaveScope()
+
+	for _, statement := range statements {
+		r.resolveStatement(statement)
+	}
+}
+
+func (r *resolver) resolveStatement(statement ast.Statement) {
+	switch statement := statement.(type) {
+	case *ast.VariableDeclaration:
+		r.resolveVariableDeclarationValue(statement)
+		r.declareVariableDeclaration(statement)
+
+	case *ast.FunctionDeclaration:
+		// Declare the function before resolving it,
+		// so it can be called recursively
+		r.declareValue(declarationSymbol(statement))
+		r.resolveFunction(
+			statement.ParameterList,
+			statement.ReturnTypeAnnotation,
+			statement.FunctionBlock,
+		)
+
+	case *ast.IfStatement:
+		switch test := statement.Test.(type) {
+		case *ast.VariableDeclaration:
+			// The variable of an optional binding is only declared in the then-branch
+			r.resolveVariableDeclarationValue(test)
+
+			r.enterScope()
+			r.declareValue(declarationSymbol(test))
+			r.resolveBlock(statement.Then)
+			r.leaveScope()
+
+		case ast.Expression:
+			r.resolveExpression(test)
+			r.resolveBlock(statement.Then)
+		}
+
+		r.resolveBlock(statement.Else)
+
+	case *ast.WhileStatement:
+		r.resolveExpression(statement.Test)
+		r.resolveBlock(statement.Block)
+
+	case *ast.ForStatement:
+		r.resolveExpression(statement.Value)
+
+		r.enterScope()
+		if statement.Index != nil {
+			r.declareValue(&Symbol{
+				Identifier:         *statement.Index,
+				Kind:               common.DeclarationKindConstant,
+				declaredIdentifier: statement.Index,
+			})
+		}
+		r.declareValue(&Symbol{
+			Identifier:         statement.Identifier,
+			Kind:               common.DeclarationKindConstant,
+			declaredIdentifier: &statement.Identifier,
+		})
+		r.resolveBlock(statement.Block)
+		r.leaveScope()
+
+	case *ast.SwitchStatement:
+		r.resolveExpression(statement.Expression)
+		for _, switchCase := range statement.Cases {
+			if switchCase.Expression != nil {
+				r.resolveExpression(switchCase.Expression)
+			}
+			r.resolveStatements(switchCase.Statements)
+		}
+
+	default:
+		statement.Walk(r.resolveElement)
+	}
+}
+
+func (r *resolver) resolveVariableDeclarationValue(declaration *ast.VariableDeclaration) {
+	r.resolveExpression(declaration.Value)
+	if declaration.SecondValue != nil {
+		r.resolveExpression(declaration.SecondValue)
+	}
+	r.resolveTypeAnnotation(declaration.TypeAnnotation)
+}
+
+func (r *resolver) resolveElement(element ast.Element) {
+	switch element := element.(type) {
+	case ast.Expression:
+		r.resolveExpression(element)
+
+	case ast.Statement:
+		r.resolveStatement(element)
+
+	default:
+		element.Walk(r.resolveElement)
+	}
+}
+
+// resolveExpression resolves the given expression,
+// and returns the symbol which the expression refers to, if any.
+//
+func (r *resolver) resolveExpression(expression ast.Expression) *Symbol {
+	switch expression := expression.(type) {
+	case *ast.IdentifierExpression:
+		symbol := r.scope.lookupValue(expression.Identifier.Identifier)
+		r.record(&expression.Identifier, symbol)
+		return symbol
+
+	case *ast.MemberExpression:
+		symbol := r.resolveExpression(expression.Expression)
+		if symbol == nil {
+			return nil
+		}
+
+		member := symbol.members[expression.Identifier.Identifier]
+		r.record(&expression.Identifier, member)
+		return member
+
+	case *ast.FunctionExpression:
+		r.resolveFunction(
+			expression.ParameterList,
+			expression.ReturnTypeAnnotation,
+			expression.FunctionBlock,
+		)
+
+	case *ast.CastingExpression:
+		r.resolveExpression(expression.Expression)
+		r.resolveTypeAnnotation(expression.TypeAnnotation)
+
+	case *ast.InvocationExpression:
+		r.resolveExpression(expression.InvokedExpression)
+		for _, typeArgument := range expression.TypeArguments {
+			r.resolveTypeAnnotation(typeArgument)
+		}
+		for _, argument := range expression.Arguments {
+			r.resolveExpression(argument.Expression)
+		}
+
+	case *ast.ReferenceExpression:
+		r.resolveExpression(expression.Expression)
+		r.resolveType(expression.Type)
+
+	default:
+		expression.Walk(r.resolveElement)
+	}
+
+	return nil
+}
+
+func (r *resolver) resolveTypeAnnotation(typeAnnotation *ast.TypeAnnotation) {
+	if typeAnnotation == nil {
+		return
+	}
+
+	r.resolveType(typeAnnotation.Type)
+}
+
+func (r *resolver) resolveNominalTypes(nominalTypes []*ast.NominalType) {
+	for _, nominalType := range nominalTypes {
+		r.resolveType(nominalType)
+	}
+}
+
+func (r *resolver) resolveType(ty ast.Type) {
+	switch ty := ty.(type) {
+	case *ast.NominalType:
+		symbol := r.scope.lookupType(ty.Identifier.Identifier)
+		r.record(&ty.Identifier, symbol)
+
+		for i := range ty.NestedIdentifiers {
+			if symbol == nil {
+				return
+			}
+
+			identifier := &ty.NestedIdentifiers[i]
+			symbol = symbol.members[identifier.Identifier]
+			r.record(identifier, symbol)
+		}
+
+	case *ast.OptionalType:
+		r.resolveType(ty.Type)
+
+	case *ast.VariableSizedType:
+		r.resolveType(ty.Type)
+
+	case *ast.ConstantSizedType:
+		r.resolveType(ty.Type)
+
+	case *ast.DictionaryType:
+		r.resolveType(ty.KeyType)
+		r.resolveType(ty.ValueType)
+
+	case *ast.FunctionType:
+		for _, parameterTypeAnnotation := range ty.ParameterTypeAnnotations {
+			r.resolveTypeAnnotation(parameterTypeAnnotation)
+		}
+		r.resolveTypeAnnotation(ty.ReturnTypeAnnotation)
+
+	case *ast.ReferenceType:
+		r.resolveType(ty.Type)
+
+	case *ast.RestrictedType:
+		if ty.Type != nil {
+			r.resolveType(ty.Type)
+		}
+		r.resolveNominalTypes(ty.Restrictions)
+
+	case *ast.InstantiationType:
+		r.resolveType(ty.Type)
+		for _, typeArgument := range ty.TypeArguments {
+			r.resolveTypeAnnotation(typeArgument)
+		}
+	}
+}
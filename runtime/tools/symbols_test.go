@@ -0,0 +1,444 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2020 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tools
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/parser2"
+)
+
+func buildSymbolTable(t *testing.T, code string) *SymbolTable {
+	program, err := parser2.ParseProgram(code)
+	require.NoError(t, err)
+
+	symbolTable, err := BuildSymbolTable(program)
+	require.NoError(t, err)
+
+	return symbolTable
+}
+
+// offsetOf returns the offset of the last occurrence of the given identifier
+// in the n-th occurrence of the given context in the code
+//
+func offsetOf(t *testing.T, code string, context string, identifier string, n int) int {
+	offset := -1
+	for i := 0; i <= n; i++ {
+		next := strings.Index(code[offset+1:], context)
+		require.GreaterOrEqual(t, next, 0)
+		offset += next + 1
+	}
+
+	index := strings.LastIndex(context, identifier)
+	require.GreaterOrEqual(t, index, 0)
+
+	return offset + index
+}
+
+// lookup returns the symbol which the given identifier
+// in the n-th occurrence of the given context refers to
+//
+func lookup(t *testing.T, symbolTable *SymbolTable, code string, context string, identifier string, n int) *Symbol {
+	offset := offsetOf(t, code, context, identifier, n)
+	symbol, ok := symbolTable.LookupAt(ast.Position{Offset: offset})
+	require.True(t, ok, "no symbol for %q in occurrence %d of %q", identifier, n, context)
+	return symbol
+}
+
+func TestBuildSymbolTable(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("globals", func(t *testing.T) {
+
+		t.Parallel()
+
+		const code = `
+          fun test(): Int {
+              return x + f()
+          }
+
+          let x = 1
+
+          fun f(): Int {
+              return x
+          }
+        `
+
+		symbolTable := buildSymbolTable(t, code)
+
+		x := lookup(t, symbolTable, code, "let x", "x", 0)
+		assert.Equal(t, "x", x.Identifier.Identifier)
+		assert.Equal(t, common.DeclarationKindConstant, x.Kind)
+		assert.IsType(t, &ast.VariableDeclaration{}, x.Declaration)
+
+		// Globals are visible before their declaration
+
+		assert.Same(t, x, lookup(t, symbolTable, code, "return x", "x", 0))
+		assert.Same(t, x, lookup(t, symbolTable, code, "return x", "x", 1))
+
+		f := lookup(t, symbolTable, code, "f(): Int", "f", 0)
+		assert.Equal(t, common.DeclarationKindFunction, f.Kind)
+		assert.Same(t, f, lookup(t, symbolTable, code, "f()", "f", 0))
+
+		// All offsets of an identifier are resolved
+
+		test := lookup(t, symbolTable, code, "test", "t", 0)
+		assert.Same(t, test, lookup(t, symbolTable, code, "test", "st", 0))
+
+		// Built-in types and whitespace are not resolved
+
+		for _, offset := range []int{
+			offsetOf(t, code, "Int", "Int", 0),
+			0,
+		} {
+			_, ok := symbolTable.LookupAt(ast.Position{Offset: offset})
+			assert.False(t, ok)
+		}
+	})
+
+	t.Run("parameters and shadowing", func(t *testing.T) {
+
+		t.Parallel()
+
+		const code = `
+          fun test(a: Int): Int {
+              let b = a
+              if true {
+                  let a = b
+                  return a
+              }
+              return a
+          }
+        `
+
+		symbolTable := buildSymbolTable(t, code)
+
+		parameter := lookup(t, symbolTable, code, "a:", "a", 0)
+		assert.Equal(t, common.DeclarationKindParameter, parameter.Kind)
+		assert.Equal(t, "a", parameter.Parameter.Identifier.Identifier)
+
+		assert.Same(t, parameter, lookup(t, symbolTable, code, "b = a", "a", 0))
+		assert.Same(t, parameter, lookup(t, symbolTable, code, "return a", "a", 1))
+
+		// The local declared in the nested block shadows the parameter
+
+		local := lookup(t, symbolTable, code, "a = b", "a", 0)
+		assert.Equal(t, common.DeclarationKindConstant, local.Kind)
+		assert.Same(t, local, lookup(t, symbolTable, code, "return a", "a", 0))
+
+		b := lookup(t, symbolTable, code, "let b", "b", 0)
+		assert.Same(t, b, lookup(t, symbolTable, code, "a = b", "b", 0))
+	})
+
+	t.Run("nested functions and closures", func(t *testing.T) {
+
+		t.Parallel()
+
+		const code = `
+          fun test(): Int {
+              var count = 0
+
+              fun increment(by: Int) {
+                  count = count + by
+              }
+
+              let add = fun (value: Int): Int {
+                  increment(by: value)
+                  return count
+              }
+
+              return add(1)
+          }
+        `
+
+		symbolTable := buildSymbolTable(t, code)
+
+		count := lookup(t, symbolTable, code, "var count", "count", 0)
+		assert.Equal(t, common.DeclarationKindVariable, count.Kind)
+		assert.Same(t, count, lookup(t, symbolTable, code, "count = count", "count", 0))
+		assert.Same(t, count, lookup(t, symbolTable, code, "= count", "count", 0))
+		assert.Same(t, count, lookup(t, symbolTable, code, "return count", "count", 0))
+
+		by := lookup(t, symbolTable, code, "by: Int", "by", 0)
+		assert.Same(t, by, lookup(t, symbolTable, code, "+ by", "by", 0))
+
+		increment := lookup(t, symbolTable, code, "fun increment", "increment", 0)
+		assert.Same(t, increment, lookup(t, symbolTable, code, "increment(by: value)", "increment", 0))
+
+		value := lookup(t, symbolTable, code, "value: Int", "value", 0)
+		assert.Equal(t, common.DeclarationKindParameter, value.Kind)
+		assert.Same(t, value, lookup(t, symbolTable, code, "by: value", "value", 0))
+
+		add := lookup(t, symbolTable, code, "let add", "add", 0)
+		assert.Same(t, add, lookup(t, symbolTable, code, "add(1)", "add", 0))
+	})
+
+	t.Run("composite members", func(t *testing.T) {
+
+		t.Parallel()
+
+		const code = `
+          pub struct S {
+              pub let x: Int
+
+              init(x: Int) {
+                  self.x = x
+              }
+
+              pub fun getX(): Int {
+                  return self.x
+              }
+
+              pub fun get(): Int {
+                  return self.getX()
+              }
+          }
+
+          fun test(): Int {
+              let s: S = S(x: 1)
+              return s.x
+          }
+        `
+
+		symbolTable := buildSymbolTable(t, code)
+
+		s := lookup(t, symbolTable, code, "struct S", "S", 0)
+		assert.Equal(t, common.DeclarationKindStructure, s.Kind)
+		assert.Same(t, s, lookup(t, symbolTable, code, "s: S", "S", 0))
+		assert.Same(t, s, lookup(t, symbolTable, code, "S(x: 1)", "S", 0))
+
+		field := lookup(t, symbolTable, code, "let x", "x", 0)
+		assert.Equal(t, common.DeclarationKindField, field.Kind)
+		assert.Same(t, field, lookup(t, symbolTable, code, "self.x =", "x", 0))
+		assert.Same(t, field, lookup(t, symbolTable, code, "return self.x", "x", 0))
+
+		parameter := lookup(t, symbolTable, code, "init(x", "x", 0)
+		assert.Equal(t, common.DeclarationKindParameter, parameter.Kind)
+		assert.Same(t, parameter, lookup(t, symbolTable, code, "= x", "x", 0))
+
+		self := lookup(t, symbolTable, code, "self.x = x", "self", 0)
+		assert.Equal(t, common.DeclarationKindSelf, self.Kind)
+		assert.Same(t, s.Declaration, self.Declaration)
+
+		getX := lookup(t, symbolTable, code, "fun getX", "getX", 0)
+		assert.Same(t, getX, lookup(t, symbolTable, code, "self.getX", "getX", 0))
+
+		// Members of values without a declared composite are not resolved
+
+		local := lookup(t, symbolTable, code, "s.x", "s", 0)
+		assert.Equal(t, common.DeclarationKindConstant, local.Kind)
+
+		_, ok := symbolTable.LookupAt(ast.Position{Offset: offsetOf(t, code, "s.x", "x", 0)})
+		assert.False(t, ok)
+	})
+
+	t.Run("nested types", func(t *testing.T) {
+
+		t.Parallel()
+
+		const code = `
+          pub contract C {
+
+              pub resource interface I {}
+
+              pub resource R: I {}
+
+              pub enum E: UInt8 {
+                  pub case a
+              }
+
+              pub fun test(): @R{I} {
+                  let e = E.a
+                  return <-create R()
+              }
+          }
+
+          fun test(r: &C.R): C.E {
+              return C.E.a
+          }
+        `
+
+		symbolTable := buildSymbolTable(t, code)
+
+		i := lookup(t, symbolTable, code, "interface I", "I", 0)
+		assert.Equal(t, common.DeclarationKindResourceInterface, i.Kind)
+		assert.Same(t, i, lookup(t, symbolTable, code, "R: I", "I", 0))
+		assert.Same(t, i, lookup(t, symbolTable, code, "R{I}", "I", 0))
+
+		r := lookup(t, symbolTable, code, "resource R", "R", 0)
+		assert.Same(t, r, lookup(t, symbolTable, code, "R{I}", "R", 0))
+		assert.Same(t, r, lookup(t, symbolTable, code, "create R", "R", 0))
+		assert.Same(t, r, lookup(t, symbolTable, code, "C.R", "R", 0))
+
+		e := lookup(t, symbolTable, code, "enum E", "E", 0)
+		assert.Same(t, e, lookup(t, symbolTable, code, "E.a", "E", 0))
+		assert.Same(t, e, lookup(t, symbolTable, code, "C.E {", "E", 0))
+		assert.Same(t, e, lookup(t, symbolTable, code, "C.E.a", "E", 0))
+
+		a := lookup(t, symbolTable, code, "case a", "a", 0)
+		assert.Equal(t, common.DeclarationKindEnumCase, a.Kind)
+		assert.Same(t, a, lookup(t, symbolTable, code, "E.a", "a", 0))
+		assert.Same(t, a, lookup(t, symbolTable, code, "C.E.a", "a", 0))
+
+		c := lookup(t, symbolTable, code, "contract C", "C", 0)
+		assert.Same(t, c, lookup(t, symbolTable, code, "C.E.a", "C", 0))
+	})
+
+	t.Run("transaction", func(t *testing.T) {
+
+		t.Parallel()
+
+		const code = `
+          transaction(amount: Int) {
+
+              let total: Int
+
+              prepare(signer: AuthAccount) {
+                  self.total = amount
+              }
+
+              post {
+                  self.total == amount
+              }
+          }
+        `
+
+		symbolTable := buildSymbolTable(t, code)
+
+		amount := lookup(t, symbolTable, code, "amount: Int", "amount", 0)
+		assert.Equal(t, common.DeclarationKindParameter, amount.Kind)
+		assert.Same(t, amount, lookup(t, symbolTable, code, "= amount", "amount", 0))
+		assert.Same(t, amount, lookup(t, symbolTable, code, "== amount", "amount", 0))
+
+		total := lookup(t, symbolTable, code, "let total", "total", 0)
+		assert.Equal(t, common.DeclarationKindField, total.Kind)
+		assert.Same(t, total, lookup(t, symbolTable, code, "self.total =", "total", 0))
+		assert.Same(t, total, lookup(t, symbolTable, code, "self.total ==", "total", 0))
+	})
+
+	t.Run("for loop and optional binding", func(t *testing.T) {
+
+		t.Parallel()
+
+		const code = `
+          fun test(values: [Int], value: Int?) {
+              for index, element in values {
+                  element + index
+              }
+
+              if let value = value {
+                  value
+              }
+          }
+        `
+
+		symbolTable := buildSymbolTable(t, code)
+
+		values := lookup(t, symbolTable, code, "values:", "values", 0)
+		assert.Same(t, values, lookup(t, symbolTable, code, "in values", "values", 0))
+
+		index := lookup(t, symbolTable, code, "for index", "index", 0)
+		assert.Same(t, index, lookup(t, symbolTable, code, "+ index", "index", 0))
+
+		element := lookup(t, symbolTable, code, ", element", "element", 0)
+		assert.Same(t, element, lookup(t, symbolTable, code, "element +", "element", 0))
+
+		parameter := lookup(t, symbolTable, code, "value:", "value", 0)
+		assert.Same(t, parameter, lookup(t, symbolTable, code, "= value", "value", 0))
+
+		binding := lookup(t, symbolTable, code, "let value", "value", 0)
+		assert.NotSame(t, parameter, binding)
+		assert.Same(t, binding, lookup(t, symbolTable, code, "value\n", "value", 0))
+	})
+
+	t.Run("destructuring", func(t *testing.T) {
+
+		t.Parallel()
+
+		const code = `
+          let (x, y) = globalPair
+
+          fun test(pair: AnyStruct, nested: AnyStruct): Int {
+              let (a, b) = pair
+              var (c, (d, _)) = nested
+              return a + b + c + d + x + y
+          }
+        `
+
+		symbolTable := buildSymbolTable(t, code)
+
+		// Each identifier of the patterns is declared as a separate symbol
+
+		a := lookup(t, symbolTable, code, "let (a", "a", 0)
+		assert.Equal(t, "a", a.Identifier.Identifier)
+		assert.Equal(t, common.DeclarationKindConstant, a.Kind)
+		assert.IsType(t, &ast.VariableDeclaration{}, a.Declaration)
+		assert.Same(t, a, lookup(t, symbolTable, code, "return a", "a", 0))
+
+		b := lookup(t, symbolTable, code, "a, b)", "b", 0)
+		assert.Same(t, b, lookup(t, symbolTable, code, "+ b", "b", 0))
+		assert.NotSame(t, a, b)
+
+		c := lookup(t, symbolTable, code, "var (c", "c", 0)
+		assert.Equal(t, common.DeclarationKindVariable, c.Kind)
+		assert.Same(t, c, lookup(t, symbolTable, code, "+ c", "c", 0))
+
+		d := lookup(t, symbolTable, code, "(d", "d", 0)
+		assert.Same(t, d, lookup(t, symbolTable, code, "+ d", "d", 0))
+
+		// Global patterns are declared, too
+
+		x := lookup(t, symbolTable, code, "let (x", "x", 0)
+		assert.Same(t, x, lookup(t, symbolTable, code, "+ x", "x", 0))
+
+		y := lookup(t, symbolTable, code, "x, y)", "y", 0)
+		assert.Same(t, y, lookup(t, symbolTable, code, "+ y", "y", 0))
+
+		// Wildcards are not declared
+
+		_, ok := symbolTable.LookupAt(ast.Position{Offset: offsetOf(t, code, "_)", "_", 0)})
+		assert.False(t, ok)
+	})
+
+	t.Run("redeclaration", func(t *testing.T) {
+
+		t.Parallel()
+
+		program, err := parser2.ParseProgram(`
+          let x = 1
+          fun x() {}
+        `)
+		require.NoError(t, err)
+
+		_, err = BuildSymbolTable(program)
+
+		var redeclarationErr *RedeclarationError
+		require.ErrorAs(t, err, &redeclarationErr)
+		assert.Equal(t, "x", redeclarationErr.Name)
+		assert.Equal(t, 2, redeclarationErr.PreviousPos.Line)
+		assert.Equal(t, 3, redeclarationErr.Pos.Line)
+	})
+}
@@ -16,7 +16,7 @@
  * limitations under the License.
  */
 
-package runtime
+package runtime_test
 
 import (
 	"testing"
@@ -25,8 +25,10 @@ import (
 
 	"github.com/onflow/cadence"
 	"github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/cadence/runtime"
 	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/runtimetest"
 )
 
 // TestRuntimeArgumentImportMissingType tests if errors produced while validating
@@ -38,46 +40,37 @@ func TestRuntimeArgumentImportMissingType(t *testing.T) {
 
 	t.Parallel()
 
+	argument, err := json.Encode(
+		cadence.Struct{}.
+			WithType(&cadence.StructType{
+				Location: common.AddressLocation{
+					Address: common.Address{},
+					Name:    "Foo",
+				},
+				QualifiedIdentifier: "Foo.Bar",
+			}),
+	)
+	require.NoError(t, err)
+
 	t.Run("transaction", func(t *testing.T) {
 
 		t.Parallel()
 
-		runtime := newTestInterpreterRuntime()
+		rt, runtimeInterface := runtimetest.NewTestRuntime()
+		rt.SetAtreeValidationEnabled(true)
 
 		script := []byte(`
           transaction(value: AnyStruct) {}
         `)
 
-		runtimeInterface := &testRuntimeInterface{
-			getSigningAccounts: func() ([]Address, error) {
-				return nil, nil
-			},
-			decodeArgument: func(b []byte, t cadence.Type) (cadence.Value, error) {
-				return json.Decode(b)
-			},
-			getAccountContractCode: func(address Address, name string) (code []byte, err error) {
-				return nil, nil
-			},
-		}
-
-		nextTransactionLocation := newTransactionLocationGenerator()
-		err := runtime.ExecuteTransaction(
-			Script{
-				Source: script,
-				Arguments: encodeArgs([]cadence.Value{
-					cadence.Struct{}.
-						WithType(&cadence.StructType{
-							Location: common.AddressLocation{
-								Address: common.Address{},
-								Name:    "Foo",
-							},
-							QualifiedIdentifier: "Foo.Bar",
-						}),
-				}),
+		err := rt.ExecuteTransaction(
+			runtime.Script{
+				Source:    script,
+				Arguments: [][]byte{argument},
 			},
-			Context{
+			runtime.Context{
 				Interface: runtimeInterface,
-				Location:  nextTransactionLocation(),
+				Location:  common.TransactionLocation{},
 			},
 		)
 
@@ -89,42 +82,21 @@ func TestRuntimeArgumentImportMissingType(t *testing.T) {
 
 		t.Parallel()
 
-		runtime := newTestInterpreterRuntime()
+		rt, runtimeInterface := runtimetest.NewTestRuntime()
+		rt.SetAtreeValidationEnabled(true)
 
 		script := []byte(`
           pub fun main(value: AnyStruct) {}
         `)
 
-		runtimeInterface := &testRuntimeInterface{
-			getSigningAccounts: func() ([]Address, error) {
-				return nil, nil
-			},
-			decodeArgument: func(b []byte, t cadence.Type) (cadence.Value, error) {
-				return json.Decode(b)
-			},
-			getAccountContractCode: func(address Address, name string) (code []byte, err error) {
-				return nil, nil
-			},
-		}
-
-		nextTransactionLocation := newTransactionLocationGenerator()
-		_, err := runtime.ExecuteScript(
-			Script{
-				Source: script,
-				Arguments: encodeArgs([]cadence.Value{
-					cadence.Struct{}.
-						WithType(&cadence.StructType{
-							Location: common.AddressLocation{
-								Address: common.Address{},
-								Name:    "Foo",
-							},
-							QualifiedIdentifier: "Foo.Bar",
-						}),
-				}),
+		_, err := rt.ExecuteScript(
+			runtime.Script{
+				Source:    script,
+				Arguments: [][]byte{argument},
 			},
-			Context{
+			runtime.Context{
 				Interface: runtimeInterface,
-				Location:  nextTransactionLocation(),
+				Location:  common.ScriptLocation{},
 			},
 		)
 
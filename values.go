@@ -1045,7 +1045,15 @@ func NewStruct(fields []Value) Struct {
 
 func (Struct) isValue() {}
 
+// Type returns the type of the structure,
+// or AnyStruct if the type is not set
+//
 func (v Struct) Type() Type {
+	// NOTE: check the pointer, returning a nil pointer
+	// would result in a non-nil interface value
+	if v.StructType == nil {
+		return AnyStructType{}
+	}
 	return v.StructType
 }
 
@@ -1102,7 +1110,13 @@ func NewResource(fields []Value) Resource {
 
 func (Resource) isValue() {}
 
+// Type returns the type of the resource,
+// or AnyResource if the type is not set
+//
 func (v Resource) Type() Type {
+	if v.ResourceType == nil {
+		return AnyResourceType{}
+	}
 	return v.ResourceType
 }
 
@@ -1138,7 +1152,13 @@ func NewEvent(fields []Value) Event {
 
 func (Event) isValue() {}
 
+// Type returns the type of the event,
+// or AnyStruct if the type is not set
+//
 func (v Event) Type() Type {
+	if v.EventType == nil {
+		return AnyStructType{}
+	}
 	return v.EventType
 }
 
@@ -1173,7 +1193,13 @@ func NewContract(fields []Value) Contract {
 
 func (Contract) isValue() {}
 
+// Type returns the type of the contract,
+// or AnyStruct if the type is not set
+//
 func (v Contract) Type() Type {
+	if v.ContractType == nil {
+		return AnyStructType{}
+	}
 	return v.ContractType
 }
 
@@ -1213,8 +1239,11 @@ func NewLink(targetPath Path, borrowType string) Link {
 
 func (Link) isValue() {}
 
+// Type returns AnyStruct, as links are not first-class values,
+// and have no more specific type in Cadence
+//
 func (v Link) Type() Type {
-	return nil
+	return AnyStructType{}
 }
 
 func (v Link) ToGoValue() interface{} {
@@ -1237,8 +1266,17 @@ type Path struct {
 
 func (Path) isValue() {}
 
-func (Path) Type() Type {
-	return PathType{}
+func (v Path) Type() Type {
+	switch common.PathDomainFromIdentifier(v.Domain) {
+	case common.PathDomainStorage:
+		return StoragePathType{}
+	case common.PathDomainPublic:
+		return PublicPathType{}
+	case common.PathDomainPrivate:
+		return PrivatePathType{}
+	default:
+		return PathType{}
+	}
 }
 
 func (Path) ToGoValue() interface{} {
@@ -1288,8 +1326,10 @@ type Capability struct {
 
 func (Capability) isValue() {}
 
-func (Capability) Type() Type {
-	return CapabilityType{}
+func (v Capability) Type() Type {
+	return CapabilityType{
+		BorrowType: v.BorrowType,
+	}
 }
 
 func (Capability) ToGoValue() interface{} {
@@ -1316,7 +1356,13 @@ func NewEnum(fields []Value) Enum {
 
 func (Enum) isValue() {}
 
+// Type returns the type of the enum,
+// or AnyStruct if the type is not set
+//
 func (v Enum) Type() Type {
+	if v.EnumType == nil {
+		return AnyStructType{}
+	}
 	return v.EnumType
 }
 
@@ -599,3 +599,286 @@ func TestNewUInt256FromBig(t *testing.T) {
 	_, err = NewUInt256FromBig(aboveMax)
 	require.Error(t, err)
 }
+
+func TestValueTypeConsistency(t *testing.T) {
+
+	t.Parallel()
+
+	type testCase struct {
+		value    Value
+		expected string
+	}
+
+	ufix64, _ := NewUFix64("64.01")
+	fix64, _ := NewFix64("-32.11")
+
+	typeTests := map[string]testCase{
+		"Void": {
+			value:    NewVoid(),
+			expected: "Void",
+		},
+		"Optional": {
+			value:    NewOptional(NewInt(1)),
+			expected: "Int?",
+		},
+		"Bool": {
+			value:    NewBool(true),
+			expected: "Bool",
+		},
+		"String": {
+			value:    String("foo"),
+			expected: "String",
+		},
+		"Bytes": {
+			value:    NewBytes([]byte{0x1}),
+			expected: "Bytes",
+		},
+		"Character": {
+			value:    Character("a"),
+			expected: "Character",
+		},
+		"Address": {
+			value:    NewAddress([8]byte{0, 0, 0, 0, 0, 0, 0, 1}),
+			expected: "Address",
+		},
+		"Int": {
+			value:    NewInt(1),
+			expected: "Int",
+		},
+		"Int8": {
+			value:    NewInt8(1),
+			expected: "Int8",
+		},
+		"Int16": {
+			value:    NewInt16(1),
+			expected: "Int16",
+		},
+		"Int32": {
+			value:    NewInt32(1),
+			expected: "Int32",
+		},
+		"Int64": {
+			value:    NewInt64(1),
+			expected: "Int64",
+		},
+		"Int128": {
+			value:    NewInt128(1),
+			expected: "Int128",
+		},
+		"Int256": {
+			value:    NewInt256(1),
+			expected: "Int256",
+		},
+		"UInt": {
+			value:    NewUInt(1),
+			expected: "UInt",
+		},
+		"UInt8": {
+			value:    NewUInt8(1),
+			expected: "UInt8",
+		},
+		"UInt16": {
+			value:    NewUInt16(1),
+			expected: "UInt16",
+		},
+		"UInt32": {
+			value:    NewUInt32(1),
+			expected: "UInt32",
+		},
+		"UInt64": {
+			value:    NewUInt64(1),
+			expected: "UInt64",
+		},
+		"UInt128": {
+			value:    NewUInt128(1),
+			expected: "UInt128",
+		},
+		"UInt256": {
+			value:    NewUInt256(1),
+			expected: "UInt256",
+		},
+		"Word8": {
+			value:    NewWord8(1),
+			expected: "Word8",
+		},
+		"Word16": {
+			value:    NewWord16(1),
+			expected: "Word16",
+		},
+		"Word32": {
+			value:    NewWord32(1),
+			expected: "Word32",
+		},
+		"Word64": {
+			value:    NewWord64(1),
+			expected: "Word64",
+		},
+		"Fix64": {
+			value:    fix64,
+			expected: "Fix64",
+		},
+		"UFix64": {
+			value:    ufix64,
+			expected: "UFix64",
+		},
+		"Array": {
+			value: NewArray([]Value{NewInt(1)}).
+				WithType(VariableSizedArrayType{ElementType: IntType{}}),
+			expected: "[Int]",
+		},
+		"Dictionary": {
+			value: NewDictionary([]KeyValuePair{
+				{
+					Key:   String("key"),
+					Value: NewInt(1),
+				},
+			}).WithType(DictionaryType{
+				KeyType:     StringType{},
+				ElementType: IntType{},
+			}),
+			expected: "{String:Int}",
+		},
+		"Struct": {
+			value: NewStruct([]Value{}).WithType(&StructType{
+				Location:            utils.TestLocation,
+				QualifiedIdentifier: "FooStruct",
+			}),
+			expected: "S.test.FooStruct",
+		},
+		"Resource": {
+			value: NewResource([]Value{}).WithType(&ResourceType{
+				Location:            utils.TestLocation,
+				QualifiedIdentifier: "FooResource",
+			}),
+			expected: "S.test.FooResource",
+		},
+		"Event": {
+			value: NewEvent([]Value{}).WithType(&EventType{
+				Location:            utils.TestLocation,
+				QualifiedIdentifier: "FooEvent",
+			}),
+			expected: "S.test.FooEvent",
+		},
+		"Contract": {
+			value: NewContract([]Value{}).WithType(&ContractType{
+				Location:            utils.TestLocation,
+				QualifiedIdentifier: "FooContract",
+			}),
+			expected: "S.test.FooContract",
+		},
+		"Enum": {
+			value: NewEnum([]Value{NewUInt8(1)}).WithType(&EnumType{
+				Location:            utils.TestLocation,
+				QualifiedIdentifier: "FooEnum",
+				RawType:             UInt8Type{},
+			}),
+			expected: "S.test.FooEnum",
+		},
+		"storage Path": {
+			value: Path{
+				Domain:     "storage",
+				Identifier: "foo",
+			},
+			expected: "StoragePath",
+		},
+		"public Path": {
+			value: Path{
+				Domain:     "public",
+				Identifier: "foo",
+			},
+			expected: "PublicPath",
+		},
+		"private Path": {
+			value: Path{
+				Domain:     "private",
+				Identifier: "foo",
+			},
+			expected: "PrivatePath",
+		},
+		"Type": {
+			value:    TypeValue{StaticType: IntType{}},
+			expected: "Type",
+		},
+		"Capability": {
+			value: Capability{
+				Path:       Path{Domain: "public", Identifier: "foo"},
+				Address:    BytesToAddress([]byte{1, 2, 3, 4, 5}),
+				BorrowType: IntType{},
+			},
+			expected: "Capability<Int>",
+		},
+	}
+
+	test := func(name string, testCase testCase) {
+
+		t.Run(name, func(t *testing.T) {
+
+			t.Parallel()
+
+			ty := testCase.value.Type()
+			require.NotNil(t, ty)
+
+			assert.Equal(t,
+				testCase.expected,
+				ty.ID(),
+			)
+		})
+	}
+
+	for name, testCase := range typeTests {
+		test(name, testCase)
+	}
+
+	t.Run("Link", func(t *testing.T) {
+
+		t.Parallel()
+
+		// Links have no more specific type
+
+		link := NewLink(
+			Path{
+				Domain:     "storage",
+				Identifier: "foo",
+			},
+			"Int",
+		)
+
+		ty := link.Type()
+		require.NotNil(t, ty)
+		assert.Equal(t, "AnyStruct", ty.ID())
+	})
+
+	t.Run("composites without type", func(t *testing.T) {
+
+		t.Parallel()
+
+		// Composites without a type have the most general type of their kind
+
+		for name, testCase := range map[string]testCase{
+			"Struct": {
+				value:    NewStruct([]Value{}),
+				expected: "AnyStruct",
+			},
+			"Resource": {
+				value:    NewResource([]Value{}),
+				expected: "AnyResource",
+			},
+			"Event": {
+				value:    NewEvent([]Value{}),
+				expected: "AnyStruct",
+			},
+			"Contract": {
+				value:    NewContract([]Value{}),
+				expected: "AnyStruct",
+			},
+			"Enum": {
+				value:    NewEnum([]Value{}),
+				expected: "AnyStruct",
+			},
+		} {
+			ty := testCase.value.Type()
+			require.NotNil(t, ty, name)
+			assert.Equal(t, testCase.expected, ty.ID(), name)
+		}
+	})
+}